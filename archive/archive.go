@@ -0,0 +1,106 @@
+// Package archive finds archived copies of web pages in the Wayback Machine.
+// This file contains the availability API client and snapshot date parsing.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	stdurl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIURL은 Wayback Machine 가용성(availability) API 주소 (테스트에서 바꿈)
+var APIURL = "https://archive.org/wayback/available"
+
+// TimestampFormat은 Wayback Machine이 쓰는 시각 형식 (UTC)
+const TimestampFormat = "20060102150405"
+
+// Snapshot은 보관된 사본 하나
+type Snapshot struct {
+	URL        string    // 보관본 주소 (web.archive.org/web/<시각>/<원래 주소>)
+	Time       time.Time // 보관한 시각 (UTC)
+	StatusCode int       // 보관할 때 원래 서버가 보낸 상태 코드
+}
+
+// NotFoundError는 보관본이 없을 때의 에러
+type NotFoundError struct {
+	Target string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s의 보관본이 없습니다", e.Target)
+}
+
+// availability는 가용성 API 응답의 필요한 부분
+type availability struct {
+	ArchivedSnapshots struct {
+		Closest *struct {
+			Available bool   `json:"available"`
+			URL       string `json:"url"`
+			Timestamp string `json:"timestamp"`
+			Status    string `json:"status"`
+		} `json:"closest"`
+	} `json:"archived_snapshots"`
+}
+
+// Closest는 target의 보관본 중 at에 가장 가까운 것을 찾음 (at이 0이면 가장 최근 것)
+func Closest(target string, at time.Time) (*Snapshot, error) {
+	query := "?url=" + stdurl.QueryEscape(target)
+	if !at.IsZero() {
+		query += "&timestamp=" + at.UTC().Format(TimestampFormat)
+	}
+	u, err := url.NewURL(APIURL + query)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := net.Fetch(u)
+	if err != nil {
+		return nil, fmt.Errorf("Wayback Machine 조회 실패: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Wayback Machine 조회 실패 (status %d)", resp.StatusCode)
+	}
+
+	var result availability
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+		return nil, fmt.Errorf("Wayback Machine 응답 형식 오류: %w", err)
+	}
+	closest := result.ArchivedSnapshots.Closest
+	if closest == nil || !closest.Available || closest.URL == "" {
+		return nil, &NotFoundError{Target: target}
+	}
+
+	snapshot := &Snapshot{URL: closest.URL}
+	snapshot.Time, _ = time.Parse(TimestampFormat, closest.Timestamp)
+	snapshot.StatusCode, _ = strconv.Atoi(closest.Status)
+	return snapshot, nil
+}
+
+// dateLayouts는 ParseDate가 받는 날짜 형식 (앞에서부터 시도)
+var dateLayouts = []string{
+	TimestampFormat,
+	"20060102",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006-01",
+	"200601",
+	"2006",
+}
+
+// ParseDate는 명령줄에서 받은 날짜를 해석함 (UTC)
+//
+// 예시: "2006", "2006-01", "2006-01-02", "20060102", "20060102150405"
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("날짜 형식이 잘못되었습니다: %q (예: 2006, 2006-01-02, 20060102150405)", s)
+}
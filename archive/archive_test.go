@@ -0,0 +1,72 @@
+package archive_test
+
+import (
+	"errors"
+	"go-web-browser/archive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestClosest 요청한 시각에 가장 가까운 보관본, 보관본이 없으면 NotFoundError
+func TestClosest(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("url") == "gone.example" {
+			w.Write([]byte(`{"url": "gone.example", "archived_snapshots": {}}`))
+			return
+		}
+		w.Write([]byte(`{"archived_snapshots": {"closest": {"status": "200", "available": true,
+			"url": "http://web.archive.org/web/20060101064348/http://www.example.com:80/", "timestamp": "20060101064348"}}}`))
+	}))
+	defer server.Close()
+
+	old := archive.APIURL
+	archive.APIURL = server.URL + "/wayback/available"
+	defer func() { archive.APIURL = old }()
+
+	snapshot, err := archive.Closest("example.com/a b", time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Closest() failed: %v", err)
+	}
+	if wantQuery := "url=example.com%2Fa+b&timestamp=20060101000000"; gotQuery != wantQuery {
+		t.Errorf("query = %q; want %q", gotQuery, wantQuery)
+	}
+	want := archive.Snapshot{
+		URL:        "http://web.archive.org/web/20060101064348/http://www.example.com:80/",
+		Time:       time.Date(2006, 1, 1, 6, 43, 48, 0, time.UTC),
+		StatusCode: 200,
+	}
+	if *snapshot != want {
+		t.Errorf("Closest() = %+v; want %+v", *snapshot, want)
+	}
+
+	_, err = archive.Closest("gone.example", time.Time{})
+	var notFound *archive.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Closest(gone.example) error = %v; want NotFoundError", err)
+	}
+}
+
+// TestParseDate 연도만, 연월, 날짜, Wayback 시각 형식
+func TestParseDate(t *testing.T) {
+	tests := map[string]time.Time{
+		"2006":           time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2006-03":        time.Date(2006, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2006-03-15":     time.Date(2006, 3, 15, 0, 0, 0, 0, time.UTC),
+		"20060315":       time.Date(2006, 3, 15, 0, 0, 0, 0, time.UTC),
+		"20060315123000": time.Date(2006, 3, 15, 12, 30, 0, 0, time.UTC),
+	}
+	for input, want := range tests {
+		got, err := archive.ParseDate(input)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	if _, err := archive.ParseDate("yesterday"); err == nil {
+		t.Error("ParseDate(\"yesterday\") returned no error; want error")
+	}
+}
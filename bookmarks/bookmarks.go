@@ -0,0 +1,103 @@
+// Package bookmarks stores the user's bookmarks.
+// This file contains the JSON-backed bookmark store.
+package bookmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName은 설정 디렉토리 안의 북마크 파일 이름
+const FileName = "bookmarks.json"
+
+// Bookmark는 북마크 하나
+type Bookmark struct {
+	Title  string    `json:"title"`
+	URL    string    `json:"url"`
+	Folder string    `json:"folder,omitempty"` // 가져온 북마크의 폴더 ("상위/하위", 없으면 "")
+	Added  time.Time `json:"added"`
+}
+
+// Store는 북마크 목록과 저장 위치
+//
+// path가 비어 있으면 파일에 저장하지 않음 (테스트, 임시 사용)
+type Store struct {
+	path  string
+	items []Bookmark
+}
+
+// Open은 path의 북마크 파일을 읽어 Store를 만듦 (파일이 없으면 빈 목록)
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("북마크 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.items); err != nil {
+		return nil, fmt.Errorf("북마크 파일 형식 오류 (%s): %w", path, err)
+	}
+	return s, nil
+}
+
+// All은 추가한 순서대로 모든 북마크를 반환함
+func (s *Store) All() []Bookmark {
+	return append([]Bookmark(nil), s.items...)
+}
+
+// Has는 rawURL이 이미 북마크되어 있는지 확인함
+func (s *Store) Has(rawURL string) bool {
+	for _, b := range s.items {
+		if b.URL == rawURL {
+			return true
+		}
+	}
+	return false
+}
+
+// Add는 북마크를 추가하고 파일에 저장함
+//
+// 이미 있는 URL이면 추가하지 않고 false를 반환함
+func (s *Store) Add(title, rawURL string) (bool, error) {
+	if s.Has(rawURL) {
+		return false, nil
+	}
+	s.items = append(s.items, Bookmark{Title: title, URL: rawURL, Added: time.Now()})
+	return true, s.Save()
+}
+
+// Save는 북마크 목록을 파일에 씀
+//
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰는 도중 종료되어도 기존 파일이 깨지지 않음
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("북마크 저장 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("북마크 저장 실패: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("북마크 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("북마크 저장 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package bookmarks_test
+
+import (
+	"go-web-browser/bookmarks"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStore_AddAndReopen 추가한 북마크가 파일에 저장되고 다시 읽힘
+func TestStore_AddAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", bookmarks.FileName)
+	s, err := bookmarks.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	if added, err := s.Add("Example", "http://example.com/"); !added || err != nil {
+		t.Fatalf("Add() = %v, %v; want true, nil", added, err)
+	}
+	if added, _ := s.Add("Again", "http://example.com/"); added {
+		t.Error("같은 URL은 다시 추가되지 않아야 함")
+	}
+
+	reopened, err := bookmarks.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	all := reopened.All()
+	if len(all) != 1 || all[0].Title != "Example" || all[0].URL != "http://example.com/" {
+		t.Errorf("All() = %+v; want Example 하나", all)
+	}
+}
+
+// chromeExport는 Chrome이 내보낸 형식의 북마크 파일 (대문자 태그, 닫지 않은 <DT>, 폴더 안 폴더)
+const chromeExport = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file. -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1700000000" PERSONAL_TOOLBAR_FOLDER="true">북마크바</H3>
+    <DL><p>
+        <DT><A HREF="https://go.dev/" ADD_DATE="1700000100" ICON="data:image/png;base64,AAAA">The Go &amp; Programming Language</A>
+        <DT><H3>Docs</H3>
+        <DL><p>
+            <DT><A HREF="https://pkg.go.dev/std">Standard library</A>
+            <DT><A HREF="javascript:alert(1)">bookmarklet</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="http://example.com/" ADD_DATE="1700000200">Example</A>
+    <DT><A HREF="https://go.dev/">Duplicate</A>
+</DL><p>
+`
+
+// TestStore_ImportNetscape 폴더 경로, 추가한 시각, 엔티티를 살리고 중복과 북마클릿은 건너뜀
+func TestStore_ImportNetscape(t *testing.T) {
+	s, _ := bookmarks.Open("")
+	n, err := s.ImportNetscape(strings.NewReader(chromeExport))
+	if err != nil || n != 3 {
+		t.Fatalf("ImportNetscape() = %d, %v; want 3, nil", n, err)
+	}
+
+	want := []struct{ title, url, folder string }{
+		{"The Go & Programming Language", "https://go.dev/", "북마크바"},
+		{"Standard library", "https://pkg.go.dev/std", "북마크바/Docs"},
+		{"Example", "http://example.com/", ""},
+	}
+	all := s.All()
+	for i, w := range want {
+		if b := all[i]; b.Title != w.title || b.URL != w.url || b.Folder != w.folder {
+			t.Errorf("All()[%d] = %+v; want %+v", i, b, w)
+		}
+	}
+	if got := all[0].Added.Unix(); got != 1700000100 {
+		t.Errorf("All()[0].Added = %d; want ADD_DATE 1700000100", got)
+	}
+
+	if _, err := s.ImportNetscape(strings.NewReader("<html>not bookmarks</html>")); err == nil {
+		t.Error("ImportNetscape(일반 HTML) error = nil; want error")
+	}
+}
+
+// TestStore_ExportNetscape 내보낸 파일을 다시 가져오면 제목, 주소, 폴더, 시각이 같음
+func TestStore_ExportNetscape(t *testing.T) {
+	s, _ := bookmarks.Open("")
+	s.ImportNetscape(strings.NewReader(chromeExport))
+	s.Add(`"따옴표" <태그>`, "http://example.com/?a=1&b=2")
+
+	var out strings.Builder
+	if err := s.ExportNetscape(&out); err != nil {
+		t.Fatalf("ExportNetscape() failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+		t.Errorf("ExportNetscape() = %q; want Netscape doctype first", out.String())
+	}
+
+	again, _ := bookmarks.Open("")
+	if n, err := again.ImportNetscape(strings.NewReader(out.String())); err != nil || n != 4 {
+		t.Fatalf("ImportNetscape(exported) = %d, %v; want 4, nil", n, err)
+	}
+	for i, b := range s.All() {
+		got := again.All()[i]
+		if got.Title != b.Title || got.URL != b.URL || got.Folder != b.Folder || got.Added.Unix() != b.Added.Unix() {
+			t.Errorf("다시 가져온 All()[%d] = %+v; want %+v", i, got, b)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package bookmarks stores the user's bookmarks.
+// This file contains import and export in the Netscape bookmark HTML format.
+package bookmarks
+
+import (
+	"fmt"
+	"go-web-browser/html"
+	stdhtml "html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FolderSeparator는 Bookmark.Folder에서 폴더 이름을 잇는 문자
+const FolderSeparator = "/"
+
+// ImportNetscape는 Firefox, Chrome 등이 내보낸 Netscape 북마크 HTML을 읽어 북마크를 추가하고 저장함
+//
+// <H3> 폴더는 Bookmark.Folder에 "상위/하위"로 남기고, ADD_DATE가 있으면 추가한 시각으로 씀
+// 이미 있는 URL과 javascript:, place: 같은 주소가 아닌 항목은 건너뜀
+// 추가한 북마크 수를 반환함
+func (s *Store) ImportNetscape(r io.Reader) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("북마크 가져오기 실패: %w", err)
+	}
+	if !strings.Contains(strings.ToUpper(string(data[:min(len(data), 1024)])), "NETSCAPE-BOOKMARK-FILE") {
+		return 0, fmt.Errorf("Netscape 북마크 파일이 아닙니다 (<!DOCTYPE NETSCAPE-Bookmark-file-1> 없음)")
+	}
+
+	var (
+		folders []string // 지금 열린 <DL>마다의 폴더 이름 (맨 바깥 목록은 "")
+		heading bool     // <H3> 폴더 이름을 읽는 중인지
+		pending string   // 다음 <DL>에 붙일 폴더 이름 (바로 앞의 <H3>)
+		link    *Bookmark
+		text    strings.Builder
+		added   int
+	)
+	t := html.NewTokenizer(string(data))
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+		switch {
+		case tok.Type == html.TextToken && (heading || link != nil):
+			text.WriteString(tok.Data)
+		case tok.Type == html.StartTagToken && tok.Data == "h3":
+			heading = true
+			text.Reset()
+		case tok.Type == html.EndTagToken && tok.Data == "h3" && heading:
+			pending, heading = strings.Join(strings.Fields(text.String()), " "), false
+		case tok.Type == html.StartTagToken && tok.Data == "dl":
+			folders = append(folders, pending)
+			pending = ""
+		case tok.Type == html.EndTagToken && tok.Data == "dl" && len(folders) > 0:
+			folders = folders[:len(folders)-1]
+		case tok.Type == html.StartTagToken && tok.Data == "a":
+			link = &Bookmark{Folder: folderPath(folders)}
+			text.Reset()
+			for _, attr := range tok.Attrs {
+				switch attr.Name {
+				case "href":
+					link.URL = strings.TrimSpace(attr.Value)
+				case "add_date":
+					if sec, err := strconv.ParseInt(attr.Value, 10, 64); err == nil && sec > 0 {
+						link.Added = time.Unix(sec, 0)
+					}
+				}
+			}
+		case tok.Type == html.EndTagToken && tok.Data == "a" && link != nil:
+			link.Title = strings.Join(strings.Fields(text.String()), " ")
+			if importable(link.URL) && !s.Has(link.URL) {
+				if link.Title == "" {
+					link.Title = link.URL
+				}
+				if link.Added.IsZero() {
+					link.Added = time.Now()
+				}
+				s.items = append(s.items, *link)
+				added++
+			}
+			link = nil
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, s.Save()
+}
+
+// folderPath는 열린 폴더 이름들을 "상위/하위"로 이음 (이름 없는 바깥 목록은 빠짐)
+func folderPath(folders []string) string {
+	var names []string
+	for _, name := range folders {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, FolderSeparator)
+}
+
+// importable은 가져올 수 있는 주소인지 확인함 (북마클릿, 브라우저 내부 쿼리는 제외)
+func importable(rawURL string) bool {
+	scheme, _, ok := strings.Cut(rawURL, ":")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "javascript", "place", "chrome", "about", "data":
+		return false
+	}
+	return true
+}
+
+// ExportNetscape는 북마크를 Firefox, Chrome이 가져올 수 있는 Netscape 북마크 HTML로 씀
+//
+// Folder가 같은 북마크는 처음 나온 위치에 모아서 <H3> 폴더로 묶음
+func (s *Store) ExportNetscape(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString("<!-- This is an automatically generated file.\n     It will be read and overwritten.\n     DO NOT EDIT! -->\n")
+	b.WriteString("<META HTTP-EQUIV=\"Content-Type\" CONTENT=\"text/html; charset=UTF-8\">\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n<H1>Bookmarks</H1>\n")
+	writeFolder(&b, exportTree(s.items), 0)
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return fmt.Errorf("북마크 내보내기 실패: %w", err)
+	}
+	return nil
+}
+
+// exportNode는 내보낼 폴더 하나 (하위 폴더와 북마크를 처음 나온 순서대로)
+type exportNode struct {
+	name     string
+	children []*exportNode // 폴더 안의 하위 폴더와 북마크
+	bookmark *Bookmark     // 북마크 항목이면 그 북마크 (폴더면 nil)
+}
+
+// exportTree는 Folder 경로대로 북마크를 묶은 트리
+func exportTree(items []Bookmark) *exportNode {
+	root := &exportNode{}
+	for i := range items {
+		node := root
+		if items[i].Folder != "" {
+			for _, name := range strings.Split(items[i].Folder, FolderSeparator) {
+				node = node.folder(name)
+			}
+		}
+		node.children = append(node.children, &exportNode{bookmark: &items[i]})
+	}
+	return root
+}
+
+// folder는 이름이 name인 하위 폴더 (없으면 만듦)
+func (n *exportNode) folder(name string) *exportNode {
+	for _, c := range n.children {
+		if c.bookmark == nil && c.name == name {
+			return c
+		}
+	}
+	c := &exportNode{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+// writeFolder는 n의 항목들을 <DL> 목록으로 씀 (depth는 들여쓰기 단계)
+func writeFolder(b *strings.Builder, n *exportNode, depth int) {
+	indent := strings.Repeat("    ", depth)
+	b.WriteString(indent + "<DL><p>\n")
+	for _, c := range n.children {
+		if c.bookmark == nil {
+			fmt.Fprintf(b, "%s    <DT><H3>%s</H3>\n", indent, stdhtml.EscapeString(c.name))
+			writeFolder(b, c, depth+1)
+			continue
+		}
+		fmt.Fprintf(b, "%s    <DT><A HREF=\"%s\" ADD_DATE=\"%d\">%s</A>\n", indent,
+			stdhtml.EscapeString(c.bookmark.URL), c.bookmark.Added.Unix(), stdhtml.EscapeString(c.bookmark.Title))
+	}
+	b.WriteString(indent + "</DL><p>\n")
+}
@@ -1,48 +1,938 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"go-web-browser/archive"
+	"go-web-browser/bookmarks"
+	"go-web-browser/config"
+	"go-web-browser/diff"
+	"go-web-browser/history"
+	"go-web-browser/layout"
+	"go-web-browser/logger"
 	"go-web-browser/net"
+	"go-web-browser/permissions"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/renderer"
+	"go-web-browser/session"
+	"go-web-browser/sitesettings"
+	"go-web-browser/term"
+	"go-web-browser/theme"
+	"go-web-browser/tui"
 	"go-web-browser/url"
+	"image/png"
+	"io"
+	"log"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 )
 
+// profileCacheFile: 프로필 캐시 디렉토리 안의 캐시 스냅샷 파일 이름
+const profileCacheFile = "cache.json"
+
+// viewportWidth: 텍스트 레이아웃 폭 (칸 수), --width 또는 터미널 크기로 결정
+var viewportWidth = layout.DefaultWidth
+
+// a11yMode: HTML을 스크린 리더용 구조 안내 텍스트로 출력 (--a11y)
+var a11yMode = false
+
+// sites: 프로필의 사이트별 설정 (HTTPS-first 예외, 이미지 끄기 등)
+var sites *sitesettings.Store
+
+// perms: 사이트별 설정에 저장한 권한 결정 (인증서 오류 무시, 비슷한 주소 경고 무시 등)
+var perms *permissions.Manager
+
+// interactive: stdout이 터미널이면 true, 파이프/파일이면 false
+// false일 때는 배너와 상태 메시지 없이 본문만 출력 (grep 등과 조합 가능)
+var interactive = true
+
+// status: 터미널에서 페이지를 불러오는 동안 진행 상황(progress)을 제자리에 보여주는 상태 줄
+// 파이프 출력, --verbose(로그를 그대로 출력), --tui에서는 nil
+var status *term.StatusLine
+
+// progress: status에 보여줄 로딩 단계, 받은 크기, 하위 리소스 수 (net.GlobalTrace로 모음)
+var progress *net.Progress
+
+// timing: --perf일 때 페이지마다 단계별 시간과 리소스 수를 모음 (net.GlobalTrace로 모음)
+var timing *net.Timing
+
+// stdout: 본문과 상태 메시지를 쓸 곳 (상태 줄이 있으면 지우고 씀)
+func stdout() io.Writer {
+	if status != nil {
+		return status
+	}
+	return os.Stdout
+}
+
+// statusf: 터미널에 출력할 때만 상태 메시지를 표시
+// 주소, 헤더 값처럼 서버가 정한 글이 섞이므로 제어 문자는 기호로 바꿈 (term.Sanitize)
+func statusf(format string, args ...any) {
+	if interactive {
+		io.WriteString(stdout(), term.Sanitize(fmt.Sprintf(format, args...)))
+	}
+}
+
+// shownError: 에러 페이지로 이미 화면에 보여준 에러 (main은 다시 출력하지 않고 종료 코드만 1로 함)
+type shownError struct{ error }
+
 // load: URL 문자열을 받아서 요청하고 화면에 표시하는 통합 함수
-func load(urlStr string) {
-	urlObj, err := url.NewURL(urlStr)
+// raw가 true면 렌더링하지 않고 응답 본문을 그대로 출력
+//
+// 터미널에 출력할 때는 요청 실패, 본문 없는 4xx/5xx 응답, 표시할 수 없는 콘텐츠를
+// 내부 에러 페이지(net.ErrorPage 등)로 바꿔서 다른 문서와 같은 렌더러로 보여줌
+func load(urlStr string, raw bool) error {
+	urlObj, resp, err := fetchURL(urlStr)
 	if err != nil {
-		fmt.Printf("URL 분석 에러 (%s): %v\n", urlStr, err)
-		return
+		if urlObj == nil || raw || !interactive {
+			return err
+		}
+		if renderErr := render(url.SchemeAbout, net.ErrorPage(urlObj, errors.Unwrap(err)), false); renderErr != nil {
+			return err
+		}
+		return shownError{err}
+	}
+	if resp.ContentType == net.MIMEEventStream {
+		return streamEvents(resp.URL)
 	}
+	if page, ok := net.StatusPage(resp); ok && !raw && interactive {
+		return render(url.SchemeAbout, page, false)
+	}
+	return render(urlObj.Scheme, resp, raw)
+}
 
-	fmt.Printf("브라우징: %s\n", urlObj.String())
+// streamEvents: Server-Sent Events 스트림을 다시 연결하면서 이벤트가 올 때마다 출력함 (Ctrl+C로 끝냄)
+func streamEvents(u *url.URL) error {
+	statusf("이벤트 스트림: %s\n", u.Redacted())
+	source := &net.EventSource{URL: u}
+	return source.Run(func(ev net.Event) error {
+		out := stdout()
+		fmt.Fprintf(out, "[%s] %s", time.Now().Format("15:04:05"), term.Sanitize(ev.Type))
+		if ev.ID != "" {
+			fmt.Fprintf(out, " (id %s)", term.Sanitize(ev.ID))
+		}
+		fmt.Fprintf(out, "\n%s\n\n", term.Sanitize(ev.Data))
+		return nil
+	})
+}
 
-	body, err := net.Request(urlObj)
+// fetchURL: 사용자가 입력한 URL을 분석해서 가져옴 (HTTPS-first 결과는 상태 메시지로 알림)
+// 요청이 실패해도 분석한 URL은 반환함 (에러 페이지용)
+func fetchURL(urlStr string) (*url.URL, *net.Response, error) {
+	urlObj, err := url.FromUserInput(urlStr)
 	if err != nil {
-		fmt.Printf("요청 실패 (%s): %v\n", urlObj.String(), err)
-		return
+		return nil, nil, fmt.Errorf("URL 분석 에러 (%s): %w", urlStr, err)
 	}
 
-	renderer := getRenderer(urlObj.Scheme)
-	renderer.Render(body)
+	statusf("브라우징: %s\n", urlObj.Display())
+	if err := checkNavigation(urlObj); err != nil {
+		return urlObj, nil, fmt.Errorf("요청 실패 (%s): %w", urlObj.Redacted(), err)
+	}
+
+	if progress != nil {
+		progress.Reset()
+	}
+	if timing != nil {
+		timing.Reset()
+	}
+	resp, err := net.Fetch(urlObj)
+	if err != nil {
+		return urlObj, nil, fmt.Errorf("요청 실패 (%s): %w", urlObj.Redacted(), err)
+	}
+	// --verbose: 어떤 리다이렉트를 거쳐 최종 주소에 왔는지, 어느 단계가 느렸는지
+	if chain := resp.RedirectChain(); chain != "" {
+		logger.Logger.Printf("리다이렉트 체인 (%d단계, 최종 %s):\n%s", len(resp.Redirects), resp.URL.Redacted(), chain)
+	}
+	switch resp.Upgrade {
+	case net.UpgradeHTTPS:
+		statusf("HTTPS로 연결했습니다: %s\n", resp.URL.Redacted())
+	case net.UpgradeFallback:
+		statusf("HTTPS 연결에 실패해서 HTTP로 연결했습니다: %s\n", resp.URL.Redacted())
+	}
+	return urlObj, resp, nil
+}
+
+// checkNavigation: 주소창에서 연 주소가 잘 알려진 사이트를 흉내 낸 것처럼 보이면 경고 에러를 반환함
+// 사이트별 설정에서 경고 무시를 허용했거나 터미널에서 물어서 허용하면 그대로 엶
+func checkNavigation(u *url.URL) error {
+	err := net.LookalikeCheck(u)
+	if err == nil || perms == nil {
+		return err
+	}
+	statusf("%v\n", err)
+	ok, permErr := perms.Check(u, permissions.LookalikeDomain)
+	if permErr != nil {
+		logger.Logger.Printf("%v", permErr)
+	}
+	if ok {
+		return nil
+	}
+	return err
+}
+
+// render: 응답을 스킴과 MIME 타입에 맞는 렌더러로 표준 출력에 씀 (raw면 본문 그대로)
+func render(scheme url.Scheme, resp *net.Response, raw bool) error {
+	if raw {
+		_, err := io.WriteString(stdout(), resp.Body)
+		return err
+	}
+
+	opts := renderer.Options{Width: viewportWidth, A11y: a11yMode}
+	r := renderer.For(scheme, resp.ContentType, opts)
+	if _, ok := r.(*renderer.BinaryRenderer); ok && interactive {
+		resp = net.UnsupportedPage(resp)
+		r = renderer.For(scheme, resp.ContentType, opts)
+	}
+	return r.Render(stdout(), resp.Body)
 }
 
 func main() {
-	fmt.Println("=== Go Web Browser ===")
-	var urlStr string
+	width := flag.Int("width", 0, "레이아웃 폭 (칸 수, 0이면 터미널 크기 자동 감지)")
+	raw := flag.Bool("raw", false, "렌더링하지 않고 응답 본문을 그대로 출력")
+	verbose := flag.Bool("verbose", false, "파이프 출력일 때도 로그를 stderr에 출력")
+	retry := flag.Int("retry", 0, "일시적인 실패(연결 끊김, 502/503/504, 429)에 대한 최대 시도 횟수 (0이면 재시도 안 함)")
+	cacheImport := flag.String("cache-import", "", "시작할 때 캐시 스냅샷(JSON)을 읽어 캐시를 채움")
+	cacheExport := flag.String("cache-export", "", "종료할 때 캐시 내용을 스냅샷(JSON)으로 저장")
+	strict := flag.Bool("strict", false, "RFC 9112 문법에 맞지 않는 상태 줄과 헤더를 에러로 처리 (프로토콜 테스트용)")
+	tuiMode := flag.Bool("tui", false, "전체 화면 대화형 모드 (j/k 스크롤, f 링크 힌트, g 주소 입력, : 명령, q 종료)")
+	configPath := flag.String("config", "", "설정 파일 경로 (기본값: 사용자 설정 디렉토리의 go-web-browser/config.json)")
+	restoreSession := flag.Bool("restore-session", false, "대화형 모드에서 지난번에 열려 있던 탭들을 다시 엶")
+	a11y := flag.Bool("a11y", false, "제목, 링크, 목록 등 문서 구조를 알려주는 텍스트로 출력 (스크린 리더, 점자 디스플레이용)")
+	screenshot := flag.String("screenshot", "", "첫 번째 URL을 화면 없이 레이아웃해서 PNG 파일로 저장 (레이아웃 회귀 테스트용)")
+	viewport := flag.String("viewport", "1024x768", "--screenshot의 화면 크기 (픽셀, 너비x높이)")
+	profileName := flag.String("profile", "", "사용할 프로필 (설정, 쿠키, 캐시, 방문 기록, 북마크를 프로필별로 분리)")
+	tofu := flag.String("tofu", "", "처음 연결한 https 호스트의 인증서 공개키를 기록하고 바뀌면 경고(warn) 또는 차단(block)")
+	watch := flag.Duration("watch", 0, "첫 번째 URL을 이 간격으로 다시 가져와서 (ETag, Last-Modified로 조건부 요청) 바뀌었을 때만 다시 표시 (예: 30s)")
+	watchHook := flag.String("watch-hook", "", "--watch에서 페이지가 바뀔 때마다 실행할 셸 명령 (URL은 환경 변수 WATCH_URL)")
+	diffWait := flag.Duration("diff-wait", 0, "diff 명령에서 같은 URL을 두 번 가져올 때 사이에 기다리는 시간 (예: 30s)")
+	dump := flag.String("dump", "", "렌더링 대신 다른 도구가 읽을 형식으로 출력 (json: URL, 상태, 헤더, 제목, 링크, 텍스트 / text / html: 정규화한 HTML / dom: DOM 트리)")
+	spoolDir := flag.String("spool", "", "네트워크에서 가져온 문서의 원본 바이트를 이 디렉토리에 하나씩 저장 (파서/레이아웃 테스트 코퍼스 수집용)")
+	httpsFirst := flag.Bool("https-first", false, "http:// 주소(스킴 없이 입력한 주소 포함)를 https://로 먼저 시도하고, 연결에 실패하면 http://로 엶")
+	ocsp := flag.Bool("ocsp", false, "https 서버가 보낸 OCSP 응답(staple)을 검증하고 폐기된 인증서면 연결을 끊음")
+	sourceHeaders := flag.Bool("source-headers", false, "view-source: 소스 앞에 상태 줄, 응답 헤더, 리다이렉트된 최종 URL을 주석으로 붙임")
+	perf := flag.Bool("perf", false, "페이지를 표시한 뒤 DNS, 연결, TLS, TTFB, 다운로드, 파싱, 레이아웃, 그리기에 걸린 시간과 요청 수를 stderr에 출력")
+	noColor := flag.Bool("no-color", false, "대화형 모드에서 페이지 CSS 색과 테마 색을 쓰지 않음 (굵게, 밑줄만 표시, NO_COLOR 환경 변수와 같음)")
+	flag.Parse()
 
-	if len(os.Args) < 2 {
-		cwd, err := os.Getwd()
-		if err != nil {
-			fmt.Println("현재 디렉토리를 가져올 수 없습니다: ", err)
+	var dumpFormat browser.DumpFormat
+	if *dump != "" {
+		var err error
+		if dumpFormat, err = browser.ParseDumpFormat(*dump); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
+	}
+
+	net.GlobalParseOptions.Strict = *strict
+	net.VerifyStapledOCSP = *ocsp
+	net.HTTPSFirst = *httpsFirst
+	if *sourceHeaders {
+		net.FetcherRegistry[url.SchemeViewSource] = &net.ViewSourceFetcher{Headers: true}
+	}
+	a11yMode = *a11y
+	if *retry > 1 {
+		policy := net.DefaultRetryPolicy
+		policy.MaxAttempts = *retry
+		net.GlobalRetryPolicy = &policy
+	}
+
+	// --dump 출력은 다른 도구가 읽으므로 터미널이어도 상태 메시지를 섞지 않음
+	interactive = term.IsTerminal(os.Stdout) && dumpFormat == ""
+
+	// 파이프 출력에서는 로그를 끔 (--verbose로 다시 켤 수 있음)
+	if !interactive && !*verbose {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
 
-		urlStr = fmt.Sprintf("file:///%s/index.html", strings.ReplaceAll(cwd, "\\", "/"))
-		fmt.Printf("기본 파일 열기: %s\n", urlStr)
+	// 폭 결정: --width > 터미널 크기 > 기본값 (파이프/파일 출력)
+	if *width > 0 {
+		viewportWidth = *width
 	} else {
-		urlStr = os.Args[1]
+		viewportWidth = term.Width(layout.DefaultWidth)
 	}
 
-	load(urlStr)
+	// 터미널에서는 흩어진 로그 대신 한 줄짜리 진행 상황을 보여줌 (--verbose면 로그를 그대로 출력)
+	traces := []*net.Trace{net.LogTrace(logger.Logger)}
+	if interactive && !*verbose && !*tuiMode {
+		logger.Logger = log.New(io.Discard, "", 0)
+		status = term.NewStatusLine(os.Stdout)
+		progress = &net.Progress{OnChange: func(text string) {
+			status.Set(layout.Truncate(text, viewportWidth-1))
+		}}
+		traces = []*net.Trace{progress.Trace()}
+	}
+	if *perf && !*tuiMode {
+		timing = net.NewTiming()
+		traces = append(traces, timing.Trace())
+	}
+	if status != nil || timing != nil {
+		net.GlobalTrace = net.MultiTrace(traces...)
+	}
+
+	profile, err := config.OpenProfile(*profileName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	sites, err = sitesettings.Open(filepath.Join(profile.Dir, sitesettings.FileName))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	net.HTTPSFirstExempt = func(u *url.URL) bool { return sites.For(u).AllowInsecure }
+
+	// 인증서 검증에 실패한 사이트는 about:site-settings의 결정을 따르고, 결정이 없으면 터미널에서 물음
+	// (대화형 모드는 화면을 쓰므로 묻지 않고 거부함)
+	perms = &permissions.Manager{Sites: sites}
+	if term.IsTerminal(os.Stdin) && !*tuiMode {
+		perms.Prompter = &permissions.TerminalPrompter{In: os.Stdin, Out: os.Stderr}
+	}
+	net.AllowInsecureCertificate = func(u *url.URL, err error) bool {
+		statusf("인증서를 검증하지 못했습니다 (%s): %v\n", u.Host, err)
+		ok, err := perms.Check(u, permissions.InsecureCertificate)
+		if err != nil {
+			logger.Logger.Printf("%v", err)
+		}
+		return ok
+	}
+
+	net.GlobalCookieJar, err = net.OpenCookieJar(filepath.Join(profile.CookiesDir(), net.CookiesFileName))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *tofu != "" {
+		mode, err := net.ParsePinMode(*tofu)
+		if err == nil {
+			net.GlobalPinStore, err = net.OpenPinStore(filepath.Join(profile.Dir, net.PinsFileName), mode)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !*tuiMode {
+			// 로그를 끈 파이프 출력에서도 경고는 보이도록
+			net.GlobalPinStore.Warn = func(err error) { fmt.Fprintln(os.Stderr, "경고:", err) }
+		}
+	}
+
+	if *spoolDir != "" {
+		net.GlobalSpool, err = net.NewSpool(*spoolDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// 프로필을 직접 지정하면 캐시도 프로필 디렉토리에 유지
+	var profileCache string
+	if *profileName != "" {
+		profileCache = filepath.Join(profile.CacheDir(), profileCacheFile)
+		if _, statErr := os.Stat(profileCache); statErr == nil {
+			if err := importCache(profileCache); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+	if *cacheImport != "" {
+		if err := importCache(*cacheImport); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+
+	if *configPath == "" {
+		*configPath = profile.ConfigFile()
+	}
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if cfg.IPFSGateway != "" {
+		gateway := &net.IPFSFetcher{Gateway: cfg.IPFSGateway}
+		net.FetcherRegistry[url.SchemeIPFS] = gateway
+		net.FetcherRegistry[url.SchemeIPNS] = gateway
+	}
+	cfg.ApplySearchKeywords(url.SearchKeywords)
+	if net.GlobalUserAgents, err = net.ParseUserAgentRules(cfg.UserAgents); err != nil {
+		fmt.Fprintf(os.Stderr, "설정 파일 오류 (%s): %v\n", *configPath, err)
+		os.Exit(1)
+	}
+	net.DoNotTrack, net.GlobalPrivacyControl = cfg.DoNotTrack, cfg.GlobalPrivacyControl
+	net.FirstPartyIsolation = cfg.FirstPartyIsolation
+	net.CheckLookalikes = cfg.LookalikeWarning
+
+	// 하위 명령: gobrowser [옵션] diff <url> [<url2>], gobrowser [옵션] archive <url> [날짜],
+	// gobrowser [옵션] raw <host:port> [--tls], gobrowser [옵션] bookmarks import|export <파일>,
+	// gobrowser [옵션] cookies list|clear [도메인]
+	commands := map[string]func(args []string) error{
+		"diff":      func(args []string) error { return runDiff(args, *diffWait) },
+		"archive":   func(args []string) error { return runArchive(args, *raw) },
+		"raw":       runRaw,
+		"bookmarks": func(args []string) error { return runBookmarks(args, profile) },
+		"cookies":   func(args []string) error { return runCookies(args, net.GlobalCookieJar) },
+	}
+	if run, ok := commands[flag.Arg(0)]; ok {
+		if err := run(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	statusf("=== Go Web Browser ===\n")
+	urls := cfg.StartPages(flag.Args(), defaultHomepage())
+	if flag.NArg() == 0 {
+		statusf("시작 페이지 열기: %s\n", urls[0])
+	}
+
+	switch {
+	case *screenshot != "":
+		err = runScreenshot(urls[0], *screenshot, *viewport, cfg, profile)
+	case dumpFormat != "":
+		for _, urlStr := range urls {
+			if err = runDump(urlStr, dumpFormat); err != nil {
+				break
+			}
+		}
+	case *watch > 0:
+		err = runWatch(urls[0], *watch, *watchHook, *raw)
+	case *tuiMode:
+		err = runTUI(urls, tuiOptions{
+			profile:        profile,
+			config:         cfg,
+			configPath:     *configPath,
+			restoreSession: *restoreSession || cfg.RestoreSession(),
+			urlGiven:       flag.NArg() > 0,
+			noColor:        *noColor,
+		})
+	default:
+		for _, urlStr := range urls {
+			err = load(urlStr, *raw)
+			if timing != nil {
+				printTiming(urlStr)
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	if status != nil {
+		status.Clear()
+	}
+
+	for _, path := range []string{profileCache, *cacheExport} {
+		if path == "" {
+			continue
+		}
+		if exportErr := exportCache(path); exportErr != nil {
+			fmt.Fprintln(os.Stderr, exportErr)
+		}
+	}
+
+	if err != nil {
+		if !errors.As(err, &shownError{}) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+// printTiming: 방금 표시한 페이지의 단계별 시간과 리소스 수를 stderr에 출력 (--perf)
+func printTiming(urlStr string) {
+	timing.Stop()
+	if status != nil {
+		status.Clear()
+	}
+	fmt.Fprintf(os.Stderr, "\n=== 성능: %s ===\n%s", urlStr, timing.Summary())
+}
+
+// defaultHomepage: 홈페이지가 설정되지 않았을 때 여는 현재 디렉토리의 index.html
+func defaultHomepage() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "현재 디렉토리를 가져올 수 없습니다: ", err)
+	}
+	return fmt.Sprintf("file:///%s/index.html", strings.ReplaceAll(cwd, "\\", "/"))
+}
+
+// tuiOptions: 대화형 모드 관련 옵션
+type tuiOptions struct {
+	profile        *config.Profile
+	config         *config.Config
+	configPath     string // 설정 파일 경로 (에러 메시지용)
+	restoreSession bool   // 지난 세션의 탭 복원
+	urlGiven       bool   // 명령줄에 URL을 직접 지정했는지
+	noColor        bool   // 색 없이 글자 모양만 표시 (--no-color)
+}
+
+// runTUI: 전체 화면 대화형 모드로 urls를 각각 탭으로 열고 종료할 때까지 키 입력을 처리
+// 설정 파일의 키 바인딩을 적용하고, 북마크와 세션은 프로필 디렉토리에 저장
+func runTUI(urls []string, opts tuiOptions) error {
+	if !term.IsTerminal(os.Stdin) || !interactive {
+		return fmt.Errorf("대화형 모드는 터미널에서만 사용할 수 있습니다")
+	}
+	width, height, err := term.Size(os.Stdout.Fd())
+	if err != nil {
+		return err
+	}
+
+	store, err := bookmarks.Open(filepath.Join(opts.profile.BookmarksDir(), bookmarks.FileName))
+	if err != nil {
+		return err
+	}
+
+	visits, err := history.Open(filepath.Join(opts.profile.HistoryDir(), history.FileName))
+	if err != nil {
+		return err
+	}
+
+	// 로그가 화면을 덮어쓰지 않도록 끔
+	logger.Logger = log.New(io.Discard, "", 0)
+
+	app := tui.NewApp(newBrowser(), width, height)
+	net.GlobalTrace = app.Trace()
+	app.Bookmarks = store
+	app.History = visits
+	app.Sites = sites
+	app.Cookies = net.GlobalCookieJar
+	if app.Theme, err = opts.config.ResolveTheme(); err != nil {
+		return err
+	}
+	app.Theme.Depth = theme.DetectDepth()
+	if opts.noColor {
+		app.Theme.Depth = theme.NoColor
+	}
+	if err := app.Bindings.Apply(opts.config.Keys); err != nil {
+		return fmt.Errorf("설정 파일의 키 바인딩 오류 (%s): %w", opts.configPath, err)
+	}
+	app.Player = opts.config.Player
+	app.Handlers = make(map[url.Scheme]string)
+	for scheme, command := range opts.config.Handlers {
+		app.Handlers[url.Scheme(strings.ToLower(scheme))] = command
+	}
+	app.SessionFile = filepath.Join(opts.profile.Dir, session.FileName)
+	tui.DrawBorders = opts.config.Borders
+	if tui.UserStyles, err = opts.profile.UserStylesheet(); err != nil {
+		return err
+	}
+
+	restored := false
+	if opts.restoreSession {
+		s, err := session.Load(app.SessionFile)
+		switch {
+		case errors.Is(err, session.ErrNoSession):
+			// 저장된 세션이 없으면 평소처럼 시작
+		case err != nil:
+			return err
+		default:
+			if err := app.Restore(s); err != nil {
+				return err
+			}
+			restored = true
+		}
+	}
+	// 세션을 복원했으면 명령줄에 직접 지정한 URL만 새 탭으로 엶
+	if !restored || opts.urlGiven {
+		if err := app.OpenTabs(urls); err != nil {
+			return err
+		}
+	}
+	return app.Run(os.Stdin, os.Stdout)
+}
+
+// newBrowser: 프로필의 사이트별 설정을 따르는 Browser
+func newBrowser() *browser.Browser {
+	return browser.New(browser.Options{Sites: sites, Check: checkNavigation})
+}
+
+// runScreenshot: urlStr을 viewport 크기("1024x768")로 레이아웃한 모습을 PNG 파일로 저장
+// 색은 설정 파일의 테마를 따르고, 프로필의 user.css를 사용자 스타일시트로 적용함
+func runScreenshot(urlStr, path, viewport string, cfg *config.Config, profile *config.Profile) error {
+	var width, height int
+	if _, err := fmt.Sscanf(viewport, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return fmt.Errorf("잘못된 화면 크기: %q (예: 1024x768)", viewport)
+	}
+	th, err := cfg.ResolveTheme()
+	if err != nil {
+		return err
+	}
+	if tui.UserStyles, err = profile.UserStylesheet(); err != nil {
+		return err
+	}
+
+	page, err := newBrowser().Navigate(urlStr)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("스크린샷 저장 실패: %w", err)
+	}
+	if err := png.Encode(f, tui.Screenshot(page, &th, width, height)); err != nil {
+		f.Close()
+		return fmt.Errorf("스크린샷 저장 실패: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("스크린샷 저장 실패: %w", err)
+	}
+	statusf("스크린샷 저장: %s (%dx%d)\n", path, width, height)
+	return nil
+}
+
+// runDump: urlStr을 가져와서 렌더링하지 않고 format 형식으로 표준 출력에 씀
+func runDump(urlStr string, format browser.DumpFormat) error {
+	page, err := newBrowser().Navigate(urlStr)
+	if err != nil {
+		return err
+	}
+	return page.WriteDump(os.Stdout, format)
+}
+
+// runWatch: urlStr을 interval마다 다시 가져와서 바뀌었을 때만 다시 표시하고 hook 명령을 실행함
+//
+// 다시 가져오기는 net.Revalidate로 조건부 요청을 보내므로 바뀌지 않았으면 본문을 다시 받지 않음
+// 일시적인 실패는 알리기만 하고 계속 지켜봄 (Ctrl+C로 끝냄)
+func runWatch(urlStr string, interval time.Duration, hook string, raw bool) error {
+	urlObj, resp, err := fetchURL(urlStr)
+	if err != nil {
+		return err
+	}
+	if err := render(urlObj.Scheme, resp, raw); err != nil {
+		return err
+	}
+
+	for {
+		time.Sleep(interval)
+		next, modified, err := net.Revalidate(resp)
+		now := time.Now().Format("15:04:05")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] 다시 가져오기 실패: %v\n", now, err)
+			continue
+		}
+		resp = next
+		if !modified {
+			statusf("[%s] 바뀌지 않음\n", now)
+			continue
+		}
+
+		if interactive {
+			fmt.Print("\x1b[H\x1b[2J") // 화면을 지우고 새 내용을 처음부터 표시
+		}
+		statusf("[%s] 바뀜: %s\n", now, resp.URL.Redacted())
+		if err := render(urlObj.Scheme, resp, raw); err != nil {
+			return err
+		}
+		if hook != "" {
+			if err := runHook(hook, resp.URL.Redacted()); err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] --watch-hook 실행 실패: %v\n", now, err)
+			}
+		}
+	}
+}
+
+// runHook: 셸 명령을 실행함 (WATCH_URL 환경 변수에 바뀐 페이지 주소, 출력은 stderr로)
+func runHook(command, pageURL string) error {
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	cmd.Env = append(os.Environ(), "WATCH_URL="+pageURL)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// runArchive: Wayback Machine에서 날짜에 가장 가까운 보관본을 찾아 일반 페이지처럼 표시
+// (날짜를 생략하면 가장 최근 보관본)
+func runArchive(args []string, raw bool) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("사용법: go-web-browser [옵션] archive <url> [날짜 (예: 2006, 2006-01-02)]")
+	}
+	var at time.Time
+	if len(args) == 2 {
+		var err error
+		if at, err = archive.ParseDate(args[1]); err != nil {
+			return err
+		}
+	}
+
+	snapshot, err := archive.Closest(args[0], at)
+	if err != nil {
+		return err
+	}
+	statusf("보관본: %s (%s 보관, 상태 %d)\n", snapshot.URL, snapshot.Time.Format("2006-01-02 15:04"), snapshot.StatusCode)
+	return load(snapshot.URL, raw)
+}
+
+// runDiff: 같은 URL을 두 번(또는 두 URL을) 가져와서 보이는 텍스트의 차이를 unified diff로 출력
+//
+// 같은 URL의 두 번째 요청도 캐시를 그대로 거치므로 캐시 만료와 재요청 동작을 확인하는 데도 씀
+func runDiff(args []string, wait time.Duration) error {
+	var first, second string
+	switch len(args) {
+	case 1:
+		first, second = args[0], args[0]
+	case 2:
+		first, second = args[0], args[1]
+	default:
+		return errors.New("사용법: go-web-browser [옵션] diff <url> [<url2>]")
+	}
+
+	b := newBrowser()
+	before, err := b.Navigate(first)
+	if err != nil {
+		return err
+	}
+	if first == second && wait > 0 {
+		statusf("%s 기다린 뒤 다시 가져옵니다\n", wait)
+		time.Sleep(wait)
+	}
+	after, err := b.Navigate(second)
+	if err != nil {
+		return err
+	}
+
+	aName, bName := before.URL().Redacted(), after.URL().Redacted()
+	if first == second {
+		aName, bName = aName+" (1)", bName+" (2)"
+	}
+	if after.Response.FromCache {
+		bName += " (캐시)"
+	}
+	out := diff.Unified(aName, bName, textLines(before), textLines(after), diff.DefaultContext)
+	if out == "" {
+		statusf("차이 없음\n")
+		return nil
+	}
+	// 페이지 텍스트의 ESC 시퀀스가 터미널을 조작하지 못하게 제어 문자는 기호로 바꿈
+	_, err = io.WriteString(stdout(), term.Sanitize(out))
+	return err
+}
+
+// textLines: 페이지의 보이는 텍스트를 줄 단위로 나눔 (줄 끝 공백은 비교하지 않음)
+func textLines(page *browser.Page) []string {
+	lines := strings.Split(page.Text(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return lines
+}
+
+// importCache: 캐시 스냅샷 파일을 읽어 GlobalCache를 채움
+func importCache(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("캐시 스냅샷 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	n, err := net.GlobalCache.Import(f)
+	if err != nil {
+		return err
+	}
+	statusf("캐시 %d개 항목을 불러왔습니다: %s\n", n, path)
+	return nil
+}
+
+// exportCache: GlobalCache 내용을 스냅샷 파일로 저장
+func exportCache(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("캐시 스냅샷 저장 실패: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("캐시 스냅샷 저장 실패: %w", err)
+	}
+	if err := net.GlobalCache.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// headerFlags: 여러 번 줄 수 있는 -H "이름: 값" 옵션
+type headerFlags []string
+
+func (h *headerFlags) String() string { return strings.Join(*h, ", ") }
+
+func (h *headerFlags) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+// runBookmarks: bookmarks 하위 명령, 프로필의 북마크를 Netscape 북마크 HTML 파일에서 가져오거나 파일로 내보냄
+// (Firefox, Chrome의 "북마크 HTML로 내보내기/가져오기"와 같은 형식, 파일이 "-"면 표준 입출력)
+func runBookmarks(args []string, profile *config.Profile) error {
+	const usage = "사용법: go-web-browser [옵션] bookmarks import|export <파일>"
+	if len(args) != 2 {
+		return errors.New(usage)
+	}
+	store, err := bookmarks.Open(filepath.Join(profile.BookmarksDir(), bookmarks.FileName))
+	if err != nil {
+		return err
+	}
+
+	switch action, path := args[0], args[1]; action {
+	case "import":
+		in := os.Stdin
+		if path != "-" {
+			if in, err = os.Open(path); err != nil {
+				return err
+			}
+			defer in.Close()
+		}
+		n, err := store.ImportNetscape(in)
+		if err != nil {
+			return err
+		}
+		statusf("북마크 %d개를 가져왔습니다 (전체 %d개)\n", n, len(store.All()))
+		return nil
+	case "export":
+		if path == "-" {
+			return store.ExportNetscape(os.Stdout)
+		}
+		out, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := store.ExportNetscape(out); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		statusf("북마크 %d개를 내보냈습니다: %s\n", len(store.All()), path)
+		return nil
+	}
+	return errors.New(usage)
+}
+
+// runRaw: raw 하위 명령, 요청 바이트를 그대로 보내고 받은 바이트를 그대로 출력 (텔넷처럼 프로토콜 관찰용)
+//
+// 표준 입력이 파이프나 파일이면 그 내용을 요청으로 보내고 (줄 끝 \n은 \r\n으로 바꿈, -binary면 그대로)
+// 아니면 -method, -path, -H로 요청을 만듦
+// 연결에는 브라우저와 같은 Dialer, TLS 설정, 인증서 핀을 씀
+func runRaw(args []string) error {
+	const usage = "사용법: go-web-browser [옵션] raw <host:port> [-tls] [-method GET] [-path /] [-H \"이름: 값\"]... [-idle 2s] [-binary]"
+	fs := flag.NewFlagSet("raw", flag.ContinueOnError)
+	useTLS := fs.Bool("tls", false, "TLS로 연결")
+	method := fs.String("method", "GET", "요청 메서드 (표준 입력으로 요청을 주지 않을 때)")
+	path := fs.String("path", "/", "요청 경로 (표준 입력으로 요청을 주지 않을 때)")
+	idle := fs.Duration("idle", net.DefaultRawIdle, "이 시간 동안 아무것도 오지 않으면 끝냄")
+	binary := fs.Bool("binary", false, "표준 입력의 줄 끝을 바꾸지 않고 그대로 보냄")
+	var headers headerFlags
+	fs.Var(&headers, "H", "덧붙일 요청 헤더 (여러 번 줄 수 있음)")
+
+	// 주소는 옵션 앞에 와도 되고 뒤에 와도 됨
+	var address string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		address, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if address == "" && fs.NArg() > 0 {
+		address = fs.Arg(0)
+	}
+	if address == "" {
+		return errors.New(usage)
+	}
+
+	var data []byte
+	if !term.IsTerminal(os.Stdin) {
+		var err error
+		if data, err = io.ReadAll(os.Stdin); err != nil {
+			return err
+		}
+		if !*binary {
+			data = []byte(strings.ReplaceAll(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n", "\r\n"))
+		}
+	}
+	if len(data) == 0 {
+		host := address
+		if h, _, ok := strings.Cut(address, ":"); ok && !strings.HasPrefix(address, "[") {
+			host = h
+		}
+		var req strings.Builder
+		fmt.Fprintf(&req, "%s %s %s\r\n", *method, *path, net.HTTPVersion)
+		fmt.Fprintf(&req, "%s: %s\r\n%s: %s\r\n", net.HeaderHost, host, net.HeaderUserAgent, net.UserAgent)
+		for _, h := range headers {
+			req.WriteString(h + "\r\n")
+		}
+		req.WriteString("\r\n")
+		data = []byte(req.String())
+	}
+
+	fetcher, _ := net.FetcherRegistry[url.SchemeHTTPS].(*net.HTTPFetcher)
+	if fetcher == nil {
+		fetcher = &net.HTTPFetcher{}
+	}
+	result, err := fetcher.RawExchange(net.RawRequest{Address: address, TLS: *useTLS, Data: data, Idle: *idle}, os.Stdout)
+	if err != nil {
+		return err
+	}
+	end := "서버가 연결을 닫음"
+	if !result.Closed {
+		end = fmt.Sprintf("%s 동안 응답 없음", *idle)
+	}
+	fmt.Fprintf(os.Stderr, "\n--- %d바이트 받음, %s, %s ---\n", result.Received, result.Elapsed.Round(time.Millisecond), end)
+	return nil
+}
+
+// runCookies: cookies list|clear [도메인] 하위 명령 (프로필에 저장된 쿠키를 보거나 지움)
+//
+// 도메인을 주면 그 도메인과 하위 도메인의 쿠키만 보여주거나 지움
+func runCookies(args []string, jar *net.CookieJar) error {
+	const usage = "사용법: go-web-browser [옵션] cookies list|clear [도메인]"
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New(usage)
+	}
+	domain := ""
+	if len(args) == 2 {
+		domain = strings.TrimPrefix(strings.ToLower(args[1]), ".")
+	}
+
+	switch args[0] {
+	case "list":
+		out := stdout()
+		for _, c := range jar.All() {
+			if domain != "" && c.Domain != domain && !strings.HasSuffix(c.Domain, "."+domain) {
+				continue
+			}
+			expires := "세션"
+			if !c.Session() {
+				expires = c.Expires.Local().Format(time.DateTime)
+			}
+			if c.Partition != "" {
+				expires += "\t" + c.Partition // first-party isolation으로 받은 최상위 사이트
+			}
+			fmt.Fprintf(out, "%s\t%s\t%s=%s\t%s\n", c.Domain, c.Path, c.Name, c.Value, expires)
+		}
+		return nil
+	case "clear":
+		var n int
+		var err error
+		if domain == "" {
+			n, err = jar.Clear()
+		} else {
+			n, err = jar.DeleteDomain(domain)
+		}
+		if err != nil {
+			return err
+		}
+		statusf("쿠키 %d개를 지웠습니다\n", n)
+		return nil
+	}
+	return errors.New(usage)
 }
@@ -0,0 +1,209 @@
+// Package charset detects and decodes the character encoding of web pages.
+// This file contains encoding labels, detection order (BOM, header, <meta>, guess) and decoding.
+package charset
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// 지원하는 인코딩 이름 (WHATWG Encoding 표준의 이름)
+const (
+	UTF8        = "utf-8"
+	UTF16LE     = "utf-16le"
+	UTF16BE     = "utf-16be"
+	EUCKR       = "euc-kr" // CP949(UHC) 확장 포함
+	Windows1252 = "windows-1252"
+)
+
+// labels는 인코딩 라벨 → 이름 (WHATWG Encoding 표준의 라벨 중 일부)
+//
+// 브라우저처럼 ks_c_5601-1987, cp949 등은 모두 EUC-KR(UHC 확장 포함)로,
+// iso-8859-1, us-ascii는 windows-1252로 처리함
+var labels = map[string]string{
+	"utf-8":             UTF8,
+	"utf8":              UTF8,
+	"unicode-1-1-utf-8": UTF8,
+	"utf-16":            UTF16LE,
+	"utf-16le":          UTF16LE,
+	"utf-16be":          UTF16BE,
+	"euc-kr":            EUCKR,
+	"cp949":             EUCKR,
+	"windows-949":       EUCKR,
+	"x-windows-949":     EUCKR,
+	"ks_c_5601-1987":    EUCKR,
+	"ks_c_5601-1989":    EUCKR,
+	"ksc5601":           EUCKR,
+	"ksc_5601":          EUCKR,
+	"iso-ir-149":        EUCKR,
+	"korean":            EUCKR,
+	"csksc56011987":     EUCKR,
+	"windows-1252":      Windows1252,
+	"cp1252":            Windows1252,
+	"x-cp1252":          Windows1252,
+	"iso-8859-1":        Windows1252,
+	"iso8859-1":         Windows1252,
+	"latin1":            Windows1252,
+	"l1":                Windows1252,
+	"us-ascii":          Windows1252,
+	"ascii":             Windows1252,
+	"ansi_x3.4-1968":    Windows1252,
+	"csisolatin1":       Windows1252,
+	"iso_8859-1:1987":   Windows1252,
+	"iso-ir-100":        Windows1252,
+	"ibm819":            Windows1252,
+	"cp819":             Windows1252,
+}
+
+// Lookup은 인코딩 라벨(대소문자, 앞뒤 공백 무시)을 지원하는 인코딩 이름으로 바꿈 (모르면 "")
+func Lookup(label string) string {
+	return labels[strings.ToLower(strings.Trim(label, " \t\n\f\r\"'"))]
+}
+
+// metaPrescanBytes는 <meta> charset을 찾는 본문 앞부분의 크기 (HTML 표준의 prescan과 같음)
+const metaPrescanBytes = 1024
+
+// Detect는 본문의 인코딩을 결정함
+//
+// 순서: BOM > Content-Type 헤더의 charset > HTML <meta> charset > 본문으로 추측(Guess)
+func Detect(contentType string, body []byte) string {
+	if name, _ := bom(body); name != "" {
+		return name
+	}
+	if name := Lookup(param(contentType, "charset")); name != "" {
+		return name
+	}
+	if name := metaCharset(body); name != "" {
+		return name
+	}
+	return Guess(body)
+}
+
+// Decode는 name 인코딩의 본문을 UTF-8 문자열로 바꿈 (BOM은 제거)
+//
+// 변환할 수 없는 바이트는 U+FFFD로 바뀜. 모르는 인코딩이면 UTF-8로 봄
+func Decode(body []byte, name string) string {
+	if bomName, n := bom(body); bomName == name {
+		body = body[n:]
+	}
+	switch name {
+	case EUCKR:
+		return decodeEUCKR(body)
+	case Windows1252:
+		return decodeWindows1252(body)
+	case UTF16LE, UTF16BE:
+		return decodeUTF16(body, name == UTF16BE)
+	}
+	return string(body)
+}
+
+// bom은 본문 앞의 BOM이 가리키는 인코딩과 BOM 길이 (없으면 "", 0)
+func bom(body []byte) (string, int) {
+	switch {
+	case bytes.HasPrefix(body, []byte{0xEF, 0xBB, 0xBF}):
+		return UTF8, 3
+	case bytes.HasPrefix(body, []byte{0xFF, 0xFE}):
+		return UTF16LE, 2
+	case bytes.HasPrefix(body, []byte{0xFE, 0xFF}):
+		return UTF16BE, 2
+	}
+	return "", 0
+}
+
+// param은 Content-Type 같은 헤더 값에서 파라미터 값을 찾음 (없으면 "")
+//
+// 예: param("text/html; charset=EUC-KR", "charset") → "EUC-KR"
+func param(value, name string) string {
+	parts := strings.Split(value, ";")
+	for _, part := range parts[1:] {
+		key, val, ok := strings.Cut(part, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+	return ""
+}
+
+// metaCharset은 본문 앞부분의 <meta charset="..."> 또는
+// <meta http-equiv="Content-Type" content="text/html; charset=..."> 에서 인코딩을 찾음
+//
+// <meta>가 UTF-16이라고 해도 ASCII로 읽을 수 있는 문서이므로 UTF-8로 봄 (HTML 표준)
+func metaCharset(body []byte) string {
+	head := strings.ToLower(string(body[:min(len(body), metaPrescanBytes)]))
+	for {
+		start := strings.Index(head, "<meta")
+		if start == -1 {
+			return ""
+		}
+		head = head[start+len("<meta"):]
+		tag := head
+		if end := strings.IndexByte(tag, '>'); end != -1 {
+			tag = tag[:end]
+		}
+
+		i := strings.Index(tag, "charset")
+		if i == -1 {
+			continue
+		}
+		rest := strings.TrimLeft(tag[i+len("charset"):], " \t\n\f\r")
+		if !strings.HasPrefix(rest, "=") {
+			continue
+		}
+		rest = strings.TrimLeft(rest[1:], " \t\n\f\r\"'")
+		end := strings.IndexAny(rest, " \t\n\f\r\"';/")
+		if end != -1 {
+			rest = rest[:end]
+		}
+		switch name := Lookup(rest); name {
+		case "":
+			continue
+		case UTF16LE, UTF16BE:
+			return UTF8
+		default:
+			return name
+		}
+	}
+}
+
+// windows1252High는 windows-1252의 0x80-0x9F (나머지는 Latin-1과 같음, 0은 정의되지 않은 자리)
+var windows1252High = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
+
+// decodeWindows1252는 windows-1252 바이트를 UTF-8로 바꿈
+func decodeWindows1252(body []byte) string {
+	var b strings.Builder
+	b.Grow(len(body))
+	for _, c := range body {
+		r := rune(c)
+		if c >= 0x80 && c < 0xA0 {
+			if r = windows1252High[c-0x80]; r == 0 {
+				r = rune(c) // 정의되지 않은 자리는 같은 코드의 C1 제어 문자 (WHATWG)
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// decodeUTF16은 UTF-16 바이트를 UTF-8로 바꿈 (홀수 바이트로 끝나면 U+FFFD)
+func decodeUTF16(body []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(body)/2)
+	for i := 0; i+1 < len(body); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(body[i])<<8|uint16(body[i+1]))
+		} else {
+			units = append(units, uint16(body[i+1])<<8|uint16(body[i]))
+		}
+	}
+	s := string(utf16.Decode(units))
+	if len(body)%2 == 1 {
+		s += string(utf8.RuneError)
+	}
+	return s
+}
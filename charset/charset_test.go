@@ -0,0 +1,94 @@
+package charset_test
+
+import (
+	"go-web-browser/charset"
+	"testing"
+)
+
+// korean은 "한국어 웹 페이지입니다. 오래된 사이트는 이렇게 인코딩되어 있습니다."의 EUC-KR 바이트
+const korean = "\xc7\xd1\xb1\xb9\xbe\xee \xc0\xa5 \xc6\xe4\xc0\xcc\xc1\xf6\xc0\xd4\xb4\xcf\xb4\xd9. " +
+	"\xbf\xc0\xb7\xa1\xb5\xc8 \xbb\xe7\xc0\xcc\xc6\xae\xb4\xc2 \xc0\xcc\xb7\xb8\xb0\xd4 " +
+	"\xc0\xce\xc4\xda\xb5\xf9\xb5\xc7\xbe\xee \xc0\xd6\xbd\xc0\xb4\xcf\xb4\xd9."
+
+// TestDecode 인코딩별 UTF-8 변환 (CP949 확장 한글, 잘못된 바이트 포함)
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		body string
+		name string
+		want string
+	}{
+		{"\xbe\xc8\xb3\xe7\xc7\xcf\xbc\xbc\xbf\xe4", charset.EUCKR, "안녕하세요"},
+		{"\x8c\x63\xb9\xe6\xb0\xa2\xc7\xcf", charset.EUCKR, "똠방각하"}, // 똠은 CP949 확장 영역
+		{"a\xb0\xa1b\xb0", charset.EUCKR, "a가b�"},
+		{"\xb0<p>", charset.EUCKR, "�<p>"}, // 둘째 바이트가 ASCII면 잃지 않음
+		{"caf\xe9 \x80", charset.Windows1252, "café €"},
+		{"\xff\xfeh\x00i\x00", charset.UTF16LE, "hi"},
+		{"\x00h\x00i", charset.UTF16BE, "hi"},
+		{"\xef\xbb\xbfhello", charset.UTF8, "hello"},
+	}
+
+	for _, tt := range tests {
+		if got := charset.Decode([]byte(tt.body), tt.name); got != tt.want {
+			t.Errorf("Decode(%q, %q) = %q; want %q", tt.body, tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDetect BOM > 헤더 > <meta> > 추측 순서
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		{"text/html; charset=EUC-KR", "<p>hi", charset.EUCKR},
+		{`text/html; charset="ks_c_5601-1987"`, "<p>hi", charset.EUCKR},
+		{"text/html; charset=utf-8", "\xff\xfe<\x00", charset.UTF16LE},
+		{"text/html", `<meta charset="euc-kr"><p>hi`, charset.EUCKR},
+		{"text/html", `<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=ISO-8859-1">`, charset.Windows1252},
+		{"text/html", `<meta charset="utf-16">`, charset.UTF8},
+		{"text/html; charset=unknown", "<p>" + korean, charset.EUCKR},
+		{"text/html", "<p>한국어</p>", charset.UTF8},
+	}
+
+	for _, tt := range tests {
+		if got := charset.Detect(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("Detect(%q, %q) = %q; want %q", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
+
+// TestGuess 헤더도 <meta>도 없을 때 한국어 EUC-KR과 서유럽 Latin-1을 구분함
+func TestGuess(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"<html><body>" + korean + "</body></html>", charset.EUCKR},
+		{"\xb0\xa1\xb3\xaa\xb4\xd9", charset.EUCKR}, // 가나다 (짧아서 빈도는 보지 않음)
+		{"Ceci est un caf\xe9 tr\xe8s \xe9l\xe9gant \xe0 No\xebl.", charset.Windows1252},
+		{"\xc3\xa9\xc3\xa8", charset.UTF8}, // 올바른 UTF-8
+		{"plain ascii", charset.UTF8},
+	}
+
+	for _, tt := range tests {
+		if got := charset.Guess([]byte(tt.body)); got != tt.want {
+			t.Errorf("Guess(%q) = %q; want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+// TestLookup 라벨은 대소문자와 공백을 무시하고, 모르는 라벨은 ""
+func TestLookup(t *testing.T) {
+	tests := map[string]string{
+		" CP949 ":   charset.EUCKR,
+		"Latin1":    charset.Windows1252,
+		"UTF8":      charset.UTF8,
+		"shift_jis": "",
+	}
+	for label, want := range tests {
+		if got := charset.Lookup(label); got != want {
+			t.Errorf("Lookup(%q) = %q; want %q", label, got, want)
+		}
+	}
+}
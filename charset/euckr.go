@@ -0,0 +1,112 @@
+// Package charset detects and decodes the character encoding of web pages.
+// This file contains the EUC-KR decoder with the CP949 (UHC) extension.
+package charset
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 한글 음절 범위 (가-힣)
+const (
+	hangulFirst = 0xAC00
+	hangulLast  = 0xD7A3
+)
+
+// uhcExtension은 KS X 1001에 없는 한글 음절 8822자 (유니코드 순서)
+//
+// CP949는 이 음절들을 0x8141부터 순서대로 KS X 1001이 쓰지 않는 자리에 배치하므로
+// 표 없이 KS X 1001 표에서 계산할 수 있음
+var (
+	uhcOnce      sync.Once
+	uhcExtension []rune
+)
+
+// extension은 uhcExtension을 처음 사용할 때 만듦
+func extension() []rune {
+	uhcOnce.Do(func() {
+		var inKSX [hangulLast - hangulFirst + 1]bool
+		for _, r := range ksx1001 {
+			if r >= hangulFirst && r <= hangulLast {
+				inKSX[r-hangulFirst] = true
+			}
+		}
+		for i, ok := range inKSX {
+			if !ok {
+				uhcExtension = append(uhcExtension, rune(hangulFirst+i))
+			}
+		}
+	})
+	return uhcExtension
+}
+
+// uhcTrail은 확장 영역의 둘째 바이트 순번 (0x41-0x5A, 0x61-0x7A, 0x81-0xFE 순서, 아니면 -1)
+func uhcTrail(trail byte) int {
+	switch {
+	case trail >= 0x41 && trail <= 0x5A:
+		return int(trail - 0x41)
+	case trail >= 0x61 && trail <= 0x7A:
+		return int(trail-0x61) + 26
+	case trail >= 0x81 && trail <= 0xFE:
+		return int(trail-0x81) + 52
+	}
+	return -1
+}
+
+// decodeEUCKRPair는 두 바이트를 문자 하나로 바꿈 (정의되지 않은 조합이면 false)
+func decodeEUCKRPair(lead, trail byte) (rune, bool) {
+	if lead >= 0xA1 && lead <= 0xFE && trail >= 0xA1 && trail <= 0xFE {
+		r := rune(ksx1001[int(lead-0xA1)*94+int(trail-0xA1)])
+		return r, r != 0
+	}
+
+	// CP949 확장: 0x81-0xA0행은 178자, 0xA1-0xC6행은 0xA1 미만의 84자
+	t := uhcTrail(trail)
+	var index int
+	switch {
+	case t < 0:
+		return 0, false
+	case lead >= 0x81 && lead <= 0xA0:
+		index = int(lead-0x81)*178 + t
+	case lead >= 0xA1 && lead <= 0xC6 && t < 84:
+		index = 32*178 + int(lead-0xA1)*84 + t
+	default:
+		return 0, false
+	}
+	ext := extension()
+	if index >= len(ext) {
+		return 0, false
+	}
+	return ext[index], true
+}
+
+// decodeEUCKR은 EUC-KR(CP949) 바이트를 UTF-8로 바꿈
+//
+// 잘못된 두 바이트 조합은 U+FFFD로 바꾸고, 둘째 바이트가 ASCII면 그 바이트는
+// 다시 읽음 (WHATWG Encoding 표준과 같이 ASCII 문자를 잃지 않도록)
+func decodeEUCKR(body []byte) string {
+	var b strings.Builder
+	b.Grow(len(body) * 3 / 2)
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c < 0x80 {
+			b.WriteByte(c)
+			continue
+		}
+		if c == 0x80 || c == 0xFF || i+1 >= len(body) {
+			b.WriteRune(utf8.RuneError)
+			continue
+		}
+		if r, ok := decodeEUCKRPair(c, body[i+1]); ok {
+			b.WriteRune(r)
+			i++
+			continue
+		}
+		b.WriteRune(utf8.RuneError)
+		if body[i+1] >= 0x80 {
+			i++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+// Package charset detects and decodes the character encoding of web pages.
+// This file contains the encoding guesser used when headers and <meta> are silent.
+package charset
+
+import "unicode/utf8"
+
+// 추측 기준 (오래된 한국어 사이트에 맞춘 값)
+const (
+	// guessMaxInvalid는 EUC-KR로 볼 때 허용하는 잘못된 두 바이트 조합의 비율 (5%)
+	guessMaxInvalid = 0.05
+	// guessMinHangul은 두 바이트 문자 중 KS X 1001 한글 음절이어야 하는 비율
+	// (한국어 본문은 대부분 한글이고, Latin-1 본문을 EUC-KR로 읽으면 한자나 기호가 나옴)
+	guessMinHangul = 0.6
+	// guessMinCommon은 한글 음절 중 자주 쓰는 음절(commonSyllables)이어야 하는 비율
+	guessMinCommon = 0.15
+	// guessFrequencySample은 빈도를 따지기 시작하는 한글 음절 수 (이보다 짧으면 빈도 무시)
+	guessFrequencySample = 20
+)
+
+// commonSyllables는 한국어 본문에서 자주 나오는 음절 (조사, 어미 등)
+//
+// 실제 한국어 본문은 한글 음절의 30~40%가 이 음절들이지만, 다른 인코딩의 바이트를
+// 우연히 한글로 읽은 경우는 거의 나오지 않음
+var commonSyllables = map[rune]bool{
+	'이': true, '다': true, '는': true, '의': true, '에': true, '을': true,
+	'하': true, '고': true, '가': true, '지': true, '로': true, '한': true,
+	'기': true, '서': true, '도': true, '를': true, '사': true, '어': true,
+	'리': true, '니': true, '으': true, '자': true, '수': true, '시': true,
+	'대': true, '인': true, '정': true, '아': true, '나': true, '게': true,
+	'있': true, '일': true, '과': true, '적': true, '은': true, '해': true,
+}
+
+// Guess는 헤더와 <meta>에 인코딩이 없을 때 본문으로 인코딩을 추측함
+//
+// 올바른 UTF-8이면 UTF-8, EUC-KR(CP949)로 읽었을 때 한국어처럼 보이면 EUC-KR,
+// 둘 다 아니면 windows-1252
+func Guess(body []byte) string {
+	if utf8.Valid(body) {
+		return UTF8
+	}
+	if looksKorean(body) {
+		return EUCKR
+	}
+	return Windows1252
+}
+
+// looksKorean은 본문을 EUC-KR로 읽었을 때 한국어 문서처럼 보이는지 확인함
+//
+// 두 바이트 조합이 대부분 올바르고, 그중 대부분이 KS X 1001 한글 음절이고,
+// 한글 음절이 충분히 많으면 자주 쓰는 음절의 비율까지 확인함
+func looksKorean(body []byte) bool {
+	var pairs, invalid, hangul, common int
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c < 0x80 {
+			continue
+		}
+		pairs++
+		if i+1 >= len(body) {
+			invalid++
+			break
+		}
+		r, ok := decodeEUCKRPair(c, body[i+1])
+		if !ok {
+			invalid++
+			continue
+		}
+		i++
+		// KS X 1001 한글 영역(0xB0A1-0xC8FE)만 셈 (확장 영역의 드문 음절은 세지 않음)
+		if c >= 0xB0 && c <= 0xC8 && body[i] >= 0xA1 {
+			hangul++
+			if commonSyllables[r] {
+				common++
+			}
+		}
+	}
+
+	if pairs == 0 || hangul == 0 {
+		return false
+	}
+	if float64(invalid) > float64(pairs)*guessMaxInvalid {
+		return false
+	}
+	if float64(hangul) < float64(pairs-invalid)*guessMinHangul {
+		return false
+	}
+	if hangul >= guessFrequencySample && float64(common) < float64(hangul)*guessMinCommon {
+		return false
+	}
+	return true
+}
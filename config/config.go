@@ -0,0 +1,134 @@
+// Package config loads user configuration for the browser.
+// This file contains the config file format and its location.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-web-browser/theme"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AppName은 설정 디렉토리 이름
+const AppName = "go-web-browser"
+
+// FileName은 설정 파일 이름
+const FileName = "config.json"
+
+// Config는 설정 파일 내용
+//
+//	{
+//	  "homepage": "https://example.com/",
+//	  "startup": "homepage",
+//	  "keys": {"o": "prompt-open", "C-r": "reload"},
+//	  "theme": "light",
+//	  "colors": {"link": {"fg": "#0066cc", "underline": true}},
+//	  "borders": true,
+//	  "player": "mpv --force-window",
+//	  "handlers": {"mailto": "thunderbird -compose"},
+//	  "ipfs_gateway": "http://127.0.0.1:8080",
+//	  "search_keywords": {"gh": "https://github.com/search?q=%s"},
+//	  "user_agents": {"example.com": "firefox", "*": "default"},
+//	  "do_not_track": true,
+//	  "global_privacy_control": true,
+//	  "first_party_isolation": true,
+//	  "lookalike_warning": true
+//	}
+type Config struct {
+	// Homepage는 URL 없이 실행할 때 여는 페이지 ("" 이면 현재 디렉토리의 index.html)
+	Homepage string `json:"homepage,omitempty"`
+
+	// Startup은 URL 없이 실행할 때의 동작 (StartupHomepage, StartupBlank, StartupSession)
+	Startup string `json:"startup,omitempty"`
+
+	// Keys는 키 이름 → 명령 이름 (기본 키 바인딩을 덮어씀, 명령이 ""이면 바인딩 해제)
+	Keys map[string]string `json:"keys,omitempty"`
+
+	// Theme은 대화형 모드의 색 테마 ("dark", "light", "auto"; ""이면 auto)
+	Theme string `json:"theme,omitempty"`
+
+	// Colors는 테마 항목 이름("link", "heading" 등) → 그 항목의 색 (테마의 값을 덮어씀)
+	Colors map[string]theme.Style `json:"colors,omitempty"`
+
+	// Borders는 대화형 모드에서 CSS 테두리를 상자 그리기 문자로 그릴지 여부
+	Borders bool `json:"borders,omitempty"`
+
+	// Player는 :play 명령이 <audio>, <video>를 넘길 외부 플레이어 명령 (인자는 공백으로 구분, 미디어 URL은 끝에 붙음)
+	Player string `json:"player,omitempty"`
+
+	// Handlers는 스킴("mailto", "tel") → 그 링크를 넘길 외부 프로그램 명령 (URL은 끝에 붙음, 없으면 내용을 페이지로 보여줌)
+	Handlers map[string]string `json:"handlers,omitempty"`
+
+	// IPFSGateway는 ipfs://, ipns:// 주소를 가져올 HTTP 게이트웨이 (""이면 https://ipfs.io)
+	IPFSGateway string `json:"ipfs_gateway,omitempty"`
+
+	// SearchKeywords는 주소창 검색 키워드 → 검색 URL 템플릿 (%s 자리에 검색어가 들어감, 기본 키워드를 덮어씀, ""이면 키워드 해제)
+	SearchKeywords map[string]string `json:"search_keywords,omitempty"`
+
+	// UserAgents는 호스트 → 그 호스트(와 하위 도메인)에 보낼 User-Agent ("firefox", "chrome"이면 그 브라우저의 User-Agent, "*"는 모든 호스트)
+	UserAgents map[string]string `json:"user_agents,omitempty"`
+
+	// DoNotTrack은 모든 요청에 "DNT: 1" 헤더를 보낼지 여부
+	DoNotTrack bool `json:"do_not_track,omitempty"`
+
+	// GlobalPrivacyControl은 모든 요청에 "Sec-GPC: 1" 헤더를 보낼지 여부
+	GlobalPrivacyControl bool `json:"global_privacy_control,omitempty"`
+
+	// FirstPartyIsolation은 쿠키를 최상위 사이트별로 나눠서 다른 사이트의 하위 리소스 요청이 보지 못하게 할지 여부
+	FirstPartyIsolation bool `json:"first_party_isolation,omitempty"`
+
+	// LookalikeWarning은 주소창에서 연 주소가 잘 알려진 사이트를 흉내 낸 것처럼 보이면 열기 전에 경고 페이지를 보여줄지 여부
+	LookalikeWarning bool `json:"lookalike_warning,omitempty"`
+}
+
+// Dir은 설정 파일과 북마크 등이 저장되는 디렉토리
+//
+// 운영체제의 사용자 설정 디렉토리 아래 AppName 디렉토리
+// (Linux: ~/.config/go-web-browser, macOS: ~/Library/Application Support/go-web-browser)
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("설정 디렉토리를 찾을 수 없습니다: %w", err)
+	}
+	return filepath.Join(base, AppName), nil
+}
+
+// Load는 path의 설정 파일을 읽음
+//
+// 파일이 없으면 빈 설정을 반환함 (설정 파일은 선택 사항)
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("설정 파일 형식 오류 (%s): %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("설정 파일 오류 (%s): %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ApplySearchKeywords는 SearchKeywords 설정을 keywords(보통 url.SearchKeywords)에 덮어씀
+//
+// 키워드는 소문자로 바꿔 넣고, 템플릿이 ""인 키워드는 지움
+func (c *Config) ApplySearchKeywords(keywords map[string]string) {
+	for keyword, template := range c.SearchKeywords {
+		keyword = strings.ToLower(keyword)
+		if template == "" {
+			delete(keywords, keyword)
+			continue
+		}
+		keywords[keyword] = template
+	}
+}
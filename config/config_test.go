@@ -0,0 +1,214 @@
+package config_test
+
+import (
+	"go-web-browser/config"
+	"go-web-browser/css"
+	"go-web-browser/theme"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLoad 설정 파일 읽기
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.FileName)
+	if err := os.WriteFile(path, []byte(`{"keys": {"o": "prompt-open", "q": ""}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	want := map[string]string{"o": "prompt-open", "q": ""}
+	if !reflect.DeepEqual(cfg.Keys, want) {
+		t.Errorf("Keys = %v; want %v", cfg.Keys, want)
+	}
+}
+
+// TestLoad_Missing 설정 파일이 없으면 빈 설정
+func TestLoad_Missing(t *testing.T) {
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if len(cfg.Keys) != 0 {
+		t.Errorf("Keys = %v; want empty", cfg.Keys)
+	}
+}
+
+// TestLoad_Invalid 형식이 잘못되었거나 값이 올바르지 않은 설정 파일은 에러
+func TestLoad_Invalid(t *testing.T) {
+	for _, content := range []string{
+		`{"keys": [`,
+		`{"startup": "restart"}`,
+		`{"theme": "solarized"}`,
+		`{"colors": {"link": {"fg": "purple-ish"}}}`,
+		`{"search_keywords": {"gh": "https://github.com/search"}}`,
+	} {
+		path := filepath.Join(t.TempDir(), config.FileName)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := config.Load(path); err == nil {
+			t.Errorf("Load(%s) should fail", content)
+		}
+	}
+}
+
+// TestApplySearchKeywords 설정의 검색 키워드를 소문자로 더하거나 덮어쓰고, ""이면 지움
+func TestApplySearchKeywords(t *testing.T) {
+	cfg := &config.Config{SearchKeywords: map[string]string{
+		"GH": "https://github.com/search?q=%s",
+		"g":  "https://google.example/?q=%s",
+		"w":  "",
+	}}
+	keywords := map[string]string{"g": "https://www.google.com/search?q=%s", "w": "https://en.wikipedia.org/w/index.php?search=%s"}
+	cfg.ApplySearchKeywords(keywords)
+
+	want := map[string]string{"g": "https://google.example/?q=%s", "gh": "https://github.com/search?q=%s"}
+	if !reflect.DeepEqual(keywords, want) {
+		t.Errorf("keywords = %v; want %v", keywords, want)
+	}
+}
+
+// TestResolveTheme 테마 이름과 항목별 색 덮어쓰기
+func TestResolveTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.FileName)
+	content := `{"theme": "light", "colors": {"link": {"fg": "#0066cc", "underline": true}}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	th, err := cfg.ResolveTheme()
+	if err != nil {
+		t.Fatalf("ResolveTheme() failed: %v", err)
+	}
+	if th.Name != "light" {
+		t.Errorf("Name = %q; want %q", th.Name, "light")
+	}
+	if got, want := th.SGR(theme.Link), "\x1b[4;38;2;0;102;204m"; got != want {
+		t.Errorf("SGR(Link) = %q; want %q", got, want)
+	}
+}
+
+// TestStartPages 명령줄 URL, 빈 페이지, 홈페이지, 기본 페이지 순서
+func TestStartPages(t *testing.T) {
+	const fallback = "file:///cwd/index.html"
+	tests := []struct {
+		name string
+		cfg  config.Config
+		args []string
+		want []string
+	}{
+		{"args as tabs", config.Config{Startup: config.StartupBlank}, []string{"a.com", "b.com"}, []string{"a.com", "b.com"}},
+		{"blank", config.Config{Startup: config.StartupBlank, Homepage: "http://home/"}, nil, []string{"about:blank"}},
+		{"homepage", config.Config{Homepage: "http://home/"}, nil, []string{"http://home/"}},
+		{"session falls back to homepage", config.Config{Startup: config.StartupSession, Homepage: "http://home/"}, nil, []string{"http://home/"}},
+		{"default", config.Config{}, nil, []string{fallback}},
+	}
+
+	for _, tt := range tests {
+		result := tt.cfg.StartPages(tt.args, fallback)
+		if !reflect.DeepEqual(result, tt.want) {
+			t.Errorf("%s: StartPages(%q) = %q; want %q", tt.name, tt.args, result, tt.want)
+		}
+	}
+}
+
+// ============================================================================
+// 프로필
+// ============================================================================
+
+// useTempConfigDir은 사용자 설정 디렉토리를 임시 디렉토리로 바꿈
+func useTempConfigDir(t *testing.T) string {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+	t.Setenv("HOME", tmp)
+	t.Setenv("AppData", tmp)
+	dir, err := config.Dir()
+	if err != nil {
+		t.Fatalf("Dir() failed: %v", err)
+	}
+	return dir
+}
+
+// TestOpenProfile 프로필별 디렉토리
+func TestOpenProfile(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	tests := []struct {
+		name     string
+		wantName string
+		wantDir  string
+	}{
+		{"", config.DefaultProfile, dir},
+		{"default", config.DefaultProfile, dir},
+		{"work", "work", filepath.Join(dir, "profiles", "work")},
+		{"test_1", "test_1", filepath.Join(dir, "profiles", "test_1")},
+	}
+	for _, tt := range tests {
+		p, err := config.OpenProfile(tt.name)
+		if err != nil {
+			t.Errorf("OpenProfile(%q) failed: %v", tt.name, err)
+			continue
+		}
+		if p.Name != tt.wantName || p.Dir != tt.wantDir {
+			t.Errorf("OpenProfile(%q) = %q %q; want %q %q", tt.name, p.Name, p.Dir, tt.wantName, tt.wantDir)
+		}
+	}
+
+	p, _ := config.OpenProfile("work")
+	if p.CacheDir() == p.BookmarksDir() || p.CookiesDir() == p.HistoryDir() {
+		t.Error("프로필의 데이터 디렉토리는 서로 달라야 함")
+	}
+
+	for _, name := range []string{"../etc", "a/b", "한글"} {
+		if _, err := config.OpenProfile(name); err == nil {
+			t.Errorf("OpenProfile(%q) should fail", name)
+		}
+	}
+}
+
+// TestProfiles 만들어진 프로필 목록
+func TestProfiles(t *testing.T) {
+	dir := useTempConfigDir(t)
+	for _, name := range []string{"work", "personal"} {
+		os.MkdirAll(filepath.Join(dir, "profiles", name), 0o755)
+	}
+
+	names, err := config.Profiles()
+	if err != nil {
+		t.Fatalf("Profiles() failed: %v", err)
+	}
+	want := []string{"default", "personal", "work"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Profiles() = %q; want %q", names, want)
+	}
+}
+
+// TestProfile_UserStylesheet 프로필의 user.css를 사용자 출처로 읽음 (없으면 nil)
+func TestProfile_UserStylesheet(t *testing.T) {
+	p := &config.Profile{Name: config.DefaultProfile, Dir: t.TempDir()}
+	sheet, err := p.UserStylesheet()
+	if err != nil || sheet != nil {
+		t.Fatalf("UserStylesheet() without file = %v, %v; want nil, nil", sheet, err)
+	}
+
+	if err := os.WriteFile(p.UserCSSFile(), []byte(`body { background: black !important }`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sheet, err = p.UserStylesheet()
+	if err != nil {
+		t.Fatalf("UserStylesheet() failed: %v", err)
+	}
+	if sheet.Origin != css.UserOrigin || len(sheet.Rules) != 1 {
+		t.Errorf("UserStylesheet() = %+v; want 1 rule with UserOrigin", sheet)
+	}
+}
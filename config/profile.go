@@ -0,0 +1,141 @@
+// Package config loads user configuration for the browser.
+// This file contains named profiles and their data directories.
+package config
+
+import (
+	"fmt"
+	"go-web-browser/css"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DefaultProfile은 --profile 없이 실행할 때 사용하는 프로필 이름
+const DefaultProfile = "default"
+
+// UserCSSFileName은 사용자 스타일시트 파일 이름 (프로필 디렉토리 안)
+const UserCSSFileName = "user.css"
+
+// profilesDir은 설정 디렉토리 안에서 이름 있는 프로필들이 들어가는 디렉토리
+const profilesDir = "profiles"
+
+// Profile은 설정, 쿠키, 캐시, 방문 기록, 북마크를 따로 저장하는 사용자 프로필
+//
+// 프로필마다 디렉토리가 분리되어 있으므로 업무용/개인용/테스트용을
+// 서로 섞이지 않게 사용할 수 있음
+//
+//	~/.config/go-web-browser/               ← default 프로필
+//	~/.config/go-web-browser/profiles/work/ ← work 프로필
+type Profile struct {
+	Name string
+	Dir  string // 프로필 루트 (설정 파일, 세션 파일)
+}
+
+// OpenProfile은 이름으로 프로필을 찾음 (""이면 DefaultProfile)
+//
+// 디렉토리는 만들지 않음 (처음 저장할 때 만들어짐)
+func OpenProfile(name string) (*Profile, error) {
+	base, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return profileIn(base, name)
+}
+
+// profileIn은 base 설정 디렉토리 아래의 프로필을 만듦
+func profileIn(base, name string) (*Profile, error) {
+	if name == "" || name == DefaultProfile {
+		return &Profile{Name: DefaultProfile, Dir: base}, nil
+	}
+	if !validProfileName(name) {
+		return nil, fmt.Errorf("잘못된 프로필 이름: %q (영문자, 숫자, '-', '_'만 사용 가능)", name)
+	}
+	return &Profile{Name: name, Dir: filepath.Join(base, profilesDir, name)}, nil
+}
+
+// validProfileName은 프로필 이름이 디렉토리 이름으로 안전한지 확인함
+func validProfileName(name string) bool {
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// Profiles는 만들어진 프로필 이름들을 정렬해서 반환함 (DefaultProfile 포함)
+func Profiles() ([]string, error) {
+	base, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return profilesIn(base)
+}
+
+// profilesIn은 base 설정 디렉토리의 프로필 이름들
+func profilesIn(base string) ([]string, error) {
+	names := []string{DefaultProfile}
+	entries, err := os.ReadDir(filepath.Join(base, profilesDir))
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("프로필 목록 읽기 실패: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && validProfileName(e.Name()) && e.Name() != DefaultProfile {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names[1:])
+	return names, nil
+}
+
+// ConfigFile은 프로필의 설정 파일 경로
+func (p *Profile) ConfigFile() string {
+	return filepath.Join(p.Dir, FileName)
+}
+
+// CookiesDir은 프로필의 쿠키 저장 디렉토리
+func (p *Profile) CookiesDir() string {
+	return filepath.Join(p.Dir, "cookies")
+}
+
+// CacheDir은 프로필의 HTTP 캐시 스냅샷 디렉토리
+func (p *Profile) CacheDir() string {
+	return filepath.Join(p.Dir, "cache")
+}
+
+// HistoryDir은 프로필의 방문 기록 디렉토리
+func (p *Profile) HistoryDir() string {
+	return filepath.Join(p.Dir, "history")
+}
+
+// BookmarksDir은 프로필의 북마크 디렉토리
+func (p *Profile) BookmarksDir() string {
+	return filepath.Join(p.Dir, "bookmarks")
+}
+
+// UserCSSFile은 프로필의 사용자 스타일시트 경로
+func (p *Profile) UserCSSFile() string {
+	return filepath.Join(p.Dir, UserCSSFileName)
+}
+
+// UserStylesheet는 프로필의 user.css를 사용자 출처(css.UserOrigin) 스타일시트로 읽음
+//
+// 파일이 없으면 nil, nil
+//
+//	/* 어두운 배경 강제 */
+//	body { background: #1e1e1e !important; color: #ddd !important }
+func (p *Profile) UserStylesheet() (*css.Stylesheet, error) {
+	data, err := os.ReadFile(p.UserCSSFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("사용자 스타일시트 읽기 실패: %w", err)
+	}
+	sheet := css.ParseStylesheet(string(data))
+	sheet.Origin = css.UserOrigin
+	return sheet, nil
+}
@@ -0,0 +1,64 @@
+// Package config loads user configuration for the browser.
+// This file contains the homepage and startup behavior settings.
+package config
+
+import (
+	"fmt"
+	"go-web-browser/theme"
+	"go-web-browser/url"
+	"strings"
+)
+
+// Startup 설정 값
+const (
+	StartupHomepage = "homepage" // 홈페이지 열기 (기본값)
+	StartupBlank    = "blank"    // 빈 페이지(about:blank)로 시작
+	StartupSession  = "session"  // 대화형 모드에서 지난 세션 복원 (그 외에는 homepage와 같음)
+)
+
+// BlankPage는 빈 페이지 주소
+const BlankPage = "about:blank"
+
+// validate는 설정 값이 올바른지 확인함
+func (c *Config) validate() error {
+	switch c.Startup {
+	case "", StartupHomepage, StartupBlank, StartupSession:
+	default:
+		return fmt.Errorf("알 수 없는 startup 값: %q (%s, %s, %s 중 하나)", c.Startup, StartupHomepage, StartupBlank, StartupSession)
+	}
+	for keyword, template := range c.SearchKeywords {
+		if template != "" && !strings.Contains(template, url.SearchPlaceholder) {
+			return fmt.Errorf("검색 키워드 %q의 URL에 검색어 자리(%s)가 없음: %q", keyword, url.SearchPlaceholder, template)
+		}
+	}
+	_, err := c.ResolveTheme()
+	return err
+}
+
+// ResolveTheme은 Theme과 Colors 설정으로 대화형 모드의 테마를 만듦
+func (c *Config) ResolveTheme() (theme.Theme, error) {
+	return theme.Resolve(c.Theme, c.Colors)
+}
+
+// RestoreSession은 시작할 때 지난 세션을 복원해야 하는지 확인함
+func (c *Config) RestoreSession() bool {
+	return c.Startup == StartupSession
+}
+
+// StartPages는 시작할 때 열 URL 목록을 반환함
+//
+// 명령줄에 URL이 있으면 모두 열고(대화형 모드에서는 각각 탭으로),
+// 없으면 Startup 설정에 따라 빈 페이지 또는 홈페이지를 엶
+// 홈페이지가 설정되지 않았으면 fallback을 사용함
+func (c *Config) StartPages(args []string, fallback string) []string {
+	if len(args) > 0 {
+		return args
+	}
+	if c.Startup == StartupBlank {
+		return []string{BlankPage}
+	}
+	if c.Homepage != "" {
+		return []string{c.Homepage}
+	}
+	return []string{fallback}
+}
@@ -0,0 +1,191 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains the cascade that computes each element's style.
+package css
+
+import (
+	"go-web-browser/html"
+	"strings"
+)
+
+// Style은 요소의 계산된 스타일 (속성 이름 → 값, 지원하는 속성만)
+type Style map[string]string
+
+// Get은 속성 값을 반환함 (없으면 "")
+func (s Style) Get(property string) string {
+	return s[property]
+}
+
+// priority는 캐스케이드에서 선언의 우선순위 (앞 필드부터 비교)
+//
+//  1. 출처와 !important (layer): 사용자 일반 < 페이지 일반 < 페이지 !important < 사용자 !important
+//  2. style 속성이 스타일시트보다 우선
+//  3. 명시도가 높은 선택자가 우선
+//  4. 나중에 나온 선언이 우선 (여러 <style> 블록은 문서 순서대로 이어 붙임)
+type priority struct {
+	layer       int
+	inline      bool
+	specificity Specificity
+	order       int
+}
+
+// layer는 출처와 !important에 따른 캐스케이드 단계 (클수록 우선)
+func layer(origin Origin, important bool) int {
+	switch {
+	case origin == UserOrigin && important:
+		return 3
+	case important:
+		return 2
+	case origin == UserOrigin:
+		return 0
+	}
+	return 1
+}
+
+// less는 p가 o보다 우선순위가 낮은지 확인함
+func (p priority) less(o priority) bool {
+	if p.layer != o.layer {
+		return p.layer < o.layer
+	}
+	if p.inline != o.inline {
+		return !p.inline
+	}
+	if p.specificity != o.specificity {
+		return p.specificity.Less(o.specificity)
+	}
+	return p.order < o.order
+}
+
+// DocumentSheets는 문서의 <style> 요소들을 문서 순서대로 파싱함
+func DocumentSheets(root *html.Node) []*Stylesheet {
+	var sheets []*Stylesheet
+	for _, n := range root.FindAll("style") {
+		sheets = append(sheets, ParseStylesheet(n.TextContent()))
+	}
+	return sheets
+}
+
+// Compute는 v 화면에서 root 아래 모든 요소의 계산된 스타일을 구함
+//
+// sheets의 규칙(@media 조건이 v와 일치하는 것만)과 각 요소의 style 속성을 캐스케이드 순서(priority)로 적용하고,
+// 상속 속성(color, font-*)은 값이 없으면 부모 값을 물려받음
+// "inherit" 값은 상속 속성이 아니어도 부모 값을 사용함
+func Compute(root *html.Node, v Viewport, sheets ...*Stylesheet) map[*html.Node]Style {
+	active := make([]*Stylesheet, len(sheets))
+	for i, sheet := range sheets {
+		active[i] = sheet.Evaluate(v)
+	}
+
+	styles := make(map[*html.Node]Style)
+	var visit func(n *html.Node, parent Style)
+	visit = func(n *html.Node, parent Style) {
+		style := parent
+		if n.Type == html.ElementNode {
+			style = computeElement(n, parent, active)
+			styles[n] = style
+		}
+		for _, c := range n.Children {
+			visit(c, style)
+		}
+	}
+	visit(root, Style{})
+	return styles
+}
+
+// ComputeDocument는 문서의 <style> 요소들로 v 화면에서 모든 요소의 계산된 스타일을 구함
+//
+// extra 스타일시트는 문서 스타일보다 앞에 둠 (출처와 명시도가 같으면 문서 스타일이 이김)
+// 사용자 스타일시트(UserOrigin)는 extra로 넘기면 사용자 출처의 우선순위로 적용됨
+func ComputeDocument(root *html.Node, v Viewport, extra ...*Stylesheet) map[*html.Node]Style {
+	return Compute(root, v, append(extra, DocumentSheets(root)...)...)
+}
+
+// Evaluate는 @media 조건이 v와 일치하는 규칙만 남긴 스타일시트를 반환함 (조건이 있는 규칙이 없으면 s 그대로)
+func (s *Stylesheet) Evaluate(v Viewport) *Stylesheet {
+	out := &Stylesheet{Rules: make([]Rule, 0, len(s.Rules)), Origin: s.Origin}
+	conditional := false
+	for _, r := range s.Rules {
+		if r.Media == nil {
+			out.Rules = append(out.Rules, r)
+			continue
+		}
+		conditional = true
+		if r.Applies(v) {
+			out.Rules = append(out.Rules, r)
+		}
+	}
+	if !conditional {
+		return s
+	}
+	return out
+}
+
+// computeElement는 요소 하나의 계산된 스타일
+func computeElement(n *html.Node, parent Style, sheets []*Stylesheet) Style {
+	type winner struct {
+		value string
+		prio  priority
+	}
+	won := make(map[string]winner)
+	apply := func(d Declaration, origin Origin, prio priority) {
+		for _, e := range expand(d) {
+			prio.layer = layer(origin, e.Important)
+			if w, ok := won[e.Property]; !ok || w.prio.less(prio) {
+				won[e.Property] = winner{e.Value, prio}
+			}
+		}
+	}
+
+	order := 0
+	for _, sheet := range sheets {
+		for _, rule := range sheet.Rules {
+			spec, ok := rule.Selector.matchSpecificity(n)
+			for _, d := range rule.Declarations {
+				order++
+				if ok {
+					apply(d, sheet.Origin, priority{specificity: spec, order: order})
+				}
+			}
+		}
+	}
+	if inline, ok := n.Attr("style"); ok {
+		for _, d := range ParseDeclarations(inline) {
+			order++
+			apply(d, AuthorOrigin, priority{inline: true, order: order})
+		}
+	}
+
+	style := make(Style)
+	for property, inherited := range properties {
+		if inherited && parent[property] != "" {
+			style[property] = parent[property]
+		}
+	}
+	for property, w := range won {
+		if strings.EqualFold(w.value, "inherit") {
+			if v := parent[property]; v != "" {
+				style[property] = v
+			} else {
+				delete(style, property)
+			}
+			continue
+		}
+		style[property] = w.value
+	}
+	return style
+}
+
+// matchSpecificity는 n과 일치하는 목록의 선택자 중 가장 높은 명시도
+func (sel Selector) matchSpecificity(n *html.Node) (Specificity, bool) {
+	var best Specificity
+	matched := false
+	for _, c := range sel {
+		if !c.Match(n) {
+			continue
+		}
+		if spec := c.Specificity(); !matched || best.Less(spec) {
+			best = spec
+		}
+		matched = true
+	}
+	return best, matched
+}
@@ -0,0 +1,114 @@
+package css_test
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"reflect"
+	"testing"
+)
+
+// TestParseStylesheet 주석, @media 외의 @규칙, 잘못된 선택자는 건너뛰고 나머지 규칙을 사용
+func TestParseStylesheet(t *testing.T) {
+	sheet := css.ParseStylesheet(`
+/* 주석 */ @import url("x.css");
+h1 { color: red; margin: 0 !important }
+@media print { p { color: black } }
+a:hover { color: blue }
+p, .note { font-family: "A; B", serif; background: url(a.png) #fff }
+`)
+	if len(sheet.Rules) != 3 {
+		t.Fatalf("len(Rules) = %d; want 3", len(sheet.Rules))
+	}
+	want := []css.Declaration{{Property: "color", Value: "red"}, {Property: "margin", Value: "0", Important: true}}
+	if !reflect.DeepEqual(sheet.Rules[0].Declarations, want) {
+		t.Errorf("Rules[0] = %+v; want %+v", sheet.Rules[0].Declarations, want)
+	}
+	want = []css.Declaration{{Property: "font-family", Value: `"A; B", serif`}, {Property: "background", Value: "url(a.png) #fff"}}
+	if !reflect.DeepEqual(sheet.Rules[2].Declarations, want) {
+		t.Errorf("Rules[2] = %+v; want %+v", sheet.Rules[2].Declarations, want)
+	}
+	if media := sheet.Rules[1].Media; len(media) != 1 || sheet.Rules[1].Applies(css.DefaultViewport) {
+		t.Errorf("Rules[1].Media = %+v; want print only", media)
+	}
+}
+
+// TestComputeDocument 캐스케이드 순서: !important > style 속성 > 명시도 > 나중 규칙
+func TestComputeDocument(t *testing.T) {
+	doc := html.Parse(`<html><head>
+<style>
+p { color: red; margin: 1em 2em }
+#x { color: green }
+p { color: blue; width: 10em !important }
+.imp { color: gray !important }
+</style>
+<style>p { color: purple; display: block } blink { color: red }</style>
+</head><body>
+<p id="x">id wins over later type rule</p>
+<p>later block wins</p>
+<p style="color: orange; width: 5em">inline</p>
+<p class="imp" style="color: orange">important wins</p>
+<p class="imp" style="color: teal !important">inline important</p>
+<div style="color: navy; font-weight: bold; padding: 1px 2px 3px"><span>inherits</span><em style="color: inherit; padding: inherit">explicit inherit</em></div>
+<p style="float: left; background: rgb(0, 0, 0) no-repeat">unsupported</p>
+</body></html>`)
+	styles := css.ComputeDocument(doc, css.DefaultViewport)
+
+	tests := []struct {
+		text, property, want string
+	}{
+		{"id wins over later type rule", "color", "green"},
+		{"later block wins", "color", "purple"},
+		{"later block wins", "margin-left", "2em"},
+		{"later block wins", "margin-bottom", "1em"},
+		{"later block wins", "display", "block"},
+		{"inline", "color", "orange"},
+		{"inline", "width", "10em"},
+		{"important wins", "color", "gray"},
+		{"inline important", "color", "teal"},
+		{"inherits", "color", "navy"},
+		{"inherits", "font-weight", "bold"},
+		{"inherits", "padding-top", ""},
+		{"explicit inherit", "padding-bottom", "3px"},
+		{"explicit inherit", "padding-left", "2px"},
+		{"unsupported", "float", ""},
+		{"unsupported", "background-color", "rgb(0, 0, 0)"},
+	}
+
+	byText := map[string]*html.Node{}
+	for n := range styles {
+		if len(n.Children) == 1 && n.Children[0].Type == html.TextNode {
+			byText[n.Children[0].Data] = n
+		}
+	}
+	for _, tt := range tests {
+		n := byText[tt.text]
+		if n == nil {
+			t.Fatalf("요소 %q를 찾을 수 없음", tt.text)
+		}
+		if got := styles[n].Get(tt.property); got != tt.want {
+			t.Errorf("%q %s = %q; want %q", tt.text, tt.property, got, tt.want)
+		}
+	}
+}
+
+// TestComputeDocument_UserOrigin 사용자 스타일시트는 페이지 일반 선언보다 약하고, 사용자 !important는 무엇보다 강함
+func TestComputeDocument_UserOrigin(t *testing.T) {
+	doc := html.Parse(`<style>p { color: red; background-color: white !important; width: 10em }</style>` +
+		`<p style="color: blue; margin-top: 1em">x</p>`)
+	user := css.ParseStylesheet(`p { color: green; width: 5em; margin-top: 0 } body p { background-color: black !important; padding-top: 2em }`)
+	user.Origin = css.UserOrigin
+	style := css.ComputeDocument(doc, css.DefaultViewport, user)[doc.Find("p")]
+
+	want := map[string]string{
+		"color":            "blue",  // 페이지 style 속성
+		"width":            "10em",  // 페이지 스타일시트 > 사용자 일반
+		"margin-top":       "1em",   // 페이지 style 속성 > 사용자 일반
+		"background-color": "black", // 사용자 !important > 페이지 !important
+		"padding-top":      "2em",   // 페이지에 없으면 사용자 값
+	}
+	for property, v := range want {
+		if got := style.Get(property); got != v {
+			t.Errorf("%s = %q; want %q", property, got, v)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package css_test
+
+import (
+	"go-web-browser/css"
+	"image/color"
+	"testing"
+)
+
+// TestParseColor 색 이름, 16진수, rgb()/rgba()
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  color.NRGBA
+		ok    bool
+	}{
+		{"red", color.NRGBA{0xff, 0, 0, 0xff}, true},
+		{"RebeccaPurple", color.NRGBA{0x66, 0x33, 0x99, 0xff}, true},
+		{"transparent", color.NRGBA{}, true},
+		{"#0f8", color.NRGBA{0, 0xff, 0x88, 0xff}, true},
+		{"#0f88", color.NRGBA{0, 0xff, 0x88, 0x88}, true},
+		{"#336699", color.NRGBA{0x33, 0x66, 0x99, 0xff}, true},
+		{"#33669980", color.NRGBA{0x33, 0x66, 0x99, 0x80}, true},
+		{"rgb(255, 128, 0)", color.NRGBA{0xff, 0x80, 0, 0xff}, true},
+		{"rgba(0,0,0,0.5)", color.NRGBA{0, 0, 0, 0x80}, true},
+		{"rgb(100% 0% 50% / 25%)", color.NRGBA{0xff, 0, 0x80, 0x40}, true},
+		{"rgb(300, -5, 0)", color.NRGBA{0xff, 0, 0, 0xff}, true},
+		{"#12345", color.NRGBA{}, false},
+		{"rgb(1, 2)", color.NRGBA{}, false},
+		{"currentcolor", color.NRGBA{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := css.ParseColor(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
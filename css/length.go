@@ -0,0 +1,65 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains length values and their conversion to terminal cells.
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 터미널 칸으로 바꿀 때의 기준 (글자 한 칸은 8x16 픽셀, 1em = 16px)
+const (
+	PixelsPerColumn = 8
+	PixelsPerLine   = 16
+	pixelsPerEm     = 16
+)
+
+// keywordPixels는 테두리 폭 키워드의 픽셀 값
+var keywordPixels = map[string]float64{"thin": 1, "medium": 3, "thick": 5}
+
+// Pixels는 길이 값을 픽셀로 바꿈 (% 는 percentBase 픽셀 기준)
+//
+// 지원 단위: px, em, rem, ch, pt, %, 단위 없는 0, thin/medium/thick
+// 알 수 없는 값(auto 등)이면 ok = false
+func Pixels(value string, percentBase float64) (px float64, ok bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if v, ok := keywordPixels[value]; ok {
+		return v, true
+	}
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"rem", pixelsPerEm}, {"em", pixelsPerEm}, {"px", 1}, {"ch", PixelsPerColumn},
+		{"pt", 4.0 / 3}, {"%", percentBase / 100},
+	}
+	for _, u := range units {
+		if num, found := strings.CutSuffix(value, u.suffix); found {
+			n, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, false
+			}
+			return n * u.scale, true
+		}
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && n == 0 {
+		return 0, true
+	}
+	return 0, false
+}
+
+// Cells는 길이 값을 터미널 칸 수(가로) 또는 줄 수(세로, vertical)로 바꿈
+//
+// % 는 percentBase 칸 기준, 반올림하고, 알 수 없는 값과 음수는 0
+func Cells(value string, vertical bool, percentBase int) int {
+	unit := float64(PixelsPerColumn)
+	if vertical {
+		unit = PixelsPerLine
+	}
+	px, ok := Pixels(value, float64(percentBase)*unit)
+	if !ok || px <= 0 {
+		return 0
+	}
+	return int(math.Round(px / unit))
+}
@@ -0,0 +1,73 @@
+package css_test
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"testing"
+)
+
+// TestCells 길이 단위를 터미널 칸 수(세로는 줄 수)로 바꿈
+func TestCells(t *testing.T) {
+	tests := []struct {
+		value    string
+		vertical bool
+		want     int
+	}{
+		{"0", false, 0},
+		{"16px", false, 2},
+		{"16px", true, 1},
+		{"2em", false, 4},
+		{"2em", true, 2},
+		{"3ch", false, 3},
+		{"50%", false, 40},
+		{"-1em", false, 0},
+		{"auto", false, 0},
+	}
+	for _, tt := range tests {
+		if got := css.Cells(tt.value, tt.vertical, 80); got != tt.want {
+			t.Errorf("Cells(%q, %v) = %d; want %d", tt.value, tt.vertical, got, tt.want)
+		}
+	}
+}
+
+// TestComputeDocument_Border border 줄임 속성은 방향별 테두리 폭으로 펼쳐짐
+func TestComputeDocument_Border(t *testing.T) {
+	doc := html.Parse(`<div style="border: 2px solid red; border-left: none">x</div>`)
+	style := css.ComputeDocument(doc, css.DefaultViewport)[doc.Find("div")]
+
+	want := map[string]string{
+		"border-top-width":    "2px",
+		"border-right-width":  "2px",
+		"border-bottom-width": "2px",
+		"border-left-width":   "0",
+	}
+	for property, v := range want {
+		if got := style.Get(property); got != v {
+			t.Errorf("%s = %q; want %q", property, got, v)
+		}
+	}
+}
+
+// TestZoom 확대 배율은 절대 길이에만 곱하고, font-size의 em과 %는 그대로 둠
+func TestZoom(t *testing.T) {
+	doc := html.Parse(`<div style="font-size: 12px; margin: 1em 5%; width: auto"><p style="font-size: 2em">x</p></div>`)
+	styles := css.ComputeDocument(doc, css.DefaultViewport)
+	css.Zoom(styles, 1.5)
+
+	want := map[string]map[string]string{
+		"div": {"font-size": "18px", "margin-top": "1.5em", "margin-right": "5%", "width": "auto"},
+		"p":   {"font-size": "2em"},
+	}
+	for tag, properties := range want {
+		style := styles[doc.Find(tag)]
+		for property, v := range properties {
+			if got := style.Get(property); got != v {
+				t.Errorf("<%s> %s = %q; want %q", tag, property, got, v)
+			}
+		}
+	}
+
+	if got := css.TerminalViewport(80, 24).Zoomed(2); got.Width != 320 || got.Height != 192 {
+		t.Errorf("TerminalViewport(80, 24).Zoomed(2) = %+v; want 320x192", got)
+	}
+}
@@ -0,0 +1,189 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains @media queries and their evaluation against the viewport.
+package css
+
+import "strings"
+
+// Viewport는 미디어 쿼리를 평가할 화면 (크기는 CSS 픽셀)
+type Viewport struct {
+	Width, Height int    // 0이면 모름 (그 방향의 크기 조건은 일치하지 않음)
+	Type          string // 미디어 종류 ("screen", "print")
+}
+
+// DefaultViewport는 화면 크기를 모를 때 쓰는 데스크톱 크기 화면
+var DefaultViewport = Viewport{Width: 1024, Height: 768, Type: "screen"}
+
+// TerminalViewport는 cols x rows 칸 터미널을 CSS 픽셀 화면으로 봄 (한 칸은 8x16 픽셀)
+//
+// 80칸 터미널은 폭 640px이므로 반응형 페이지는 좁은 화면용 규칙을 사용함
+func TerminalViewport(cols, rows int) Viewport {
+	return Viewport{Width: cols * PixelsPerColumn, Height: rows * PixelsPerLine, Type: "screen"}
+}
+
+// MediaFeature는 미디어 쿼리의 조건 하나 ("(max-width: 600px)", "(width >= 40em)")
+type MediaFeature struct {
+	Name  string // "width", "min-width", "orientation" 등
+	Op    string // 범위 문법의 비교 연산자 ("<", "<=", ">", ">=", "="), 보통 문법이면 ""
+	Value string // 값이 없는 조건("(color)")이면 ""
+}
+
+// MediaQuery는 쉼표로 나뉜 쿼리 하나 ("screen and (max-width: 600px)")
+type MediaQuery struct {
+	Not      bool
+	Type     string // "" 또는 "all"이면 모든 미디어
+	Features []MediaFeature
+}
+
+// MediaList는 쉼표로 나뉜 쿼리 목록 (하나라도 일치하면 일치, 비어 있으면 항상 일치)
+type MediaList []MediaQuery
+
+// ParseMediaList는 @media 뒤의 쿼리 목록을 파싱함
+//
+// 브라우저처럼 잘못된 쿼리는 "not all"(항상 불일치)로 바꿈
+func ParseMediaList(s string) MediaList {
+	var list MediaList
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		q, ok := parseMediaQuery(part)
+		if !ok {
+			q = MediaQuery{Not: true, Type: "all"}
+		}
+		list = append(list, q)
+	}
+	return list
+}
+
+// parseMediaQuery는 "[not|only] type [and (feature)]..." 또는 "(feature) [and (feature)]..."를 파싱함
+func parseMediaQuery(s string) (MediaQuery, bool) {
+	var q MediaQuery
+	s = strings.ToLower(strings.TrimSpace(s))
+	if rest, ok := strings.CutPrefix(s, "not "); ok {
+		q.Not, s = true, strings.TrimSpace(rest)
+	} else if rest, ok := strings.CutPrefix(s, "only "); ok {
+		s = strings.TrimSpace(rest)
+	}
+
+	expectType := true
+	for s != "" {
+		if s[0] == '(' {
+			end := strings.IndexByte(s, ')')
+			if end < 0 {
+				return q, false
+			}
+			f, ok := parseMediaFeature(s[1:end])
+			if !ok {
+				return q, false
+			}
+			q.Features = append(q.Features, f)
+			s = strings.TrimSpace(s[end+1:])
+		} else {
+			word, rest, _ := strings.Cut(s, " ")
+			switch {
+			case expectType && word != "and":
+				q.Type = word
+			case word != "and" || len(rest) == 0:
+				return q, false
+			}
+			s = strings.TrimSpace(rest)
+		}
+		expectType = false
+	}
+	return q, q.Type != "" || len(q.Features) > 0
+}
+
+// rangeOps는 범위 문법의 비교 연산자 (두 글자 연산자를 먼저 찾음)
+var rangeOps = []string{"<=", ">=", "<", ">", "="}
+
+// parseMediaFeature는 괄호 안의 조건 하나를 파싱함 ("max-width: 600px", "width <= 600px", "color")
+func parseMediaFeature(s string) (MediaFeature, bool) {
+	if name, value, ok := strings.Cut(s, ":"); ok {
+		f := MediaFeature{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)}
+		return f, f.Name != "" && f.Value != ""
+	}
+	for _, op := range rangeOps {
+		if name, value, ok := strings.Cut(s, op); ok {
+			f := MediaFeature{Name: strings.TrimSpace(name), Op: op, Value: strings.TrimSpace(value)}
+			return f, f.Name != "" && f.Value != ""
+		}
+	}
+	f := MediaFeature{Name: strings.TrimSpace(s)}
+	return f, f.Name != ""
+}
+
+// Match는 목록의 쿼리 중 하나라도 v와 일치하는지 확인함
+func (l MediaList) Match(v Viewport) bool {
+	if len(l) == 0 {
+		return true
+	}
+	for _, q := range l {
+		if q.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match는 쿼리가 v와 일치하는지 확인함
+func (q MediaQuery) Match(v Viewport) bool {
+	ok := q.Type == "" || q.Type == "all" || q.Type == v.Type
+	for _, f := range q.Features {
+		ok = ok && f.Match(v)
+	}
+	return ok != q.Not
+}
+
+// Match는 조건 하나가 v와 일치하는지 확인함 (모르는 조건은 불일치)
+//
+// 지원: width, height (min-/max- 접두사와 범위 문법), orientation, color
+func (f MediaFeature) Match(v Viewport) bool {
+	name, op := f.Name, f.Op
+	if rest, ok := strings.CutPrefix(name, "min-"); ok && op == "" {
+		name, op = rest, ">="
+	} else if rest, ok := strings.CutPrefix(name, "max-"); ok && op == "" {
+		name, op = rest, "<="
+	} else if op == "" {
+		op = "="
+	}
+
+	switch name {
+	case "width", "height":
+		size := v.Width
+		if name == "height" {
+			size = v.Height
+		}
+		if size <= 0 {
+			return false
+		}
+		if f.Value == "" {
+			return true
+		}
+		px, ok := Pixels(f.Value, 0)
+		return ok && compare(float64(size), op, px)
+	case "orientation":
+		if v.Width <= 0 || v.Height <= 0 {
+			return false
+		}
+		portrait := v.Height >= v.Width
+		return f.Value == "" || f.Value == "portrait" && portrait || f.Value == "landscape" && !portrait
+	case "color":
+		return f.Value == "" && v.Type != "print"
+	}
+	return false
+}
+
+// compare는 a op b
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return a == b
+}
@@ -0,0 +1,67 @@
+package css_test
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"testing"
+)
+
+// TestMediaList_Match 폭, 높이, 미디어 종류, not, 쉼표 목록, 범위 문법
+func TestMediaList_Match(t *testing.T) {
+	narrow := css.TerminalViewport(80, 24) // 640 x 384
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"all", true},
+		{"screen", true},
+		{"print", false},
+		{"not print", true},
+		{"only screen and (max-width: 768px)", true},
+		{"(min-width: 768px)", false},
+		{"(min-width: 40em)", true},
+		{"(max-width: 600px), (orientation: landscape)", true},
+		{"screen and (min-width: 600px) and (max-height: 300px)", false},
+		{"(width <= 640px)", true},
+		{"(width > 640px)", false},
+		{"(min-resolution: 2dppx)", false},
+		{"screen and", false},
+		{"(max-width: 700px", false},
+	}
+	for _, tt := range tests {
+		if got := css.ParseMediaList(tt.query).Match(narrow); got != tt.want {
+			t.Errorf("ParseMediaList(%q).Match(%+v) = %v; want %v", tt.query, narrow, got, tt.want)
+		}
+	}
+}
+
+// TestComputeDocument_Media @media 블록(중첩 포함)은 화면 크기가 일치할 때만 적용됨
+func TestComputeDocument_Media(t *testing.T) {
+	doc := html.Parse(`<style>
+nav { display: block }
+@media (max-width: 768px) {
+  nav { display: none }
+  @media (min-width: 500px) { nav { width: 50% } }
+}
+@MEDIA print { nav { color: black } }
+</style><nav>menu</nav>`)
+	nav := doc.Find("nav")
+
+	tests := []struct {
+		v                     css.Viewport
+		display, width, color string
+	}{
+		{css.DefaultViewport, "block", "", ""},
+		{css.TerminalViewport(80, 24), "none", "50%", ""},
+		{css.TerminalViewport(40, 24), "none", "", ""},
+		{css.Viewport{Width: 1024, Type: "print"}, "block", "", "black"},
+	}
+	for _, tt := range tests {
+		style := css.ComputeDocument(doc, tt.v)[nav]
+		if style.Get("display") != tt.display || style.Get("width") != tt.width || style.Get("color") != tt.color {
+			t.Errorf("ComputeDocument(%+v) = %v; want display %q, width %q, color %q",
+				tt.v, style, tt.display, tt.width, tt.color)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains the supported property subset and shorthand expansion.
+package css
+
+import (
+	"fmt"
+	"strings"
+)
+
+// properties는 지원하는 속성과 상속 여부
+//
+// 여기 없는 속성은 파싱은 되지만 계산된 스타일에 들어가지 않음
+var properties = map[string]bool{
+	"color":               true,
+	"background-color":    false,
+	"margin-top":          false,
+	"margin-right":        false,
+	"margin-bottom":       false,
+	"margin-left":         false,
+	"padding-top":         false,
+	"padding-right":       false,
+	"padding-bottom":      false,
+	"padding-left":        false,
+	"border-top-width":    false,
+	"border-right-width":  false,
+	"border-bottom-width": false,
+	"border-left-width":   false,
+	"font-family":         true,
+	"font-size":           true,
+	"font-style":          true,
+	"font-weight":         true,
+	"display":             false,
+	"width":               false,
+}
+
+// Supported는 계산된 스타일에 들어가는 속성인지 확인함 (margin, padding, border, background 같은 줄임 속성 포함)
+func Supported(property string) bool {
+	if _, ok := properties[property]; ok {
+		return true
+	}
+	switch property {
+	case "margin", "padding", "background", "border", "border-width",
+		"border-top", "border-right", "border-bottom", "border-left":
+		return true
+	}
+	return false
+}
+
+// Inherited는 부모에서 값을 물려받는 속성인지 확인함 (color, font-*)
+func Inherited(property string) bool {
+	return properties[property]
+}
+
+// sides는 margin, padding 줄임 속성이 펼쳐지는 방향 (CSS 순서)
+var sides = [4]string{"top", "right", "bottom", "left"}
+
+// expand는 줄임 속성을 개별 속성으로 펼침 (지원하지 않는 속성은 nil)
+//
+//	margin: 1em 2em       → margin-top: 1em, margin-right: 2em, margin-bottom: 1em, margin-left: 2em
+//	border: 1px solid red → border-top-width: 1px, ... (폭만 사용)
+//	background: #fff url() → background-color: #fff (색만 사용)
+func expand(d Declaration) []Declaration {
+	switch d.Property {
+	case "margin", "padding":
+		return expandSides(d.Property+"-%s", fields(d.Value), d.Important)
+	case "border-width":
+		return expandSides("border-%s-width", fields(d.Value), d.Important)
+	case "border":
+		return expandSides("border-%s-width", []string{borderWidth(d.Value)}, d.Important)
+	case "border-top", "border-right", "border-bottom", "border-left":
+		return []Declaration{{Property: d.Property + "-width", Value: borderWidth(d.Value), Important: d.Important}}
+	case "background":
+		for _, v := range fields(d.Value) {
+			if !strings.HasPrefix(v, "url(") && !strings.Contains(v, "repeat") && !strings.Contains(v, "/") {
+				return []Declaration{{Property: "background-color", Value: v, Important: d.Important}}
+			}
+		}
+		return nil
+	}
+	if _, ok := properties[d.Property]; ok {
+		return []Declaration{d}
+	}
+	return nil
+}
+
+// expandSides는 1~4개의 값을 top, right, bottom, left 속성으로 펼침 (format의 %s에 방향)
+func expandSides(format string, values []string, important bool) []Declaration {
+	if len(values) == 0 || len(values) > 4 {
+		return nil
+	}
+	// 값이 1~4개일 때 top, right, bottom, left에 쓸 값의 인덱스
+	index := [][4]int{{0, 0, 0, 0}, {0, 1, 0, 1}, {0, 1, 2, 1}, {0, 1, 2, 3}}[len(values)-1]
+	out := make([]Declaration, 4)
+	for i, side := range sides {
+		out[i] = Declaration{Property: fmt.Sprintf(format, side), Value: values[index[i]], Important: important}
+	}
+	return out
+}
+
+// borderWidth는 border 줄임 속성 값에서 폭을 찾음
+//
+// 스타일이 none/hidden이면 "0", 폭이 없으면 기본값 "medium"
+func borderWidth(value string) string {
+	width := "medium"
+	for _, v := range fields(strings.ToLower(value)) {
+		switch {
+		case v == "none" || v == "hidden":
+			return "0"
+		case v == "thin" || v == "medium" || v == "thick" || v != "" && (v[0] >= '0' && v[0] <= '9' || v[0] == '.'):
+			width = v
+		}
+	}
+	return width
+}
+
+// fields는 괄호 밖의 공백으로 값을 나눔 ("rgb(0, 0, 0) url(a b)" → 두 개)
+func fields(s string) []string {
+	var out []string
+	depth, start := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case isSpace(c) && depth == 0:
+			if start >= 0 {
+				out = append(out, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		out = append(out, s[start:])
+	}
+	return out
+}
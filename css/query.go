@@ -0,0 +1,51 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains the querySelector-style DOM query helpers.
+package css
+
+import "go-web-browser/html"
+
+// QuerySelector는 root의 자손 중 selector와 일치하는 첫 번째 요소를 반환함 (없으면 nil)
+func QuerySelector(root *html.Node, selector string) (*html.Node, error) {
+	sel, err := Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	return sel.First(root), nil
+}
+
+// QuerySelectorAll은 root의 자손 중 selector와 일치하는 모든 요소를 문서 순서로 반환함
+func QuerySelectorAll(root *html.Node, selector string) ([]*html.Node, error) {
+	sel, err := Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	return sel.All(root), nil
+}
+
+// First는 root의 자손 중 sel과 일치하는 첫 번째 요소 (없으면 nil)
+func (sel Selector) First(root *html.Node) *html.Node {
+	var found *html.Node
+	root.Walk(func(n *html.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n != root && sel.Match(n) {
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// All은 root의 자손 중 sel과 일치하는 모든 요소 (문서 순서)
+func (sel Selector) All(root *html.Node) []*html.Node {
+	var result []*html.Node
+	root.Walk(func(n *html.Node) bool {
+		if n != root && sel.Match(n) {
+			result = append(result, n)
+		}
+		return true
+	})
+	return result
+}
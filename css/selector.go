@@ -0,0 +1,362 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains the selector grammar, matcher and specificity.
+package css
+
+import (
+	"fmt"
+	"go-web-browser/html"
+	"strings"
+)
+
+// Selector는 쉼표로 구분된 선택자 목록 ("h1, h2 > a")
+//
+// 목록의 선택자 중 하나라도 일치하면 요소가 일치함
+type Selector []Complex
+
+// Complex는 결합자로 이어진 선택자 하나 ("div.note > p a")
+type Complex struct {
+	steps []step // 왼쪽부터 (마지막 step이 대상 요소)
+}
+
+// combinator는 앞 step과의 관계
+type combinator byte
+
+const (
+	noCombinator combinator = 0   // 첫 step
+	descendant   combinator = ' ' // 자손 (공백)
+	child        combinator = '>' // 자식
+)
+
+// step은 결합자와 복합 선택자 ("> a.external[href]")
+type step struct {
+	comb    combinator
+	tag     string // "" 또는 "*"이면 모든 태그
+	id      string
+	classes []string
+	attrs   []attrSelector
+}
+
+// attrSelector는 속성 선택자 하나 ("[href]", "[lang|=ko]", "[href^='https:']")
+type attrSelector struct {
+	name  string
+	op    string // ""(존재), "=", "~=", "|=", "^=", "$=", "*="
+	value string
+}
+
+// Specificity는 선택자의 명시도 (id 수, class/속성 수, 태그 수)
+//
+// 배열 비교처럼 앞 자리부터 비교함 (Less 참고)
+type Specificity [3]int
+
+// Less는 s가 o보다 명시도가 낮은지 확인함
+func (s Specificity) Less(o Specificity) bool {
+	for i := range s {
+		if s[i] != o[i] {
+			return s[i] < o[i]
+		}
+	}
+	return false
+}
+
+// Parse는 선택자 문자열을 파싱함
+//
+// 지원: 태그, *, #id, .class, [attr], [attr=v] (~= |= ^= $= *=), 자손(공백), 자식(>)
+func Parse(s string) (Selector, error) {
+	p := &selectorParser{src: s}
+	var sel Selector
+	for {
+		c, err := p.complex()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, c)
+		p.skipSpace()
+		if p.eof() {
+			return sel, nil
+		}
+		if p.peek() != ',' {
+			return nil, p.errorf("',' 또는 선택자 끝이 필요함")
+		}
+		p.pos++
+	}
+}
+
+// selectorParser는 선택자 문자열과 읽는 위치
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func (p *selectorParser) eof() bool  { return p.pos >= len(p.src) }
+func (p *selectorParser) peek() byte { return p.src[p.pos] }
+
+// errorf는 위치가 들어간 파싱 에러를 만듦
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("잘못된 선택자 %q (위치 %d): %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+// skipSpace는 공백을 건너뛰고 건너뛴 공백이 있었는지 반환함
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// complex는 결합자로 이어진 step들을 읽음 (',' 또는 끝에서 멈춤)
+func (p *selectorParser) complex() (Complex, error) {
+	var c Complex
+	p.skipSpace()
+	comb := noCombinator
+	for {
+		s, err := p.compound()
+		if err != nil {
+			return Complex{}, err
+		}
+		s.comb = comb
+		c.steps = append(c.steps, s)
+
+		spaced := p.skipSpace()
+		switch {
+		case p.eof() || p.peek() == ',':
+			return c, nil
+		case p.peek() == '>':
+			p.pos++
+			p.skipSpace()
+			comb = child
+		case spaced:
+			comb = descendant
+		default:
+			return Complex{}, p.errorf("예상하지 못한 문자 %q", p.peek())
+		}
+	}
+}
+
+// compound는 태그와 #id, .class, [attr]가 붙은 복합 선택자 하나를 읽음
+func (p *selectorParser) compound() (step, error) {
+	var s step
+	start := p.pos
+	if !p.eof() && p.peek() == '*' {
+		p.pos++
+		s.tag = "*"
+	} else if name := p.ident(); name != "" {
+		s.tag = strings.ToLower(name)
+	}
+
+	for !p.eof() {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			if s.id = p.ident(); s.id == "" {
+				return step{}, p.errorf("'#' 뒤에 id가 필요함")
+			}
+		case '.':
+			p.pos++
+			class := p.ident()
+			if class == "" {
+				return step{}, p.errorf("'.' 뒤에 class 이름이 필요함")
+			}
+			s.classes = append(s.classes, class)
+		case '[':
+			a, err := p.attr()
+			if err != nil {
+				return step{}, err
+			}
+			s.attrs = append(s.attrs, a)
+		default:
+			if p.pos == start {
+				return step{}, p.errorf("선택자가 필요함")
+			}
+			return s, nil
+		}
+	}
+	if p.pos == start {
+		return step{}, p.errorf("선택자가 필요함")
+	}
+	return s, nil
+}
+
+// attr은 "[name]" 또는 "[name op value]"를 읽음 (값은 따옴표로 감쌀 수 있음)
+func (p *selectorParser) attr() (attrSelector, error) {
+	p.pos++ // '['
+	p.skipSpace()
+	a := attrSelector{name: strings.ToLower(p.ident())}
+	if a.name == "" {
+		return a, p.errorf("속성 이름이 필요함")
+	}
+	p.skipSpace()
+	if p.eof() {
+		return a, p.errorf("']'가 필요함")
+	}
+	if p.peek() == ']' {
+		p.pos++
+		return a, nil
+	}
+
+	for _, op := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			a.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if a.op == "" {
+		return a, p.errorf("알 수 없는 속성 연산자")
+	}
+	p.skipSpace()
+	if !p.eof() && (p.peek() == '"' || p.peek() == '\'') {
+		quote := p.peek()
+		end := strings.IndexByte(p.src[p.pos+1:], quote)
+		if end < 0 {
+			return a, p.errorf("닫는 따옴표가 없음")
+		}
+		a.value = p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	} else {
+		a.value = p.ident()
+	}
+	p.skipSpace()
+	if p.eof() || p.peek() != ']' {
+		return a, p.errorf("']'가 필요함")
+	}
+	p.pos++
+	return a, nil
+}
+
+// ident는 이름(영문자, 숫자, '-', '_', ASCII 밖의 문자)을 읽음
+func (p *selectorParser) ident() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c >= 0x80) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// isSpace는 CSS 공백 문자인지 확인함
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// Match는 n이 목록의 선택자 중 하나와 일치하는지 확인함
+func (sel Selector) Match(n *html.Node) bool {
+	for _, c := range sel {
+		if c.Match(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match는 n이 선택자와 일치하는지 확인함 (오른쪽 step부터 조상 방향으로 비교)
+func (c Complex) Match(n *html.Node) bool {
+	return matchSteps(c.steps, n)
+}
+
+// matchSteps는 steps의 마지막이 n과 일치하고 앞 step들이 결합자 관계를 만족하는지 확인함
+func matchSteps(steps []step, n *html.Node) bool {
+	last := steps[len(steps)-1]
+	if !last.match(n) {
+		return false
+	}
+	rest := steps[:len(steps)-1]
+	if len(rest) == 0 {
+		return true
+	}
+
+	switch last.comb {
+	case child:
+		return n.Parent != nil && matchSteps(rest, n.Parent)
+	default: // descendant
+		for a := n.Parent; a != nil; a = a.Parent {
+			if matchSteps(rest, a) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// match는 요소 n이 복합 선택자와 일치하는지 확인함
+func (s step) match(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if s.tag != "" && s.tag != "*" && s.tag != n.Tag {
+		return false
+	}
+	if s.id != "" {
+		if id, _ := n.Attr("id"); id != s.id {
+			return false
+		}
+	}
+	if len(s.classes) > 0 {
+		class, _ := n.Attr("class")
+		have := strings.Fields(class)
+		for _, want := range s.classes {
+			if !contains(have, want) {
+				return false
+			}
+		}
+	}
+	for _, a := range s.attrs {
+		if !a.match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// match는 요소 n의 속성이 속성 선택자와 일치하는지 확인함
+func (a attrSelector) match(n *html.Node) bool {
+	v, ok := n.Attr(a.name)
+	if !ok {
+		return false
+	}
+	switch a.op {
+	case "":
+		return true
+	case "=":
+		return v == a.value
+	case "~=":
+		return contains(strings.Fields(v), a.value)
+	case "|=":
+		return v == a.value || strings.HasPrefix(v, a.value+"-")
+	case "^=":
+		return a.value != "" && strings.HasPrefix(v, a.value)
+	case "$=":
+		return a.value != "" && strings.HasSuffix(v, a.value)
+	case "*=":
+		return a.value != "" && strings.Contains(v, a.value)
+	}
+	return false
+}
+
+// contains는 list에 s가 있는지 확인함
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Specificity는 선택자의 명시도를 계산함
+func (c Complex) Specificity() Specificity {
+	var sp Specificity
+	for _, s := range c.steps {
+		if s.id != "" {
+			sp[0]++
+		}
+		sp[1] += len(s.classes) + len(s.attrs)
+		if s.tag != "" && s.tag != "*" {
+			sp[2]++
+		}
+	}
+	return sp
+}
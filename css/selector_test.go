@@ -0,0 +1,109 @@
+package css_test
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDoc = `<div id="main" class="content wide">
+<h1>Title</h1>
+<p class="intro">Hello <a href="https://a.com/" lang="en-US">A</a></p>
+<ul><li><a href="/b" class="nav">B</a></li><li><span><a href="/c">C</a></span></li></ul>
+</div>
+<p>Outside <a href="#top" rel="nofollow noopener">top</a></p>`
+
+// texts는 노드들의 텍스트
+func texts(nodes []*html.Node) []string {
+	result := []string{}
+	for _, n := range nodes {
+		result = append(result, strings.TrimSpace(n.TextContent()))
+	}
+	return result
+}
+
+// TestQuerySelectorAll 태그, id, class, 속성, 자손, 자식 선택자
+func TestQuerySelectorAll(t *testing.T) {
+	doc := html.Parse(testDoc)
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"a", []string{"A", "B", "C", "top"}},
+		{"#main a", []string{"A", "B", "C"}},
+		{"div.content.wide > h1", []string{"Title"}},
+		{".intro a", []string{"A"}},
+		{"li > a", []string{"B"}},
+		{"ul a", []string{"B", "C"}},
+		{"a.nav, h1", []string{"Title", "B"}},
+		{"a[href^='https:']", []string{"A"}},
+		{`a[href$="c"]`, []string{"C"}},
+		{"a[href*=b]", []string{"B"}},
+		{"[rel~=noopener]", []string{"top"}},
+		{"[lang|=en]", []string{"A"}},
+		{"A[HREF='#top']", []string{"top"}},
+		{"div > a", []string{}},
+		{"* > span > a", []string{"C"}},
+		{"#missing", []string{}},
+	}
+
+	for _, tt := range tests {
+		nodes, err := css.QuerySelectorAll(doc, tt.selector)
+		if err != nil {
+			t.Errorf("QuerySelectorAll(%q) failed: %v", tt.selector, err)
+			continue
+		}
+		if got := texts(nodes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("QuerySelectorAll(%q) = %q; want %q", tt.selector, got, tt.want)
+		}
+	}
+}
+
+// TestQuerySelector 첫 번째 요소만, 없으면 nil
+func TestQuerySelector(t *testing.T) {
+	doc := html.Parse(testDoc)
+	n, err := css.QuerySelector(doc, "p a")
+	if err != nil || n == nil || n.TextContent() != "A" {
+		t.Errorf("QuerySelector(\"p a\") = %v, %v; want A", n, err)
+	}
+	if n, _ := css.QuerySelector(doc, "table"); n != nil {
+		t.Errorf("QuerySelector(\"table\") = %v; want nil", n)
+	}
+}
+
+// TestParse_Invalid 문법 오류는 에러
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{"", "a >", "> a", "a,", "#", ".", "a[href", "a[=x]", "a[href!=x]", "a[href='x]", "a:hover", "a + b"} {
+		if _, err := css.Parse(s); err == nil {
+			t.Errorf("Parse(%q) should fail", s)
+		}
+	}
+}
+
+// TestSpecificity id, class/속성, 태그 개수
+func TestSpecificity(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     css.Specificity
+	}{
+		{"*", css.Specificity{0, 0, 0}},
+		{"li > a", css.Specificity{0, 0, 2}},
+		{"a.nav[href]", css.Specificity{0, 2, 1}},
+		{"#main .intro a", css.Specificity{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		sel, err := css.Parse(tt.selector)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.selector, err)
+		}
+		if got := sel[0].Specificity(); got != tt.want {
+			t.Errorf("Specificity(%q) = %v; want %v", tt.selector, got, tt.want)
+		}
+	}
+	if !(css.Specificity{0, 9, 9}).Less(css.Specificity{1, 0, 0}) {
+		t.Error("Specificity{0,9,9}.Less({1,0,0}) = false; want true")
+	}
+}
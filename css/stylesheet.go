@@ -0,0 +1,201 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains the stylesheet and declaration block parser.
+package css
+
+import "strings"
+
+// Declaration은 "property: value" 선언 하나
+type Declaration struct {
+	Property  string // 소문자 속성 이름
+	Value     string // 앞뒤 공백을 뺀 값 (!important 제외)
+	Important bool
+}
+
+// Rule은 선택자와 선언 블록
+type Rule struct {
+	Selector     Selector
+	Declarations []Declaration
+	Media        []MediaList // 규칙을 감싼 @media 블록들의 조건 (모두 일치해야 적용, 없으면 항상 적용)
+}
+
+// Applies는 규칙의 @media 조건이 v와 일치하는지 확인함
+func (r Rule) Applies(v Viewport) bool {
+	for _, l := range r.Media {
+		if !l.Match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Origin은 스타일시트의 출처 (캐스케이드 우선순위가 다름)
+type Origin int
+
+// 스타일시트 출처
+const (
+	AuthorOrigin Origin = iota // 페이지의 <style>, style 속성
+	UserOrigin                 // 사용자 스타일시트 (user.css)
+)
+
+// Stylesheet는 규칙 목록 (원본 순서 유지, 나중 규칙이 같은 명시도의 앞 규칙을 덮어씀)
+type Stylesheet struct {
+	Rules  []Rule
+	Origin Origin
+}
+
+// ParseStylesheet는 CSS 텍스트를 파싱함
+//
+// 브라우저처럼 관대하게 처리함: 선택자가 잘못된 규칙과 @media 외의 @규칙은 건너뛰고 나머지를 사용함
+// @media 블록 안의 규칙은 Media에 조건을 기억해두고 캐스케이드할 때 화면과 비교함
+func ParseStylesheet(src string) *Stylesheet {
+	sheet := &Stylesheet{}
+	sheet.parseRules(stripComments(src), nil)
+	return sheet
+}
+
+// parseRules는 src의 규칙들을 media 조건과 함께 추가함 (@media 블록은 조건을 더해서 재귀)
+func (sheet *Stylesheet) parseRules(src string, media []MediaList) {
+	for i := 0; i < len(src); {
+		for i < len(src) && isSpace(src[i]) {
+			i++
+		}
+		if i >= len(src) {
+			break
+		}
+
+		open := indexOutsideQuotes(src[i:], '{')
+		semi := indexOutsideQuotes(src[i:], ';')
+		if src[i] == '@' && semi >= 0 && (open < 0 || semi < open) {
+			// @import, @charset 같은 블록 없는 @규칙
+			i += semi + 1
+			continue
+		}
+		if open < 0 {
+			break // 블록 없이 끝난 규칙은 무시
+		}
+		prelude := strings.TrimSpace(src[i : i+open])
+		bodyStart := i + open + 1
+		bodyEnd := matchingBrace(src, bodyStart)
+		body := src[bodyStart:bodyEnd]
+		i = min(bodyEnd+1, len(src))
+
+		if query, ok := cutAtRule(prelude, "@media"); ok {
+			sheet.parseRules(body, append(media[:len(media):len(media)], ParseMediaList(query)))
+			continue
+		}
+		if strings.HasPrefix(prelude, "@") {
+			continue // @font-face, @keyframes 등은 지원하지 않음
+		}
+		sel, err := Parse(prelude)
+		if err != nil {
+			continue
+		}
+		sheet.Rules = append(sheet.Rules, Rule{Selector: sel, Declarations: ParseDeclarations(body), Media: media})
+	}
+}
+
+// cutAtRule은 prelude가 name @규칙이면 나머지 부분을 반환함 (대소문자 무시)
+func cutAtRule(prelude, name string) (rest string, ok bool) {
+	if len(prelude) < len(name) || !strings.EqualFold(prelude[:len(name)], name) {
+		return "", false
+	}
+	rest = prelude[len(name):]
+	if rest != "" && !isSpace(rest[0]) && rest[0] != '(' {
+		return "", false // @media-foo 같은 다른 이름
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// ParseDeclarations는 선언 블록("color: red; margin: 0 !important")을 파싱함
+//
+// style 속성 값도 이 함수로 파싱함. 이름이나 값이 없는 선언은 건너뜀
+func ParseDeclarations(src string) []Declaration {
+	src = stripComments(src)
+	var decls []Declaration
+	for src != "" {
+		end := indexOutsideQuotes(src, ';')
+		part := src
+		if end >= 0 {
+			part, src = src[:end], src[end+1:]
+		} else {
+			src = ""
+		}
+
+		name, value, ok := strings.Cut(part, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if !ok || name == "" {
+			continue
+		}
+		d := Declaration{Property: name, Value: value}
+		if bang := strings.LastIndex(value, "!"); bang >= 0 &&
+			strings.EqualFold(strings.TrimSpace(value[bang+1:]), "important") {
+			d.Value = strings.TrimSpace(value[:bang])
+			d.Important = true
+		}
+		if d.Value != "" {
+			decls = append(decls, d)
+		}
+	}
+	return decls
+}
+
+// stripComments는 /* ... */ 주석을 지움 (닫히지 않은 주석은 끝까지)
+func stripComments(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "/*")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:start])
+		end := strings.Index(s[start+2:], "*/")
+		if end < 0 {
+			return b.String()
+		}
+		s = s[start+2+end+2:]
+	}
+}
+
+// indexOutsideQuotes는 따옴표와 괄호 밖에서 c가 처음 나오는 위치 (없으면 -1)
+func indexOutsideQuotes(s string, c byte) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '(':
+			depth++
+		case s[i] == ')' && depth > 0:
+			depth--
+		case s[i] == c && depth == 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace는 start부터 시작하는 블록의 닫는 '}' 위치 (중첩 블록 고려, 없으면 len(s))
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return len(s)
+}
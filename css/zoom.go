@@ -0,0 +1,71 @@
+// Package css implements CSS selector parsing and matching on the DOM.
+// This file contains page zoom applied to computed styles and the viewport.
+package css
+
+import (
+	"go-web-browser/html"
+	"strconv"
+	"strings"
+)
+
+// zoomUnits는 확대할 때 배율을 곱하는 길이 단위 (%는 기준 크기를 따라가므로 제외)
+//
+// font-size의 em은 부모 글자 크기 기준이라 부모가 이미 확대되었으므로 곱하지 않음
+var zoomUnits = []string{"rem", "em", "px", "pt", "ch"}
+
+// zoomProperties는 확대 배율을 적용하는 속성 (테두리는 항상 한 칸이라 제외)
+var zoomProperties = []string{
+	"font-size", "width",
+	"margin-top", "margin-right", "margin-bottom", "margin-left",
+	"padding-top", "padding-right", "padding-bottom", "padding-left",
+}
+
+// Zoomed는 화면을 factor배 확대했을 때의 화면 (CSS 픽셀 기준 크기가 1/factor로 줄어듦)
+//
+// 200%로 확대한 80칸 터미널은 폭 320px 화면이 되어 좁은 화면용 @media 규칙을 사용함
+func (v Viewport) Zoomed(factor float64) Viewport {
+	if factor <= 0 || factor == 1 {
+		return v
+	}
+	v.Width = int(float64(v.Width) / factor)
+	v.Height = int(float64(v.Height) / factor)
+	return v
+}
+
+// Zoom은 계산된 스타일의 글자 크기와 상자 길이(margin, padding, width)에 factor를 곱함
+//
+// Compute 결과에 레이아웃 전에 적용함 (요소마다 스타일이 따로 있으므로 상속된 값도 한 번만 곱해짐)
+func Zoom(styles map[*html.Node]Style, factor float64) {
+	if factor <= 0 || factor == 1 {
+		return
+	}
+	for _, style := range styles {
+		for _, property := range zoomProperties {
+			if v, ok := style[property]; ok {
+				style[property] = zoomLength(v, factor, property == "font-size")
+			}
+		}
+	}
+}
+
+// zoomLength는 길이 값 하나에 factor를 곱함 (알 수 없는 값은 그대로)
+//
+// 예시: zoomLength("12px", 1.5, false) → "18px"
+func zoomLength(value string, factor float64, fontSize bool) string {
+	v := strings.ToLower(strings.TrimSpace(value))
+	for _, unit := range zoomUnits {
+		num, found := strings.CutSuffix(v, unit)
+		if !found {
+			continue
+		}
+		if fontSize && unit == "em" {
+			return value
+		}
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return value
+		}
+		return strconv.FormatFloat(n*factor, 'f', -1, 64) + unit
+	}
+	return value
+}
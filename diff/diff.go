@@ -0,0 +1,201 @@
+// Package diff compares lines of text and formats the result as a unified diff.
+// This file contains the Myers line diff and the unified diff writer.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op는 편집 스크립트의 한 줄이 어떤 변경인지
+type Op int
+
+// 변경 종류
+const (
+	Equal  Op = iota // 양쪽에 모두 있음
+	Delete           // a에만 있음
+	Insert           // b에만 있음
+)
+
+// Line은 편집 스크립트의 한 줄
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// DefaultContext는 unified diff에서 변경 앞뒤로 보여주는 줄 수 (diff -u와 같음)
+const DefaultContext = 3
+
+// maxEdits는 최소 편집을 찾는 최대 편집 횟수 (넘으면 공통 앞뒤를 뺀 나머지를 통째로 바꾼 것으로 봄)
+//
+// Myers 알고리즘의 메모리는 편집 횟수의 제곱에 비례하므로 완전히 다른 큰 문서에서 멈추지 않도록 제한함
+const maxEdits = 2000
+
+// Lines는 a를 b로 바꾸는 최소 편집 스크립트를 반환함 (Myers 알고리즘)
+func Lines(a, b []string) []Line {
+	// 공통 앞부분과 뒷부분은 비교하지 않음
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var script []Line
+	for _, s := range a[:prefix] {
+		script = append(script, Line{Equal, s})
+	}
+	script = append(script, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, s := range a[len(a)-suffix:] {
+		script = append(script, Line{Equal, s})
+	}
+	return script
+}
+
+// myers는 Myers의 O(ND) 알고리즘으로 편집 스크립트를 구함
+//
+// 편집 횟수 d마다 대각선 k(= x - y)에서 가장 멀리 간 x를 기록해두고,
+// 끝에 도달하면 기록을 거꾸로 따라가며 스크립트를 만듦
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	// v[k+offset]: 대각선 k에서 가장 멀리 간 x
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d]: d번째 편집을 시작하기 전 대각선 -(d+1)..d+1의 v (메모리를 d²으로 제한)
+	var trace [][]int
+
+	for d := 0; d <= n+m && d <= maxEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // 아래로 (b의 줄 삽입)
+			} else {
+				x = v[offset+k-1] + 1 // 오른쪽으로 (a의 줄 삭제)
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	// 너무 다르면 통째로 지우고 새로 넣은 것으로 봄
+	script := make([]Line, 0, n+m)
+	for _, s := range a {
+		script = append(script, Line{Delete, s})
+	}
+	for _, s := range b {
+		script = append(script, Line{Insert, s})
+	}
+	return script
+}
+
+// backtrack은 myers의 기록을 끝에서부터 따라가며 편집 스크립트를 만듦
+func backtrack(trace [][]int, a, b []string) []Line {
+	x, y := len(a), len(b)
+	var reversed []Line
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Line{Equal, a[x-1]})
+			x, y = x-1, y-1
+		}
+		if x == prevX {
+			reversed = append(reversed, Line{Insert, b[y-1]})
+		} else {
+			reversed = append(reversed, Line{Delete, a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, Line{Equal, a[x-1]})
+		x, y = x-1, y-1
+	}
+
+	script := make([]Line, len(reversed))
+	for i, l := range reversed {
+		script[len(reversed)-1-i] = l
+	}
+	return script
+}
+
+// Unified는 a와 b의 차이를 unified diff 형식으로 반환함 (차이가 없으면 "")
+//
+// aName, bName은 "---", "+++" 머리줄에 쓰는 이름이고, context는 변경 앞뒤로 보여줄 줄 수
+func Unified(aName, bName string, a, b []string, context int) string {
+	script := Lines(a, b)
+
+	// 변경된 줄 앞뒤 context 줄씩을 한 덩어리(hunk)로 묶음 (덩어리끼리 겹치거나 붙으면 합침)
+	var hunks [][2]int
+	for i, l := range script {
+		if l.Op == Equal {
+			continue
+		}
+		lo, hi := max(0, i-context), min(len(script), i+context+1)
+		if n := len(hunks); n > 0 && lo <= hunks[n-1][1] {
+			hunks[n-1][1] = hi
+		} else {
+			hunks = append(hunks, [2]int{lo, hi})
+		}
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	// 편집 스크립트의 각 위치가 a, b의 몇 번째 줄인지 (0부터)
+	aPos := make([]int, len(script)+1)
+	bPos := make([]int, len(script)+1)
+	for i, l := range script {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if l.Op != Insert {
+			aPos[i+1]++
+		}
+		if l.Op != Delete {
+			bPos[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for _, h := range hunks {
+		lo, hi := h[0], h[1]
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aPos[lo], aPos[hi]-aPos[lo]), hunkRange(bPos[lo], bPos[hi]-bPos[lo]))
+		for _, l := range script[lo:hi] {
+			out.WriteString([]string{" ", "-", "+"}[l.Op] + l.Text + "\n")
+		}
+	}
+	return out.String()
+}
+
+// hunkRange는 덩어리 머리줄의 "시작,줄 수" (diff -u처럼 한 줄이면 줄 수를 생략하고, 빈 범위면 바로 앞 줄 번호)
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,83 @@
+package diff_test
+
+import (
+	"go-web-browser/diff"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestLines 최소 편집 스크립트를 적용하면 b가 나옴
+func TestLines(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		edits int
+	}{
+		{"", "", 0},
+		{"a b c", "a b c", 0},
+		{"a b c", "a x c", 2},
+		{"a b c a b b a", "c b a b a c", 5},
+		{"", "x y", 2},
+		{"x y", "", 2},
+	}
+
+	for _, tt := range tests {
+		a, b := strings.Fields(tt.a), strings.Fields(tt.b)
+		script := diff.Lines(a, b)
+
+		var gotA, gotB []string
+		edits := 0
+		for _, l := range script {
+			if l.Op != diff.Insert {
+				gotA = append(gotA, l.Text)
+			}
+			if l.Op != diff.Delete {
+				gotB = append(gotB, l.Text)
+			}
+			if l.Op != diff.Equal {
+				edits++
+			}
+		}
+		if strings.Join(gotA, " ") != tt.a || strings.Join(gotB, " ") != tt.b {
+			t.Errorf("Lines(%q, %q) = %v; 적용 결과가 다릅니다", tt.a, tt.b, script)
+		}
+		if edits != tt.edits {
+			t.Errorf("Lines(%q, %q) 편집 %d개; want %d", tt.a, tt.b, edits, tt.edits)
+		}
+	}
+}
+
+// TestUnified diff -u와 같은 머리줄, 덩어리 범위, 앞뒤 문맥 (멀리 떨어진 변경은 덩어리를 나눔)
+func TestUnified(t *testing.T) {
+	var a []string
+	for i := 1; i <= 20; i++ {
+		a = append(a, strconv.Itoa(i))
+	}
+	b := append(append([]string(nil), a...), "21")
+	b[5] = "six"
+
+	want := `--- old
++++ new
+@@ -3,7 +3,7 @@
+ 3
+ 4
+ 5
+-6
++six
+ 7
+ 8
+ 9
+@@ -18,3 +18,4 @@
+ 18
+ 19
+ 20
++21
+`
+	if got := diff.Unified("old", "new", a, b, 3); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := diff.Unified("old", "new", a, a, 3); got != "" {
+		t.Errorf("Unified(같은 내용) = %q; want \"\"", got)
+	}
+}
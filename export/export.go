@@ -0,0 +1,83 @@
+// Package export writes rendered pages to paginated files for printing.
+// This file contains pagination with headers and footers, and the text writer.
+package export
+
+import (
+	"fmt"
+	"go-web-browser/layout"
+	"io"
+	"strings"
+)
+
+// 기본 인쇄 크기
+const (
+	DefaultWidth      = 80 // 한 줄의 칸 수
+	DefaultPageHeight = 60 // 머리말, 꼬리말을 포함한 한 페이지의 줄 수
+)
+
+// Options는 페이지 나누기 설정
+type Options struct {
+	Width      int    // 한 줄의 칸 수 (0이면 DefaultWidth)
+	PageHeight int    // 한 페이지의 줄 수 (0이면 DefaultPageHeight)
+	Title      string // 머리말 왼쪽에 표시할 문서 제목
+	URL        string // 꼬리말 왼쪽에 표시할 문서 주소
+}
+
+// Page는 인쇄할 페이지 하나 (머리말, 본문, 꼬리말이 모두 들어 있는 줄들)
+type Page []string
+
+// headerLines, footerLines는 머리말(제목, 구분선)과 꼬리말(구분선, 주소와 쪽 번호)의 줄 수
+const (
+	headerLines = 2
+	footerLines = 2
+)
+
+// Paginate는 렌더링된 줄들을 페이지로 나누고 머리말과 꼬리말을 붙임
+//
+// 본문은 Width 칸에서 잘리고, 빈 문서도 한 페이지로 인쇄됨
+func Paginate(lines []string, opts Options) []Page {
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+	if opts.PageHeight <= 0 {
+		opts.PageHeight = DefaultPageHeight
+	}
+	body := max(opts.PageHeight-headerLines-footerLines, 1)
+	total := max((len(lines)+body-1)/body, 1)
+	rule := strings.Repeat("-", opts.Width)
+
+	pages := make([]Page, 0, total)
+	for n := 0; n < total; n++ {
+		page := Page{layout.Truncate(opts.Title, opts.Width), rule}
+		for _, line := range lines[n*body : min((n+1)*body, len(lines))] {
+			page = append(page, layout.Truncate(line, opts.Width))
+		}
+		for len(page) < headerLines+body {
+			page = append(page, "")
+		}
+		page = append(page, rule, footer(opts.URL, fmt.Sprintf("%d/%d", n+1, total), opts.Width))
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// footer는 왼쪽에 주소, 오른쪽에 쪽 번호를 둔 꼬리말 (주소가 길면 자름)
+func footer(url, number string, width int) string {
+	left := layout.Truncate(url, max(width-len(number)-1, 0))
+	return layout.PadRight(left, width-len(number)) + number
+}
+
+// WriteText는 페이지들을 고정폭 텍스트로 씀 (페이지 사이는 폼 피드 문자)
+func WriteText(w io.Writer, pages []Page) error {
+	var b strings.Builder
+	for i, page := range pages {
+		if i > 0 {
+			b.WriteString("\f")
+		}
+		for _, line := range page {
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
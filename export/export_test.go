@@ -0,0 +1,125 @@
+package export_test
+
+import (
+	"bytes"
+	"go-web-browser/export"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPaginate 본문을 페이지로 나누고 머리말(제목)과 꼬리말(주소, 쪽 번호)을 붙임
+func TestPaginate(t *testing.T) {
+	lines := []string{"one", "two", "three"}
+	pages := export.Paginate(lines, export.Options{Width: 20, PageHeight: 6, Title: "Title", URL: "http://a.com/"})
+
+	want := []export.Page{
+		{"Title", strings.Repeat("-", 20), "one", "two", strings.Repeat("-", 20), "http://a.com/    1/2"},
+		{"Title", strings.Repeat("-", 20), "three", "", strings.Repeat("-", 20), "http://a.com/    2/2"},
+	}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("Paginate() = %q; want %q", pages, want)
+	}
+}
+
+// TestPaginate_Empty 빈 문서도 한 페이지
+func TestPaginate_Empty(t *testing.T) {
+	pages := export.Paginate(nil, export.Options{})
+	if len(pages) != 1 {
+		t.Fatalf("len(Paginate(nil)) = %d; want 1", len(pages))
+	}
+	if len(pages[0]) != export.DefaultPageHeight {
+		t.Errorf("len(page) = %d; want %d", len(pages[0]), export.DefaultPageHeight)
+	}
+}
+
+// TestWriteText 페이지 사이는 폼 피드, 줄 끝 공백은 지움
+func TestWriteText(t *testing.T) {
+	var b bytes.Buffer
+	if err := export.WriteText(&b, []export.Page{{"a  ", "b"}, {"c"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "a\nb\n\fc\n"; got != want {
+		t.Errorf("WriteText() = %q; want %q", got, want)
+	}
+}
+
+// TestWritePDF 페이지 수, 이스케이프, 교차 참조 표
+func TestWritePDF(t *testing.T) {
+	var b bytes.Buffer
+	pages := []export.Page{{"f(x) = \\y", "café 한"}, {"second"}}
+	if err := export.WritePDF(&b, pages); err != nil {
+		t.Fatal(err)
+	}
+
+	pdf := b.String()
+	for _, want := range []string{
+		"%PDF-1.4\n",
+		"/Count 2",
+		"/BaseFont /Courier",
+		`(f\(x\) = \\y) Tj`,
+		`(caf\351 ??) Tj`,
+		"(second) Tj",
+		"xref\n0 8\n",
+		"%%EOF\n",
+	} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("WritePDF() output missing %q", want)
+		}
+	}
+}
+
+// TestRasterize 배경, 글자 블록, 밑줄, 넓은 문자의 위치
+func TestRasterize(t *testing.T) {
+	bg := color.RGBA{0, 0, 0, 0xff}
+	fg := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	link := color.RGBA{0, 0, 0xff, 0xff}
+	rows := [][]export.Run{
+		{{Text: "a ", Fg: fg, Bg: bg}, {Text: "한", Fg: link, Bg: bg, Underline: true}},
+	}
+	img := export.Rasterize(rows, 64, 32, bg)
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"글자 블록", 4, 8, fg},
+		{"공백", 12, 8, bg},
+		{"넓은 문자는 두 칸", 28, 8, link},
+		{"밑줄", 20, export.CellHeight - 1, link},
+		{"줄 밖", 4, 24, bg},
+	}
+	for _, tt := range tests {
+		if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: pixel(%d, %d) = %v; want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+// TestRasterize_BoxDrawing 상자 그리기 문자는 칸 가운데를 지나는 선으로 그려서 이웃 칸과 이어짐
+func TestRasterize_BoxDrawing(t *testing.T) {
+	bg := color.RGBA{0, 0, 0, 0xff}
+	fg := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	rows := [][]export.Run{{{Text: "┌─┐", Fg: fg, Bg: bg}}, {{Text: "└─┘", Fg: fg, Bg: bg}}}
+	img := export.Rasterize(rows, 24, 32, bg)
+
+	mid := export.CellHeight / 2
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"가로선 왼쪽 끝", 8, mid, fg},
+		{"가로선 오른쪽 끝", 15, mid, fg},
+		{"모서리 바깥", 1, mid, bg},
+		{"세로선이 아래 줄로 이어짐", 4, export.CellHeight - 1, fg},
+		{"글자 블록이 아님", 10, mid - 3, bg},
+	}
+	for _, tt := range tests {
+		if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: pixel(%d, %d) = %v; want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
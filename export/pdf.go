@@ -0,0 +1,106 @@
+// Package export writes rendered pages to paginated files for printing.
+// This file contains a minimal PDF writer for fixed-width pages.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"go-web-browser/layout"
+	"io"
+	"strings"
+)
+
+// A4 용지 크기와 여백 (포인트, 1/72인치)
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 36
+)
+
+// WritePDF는 페이지들을 Courier 글꼴의 PDF로 씀
+//
+// 외부 라이브러리 없이 PDF 1.4의 최소 구조(카탈로그, 페이지 트리, 글꼴,
+// 페이지별 내용 스트림)만 만듦. 기본 글꼴은 Latin-1 문자만 그릴 수 있으므로
+// 그 밖의 문자(한글 등)는 칸 수만큼 '?'로 바꿈
+func WritePDF(w io.Writer, pages []Page) error {
+	width := 1
+	for _, page := range pages {
+		for _, line := range page {
+			width = max(width, layout.StringWidth(line))
+		}
+	}
+	// Courier의 글자 폭은 글꼴 크기의 0.6배
+	size := min(10.0, float64(pdfPageWidth-2*pdfMargin)/(0.6*float64(width)))
+	leading := size * 1.2
+
+	pw := &pdfWriter{}
+	pw.buf.WriteString("%PDF-1.4\n")
+	pw.object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	// 페이지 트리(2번)는 페이지 객체 번호를 알아야 하므로 미리 번호를 정함
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	pw.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	pw.object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT /F1 %.2f Tf %.2f TL %d %.2f Td\n", size, leading, pdfMargin, float64(pdfPageHeight-pdfMargin)-size)
+		for _, line := range page {
+			content.WriteString("(" + pdfString(line) + ") Tj T*\n")
+		}
+		content.WriteString("ET\n")
+
+		pw.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+		pw.object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	pw.finish()
+	_, err := w.Write(pw.buf.Bytes())
+	return err
+}
+
+// pdfWriter는 객체를 차례로 쓰면서 교차 참조 표에 넣을 위치를 기록함
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int // 객체 번호 - 1 → 파일 안의 위치
+}
+
+// object는 다음 번호의 객체를 씀 (번호는 1부터)
+func (pw *pdfWriter) object(body string) {
+	pw.offsets = append(pw.offsets, pw.buf.Len())
+	fmt.Fprintf(&pw.buf, "%d 0 obj\n%s\nendobj\n", len(pw.offsets), body)
+}
+
+// finish는 교차 참조 표와 트레일러를 씀
+func (pw *pdfWriter) finish() {
+	xref := pw.buf.Len()
+	fmt.Fprintf(&pw.buf, "xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		fmt.Fprintf(&pw.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&pw.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, xref)
+}
+
+// pdfString은 줄을 PDF 문자열 리터럴 안에 넣을 수 있게 바꿈
+//
+// 괄호와 역슬래시는 이스케이프하고, ASCII 밖의 Latin-1 문자는 8진수로 씀
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteString("\\" + string(r))
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteString(strings.Repeat("?", max(layout.RuneWidth(r), 1)))
+		}
+	}
+	return b.String()
+}
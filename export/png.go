@@ -0,0 +1,122 @@
+// Package export writes rendered pages to paginated files for printing.
+// This file contains rasterizing laid-out text into PNG screenshots.
+package export
+
+import (
+	"go-web-browser/layout"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"unicode"
+)
+
+// 글자 한 칸의 픽셀 크기 (넓은 문자는 두 칸)
+const (
+	CellWidth  = 8
+	CellHeight = 16
+)
+
+// Run은 같은 색으로 그리는 텍스트 조각
+type Run struct {
+	Text      string
+	Fg, Bg    color.RGBA
+	Underline bool
+}
+
+// Rasterize는 줄마다 조각들을 width x height 픽셀 이미지에 그림
+//
+// 표준 라이브러리에는 글꼴이 없으므로 글자는 모양 대신 자리만 블록으로 그림
+// (대문자와 b, d, f 같은 글자는 위로, g, p, y 같은 글자는 아래로 길게)
+// 글자를 읽을 수는 없지만 줄바꿈, 들여쓰기, 색 같은 레이아웃 변화는 그대로 보이므로
+// 레이아웃 엔진의 시각적 회귀 테스트에 쓸 수 있음
+func Rasterize(rows [][]Run, width, height int, background color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for row, runs := range rows {
+		y := row * CellHeight
+		if y >= height {
+			break
+		}
+		x := 0
+		for _, run := range runs {
+			w := layout.StringWidth(run.Text) * CellWidth
+			fill(img, image.Rect(x, y, x+w, y+CellHeight), run.Bg)
+			cx := x
+			for _, r := range run.Text {
+				cw := layout.RuneWidth(r) * CellWidth
+				if lines, ok := boxLines(r, cx, y, cw); ok {
+					for _, l := range lines {
+						fill(img, l, run.Fg)
+					}
+				} else if !unicode.IsSpace(r) && cw > 0 {
+					fill(img, glyphRect(r, cx, y, cw), run.Fg)
+				}
+				cx += cw
+			}
+			if run.Underline {
+				fill(img, image.Rect(x, y+CellHeight-1, x+w, y+CellHeight), run.Fg)
+			}
+			x += w
+		}
+	}
+	return img
+}
+
+// ascenders, descenders는 블록을 위나 아래로 길게 그리는 소문자
+const (
+	ascenders  = "bdfhklt"
+	descenders = "gjpqy"
+)
+
+// glyphRect는 글자 하나를 대신하는 블록의 위치 (칸 안에서 양옆 1픽셀 여백)
+func glyphRect(r rune, x, y, w int) image.Rectangle {
+	top, bottom := 6, 13
+	if unicode.IsUpper(r) || unicode.IsDigit(r) || strings.ContainsRune(ascenders, r) || r > unicode.MaxLatin1 {
+		top = 3
+	}
+	if strings.ContainsRune(descenders, r) {
+		bottom = 15
+	}
+	return image.Rect(x+1, y+top, x+w-1, y+bottom)
+}
+
+// boxArms는 상자 그리기 문자가 칸 가운데에서 뻗는 방향 (위, 오른쪽, 아래, 왼쪽)
+var boxArms = map[rune][4]bool{
+	'─': {false, true, false, true},
+	'│': {true, false, true, false},
+	'┌': {false, true, true, false},
+	'┐': {false, false, true, true},
+	'└': {true, true, false, false},
+	'┘': {true, false, false, true},
+}
+
+// boxLines는 상자 그리기 문자를 칸 가운데를 지나는 1픽셀 선들로 바꿈 (상자 문자가 아니면 ok = false)
+//
+// 이웃한 칸의 선과 이어지므로 CSS 테두리가 끊기지 않은 사각형으로 보임
+func boxLines(r rune, x, y, w int) (lines []image.Rectangle, ok bool) {
+	arms, ok := boxArms[r]
+	if !ok {
+		return nil, false
+	}
+	cx, cy := x+w/2, y+CellHeight/2
+	if arms[0] {
+		lines = append(lines, image.Rect(cx, y, cx+1, cy+1))
+	}
+	if arms[1] {
+		lines = append(lines, image.Rect(cx, cy, x+w, cy+1))
+	}
+	if arms[2] {
+		lines = append(lines, image.Rect(cx, cy, cx+1, y+CellHeight))
+	}
+	if arms[3] {
+		lines = append(lines, image.Rect(x, cy, cx+1, cy+1))
+	}
+	return lines, true
+}
+
+// fill은 r 영역을 c로 칠함 (이미지 밖은 무시)
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r.Intersect(img.Bounds()), image.NewUniform(c), image.Point{}, draw.Src)
+}
@@ -0,0 +1,119 @@
+// Package history stores the pages the user has visited.
+// This file contains the JSON-backed visit history store.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName은 프로필의 방문 기록 디렉토리 안의 파일 이름
+const FileName = "history.json"
+
+// MaxEntries는 저장하는 방문 기록의 최대 개수 (넘으면 오래된 것부터 지움)
+const MaxEntries = 1000
+
+// Entry는 방문 한 번
+type Entry struct {
+	Title   string    `json:"title"`
+	URL     string    `json:"url"`
+	Lang    string    `json:"lang,omitempty"` // 문서 언어 (<html lang> 또는 Content-Language, 모르면 "")
+	Visited time.Time `json:"visited"`
+}
+
+// Store는 방문 기록과 저장 위치
+//
+// path가 비어 있으면 파일에 저장하지 않음 (테스트, 임시 사용)
+type Store struct {
+	path    string
+	entries []Entry
+	visited map[string]int // URL → entries 안의 방문 횟수
+}
+
+// Open은 path의 방문 기록 파일을 읽어 Store를 만듦 (파일이 없으면 빈 기록)
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, visited: make(map[string]int)}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("방문 기록 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("방문 기록 파일 형식 오류 (%s): %w", path, err)
+	}
+	for _, e := range s.entries {
+		s.visited[e.URL]++
+	}
+	return s, nil
+}
+
+// All은 방문한 순서대로(오래된 것부터) 모든 기록을 반환함
+func (s *Store) All() []Entry {
+	return append([]Entry(nil), s.entries...)
+}
+
+// Visited는 rawURL을 방문한 적이 있는지 확인함
+func (s *Store) Visited(rawURL string) bool {
+	return s.visited[rawURL] > 0
+}
+
+// Add는 언어를 모르는 방문 기록을 추가하고 파일에 저장함 (AddEntry 참고)
+func (s *Store) Add(title, rawURL string) error {
+	return s.AddEntry(Entry{Title: title, URL: rawURL})
+}
+
+// AddEntry는 방문 기록 e를 추가하고 파일에 저장함 (e.Visited가 비어 있으면 지금 시각)
+//
+// MaxEntries를 넘으면 가장 오래된 기록부터 지움
+func (s *Store) AddEntry(e Entry) error {
+	if e.Visited.IsZero() {
+		e.Visited = time.Now()
+	}
+	s.entries = append(s.entries, e)
+	s.visited[e.URL]++
+	if over := len(s.entries) - MaxEntries; over > 0 {
+		for _, e := range s.entries[:over] {
+			if s.visited[e.URL]--; s.visited[e.URL] == 0 {
+				delete(s.visited, e.URL)
+			}
+		}
+		s.entries = append([]Entry(nil), s.entries[over:]...)
+	}
+	return s.Save()
+}
+
+// Save는 방문 기록을 파일에 씀
+//
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰는 도중 종료되어도 기존 파일이 깨지지 않음
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("방문 기록 저장 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("방문 기록 저장 실패: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("방문 기록 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("방문 기록 저장 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package history_test
+
+import (
+	"go-web-browser/history"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestStore_AddAndReopen 추가한 방문 기록이 파일에 저장되고 다시 읽힘
+func TestStore_AddAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", history.FileName)
+	s, err := history.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	if s.Visited("http://example.com/") {
+		t.Error("빈 기록에서 Visited() = true")
+	}
+
+	for _, u := range []string{"http://example.com/", "http://example.com/a", "http://example.com/"} {
+		if err := s.Add("Example", u); err != nil {
+			t.Fatalf("Add(%q) failed: %v", u, err)
+		}
+	}
+
+	reopened, err := history.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	if n := len(reopened.All()); n != 3 {
+		t.Errorf("len(All()) = %d; want 3", n)
+	}
+	for _, u := range []string{"http://example.com/", "http://example.com/a"} {
+		if !reopened.Visited(u) {
+			t.Errorf("Visited(%q) = false; want true", u)
+		}
+	}
+	if reopened.Visited("http://example.com/b") {
+		t.Error("Visited(/b) = true; want false")
+	}
+}
+
+// TestStore_MaxEntries 기록이 MaxEntries를 넘으면 오래된 방문은 잊음
+func TestStore_MaxEntries(t *testing.T) {
+	s, _ := history.Open("")
+	s.Add("first", "http://first/")
+	for i := 0; i < history.MaxEntries; i++ {
+		s.Add("other", "http://other/")
+	}
+
+	if n := len(s.All()); n != history.MaxEntries {
+		t.Errorf("len(All()) = %d; want %d", n, history.MaxEntries)
+	}
+	if s.Visited("http://first/") {
+		t.Error("잘려 나간 기록의 Visited() = true; want false")
+	}
+}
+
+// TestStore_Search 퍼지 검색은 잘 맞는 순서로, 같은 URL은 한 번만, 언어 필터는 앞부분 비교
+func TestStore_Search(t *testing.T) {
+	s, _ := history.Open("")
+	s.AddEntry(history.Entry{Title: "Go Web Browser", URL: "http://example.com/gwb", Lang: "en"})
+	s.AddEntry(history.Entry{Title: "브라우저 만들기", URL: "http://example.kr/", Lang: "ko-KR"})
+	s.AddEntry(history.Entry{Title: "Browser news", URL: "http://news.example/", Lang: "en-US"})
+	s.AddEntry(history.Entry{Title: "Go Web Browser", URL: "http://example.com/gwb", Lang: "en"})
+
+	tests := []struct {
+		query, lang string
+		want        []string // URL
+	}{
+		{"", "", []string{"http://example.com/gwb", "http://news.example/", "http://example.kr/"}},
+		{"browser", "", []string{"http://news.example/", "http://example.com/gwb"}}, // 앞쪽에서 맞는 것이 먼저
+		{"gwbr", "", []string{"http://example.com/gwb"}},
+		{"브라우저", "", []string{"http://example.kr/"}},
+		{"", "ko", []string{"http://example.kr/"}},
+		{"browser", "en", []string{"http://news.example/", "http://example.com/gwb"}},
+		{"browser", "en-us", []string{"http://news.example/"}},
+		{"zzz", "", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, e := range s.Search(tt.query, tt.lang) {
+			got = append(got, e.URL)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q, %q) = %q; want %q", tt.query, tt.lang, got, tt.want)
+		}
+	}
+	if got, want := s.Langs(), []string{"en", "ko-kr", "en-us"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Langs() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+// Package history stores the pages the user has visited.
+// This file contains history search with language filtering and fuzzy matching.
+package history
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Search는 query와 맞는 방문 기록을 잘 맞는 순서로 반환함 (같은 URL은 가장 최근 방문 하나만)
+//
+// query의 단어마다 제목이나 URL에 있어야 하고, 대소문자는 구분하지 않음
+// 단어가 그대로 들어 있지 않아도 글자가 순서대로 나오면 맞는 것으로 봄 (예: "gwb" → "go-web-browser")
+// 점수가 같으면 최근 방문이 먼저 옴
+// lang이 ""가 아니면 그 언어의 기록만 반환함 ("ko"는 "ko", "ko-KR" 모두와 맞음)
+// query가 비어 있으면 언어만 거른 최근 방문 순서
+func (s *Store) Search(query, lang string) []Entry {
+	words := strings.Fields(strings.ToLower(query))
+	type match struct {
+		entry Entry
+		score int
+		order int // 최근 방문일수록 작음
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+		if seen[e.URL] || !MatchLang(e.Lang, lang) {
+			continue
+		}
+		score, ok := matchWords(words, strings.ToLower(e.Title), strings.ToLower(e.URL))
+		if !ok {
+			continue
+		}
+		seen[e.URL] = true
+		matches = append(matches, match{entry: e, score: score, order: len(matches)})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	entries := make([]Entry, len(matches))
+	for i, m := range matches {
+		entries[i] = m.entry
+	}
+	return entries
+}
+
+// Langs는 방문 기록에 있는 문서 언어들 (소문자, 처음 나온 순서)
+func (s *Store) Langs() []string {
+	var langs []string
+	seen := make(map[string]bool)
+	for _, e := range s.entries {
+		lang := strings.ToLower(e.Lang)
+		if lang != "" && !seen[lang] {
+			seen[lang] = true
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// MatchLang은 문서 언어 tag가 filter와 맞는지 확인함 (filter가 ""면 항상 true)
+//
+// BCP 47의 앞부분 비교: "ko"는 "ko-KR"과 맞지만 "ko-KR"은 "ko"와 맞지 않음
+func MatchLang(tag, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	tag, filter = strings.ToLower(tag), strings.ToLower(filter)
+	return tag == filter || strings.HasPrefix(tag, filter+"-")
+}
+
+// matchWords는 모든 단어가 title이나 rawURL에 맞으면 점수의 합을 반환함
+func matchWords(words []string, title, rawURL string) (int, bool) {
+	total := 0
+	for _, w := range words {
+		score, ok := fuzzyScore(w, title)
+		if urlScore, urlOK := fuzzyScore(w, rawURL); urlOK && (!ok || urlScore > score) {
+			score, ok = urlScore, true
+		}
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+// fuzzyScore는 pattern의 글자가 text에 순서대로 모두 나오는지와 얼마나 잘 맞는지를 반환함
+//
+// 그대로 들어 있으면 가장 높고(앞쪽일수록 높음), 글자 사이가 떨어질수록 낮음
+func fuzzyScore(pattern, text string) (int, bool) {
+	if i := strings.Index(text, pattern); i >= 0 {
+		return 1000 - min(utf8.RuneCountInString(text[:i]), 500), true
+	}
+	score, gap := 0, 0
+	rest := text
+	for _, r := range pattern {
+		i := strings.IndexRune(rest, r)
+		if i < 0 {
+			return 0, false
+		}
+		gap += utf8.RuneCountInString(rest[:i])
+		rest = rest[i+utf8.RuneLen(r):]
+		score += 10
+	}
+	return max(score-gap, 1), true
+}
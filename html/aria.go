@@ -0,0 +1,60 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains accessibility helpers (alt text, ARIA labels).
+package html
+
+import "strings"
+
+// AriaHidden은 요소에 aria-hidden="true"가 있는지 확인함
+//
+// 아이콘 글꼴 같은 장식용 요소를 텍스트 출력에서 빼기 위해 사용함
+func (n *Node) AriaHidden() bool {
+	if n.Type != ElementNode {
+		return false
+	}
+	v, ok := n.Attr("aria-hidden")
+	return ok && strings.EqualFold(strings.TrimSpace(v), "true")
+}
+
+// AccessibleLabel은 내용이 비어 있는 요소의 대체 이름을 반환함
+//
+// aria-label, 없으면 title 속성 (둘 다 없으면 빈 문자열)
+func (n *Node) AccessibleLabel() string {
+	for _, name := range []string{"aria-label", "title"} {
+		if v, _ := n.Attr(name); strings.TrimSpace(v) != "" {
+			return strings.Join(strings.Fields(v), " ")
+		}
+	}
+	return ""
+}
+
+// AltText는 <img>의 대체 텍스트 (공백 정리됨, 없으면 빈 문자열)
+func (n *Node) AltText() string {
+	alt, _ := n.Attr("alt")
+	return strings.Join(strings.Fields(alt), " ")
+}
+
+// HasVisibleText는 n 안에 화면에 보이는 텍스트가 있는지 확인함
+//
+// 보이지 않는 요소, aria-hidden 요소는 제외하고 이미지의 대체 텍스트는 포함함
+func (n *Node) HasVisibleText() bool {
+	found := false
+	n.Walk(func(c *Node) bool {
+		switch {
+		case found:
+			return false
+		case c.Type == ElementNode && (IsHidden(c.Tag) || c.AriaHidden()):
+			return false
+		case c.Type == ElementNode && c.Tag == "img":
+			found = c.AltText() != ""
+		case c.Type == ElementNode && IsMedia(c.Tag):
+			found = true
+		case c.Type == TextNode:
+			found = strings.TrimSpace(c.Data) != ""
+		}
+		return true
+	})
+	return found
+}
+
+// labelledElements는 내용이 비어 있으면 AccessibleLabel을 대신 표시하는 요소들
+var labelledElements = map[string]bool{"a": true, "button": true}
@@ -0,0 +1,43 @@
+package html
+
+import "testing"
+
+// TestAccessibleLabel aria-label, title 순서로 대체 이름 찾기
+func TestAccessibleLabel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`<a aria-label=" Open  menu " title="Menu">`, "Open menu"},
+		{`<a aria-label="" title="Menu">`, "Menu"},
+		{`<a>`, ""},
+	}
+
+	for _, tt := range tests {
+		a := Parse(tt.input).Find("a")
+		if got := a.AccessibleLabel(); got != tt.want {
+			t.Errorf("AccessibleLabel(%q) = %q; want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestHasVisibleText 보이는 텍스트 판단 (aria-hidden 제외, alt 포함)
+func TestHasVisibleText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{`<a>text</a>`, true},
+		{`<a> <i aria-hidden="true">x</i> </a>`, false},
+		{`<a><img src=a.png></a>`, false},
+		{`<a><img alt="Logo"></a>`, true},
+		{`<a><script>x</script></a>`, false},
+	}
+
+	for _, tt := range tests {
+		a := Parse(tt.input).Find("a")
+		if got := a.HasVisibleText(); got != tt.want {
+			t.Errorf("HasVisibleText(%q) = %v; want %v", tt.input, got, tt.want)
+		}
+	}
+}
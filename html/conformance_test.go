@@ -0,0 +1,294 @@
+package html
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// html5lib-tests 형식의 적합성 테스트
+//
+//   - testdata/tokenizer/*.test: JSON ({"tests": [{"description", "input", "output"}]})
+//   - testdata/tree-construction/*.dat: #data / #errors / #document 블록
+//
+// 아직 통과하지 못하는 케이스는 각 디렉토리의 known-failures.txt에 "파일#번호"로 적어둠
+// 목록에 없는 케이스가 실패하거나, 목록에 있는 케이스가 통과하면 테스트 실패
+// 파서를 고친 뒤 go test ./html -run Conformance -update-known-failures 로 목록을 다시 씀
+
+var updateKnownFailures = flag.Bool("update-known-failures", false, "적합성 테스트 결과로 known-failures.txt를 다시 씀")
+
+// knownFailuresFile은 통과하지 못하는 케이스 목록 파일 이름
+const knownFailuresFile = "known-failures.txt"
+
+// conformanceCase는 코퍼스의 케이스 하나
+type conformanceCase struct {
+	id    string // "파일#번호" (번호는 0부터)
+	name  string // 설명 또는 입력
+	input string
+	check func(input string) (got, want string, ok bool)
+}
+
+// runConformance는 케이스를 모두 실행하고 known-failures.txt와 비교함
+func runConformance(t *testing.T, dir string, cases []conformanceCase) {
+	known := readKnownFailures(t, filepath.Join(dir, knownFailuresFile))
+
+	var failed []string
+	for _, c := range cases {
+		got, want, ok := c.check(c.input)
+		if !ok {
+			failed = append(failed, c.id)
+		}
+		switch {
+		case *updateKnownFailures:
+		case !ok && !known[c.id]:
+			t.Errorf("%s (%s)\ninput: %q\ngot:\n%s\nwant:\n%s", c.id, c.name, c.input, got, want)
+		case ok && known[c.id]:
+			t.Errorf("%s (%s) 통과함; %s에서 지우세요", c.id, c.name, knownFailuresFile)
+		}
+	}
+	t.Logf("%s: %d/%d 통과", dir, len(cases)-len(failed), len(cases))
+
+	if *updateKnownFailures {
+		data := strings.Join(failed, "\n")
+		if data != "" {
+			data += "\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, knownFailuresFile), []byte(data), 0o644); err != nil {
+			t.Fatalf("%s 쓰기 실패: %v", knownFailuresFile, err)
+		}
+	}
+}
+
+// readKnownFailures는 known-failures.txt의 케이스 ID 목록을 읽음 (없으면 빈 목록)
+func readKnownFailures(t *testing.T, path string) map[string]bool {
+	known := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return known
+	}
+	if err != nil {
+		t.Fatalf("%s 읽기 실패: %v", path, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			known[line] = true
+		}
+	}
+	return known
+}
+
+// TestConformance_Tokenizer 토크나이저 코퍼스 (html5lib-tests tokenizer 형식)
+func TestConformance_Tokenizer(t *testing.T) {
+	dir := filepath.Join("testdata", "tokenizer")
+	files, _ := filepath.Glob(filepath.Join(dir, "*.test"))
+	if len(files) == 0 {
+		t.Fatalf("%s에 코퍼스가 없습니다", dir)
+	}
+
+	var cases []conformanceCase
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile(%q) failed: %v", file, err)
+		}
+		var corpus struct {
+			Tests []struct {
+				Description string `json:"description"`
+				Input       string `json:"input"`
+				Output      []any  `json:"output"`
+			} `json:"tests"`
+		}
+		if err := json.Unmarshal(data, &corpus); err != nil {
+			t.Fatalf("%s 형식 오류: %v", file, err)
+		}
+		for i, test := range corpus.Tests {
+			want := test.Output
+			cases = append(cases, conformanceCase{
+				id:    fmt.Sprintf("%s#%d", filepath.Base(file), i),
+				name:  test.Description,
+				input: test.Input,
+				check: func(input string) (string, string, bool) {
+					got := html5libTokens(Tokenize(input))
+					return toJSON(got), toJSON(want), reflect.DeepEqual(normalizeJSON(got), normalizeJSON(want))
+				},
+			})
+		}
+	}
+	runConformance(t, dir, cases)
+}
+
+// html5libTokens는 토큰을 html5lib-tests의 출력 형식으로 바꿈 (연속된 텍스트는 합침)
+//
+// doctype은 이름만 알기 때문에 public/system 식별자는 항상 null
+func html5libTokens(tokens []Token) []any {
+	out := []any{}
+	for _, tok := range tokens {
+		switch tok.Type {
+		case TextToken:
+			if n := len(out); n > 0 {
+				if prev, ok := out[n-1].([]any); ok && prev[0] == "Character" {
+					prev[1] = prev[1].(string) + tok.Data
+					continue
+				}
+			}
+			out = append(out, []any{"Character", tok.Data})
+		case StartTagToken, SelfClosingTagToken:
+			attrs := map[string]any{}
+			for _, a := range tok.Attrs {
+				attrs[a.Name] = a.Value
+			}
+			token := []any{"StartTag", tok.Data, attrs}
+			if tok.Type == SelfClosingTagToken {
+				token = append(token, true)
+			}
+			out = append(out, token)
+		case EndTagToken:
+			out = append(out, []any{"EndTag", tok.Data})
+		case CommentToken:
+			out = append(out, []any{"Comment", tok.Data})
+		case DoctypeToken:
+			out = append(out, []any{"DOCTYPE", tok.Data, nil, nil, true})
+		}
+	}
+	return out
+}
+
+// normalizeJSON은 JSON으로 한 번 주고받아서 비교할 수 있는 형태로 맞춤
+func normalizeJSON(v any) any {
+	var out any
+	json.Unmarshal([]byte(toJSON(v)), &out)
+	return out
+}
+
+// toJSON은 실패 메시지에 보여줄 JSON (<, & 등을 이스케이프하지 않음)
+func toJSON(v any) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// TestConformance_TreeConstruction 트리 빌더 코퍼스 (html5lib-tests tree-construction 형식)
+func TestConformance_TreeConstruction(t *testing.T) {
+	dir := filepath.Join("testdata", "tree-construction")
+	files, _ := filepath.Glob(filepath.Join(dir, "*.dat"))
+	if len(files) == 0 {
+		t.Fatalf("%s에 코퍼스가 없습니다", dir)
+	}
+
+	var cases []conformanceCase
+	for _, file := range files {
+		tests, err := readTreeTests(file)
+		if err != nil {
+			t.Fatalf("%s 형식 오류: %v", file, err)
+		}
+		for i, test := range tests {
+			want := test.document
+			cases = append(cases, conformanceCase{
+				id:    fmt.Sprintf("%s#%d", filepath.Base(file), i),
+				name:  fmt.Sprintf("%q", test.data),
+				input: test.data,
+				check: func(input string) (string, string, bool) {
+					got := dumpTree(Parse(input))
+					return got, want, got == want
+				},
+			})
+		}
+	}
+	runConformance(t, dir, cases)
+}
+
+// treeTest는 .dat 파일의 케이스 하나
+type treeTest struct {
+	data     string // #data
+	document string // #document (각 줄 "| "로 시작)
+}
+
+// readTreeTests는 .dat 파일을 읽음 (#errors 등 다른 섹션은 무시)
+func readTreeTests(path string) ([]treeTest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tests []treeTest
+	var section string
+	var data, document []string
+	flush := func() {
+		if data != nil {
+			tests = append(tests, treeTest{
+				data:     strings.Join(data, "\n"),
+				document: strings.Join(document, "\n"),
+			})
+		}
+		data, document = nil, nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			section = line
+			if section == "#data" {
+				flush()
+				data = []string{}
+			}
+			continue
+		}
+		switch section {
+		case "#data":
+			data = append(data, line)
+		case "#document":
+			if line != "" {
+				document = append(document, line)
+			}
+		}
+	}
+	flush()
+	return tests, scanner.Err()
+}
+
+// dumpTree는 DOM을 html5lib-tests의 #document 형식으로 씀
+//
+// 요소는 <tag>, 속성은 이름순으로 요소보다 두 칸 들여서 name="value",
+// 텍스트는 "text", 주석은 <!-- data -->
+func dumpTree(doc *Node) string {
+	var lines []string
+	var dump func(n *Node, depth int)
+	dump = func(n *Node, depth int) {
+		indent := "| " + strings.Repeat("  ", depth)
+		switch n.Type {
+		case DoctypeNode:
+			lines = append(lines, indent+"<!DOCTYPE "+n.Data+">")
+		case ElementNode:
+			lines = append(lines, indent+"<"+n.Tag+">")
+			attrs := append([]Attribute(nil), n.Attrs...)
+			sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+			for _, a := range attrs {
+				lines = append(lines, fmt.Sprintf("%s  %s=\"%s\"", indent, a.Name, a.Value))
+			}
+		case TextNode:
+			lines = append(lines, indent+`"`+n.Data+`"`)
+		case CommentNode:
+			lines = append(lines, indent+"<!-- "+n.Data+" -->")
+		}
+		for _, c := range n.Children {
+			next := depth + 1
+			if n.Type == DocumentNode {
+				next = depth
+			}
+			dump(c, next)
+		}
+	}
+	dump(doc, 0)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,59 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains <audio>/<video> source lookup and their text placeholders.
+package html
+
+import (
+	"path"
+	"strings"
+)
+
+// mediaElements는 재생할 수 없어서 자리 표시 텍스트로 보여주는 요소와 그 이름
+var mediaElements = map[string]string{"audio": "Audio", "video": "Video"}
+
+// IsMedia는 tag가 <audio>나 <video>인지 확인함
+func IsMedia(tag string) bool {
+	_, ok := mediaElements[tag]
+	return ok
+}
+
+// MediaSource는 미디어 요소의 주소와 MIME 타입 (src 속성, 없으면 첫 번째 <source src>)
+//
+// 주소는 속성 값 그대로(상대 URL일 수 있음)이고, 없으면 빈 문자열
+func (n *Node) MediaSource() (src, mimeType string) {
+	if src, _ := n.Attr("src"); strings.TrimSpace(src) != "" {
+		mimeType, _ = n.Attr("type")
+		return strings.TrimSpace(src), mimeType
+	}
+	for _, c := range n.Children {
+		if c.Type != ElementNode || c.Tag != "source" {
+			continue
+		}
+		if src, _ := c.Attr("src"); strings.TrimSpace(src) != "" {
+			mimeType, _ = c.Attr("type")
+			return strings.TrimSpace(src), mimeType
+		}
+	}
+	return "", ""
+}
+
+// MediaPlaceholder는 미디어 요소 대신 표시하는 텍스트
+//
+// 이름은 aria-label, title, 없으면 파일 이름을 씀 (재생 시간은 미디어를 받아 봐야 알 수 있어서 넣지 않음)
+//
+// 예시: <video src="/clips/intro.mp4" type="video/mp4"> → "[Video: intro.mp4 (video/mp4)]"
+func (n *Node) MediaPlaceholder() string {
+	src, mimeType := n.MediaSource()
+	name := n.AccessibleLabel()
+	if name == "" && src != "" {
+		name = path.Base(strings.SplitN(src, "?", 2)[0])
+	}
+
+	text := "[" + mediaElements[n.Tag]
+	if name != "" {
+		text += ": " + name
+	}
+	if mimeType != "" {
+		text += " (" + mimeType + ")"
+	}
+	return text + "]"
+}
@@ -0,0 +1,204 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains the DOM node types.
+package html
+
+import "strings"
+
+// NodeType은 DOM 노드의 종류
+type NodeType int
+
+// 노드 종류 상수
+const (
+	DocumentNode NodeType = iota // 문서 루트
+	ElementNode                  // <tag> 요소
+	TextNode                     // 텍스트
+	CommentNode                  // <!-- 주석 -->
+	DoctypeNode                  // <!DOCTYPE html>
+)
+
+// Attribute는 요소의 속성 하나 (원본 순서를 유지하기 위해 map 대신 slice로 저장)
+type Attribute struct {
+	Name  string // 소문자로 정규화된 속성 이름
+	Value string // 엔티티가 디코딩된 속성 값
+}
+
+// Node는 DOM 트리의 노드
+//
+// 요소(ElementNode)는 Tag와 Attrs를, 텍스트/주석/doctype은 Data를 사용함
+type Node struct {
+	Type     NodeType
+	Tag      string      // 소문자 태그 이름 (ElementNode)
+	Data     string      // 텍스트/주석 내용 또는 doctype 이름
+	Attrs    []Attribute // 속성 목록 (ElementNode)
+	Parent   *Node
+	Children []*Node
+
+	dirty bool // 마지막 ClearDirty 이후 이 노드나 자손이 바뀌었는지
+}
+
+// NewElement는 태그 이름과 속성으로 요소 노드를 만듦
+func NewElement(tag string, attrs []Attribute) *Node {
+	return &Node{Type: ElementNode, Tag: tag, Attrs: attrs}
+}
+
+// NewText는 텍스트 노드를 만듦
+func NewText(data string) *Node {
+	return &Node{Type: TextNode, Data: data}
+}
+
+// AppendChild는 child를 마지막 자식으로 추가함
+//
+// 마지막 자식과 새 자식이 모두 텍스트면 하나의 텍스트 노드로 합침
+func (n *Node) AppendChild(child *Node) {
+	n.MarkDirty()
+	if child.Type == TextNode && len(n.Children) > 0 {
+		last := n.Children[len(n.Children)-1]
+		if last.Type == TextNode {
+			last.Data += child.Data
+			return
+		}
+	}
+	child.Parent = n
+	n.Children = append(n.Children, child)
+}
+
+// InsertBefore는 child를 ref 앞에 삽입함 (ref가 nil이면 마지막에 추가)
+func (n *Node) InsertBefore(child, ref *Node) {
+	if ref == nil {
+		n.AppendChild(child)
+		return
+	}
+	for i, c := range n.Children {
+		if c == ref {
+			n.MarkDirty()
+			child.Parent = n
+			n.Children = append(n.Children[:i], append([]*Node{child}, n.Children[i:]...)...)
+			return
+		}
+	}
+	n.AppendChild(child)
+}
+
+// RemoveChild는 자식 목록에서 child를 제거함
+func (n *Node) RemoveChild(child *Node) {
+	for i, c := range n.Children {
+		if c == child {
+			n.MarkDirty()
+			n.Children = append(n.Children[:i], n.Children[i+1:]...)
+			child.Parent = nil
+			return
+		}
+	}
+}
+
+// Attr는 속성 값을 반환함 (없으면 "", false)
+func (n *Node) Attr(name string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetAttr는 속성 값을 설정함 (없으면 추가)
+func (n *Node) SetAttr(name, value string) {
+	n.MarkDirty()
+	for i := range n.Attrs {
+		if n.Attrs[i].Name == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, Attribute{Name: name, Value: value})
+}
+
+// SetData는 텍스트/주석 노드의 내용을 바꿈
+func (n *Node) SetData(data string) {
+	n.MarkDirty()
+	n.Data = data
+}
+
+// MarkDirty는 n과 모든 조상을 바뀐 것으로 표시함
+//
+// 노드를 바꾸는 메서드(AppendChild, SetAttr 등)가 자동으로 부르므로
+// Data, Attrs 같은 필드를 직접 바꿨을 때만 부르면 됨
+// (바뀐 노드의 조상은 항상 dirty이므로 이미 dirty인 조상에서 멈춤)
+func (n *Node) MarkDirty() {
+	for a := n; a != nil && !a.dirty; a = a.Parent {
+		a.dirty = true
+	}
+}
+
+// Dirty는 마지막 ClearDirty 이후 n이나 자손이 바뀌었는지 확인함
+//
+// 렌더러는 dirty가 아닌 서브트리의 스타일과 레이아웃 결과를 그대로 재사용할 수 있음
+func (n *Node) Dirty() bool {
+	return n.dirty
+}
+
+// ClearDirty는 n과 모든 자손의 바뀐 표시를 지움 (다시 그린 뒤에 부름)
+func (n *Node) ClearDirty() {
+	n.Walk(func(node *Node) bool {
+		if !node.dirty {
+			return false // dirty가 아닌 노드의 자손은 dirty가 아님
+		}
+		node.dirty = false
+		return true
+	})
+}
+
+// Walk는 n과 모든 자손을 문서 순서(전위 순회)로 방문함
+//
+// fn이 false를 반환하면 해당 노드의 자식은 방문하지 않음
+func (n *Node) Walk(fn func(*Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+// FindAll은 태그 이름이 tag인 모든 자손 요소를 문서 순서로 반환함
+func (n *Node) FindAll(tag string) []*Node {
+	var result []*Node
+	n.Walk(func(node *Node) bool {
+		if node != n && node.Type == ElementNode && node.Tag == tag {
+			result = append(result, node)
+		}
+		return true
+	})
+	return result
+}
+
+// Find는 태그 이름이 tag인 첫 번째 자손 요소를 반환함 (없으면 nil)
+func (n *Node) Find(tag string) *Node {
+	var found *Node
+	n.Walk(func(node *Node) bool {
+		if found != nil {
+			return false
+		}
+		if node != n && node.Type == ElementNode && node.Tag == tag {
+			found = node
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// TextContent는 모든 자손 텍스트 노드를 그대로 이어 붙인 문자열을 반환함
+func (n *Node) TextContent() string {
+	if n.Type == TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	n.Walk(func(node *Node) bool {
+		if node.Type == TextNode {
+			b.WriteString(node.Data)
+		}
+		return true
+	})
+	return b.String()
+}
@@ -0,0 +1,33 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains <noscript> handling, which depends on whether scripting is enabled.
+package html
+
+// Scripting은 스크립트를 실행하는 브라우저처럼 <noscript>를 다룰지 여부
+//
+// false(기본값, JS 엔진 없음)면 <noscript> 내용을 보통 HTML로 파싱해서 화면에 표시하고,
+// true(JS 엔진을 켰을 때)면 HTML 규격대로 내용을 텍스트 그대로 두고 표시하지 않음
+var Scripting = false
+
+// noscriptHeadElements는 <head> 안의 <noscript>에 들어갈 수 있는 요소들 (스크립트가 꺼져 있을 때)
+//
+// 다른 태그나 텍스트가 오면 noscript와 head를 닫고 body에 넣음 (HTML 규격의 "in head noscript" 모드)
+var noscriptHeadElements = map[string]bool{
+	"basefont": true, "bgsound": true, "link": true, "meta": true, "noframes": true, "style": true,
+}
+
+// rawTextKind는 tag가 내용을 태그로 해석하지 않는 요소인지와 RCDATA(엔티티 디코딩)인지 확인함
+//
+// 스크립트가 켜져 있으면 noscript도 raw text 요소임
+func rawTextKind(tag string) (rcdata, ok bool) {
+	if tag == "noscript" && Scripting {
+		return false, true
+	}
+	rcdata, ok = rawTextElements[tag]
+	return rcdata, ok
+}
+
+// inHeadNoscript는 <head> 안의 <noscript>가 열려 있는데 tag(텍스트면 "")가 그 안에 들어갈 수 없는지 확인함
+func (p *Parser) inHeadNoscript(tag string) bool {
+	open := p.openTags()
+	return !Scripting && len(open) == 3 && open[1] == "head" && open[2] == "noscript" && !noscriptHeadElements[tag]
+}
@@ -0,0 +1,47 @@
+package html
+
+import "testing"
+
+// TestParse_Noscript 스크립트가 꺼져 있으면 <noscript> 내용을 보통 HTML로 파싱해서 표시함
+func TestParse_Noscript(t *testing.T) {
+	tests := []struct {
+		input string
+		tree  string
+		text  string
+	}{
+		// head 자리에 온 noscript에 본문 내용이 있으면 body로 옮김
+		{"<noscript><p>Enable JS</p></noscript><p>body</p>",
+			"#document(html(head(noscript),body(p(#text),p(#text))))", "Enable JS\n\nbody"},
+		{"<head><noscript><link rel=stylesheet href=x></noscript></head><p>a",
+			"#document(html(head(noscript(link)),body(p(#text))))", "a"},
+		{"<p>a</p><noscript>Please enable <b>JS</b></noscript>",
+			"#document(html(head,body(p(#text),noscript(#text,b(#text)))))", "a\n\nPlease enable JS"},
+	}
+	for _, tt := range tests {
+		doc := Parse(tt.input)
+		if got := treeString(doc); got != tt.tree {
+			t.Errorf("Parse(%q) = %s; want %s", tt.input, got, tt.tree)
+		}
+		if got := doc.InnerText(); got != tt.text {
+			t.Errorf("InnerText(%q) = %q; want %q", tt.input, got, tt.text)
+		}
+	}
+}
+
+// TestParse_NoscriptScripting 스크립트가 켜져 있으면 <noscript> 내용은 텍스트 하나로 두고 표시하지 않음
+func TestParse_NoscriptScripting(t *testing.T) {
+	Scripting = true
+	defer func() { Scripting = false }()
+
+	input := "<p>a</p><noscript><p>Enable &amp; JS</p></noscript>"
+	doc := Parse(input)
+	if got, want := treeString(doc), "#document(html(head,body(p(#text),noscript(#text))))"; got != want {
+		t.Errorf("Parse(%q) = %s; want %s", input, got, want)
+	}
+	if got := doc.Find("noscript").TextContent(); got != "<p>Enable &amp; JS</p>" {
+		t.Errorf("noscript TextContent() = %q; want 원본 그대로", got)
+	}
+	if got := doc.InnerText(); got != "a" {
+		t.Errorf("InnerText(%q) = %q; want %q", input, got, "a")
+	}
+}
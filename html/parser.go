@@ -0,0 +1,376 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains the tree builder that turns tokens into a DOM tree.
+package html
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// voidElements는 종료 태그가 없는 요소들 (자식을 가질 수 없음)
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// headElements는 <head> 안에 들어가는 요소들 (암묵적으로 head를 만들 때 사용)
+var headElements = map[string]bool{
+	"base": true, "basefont": true, "bgsound": true, "noscript": true,
+	"link": true, "meta": true, "title": true, "style": true, "script": true,
+}
+
+// closesParagraph는 열린 <p>를 자동으로 닫는 블록 요소들
+var closesParagraph = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"details": true, "div": true, "dl": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "ul": true,
+}
+
+// scopeBoundaries는 자동 닫기 탐색을 멈추는 요소들 (이 바깥의 요소는 닫지 않음)
+var scopeBoundaries = map[string]bool{
+	"html": true, "body": true, "table": true, "td": true, "th": true,
+	"button": true, "marquee": true, "object": true, "template": true,
+}
+
+// Parser는 토큰을 읽어 DOM 트리를 만드는 트리 빌더
+//
+// HTML 규격의 삽입 모드를 단순화한 방식:
+//   - html, head, body는 없으면 암묵적으로 만듦
+//   - void 요소는 자식 없이 바로 닫음
+//   - <p>, <li>, <td> 같은 요소는 같은 종류가 다시 열리면 이전 것을 자동으로 닫음
+//   - 짝이 맞지 않는 종료 태그는 무시함
+type Parser struct {
+	doc        *Node
+	unfinished []*Node // 아직 닫히지 않은 요소 스택
+	headSeen   bool    // <head>를 이미 만들었는지
+	nodes      int     // 지금까지 만든 노드 수 (Limits.MaxNodes와 비교)
+}
+
+// Limits는 한 문서를 파싱할 때의 크기 제한 (0이면 제한 없음)
+//
+// 비정상적으로 큰 페이지가 메모리를 다 쓰지 않도록 제한을 넘으면 거기서 파싱을 멈추고
+// 그때까지 파싱한 부분 끝에 잘렸다는 안내 문단(TruncatedNoticeClass)을 붙임
+type Limits struct {
+	MaxBytes int // 파싱할 입력의 최대 바이트 수 (넘는 부분은 읽지 않음)
+	MaxNodes int // 만들 최대 노드 수 (요소, 텍스트, 주석)
+}
+
+// DefaultLimits는 Parse가 쓰는 제한 (라이브러리로 쓸 때 바꿀 수 있음)
+var DefaultLimits = Limits{
+	MaxBytes: 32 << 20, // 32 MB
+	MaxNodes: 1_000_000,
+}
+
+// TruncatedNoticeClass는 문서가 잘렸을 때 body 끝에 붙이는 안내 문단의 class
+const TruncatedNoticeClass = "document-truncated"
+
+// Parse는 HTML 문자열을 DefaultLimits 안에서 파싱해서 Document 노드를 반환함
+func Parse(input string) *Node {
+	doc, _ := ParseWithLimits(input, DefaultLimits)
+	return doc
+}
+
+// ParseWithLimits는 limits 안에서 파싱하고, 제한을 넘어서 잘랐는지도 반환함
+func ParseWithLimits(input string, limits Limits) (doc *Node, truncated bool) {
+	p := &Parser{doc: &Node{Type: DocumentNode}}
+
+	var reason string
+	if limits.MaxBytes > 0 && len(input) > limits.MaxBytes {
+		input = cutUTF8(input, limits.MaxBytes)
+		reason = fmt.Sprintf("%d바이트", limits.MaxBytes)
+	}
+
+	t := NewTokenizer(input)
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+		if limits.MaxNodes > 0 && p.nodes >= limits.MaxNodes && tok.Type != EndTagToken {
+			reason = fmt.Sprintf("노드 %d개", limits.MaxNodes)
+			break
+		}
+		p.process(tok)
+	}
+
+	doc = p.finish()
+	if reason != "" {
+		appendTruncatedNotice(doc, reason)
+	}
+	doc.ClearDirty() // 파싱하면서 만든 노드는 바뀐 것으로 보지 않음
+	return doc, reason != ""
+}
+
+// cutUTF8은 s를 n바이트 이하로 자름 (여러 바이트 문자가 반으로 잘리지 않음)
+func cutUTF8(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// appendTruncatedNotice는 잘린 문서의 body 끝에 안내 문단을 붙임 (reason은 넘은 제한)
+func appendTruncatedNotice(doc *Node, reason string) {
+	notice := NewElement("p", []Attribute{{Name: "class", Value: TruncatedNoticeClass}, {Name: "role", Value: "status"}})
+	notice.AppendChild(NewText(fmt.Sprintf("문서가 너무 커서 앞부분만 표시합니다 (제한: %s)", reason)))
+	doc.Find("body").AppendChild(notice)
+}
+
+// current는 현재 삽입 위치 (스택의 맨 위, 비어 있으면 문서 루트)
+func (p *Parser) current() *Node {
+	if len(p.unfinished) == 0 {
+		return p.doc
+	}
+	return p.unfinished[len(p.unfinished)-1]
+}
+
+// openTags는 열린 요소들의 태그 이름 목록
+func (p *Parser) openTags() []string {
+	tags := make([]string, len(p.unfinished))
+	for i, n := range p.unfinished {
+		tags[i] = n.Tag
+	}
+	return tags
+}
+
+// push는 요소를 현재 위치에 추가하고 스택에 올림
+func (p *Parser) push(n *Node) {
+	p.current().AppendChild(n)
+	p.unfinished = append(p.unfinished, n)
+	if n.Tag == "head" {
+		p.headSeen = true
+	}
+}
+
+// popUntil은 스택의 idx 위치 요소까지 모두 닫음
+func (p *Parser) popUntil(idx int) {
+	p.unfinished = p.unfinished[:idx]
+}
+
+// process는 토큰 하나를 트리에 반영함
+func (p *Parser) process(tok Token) {
+	if tok.Type != EndTagToken {
+		p.nodes++
+	}
+	switch tok.Type {
+	case DoctypeToken:
+		if len(p.unfinished) == 0 {
+			p.doc.AppendChild(&Node{Type: DoctypeNode, Data: tok.Data})
+		}
+
+	case CommentToken:
+		p.current().AppendChild(&Node{Type: CommentNode, Data: tok.Data})
+
+	case TextToken:
+		p.addText(tok.Data)
+
+	case StartTagToken, SelfClosingTagToken:
+		p.addStartTag(tok)
+
+	case EndTagToken:
+		p.addEndTag(tok.Data)
+	}
+}
+
+// addText는 텍스트 노드를 추가함 (body가 만들어지기 전의 공백은 무시)
+func (p *Parser) addText(text string) {
+	if strings.TrimLeft(text, " \t\n\r\f") == "" && !p.inBody() {
+		return
+	}
+	p.implicitTags("")
+	p.current().AppendChild(NewText(text))
+}
+
+// inBody는 body(또는 그 안쪽)가 열려 있는지 확인함
+func (p *Parser) inBody() bool {
+	for _, n := range p.unfinished {
+		if n.Tag == "body" {
+			return true
+		}
+	}
+	return false
+}
+
+// addStartTag는 시작 태그를 처리함
+func (p *Parser) addStartTag(tok Token) {
+	tag := tok.Data
+
+	switch tag {
+	case "html":
+		// 이미 있는 html에 속성만 병합
+		if len(p.unfinished) > 0 {
+			mergeAttrs(p.unfinished[0], tok.Attrs)
+			return
+		}
+	case "head":
+		if p.headSeen {
+			return
+		}
+	case "body":
+		if body := p.openElement("body"); body != nil {
+			mergeAttrs(body, tok.Attrs)
+			return
+		}
+	}
+
+	p.implicitTags(tag)
+	p.autoClose(tag)
+
+	node := NewElement(tag, tok.Attrs)
+	if voidElements[tag] || tok.Type == SelfClosingTagToken {
+		p.current().AppendChild(node)
+		return
+	}
+	p.push(node)
+}
+
+// addEndTag는 종료 태그를 처리함
+//
+// html, body 종료 태그는 무시하고 문서 끝에서 닫음 (뒤에 오는 내용도 body에 들어가도록)
+func (p *Parser) addEndTag(tag string) {
+	switch tag {
+	case "html", "body":
+		return
+	case "br":
+		// </br>는 <br>로 취급 (HTML 규격)
+		p.addStartTag(Token{Type: StartTagToken, Data: "br"})
+		return
+	}
+
+	for i := len(p.unfinished) - 1; i >= 0; i-- {
+		if p.unfinished[i].Tag == tag {
+			p.popUntil(i)
+			return
+		}
+	}
+	// 짝이 맞는 시작 태그가 없으면 무시
+}
+
+// implicitTags는 생략된 html, head, body 태그를 보충함
+//
+// tag는 곧 추가할 태그 이름 (텍스트면 "")
+func (p *Parser) implicitTags(tag string) {
+	for {
+		open := p.openTags()
+		switch {
+		case len(open) == 0 && tag != "html":
+			p.push(NewElement("html", nil))
+		case len(open) == 1 && open[0] == "html" && tag != "head" && tag != "body":
+			if headElements[tag] && !p.headSeen {
+				p.push(NewElement("head", nil))
+			} else {
+				if !p.headSeen {
+					// head 없이 body가 시작되면 빈 head를 먼저 만듦
+					p.push(NewElement("head", nil))
+					p.popUntil(1)
+				}
+				p.push(NewElement("body", nil))
+			}
+		case len(open) == 2 && open[1] == "head" && !headElements[tag] && tag != "head":
+			p.popUntil(1)
+		case p.inHeadNoscript(tag):
+			// <head>의 <noscript>에 본문 내용이 오면 noscript를 닫고 위 경우로 head도 닫음
+			p.popUntil(2)
+		default:
+			return
+		}
+	}
+}
+
+// autoClose는 새 요소가 열릴 때 자동으로 닫혀야 하는 요소들을 닫음
+func (p *Parser) autoClose(tag string) {
+	switch {
+	case tag == "li":
+		p.closeInScope([]string{"li"}, []string{"ul", "ol"})
+	case tag == "dt" || tag == "dd":
+		p.closeInScope([]string{"dt", "dd"}, []string{"dl"})
+	case tag == "tr":
+		p.closeInScope([]string{"tr"}, []string{"table", "tbody", "thead", "tfoot"})
+	case tag == "td" || tag == "th":
+		p.closeInScope([]string{"td", "th"}, []string{"tr", "table"})
+	case tag == "option":
+		p.closeInScope([]string{"option"}, []string{"select", "datalist"})
+	case tag == "a":
+		p.closeInScope([]string{"a"}, nil)
+	}
+
+	if closesParagraph[tag] || tag == "li" || tag == "dt" || tag == "dd" {
+		p.closeInScope([]string{"p"}, nil)
+	}
+}
+
+// closeInScope는 스택 위쪽부터 targets 중 하나를 찾아 그 요소까지 닫음
+//
+// stops에 있는 요소나 scope 경계를 먼저 만나면 아무것도 닫지 않음
+func (p *Parser) closeInScope(targets, stops []string) {
+	for i := len(p.unfinished) - 1; i >= 0; i-- {
+		tag := p.unfinished[i].Tag
+		if contains(targets, tag) {
+			p.popUntil(i)
+			return
+		}
+		if contains(stops, tag) || scopeBoundaries[tag] {
+			return
+		}
+	}
+}
+
+// openElement는 스택에서 tag 요소를 찾음 (없으면 nil)
+func (p *Parser) openElement(tag string) *Node {
+	for _, n := range p.unfinished {
+		if n.Tag == tag {
+			return n
+		}
+	}
+	return nil
+}
+
+// finish는 남은 요소를 모두 닫고 html/head/body 구조를 보장함
+func (p *Parser) finish() *Node {
+	if len(p.unfinished) == 0 {
+		p.implicitTags("")
+	}
+	p.unfinished = nil
+
+	htmlNode := p.doc.Find("html")
+	if htmlNode == nil {
+		htmlNode = NewElement("html", nil)
+		p.doc.AppendChild(htmlNode)
+	}
+	if htmlNode.Find("head") == nil {
+		var first *Node
+		if len(htmlNode.Children) > 0 {
+			first = htmlNode.Children[0]
+		}
+		htmlNode.InsertBefore(NewElement("head", nil), first)
+	}
+	if htmlNode.Find("body") == nil {
+		htmlNode.AppendChild(NewElement("body", nil))
+	}
+
+	return p.doc
+}
+
+// mergeAttrs는 node에 없는 속성만 추가함 (<html>, <body>가 중복으로 나올 때)
+func mergeAttrs(node *Node, attrs []Attribute) {
+	for _, a := range attrs {
+		if _, ok := node.Attr(a.Name); !ok {
+			node.Attrs = append(node.Attrs, a)
+		}
+	}
+}
+
+// contains는 목록에 s가 있는지 확인함
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package html
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// treeString은 테스트용으로 트리를 "html(head,body(p(#text)))" 형태로 표현함
+func treeString(n *Node) string {
+	var name string
+	switch n.Type {
+	case DocumentNode:
+		name = "#document"
+	case TextNode:
+		return "#text"
+	case CommentNode:
+		return "#comment"
+	case DoctypeNode:
+		return "!doctype"
+	default:
+		name = n.Tag
+	}
+	if len(n.Children) == 0 {
+		return name
+	}
+	parts := make([]string, len(n.Children))
+	for i, c := range n.Children {
+		parts[i] = treeString(c)
+	}
+	return name + "(" + strings.Join(parts, ",") + ")"
+}
+
+// TestParse_ImplicitTags html/head/body가 없어도 자동으로 만듦
+func TestParse_ImplicitTags(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "#document(html(head,body))"},
+		{"Hello", "#document(html(head,body(#text)))"},
+		{"<title>T</title><p>x", "#document(html(head(title(#text)),body(p(#text))))"},
+		{"<!DOCTYPE html><html><body></body></html>", "#document(!doctype,html(head,body))"},
+	}
+
+	for _, tt := range tests {
+		got := treeString(Parse(tt.input))
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %s; want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestParse_AutoClose 생략된 종료 태그 처리
+func TestParse_AutoClose(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"<p>a<p>b", "#document(html(head,body(p(#text),p(#text))))"},
+		{"<ul><li>a<li>b</ul>", "#document(html(head,body(ul(li(#text),li(#text)))))"},
+		{"<p>a<div>b</div>", "#document(html(head,body(p(#text),div(#text))))"},
+		{"<p>a<br>b</p>", "#document(html(head,body(p(#text,br,#text))))"},
+		{"<div>a</span>b</div>", "#document(html(head,body(div(#text))))"},
+	}
+
+	for _, tt := range tests {
+		got := treeString(Parse(tt.input))
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %s; want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestParse_Attributes 속성과 Find/Attr
+func TestParse_Attributes(t *testing.T) {
+	doc := Parse(`<html lang="ko"><body><a href="/x" id="l">link</a></body></html>`)
+
+	htmlNode := doc.Find("html")
+	if lang, _ := htmlNode.Attr("lang"); lang != "ko" {
+		t.Errorf("html lang = %q; want %q", lang, "ko")
+	}
+
+	a := doc.Find("a")
+	if a == nil {
+		t.Fatal("Find(\"a\") = nil")
+	}
+	if href, ok := a.Attr("href"); !ok || href != "/x" {
+		t.Errorf("a href = %q, %v; want %q, true", href, ok, "/x")
+	}
+	if a.TextContent() != "link" {
+		t.Errorf("a TextContent = %q; want %q", a.TextContent(), "link")
+	}
+}
+
+// TestNode_Dirty 바뀐 노드와 조상만 dirty, ClearDirty로 지움
+func TestNode_Dirty(t *testing.T) {
+	doc := Parse(`<div><p id="a">one</p><p id="b">two</p></div>`)
+	if doc.Dirty() {
+		t.Fatal("파싱 직후 Dirty() = true; want false")
+	}
+
+	ps := doc.FindAll("p")
+	ps[0].Children[0].SetData("uno")
+	for _, n := range []*Node{ps[0].Children[0], ps[0], doc.Find("div"), doc} {
+		if !n.Dirty() {
+			t.Errorf("<%s> Dirty() = false; want true", n.Tag)
+		}
+	}
+	if ps[1].Dirty() {
+		t.Error("바뀌지 않은 형제 <p id=b> Dirty() = true; want false")
+	}
+
+	doc.ClearDirty()
+	if doc.Dirty() || ps[0].Dirty() || ps[0].Children[0].Dirty() {
+		t.Error("ClearDirty() 후에도 dirty인 노드가 있음")
+	}
+
+	ps[1].SetAttr("class", "x")
+	if !doc.Dirty() || ps[0].Dirty() {
+		t.Errorf("SetAttr 후 doc.Dirty() = %v, <p id=a>.Dirty() = %v; want true, false", doc.Dirty(), ps[0].Dirty())
+	}
+}
+
+// TestParseWithLimits 노드 수나 바이트 수를 넘으면 그때까지 파싱한 부분과 잘렸다는 안내만 남김
+func TestParseWithLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		limits        Limits
+		wantTruncated bool
+		wantText      string
+	}{
+		{"제한 안", "<p>a</p><p>b</p>", Limits{MaxNodes: 10, MaxBytes: 100}, false, "a"},
+		{"노드 수", "<p>a</p><p>b</p><p>c</p>", Limits{MaxNodes: 4}, true, "노드 4개"},
+		{"바이트 수", "<p>가나다</p>", Limits{MaxBytes: 7}, true, "7바이트"},
+		{"제한 없음", strings.Repeat("<p>x</p>", 100), Limits{}, false, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, truncated := ParseWithLimits(tt.input, tt.limits)
+			if truncated != tt.wantTruncated {
+				t.Errorf("ParseWithLimits(%q) truncated = %v; want %v", tt.input, truncated, tt.wantTruncated)
+			}
+			if text := doc.InnerText(); !strings.Contains(text, tt.wantText) {
+				t.Errorf("ParseWithLimits(%q) text = %q; want to contain %q", tt.input, text, tt.wantText)
+			}
+			if text := doc.InnerText(); tt.wantTruncated && (strings.Contains(text, "c") || strings.Contains(text, "나")) {
+				t.Errorf("ParseWithLimits(%q) text = %q; want content after the limit dropped", tt.input, text)
+			}
+			if text := doc.InnerText(); !utf8.ValidString(text) {
+				t.Errorf("ParseWithLimits(%q) text = %q; want valid UTF-8", tt.input, text)
+			}
+		})
+	}
+}
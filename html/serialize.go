@@ -0,0 +1,106 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains HTML serialization and the indented DOM tree dump.
+package html
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// textEscaper, attrEscaper는 텍스트와 속성 값을 HTML로 쓸 때의 이스케이프
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\u00a0", "&nbsp;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", `"`, "&quot;", "\u00a0", "&nbsp;")
+)
+
+// Render는 n과 자손을 정규화된 HTML로 씀
+//
+// 파서가 보정한 트리(암묵적 html/head/body, 닫히지 않은 태그 등)를 그대로 쓰므로
+// 원본과 달라도 다시 파싱하면 같은 트리가 나옴
+// 속성 값은 항상 큰따옴표로 감싸고, void 요소는 종료 태그를 쓰지 않음
+func Render(w io.Writer, n *Node) error {
+	bw := bufio.NewWriter(w)
+	render(bw, n)
+	return bw.Flush()
+}
+
+// render는 Render의 재귀 부분
+func render(w *bufio.Writer, n *Node) {
+	switch n.Type {
+	case DoctypeNode:
+		fmt.Fprintf(w, "<!DOCTYPE %s>", n.Data)
+	case TextNode:
+		if n.Parent != nil && n.Parent.Type == ElementNode && isRawText(n.Parent.Tag) {
+			w.WriteString(n.Data)
+		} else {
+			textEscaper.WriteString(w, n.Data)
+		}
+	case CommentNode:
+		fmt.Fprintf(w, "<!--%s-->", n.Data)
+	case ElementNode:
+		w.WriteString("<" + n.Tag)
+		for _, a := range n.Attrs {
+			w.WriteString(" " + a.Name + `="`)
+			attrEscaper.WriteString(w, a.Value)
+			w.WriteString(`"`)
+		}
+		w.WriteString(">")
+		if voidElements[n.Tag] {
+			return
+		}
+	}
+	for _, c := range n.Children {
+		render(w, c)
+	}
+	if n.Type == ElementNode {
+		w.WriteString("</" + n.Tag + ">")
+	}
+}
+
+// isRawText는 내용을 이스케이프하지 않고 쓰는 요소인지 확인함 (script, style, 스크립트가 켜져 있으면 noscript)
+func isRawText(tag string) bool {
+	rcdata, ok := rawTextKind(tag)
+	return ok && !rcdata
+}
+
+// DumpTree는 DOM 트리를 노드 하나당 한 줄로, 깊이만큼 두 칸씩 들여서 씀
+//
+// 예시:
+//
+//	#document
+//	  <!DOCTYPE html>
+//	  <html>
+//	    <body class="main">
+//	      "Hello"
+func DumpTree(w io.Writer, n *Node) error {
+	bw := bufio.NewWriter(w)
+	n.dumpTree(bw, 0)
+	return bw.Flush()
+}
+
+// dumpTree는 DumpTree의 재귀 부분
+func (n *Node) dumpTree(w *bufio.Writer, depth int) {
+	w.WriteString(strings.Repeat("  ", depth))
+	switch n.Type {
+	case DocumentNode:
+		w.WriteString("#document")
+	case DoctypeNode:
+		fmt.Fprintf(w, "<!DOCTYPE %s>", n.Data)
+	case TextNode:
+		fmt.Fprintf(w, "%q", n.Data)
+	case CommentNode:
+		fmt.Fprintf(w, "<!-- %s -->", n.Data)
+	case ElementNode:
+		w.WriteString("<" + n.Tag)
+		for _, a := range n.Attrs {
+			fmt.Fprintf(w, " %s=%q", a.Name, a.Value)
+		}
+		w.WriteString(">")
+	}
+	w.WriteString("\n")
+	for _, c := range n.Children {
+		c.dumpTree(w, depth+1)
+	}
+}
@@ -0,0 +1,52 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRender 파서가 보정한 트리를 정규화된 HTML로 쓰고, 다시 파싱하면 같은 트리
+func TestRender(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"<p>a<p>b", "<html><head></head><body><p>a</p><p>b</p></body></html>"},
+		{"<!DOCTYPE html><title>x &amp; y</title>", "<!DOCTYPE html><html><head><title>x &amp; y</title></head><body></body></html>"},
+		{`<img src=a.png alt='"q"'><br>`, `<html><head></head><body><img src="a.png" alt="&quot;q&quot;"><br></body></html>`},
+		{"<script>if (a < b) {}</script>", "<html><head><script>if (a < b) {}</script></head><body></body></html>"},
+		{"<p>1 &lt; 2&nbsp;<!--c-->", "<html><head></head><body><p>1 &lt; 2&nbsp;<!--c--></p></body></html>"},
+	}
+
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := Render(&b, Parse(tt.input)); err != nil {
+			t.Fatalf("Render(%q) failed: %v", tt.input, err)
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("Render(%q) = %q; want %q", tt.input, got, tt.want)
+		}
+		if dumpTree(Parse(b.String())) != dumpTree(Parse(tt.input)) {
+			t.Errorf("Parse(Render(%q))가 원래 트리와 다릅니다", tt.input)
+		}
+	}
+}
+
+// TestDumpTree 노드 하나당 한 줄, 깊이만큼 두 칸씩 들여씀
+func TestDumpTree(t *testing.T) {
+	var b strings.Builder
+	DumpTree(&b, Parse(`<!DOCTYPE html><p class="x">Hi<!--c-->`))
+
+	want := `#document
+  <!DOCTYPE html>
+  <html>
+    <head>
+    <body>
+      <p class="x">
+        "Hi"
+        <!-- c -->
+`
+	if got := b.String(); got != want {
+		t.Errorf("DumpTree() = %q; want %q", got, want)
+	}
+}
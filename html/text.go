@@ -0,0 +1,274 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains layout-aware text extraction (innerText).
+package html
+
+import "strings"
+
+// hiddenElements는 텍스트로 표시하지 않는 요소들
+var hiddenElements = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true,
+	"title": true, "meta": true, "link": true,
+}
+
+// blockElements는 앞뒤로 줄바꿈이 들어가는 블록 요소들
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"body": true, "dd": true, "details": true, "dialog": true, "div": true,
+	"dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "header": true, "hr": true, "html": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "summary": true, "table": true, "tr": true, "ul": true,
+	"caption": true, "thead": true, "tbody": true, "tfoot": true,
+}
+
+// paragraphElements는 앞뒤로 빈 줄이 들어가는 요소들
+var paragraphElements = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "pre": true, "blockquote": true,
+}
+
+// IsBlock은 tag가 블록 요소인지 확인함
+func IsBlock(tag string) bool {
+	return blockElements[tag]
+}
+
+// IsHidden은 tag가 화면에 표시되지 않는 요소인지 확인함 (스크립트가 켜져 있으면 noscript 포함)
+func IsHidden(tag string) bool {
+	return hiddenElements[tag] || tag == "noscript" && Scripting
+}
+
+// textWriter는 innerText 규칙에 따라 텍스트를 모으는 버퍼
+//
+// 블록 경계의 줄바꿈과 단어 사이 공백을 바로 쓰지 않고 기억해뒀다가
+// 다음 텍스트 앞에 한 번만 씀 (연속된 블록이 빈 줄을 여러 개 만들거나
+// 줄 끝에 공백이 남지 않도록)
+type textWriter struct {
+	b              strings.Builder
+	runs           []TextRun // 쓴 텍스트를 요소별로 나눈 구간 (TextRuns용)
+	node           *Node     // 지금 쓰고 있는 텍스트 노드의 부모 요소
+	pendingBreaks  int       // 다음 텍스트 앞에 넣을 줄바꿈 수
+	pendingSpace   bool      // 다음 텍스트 앞에 넣을 공백
+	lineStart      bool      // 줄의 시작인지 (<br> 직후)
+	preDepth       int       // <pre> 중첩 깊이 (공백 유지)
+	startedContent bool      // 텍스트를 한 번이라도 썼는지 (문서 앞 줄바꿈 제거용)
+
+	root *Node      // 텍스트를 모으기 시작한 노드
+	opts RunOptions // TextRunsWith의 옵션
+}
+
+// TextRun은 InnerText 결과의 한 구간과 그 텍스트를 포함하는 요소
+//
+// 렌더러가 링크나 제목 같은 요소별 스타일을 입힐 때 사용함
+type TextRun struct {
+	Text string
+	Node *Node // 텍스트를 만든 요소: 텍스트 노드의 부모, 또는 img/링크 자신 (블록 사이 줄바꿈, 단어 사이 공백은 nil)
+	Box  bool  // TextRunsWith에서 따로 레이아웃할 상자 요소(Node)의 자리 (Text는 비어 있음)
+}
+
+// emit은 s를 쓰고 node의 구간으로 기록함 (같은 node가 이어지면 합침)
+func (w *textWriter) emit(s string, node *Node) {
+	if s == "" {
+		return
+	}
+	w.b.WriteString(s)
+	if last := len(w.runs) - 1; last >= 0 && w.runs[last].Node == node {
+		w.runs[last].Text += s
+		return
+	}
+	w.runs = append(w.runs, TextRun{Text: s, Node: node})
+}
+
+// requireBreaks는 최소 n개의 줄바꿈을 요구함
+func (w *textWriter) requireBreaks(n int) {
+	if n > w.pendingBreaks {
+		w.pendingBreaks = n
+	}
+}
+
+// flushPending은 기억해둔 줄바꿈 또는 공백을 씀
+func (w *textWriter) flushPending() {
+	if w.startedContent {
+		if w.pendingBreaks > 0 {
+			w.emit(strings.Repeat("\n", w.pendingBreaks), nil)
+		} else if w.pendingSpace && !w.lineStart {
+			w.emit(" ", nil)
+		}
+	}
+	w.pendingBreaks = 0
+	w.pendingSpace = false
+	w.lineStart = false
+	w.startedContent = true
+}
+
+// writeText는 텍스트를 공백 규칙에 맞게 씀
+func (w *textWriter) writeText(text string) {
+	if w.preDepth > 0 {
+		if text != "" {
+			w.flushPending()
+			w.emit(text, w.node)
+		}
+		return
+	}
+
+	text = collapseSpaces(text)
+	if strings.HasPrefix(text, " ") {
+		w.pendingSpace = true
+		text = text[1:]
+	}
+	if text == "" {
+		return
+	}
+
+	trailing := strings.HasSuffix(text, " ")
+	w.flushPending()
+	w.emit(strings.TrimRight(text, " "), w.node)
+	w.pendingSpace = trailing
+}
+
+// writeLineBreak는 <br>에 해당하는 줄바꿈을 씀
+func (w *textWriter) writeLineBreak() {
+	if w.pendingBreaks > 0 {
+		w.flushPending()
+	}
+	w.emit("\n", nil)
+	w.pendingSpace = false
+	w.lineStart = true
+	w.startedContent = true
+}
+
+// collapseSpaces는 연속된 공백 문자(줄바꿈 포함)를 공백 하나로 줄임
+func collapseSpaces(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// InnerText는 화면에 보이는 텍스트를 블록 구조에 맞춰 줄바꿈해서 반환함
+//
+// DOM의 innerText와 비슷한 규칙:
+//   - script, style, head 등 보이지 않는 요소와 aria-hidden 요소는 제외
+//   - 이미지는 alt 텍스트, 내용이 없는 링크/버튼은 aria-label 또는 title
+//   - <audio>, <video>는 대체 내용 대신 자리 표시 텍스트 (MediaPlaceholder)
+//   - 공백은 하나로 축약 (<pre> 안은 그대로 유지)
+//   - 블록 요소 앞뒤는 줄바꿈, 문단/제목 앞뒤는 빈 줄
+//   - <br>은 줄바꿈
+func (n *Node) InnerText() string {
+	w := &textWriter{}
+	w.walk(n)
+	return w.b.String()
+}
+
+// TextRuns는 InnerText와 같은 텍스트를 요소별 구간으로 나눠서 반환함
+//
+// 모든 구간의 Text를 이어 붙이면 InnerText()와 같음
+func (n *Node) TextRuns() []TextRun {
+	w := &textWriter{}
+	w.walk(n)
+	return w.runs
+}
+
+// RunOptions는 TextRunsWith에서 요소를 다르게 다룰 조건 (CSS 계산 결과에 따라 레이아웃 엔진이 정함)
+type RunOptions struct {
+	// Box가 true인 자손 요소는 내용 대신 Box 구간 하나로 남김 (여백, 테두리가 있는 상자를 따로 배치할 때)
+	Box func(*Node) bool
+	// Hidden이 true인 요소는 내용까지 건너뜀 (display: none)
+	Hidden func(*Node) bool
+}
+
+// TextRunsWith는 TextRuns와 같지만 opts에 따라 일부 요소를 상자 자리로 남기거나 숨김
+//
+// 상자 앞뒤는 블록 경계이므로 상자 뒤의 텍스트는 줄바꿈으로 시작하고,
+// 상자 앞의 텍스트는 문단 끝이면 줄바꿈으로 끝남
+func (n *Node) TextRunsWith(opts RunOptions) []TextRun {
+	w := &textWriter{root: n, opts: opts}
+	w.walk(n)
+	return w.runs
+}
+
+// IsParagraph는 tag가 앞뒤로 빈 줄이 들어가는 요소(문단, 제목 등)인지 확인함
+func IsParagraph(tag string) bool {
+	return paragraphElements[tag]
+}
+
+// walk는 노드를 순회하며 텍스트를 씀
+func (w *textWriter) walk(n *Node) {
+	switch n.Type {
+	case TextNode:
+		w.node = n.Parent
+		w.writeText(n.Data)
+		return
+	case CommentNode, DoctypeNode:
+		return
+	case ElementNode:
+		if IsHidden(n.Tag) || n.AriaHidden() || w.opts.Hidden != nil && w.opts.Hidden(n) {
+			return
+		}
+		switch {
+		case n.Tag == "br":
+			w.writeLineBreak()
+			return
+		case n.Tag == "img":
+			// 이미지는 대체 텍스트로 표시
+			w.node = n
+			w.writeText(n.AltText())
+			return
+		case IsMedia(n.Tag):
+			// 재생할 수 없으므로 대체 내용 대신 주소와 형식을 표시
+			w.node = n
+			w.writeText(n.MediaPlaceholder())
+			return
+		case labelledElements[n.Tag] && !n.HasVisibleText():
+			// 아이콘만 있는 링크/버튼은 aria-label, title로 표시
+			w.node = n
+			w.writeText(n.AccessibleLabel())
+			return
+		case w.opts.Box != nil && n != w.root && w.opts.Box(n):
+			// 상자 앞 블록 경계의 줄바꿈은 남김 (앞 텍스트의 아래 여백)
+			if w.pendingBreaks > 0 {
+				w.flushPending()
+			}
+			w.runs = append(w.runs, TextRun{Node: n, Box: true})
+			w.pendingBreaks, w.pendingSpace, w.lineStart = 1, false, false
+			w.startedContent = true
+			return
+		}
+	}
+
+	breaks := 0
+	if n.Type == ElementNode {
+		if paragraphElements[n.Tag] {
+			breaks = 2
+		} else if blockElements[n.Tag] {
+			breaks = 1
+		}
+		if n.Tag == "pre" {
+			w.preDepth++
+		}
+	}
+
+	w.requireBreaks(breaks)
+	for _, c := range n.Children {
+		w.walk(c)
+	}
+	w.requireBreaks(breaks)
+
+	if n.Type == ElementNode && n.Tag == "pre" {
+		w.preDepth--
+	}
+	if n.Type == ElementNode && (n.Tag == "td" || n.Tag == "th") {
+		// 표의 셀 사이는 공백으로 구분
+		w.pendingSpace = true
+	}
+}
@@ -0,0 +1,70 @@
+package html
+
+import "testing"
+
+// TestInnerText 블록 구조에 맞춘 텍스트 추출
+func TestInnerText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"simple", "<p>Hello</p>", "Hello"},
+		{"paragraphs", "<p>a</p><p>b</p>", "a\n\nb"},
+		{"blocks", "<div>a</div><div>b</div>", "a\nb"},
+		{"inline", "<p>a <b>bold</b> c</p>", "a bold c"},
+		{"collapse", "<p>  a \n\n  b  </p>", "a b"},
+		{"hidden", "<head><title>T</title><style>p{}</style></head><script>x</script><p>v</p>", "v"},
+		{"br", "a<br>b", "a\nb"},
+		{"pre", "<pre>a  b\n c</pre>", "a  b\n c"},
+		{"entities", "<p>&lt;code&gt;</p>", "<code>"},
+		{"img alt", `<p>a <img src="x.png" alt=" cat  photo "> b <img src="y.png"></p>`, "a cat photo b"},
+		{"aria-hidden", `<p><span aria-hidden="true">★</span>Star <i aria-hidden="TRUE">x</i></p>`, "Star"},
+		{"icon link aria-label", `<a href="/s" aria-label="Search"><i class="icon" aria-hidden="true"></i></a>`, "Search"},
+		{"icon button title", `<button title="Close"><span aria-hidden=true>×</span></button>`, "Close"},
+		{"link text wins", `<a href="/" aria-label="Home page">Home</a>`, "Home"},
+		{"image link", `<a href="/" aria-label="Home"><img alt="Logo"></a>`, "Logo"},
+		{"video", `<video controls><source src="/clips/intro.mp4?v=2" type="video/mp4">Not supported</video>`, "[Video: intro.mp4 (video/mp4)]"},
+		{"audio title", `<p>Listen: <audio src="a.ogg" title="Episode 1"></audio></p>`, "Listen: [Audio: Episode 1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.input).InnerText()
+			if got != tt.want {
+				t.Errorf("InnerText(%q) = %q; want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTextRuns 요소별 구간으로 나눈 텍스트
+func TestTextRuns(t *testing.T) {
+	input := `<p>Go to <a href="/x">the <b>next</b> page</a>.</p><p>End</p>`
+
+	runs := Parse(input).TextRuns()
+
+	var got []string
+	joined := ""
+	for _, r := range runs {
+		tag := ""
+		if r.Node != nil {
+			tag = r.Node.Tag
+		}
+		got = append(got, tag+":"+r.Text)
+		joined += r.Text
+	}
+	want := []string{"p:Go to", ":" + " ", "a:the", ": ", "b:next", ": ", "a:page", "p:.", ":\n\n", "p:End"}
+
+	if joined != Parse(input).InnerText() {
+		t.Errorf("joined runs = %q; want InnerText %q", joined, Parse(input).InnerText())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("TextRuns(%q) = %q; want %q", input, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TextRuns(%q)[%d] = %q; want %q", input, i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,351 @@
+// Package html implements HTML tokenizing and DOM tree construction.
+// This file contains the tokenizer that turns HTML source into tokens.
+package html
+
+import (
+	stdhtml "html"
+	"strings"
+)
+
+// TokenType은 토큰 종류
+type TokenType int
+
+// 토큰 종류 상수
+const (
+	TextToken           TokenType = iota // 텍스트
+	StartTagToken                        // <tag>
+	EndTagToken                          // </tag>
+	SelfClosingTagToken                  // <tag/>
+	CommentToken                         // <!-- ... -->
+	DoctypeToken                         // <!DOCTYPE ...>
+)
+
+// Token은 토크나이저가 만드는 토큰 하나
+type Token struct {
+	Type  TokenType
+	Data  string      // 태그 이름(소문자), 텍스트, 주석 내용, doctype 이름
+	Attrs []Attribute // 시작 태그의 속성
+}
+
+// rawTextElements는 내용을 태그로 해석하지 않는 요소들 (noscript는 rawTextKind 참고)
+//
+// 값이 true면 RCDATA (엔티티는 디코딩), false면 raw text (디코딩 안 함)
+var rawTextElements = map[string]bool{
+	"script":   false,
+	"style":    false,
+	"title":    true,
+	"textarea": true,
+}
+
+// Tokenizer는 HTML 문자열을 토큰 스트림으로 바꿈
+type Tokenizer struct {
+	input  string
+	pos    int
+	rawTag string // raw text 요소 안이면 해당 태그 이름 (종료 태그까지 텍스트로 읽음)
+}
+
+// NewTokenizer는 input을 읽는 Tokenizer를 만듦
+func NewTokenizer(input string) *Tokenizer {
+	return &Tokenizer{input: input}
+}
+
+// Tokenize는 input 전체를 토큰 목록으로 변환함
+func Tokenize(input string) []Token {
+	var tokens []Token
+	t := NewTokenizer(input)
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// Next는 다음 토큰을 반환함 (입력 끝이면 false)
+func (t *Tokenizer) Next() (Token, bool) {
+	if t.rawTag != "" {
+		if tok, ok := t.readRawText(); ok {
+			return tok, true
+		}
+	}
+
+	if t.pos >= len(t.input) {
+		return Token{}, false
+	}
+
+	if t.input[t.pos] == '<' {
+		if tok, ok := t.readMarkup(); ok {
+			return tok, true
+		}
+	}
+
+	return t.readText(), true
+}
+
+// readRawText는 raw text 요소의 내용을 종료 태그 직전까지 하나의 텍스트로 읽음
+func (t *Tokenizer) readRawText() (Token, bool) {
+	tag := t.rawTag
+	t.rawTag = ""
+
+	rest := t.input[t.pos:]
+	end := indexFold(rest, "</"+tag)
+	if end == -1 {
+		end = len(rest)
+	}
+
+	data := rest[:end]
+	t.pos += end
+	if data == "" {
+		return Token{}, false
+	}
+	if rcdata, _ := rawTextKind(tag); rcdata {
+		data = stdhtml.UnescapeString(data)
+	}
+	return Token{Type: TextToken, Data: data}, true
+}
+
+// readText는 다음 마크업 시작 전까지의 텍스트를 읽음 (태그가 아닌 '<'는 텍스트로 취급)
+func (t *Tokenizer) readText() Token {
+	start := t.pos
+	i := t.pos
+	if t.input[i] == '<' {
+		i++
+	}
+	for i < len(t.input) {
+		next := strings.IndexByte(t.input[i:], '<')
+		if next == -1 {
+			i = len(t.input)
+			break
+		}
+		i += next
+		if startsMarkup(t.input[i:]) {
+			break
+		}
+		i++
+	}
+	t.pos = i
+	return Token{Type: TextToken, Data: stdhtml.UnescapeString(t.input[start:i])}
+}
+
+// startsMarkup은 s가 태그, 주석, doctype 등 마크업의 시작인지 확인함
+func startsMarkup(s string) bool {
+	if len(s) < 2 || s[0] != '<' {
+		return false
+	}
+	c := s[1]
+	return isASCIILetter(c) || c == '/' || c == '!' || c == '?'
+}
+
+// readMarkup은 '<'로 시작하는 마크업을 읽음 (마크업이 아니면 false)
+func (t *Tokenizer) readMarkup() (Token, bool) {
+	rest := t.input[t.pos:]
+	if !startsMarkup(rest) {
+		return Token{}, false
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		return t.readComment(), true
+	case rest[1] == '!' || rest[1] == '?':
+		return t.readDeclaration(), true
+	case rest[1] == '/':
+		if len(rest) > 2 && rest[2] == '>' {
+			// "</>"는 무시
+			t.pos += 3
+			return t.Next()
+		}
+		if len(rest) > 2 && isASCIILetter(rest[2]) {
+			return t.readTag(true), true
+		}
+		return t.readBogusComment(2), true
+	default:
+		return t.readTag(false), true
+	}
+}
+
+// readComment는 <!-- ... --> 주석을 읽음 (닫히지 않으면 입력 끝까지)
+func (t *Tokenizer) readComment() Token {
+	start := t.pos + len("<!--")
+	end := strings.Index(t.input[start:], "-->")
+	if end == -1 {
+		t.pos = len(t.input)
+		return Token{Type: CommentToken, Data: t.input[start:]}
+	}
+	t.pos = start + end + len("-->")
+	return Token{Type: CommentToken, Data: t.input[start : start+end]}
+}
+
+// readDeclaration은 <!DOCTYPE ...> 또는 <!...>, <?...> 형태를 읽음
+func (t *Tokenizer) readDeclaration() Token {
+	rest := t.input[t.pos:]
+	if len(rest) >= 9 && strings.EqualFold(rest[:9], "<!doctype") {
+		end := strings.IndexByte(rest, '>')
+		if end == -1 {
+			end = len(rest)
+			t.pos = len(t.input)
+		} else {
+			t.pos += end + 1
+		}
+		fields := strings.Fields(rest[9:end])
+		name := ""
+		if len(fields) > 0 {
+			name = strings.ToLower(fields[0])
+		}
+		return Token{Type: DoctypeToken, Data: name}
+	}
+	return t.readBogusComment(2)
+}
+
+// readBogusComment는 '>'까지를 주석으로 취급함 (<?xml ...>, </ 1> 같은 잘못된 마크업)
+func (t *Tokenizer) readBogusComment(skip int) Token {
+	start := t.pos + skip
+	end := strings.IndexByte(t.input[start:], '>')
+	if end == -1 {
+		t.pos = len(t.input)
+		return Token{Type: CommentToken, Data: t.input[start:]}
+	}
+	t.pos = start + end + 1
+	return Token{Type: CommentToken, Data: t.input[start : start+end]}
+}
+
+// readTag는 시작 태그 또는 종료 태그를 속성과 함께 읽음
+func (t *Tokenizer) readTag(isEnd bool) Token {
+	i := t.pos + 1
+	if isEnd {
+		i++
+	}
+
+	// 태그 이름
+	nameStart := i
+	for i < len(t.input) && !isTagNameEnd(t.input[i]) {
+		i++
+	}
+	tok := Token{Type: StartTagToken, Data: strings.ToLower(t.input[nameStart:i])}
+	if isEnd {
+		tok.Type = EndTagToken
+	}
+
+	// 속성
+	for i < len(t.input) {
+		c := t.input[i]
+		switch {
+		case isSpace(c):
+			i++
+			continue
+		case c == '>':
+			i++
+			t.pos = i
+			t.finishTag(&tok)
+			return tok
+		case c == '/':
+			i++
+			if i < len(t.input) && t.input[i] == '>' && !isEnd {
+				tok.Type = SelfClosingTagToken
+			}
+			continue
+		}
+
+		var attr Attribute
+		attr, i = readAttribute(t.input, i)
+		if !isEnd && !hasAttr(tok.Attrs, attr.Name) {
+			// 같은 이름의 속성이 여러 번 나오면 첫 번째만 사용 (HTML 규격)
+			tok.Attrs = append(tok.Attrs, attr)
+		}
+	}
+
+	// 태그가 닫히지 않고 입력이 끝남
+	t.pos = len(t.input)
+	t.finishTag(&tok)
+	return tok
+}
+
+// finishTag는 raw text 요소의 시작 태그면 다음 읽기를 raw text 모드로 바꿈
+func (t *Tokenizer) finishTag(tok *Token) {
+	if tok.Type == StartTagToken {
+		if _, ok := rawTextKind(tok.Data); ok {
+			t.rawTag = tok.Data
+		}
+	}
+}
+
+// readAttribute는 i 위치에서 속성 하나(name, name=value, name="value", name='value')를 읽음
+func readAttribute(s string, i int) (Attribute, int) {
+	nameStart := i
+	for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && !(s[i] == '/' && i > nameStart) {
+		i++
+	}
+	attr := Attribute{Name: strings.ToLower(s[nameStart:i])}
+
+	// '=' 앞뒤 공백 건너뛰기
+	j := i
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+	if j >= len(s) || s[j] != '=' {
+		return attr, i
+	}
+	j++
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+	if j >= len(s) {
+		return attr, j
+	}
+
+	// 따옴표 값
+	if q := s[j]; q == '"' || q == '\'' {
+		end := strings.IndexByte(s[j+1:], q)
+		if end == -1 {
+			attr.Value = stdhtml.UnescapeString(s[j+1:])
+			return attr, len(s)
+		}
+		attr.Value = stdhtml.UnescapeString(s[j+1 : j+1+end])
+		return attr, j + 1 + end + 1
+	}
+
+	// 따옴표 없는 값: 공백 또는 '>'까지
+	valStart := j
+	for j < len(s) && !isSpace(s[j]) && s[j] != '>' {
+		j++
+	}
+	attr.Value = stdhtml.UnescapeString(s[valStart:j])
+	return attr, j
+}
+
+// hasAttr는 속성 목록에 name이 있는지 확인함
+func hasAttr(attrs []Attribute, name string) bool {
+	for _, a := range attrs {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isTagNameEnd는 태그 이름이 끝나는 문자인지 확인함
+func isTagNameEnd(c byte) bool {
+	return isSpace(c) || c == '/' || c == '>'
+}
+
+// isSpace는 HTML 공백 문자인지 확인함 (TAB, LF, FF, CR, SP)
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// isASCIILetter는 ASCII 알파벳인지 확인함
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// indexFold는 대소문자를 무시하고 s에서 substr의 위치를 찾음 (ASCII 전용)
+func indexFold(s, substr string) int {
+	n := len(substr)
+	for i := 0; i+n <= len(s); i++ {
+		if strings.EqualFold(s[i:i+n], substr) {
+			return i
+		}
+	}
+	return -1
+}
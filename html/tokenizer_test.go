@@ -0,0 +1,85 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTokenize_Basic 시작/종료 태그와 텍스트
+func TestTokenize_Basic(t *testing.T) {
+	got := Tokenize("<p>Hello</p>")
+	want := []Token{
+		{Type: StartTagToken, Data: "p"},
+		{Type: TextToken, Data: "Hello"},
+		{Type: EndTagToken, Data: "p"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v; want %+v", got, want)
+	}
+}
+
+// TestTokenize_Attributes 따옴표/따옴표 없는/값 없는 속성, 엔티티 디코딩
+func TestTokenize_Attributes(t *testing.T) {
+	got := Tokenize(`<A HREF="/a?x=1&amp;y=2" class='big red' id=main disabled>`)
+	want := []Token{{
+		Type: StartTagToken,
+		Data: "a",
+		Attrs: []Attribute{
+			{Name: "href", Value: "/a?x=1&y=2"},
+			{Name: "class", Value: "big red"},
+			{Name: "id", Value: "main"},
+			{Name: "disabled", Value: ""},
+		},
+	}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v; want %+v", got, want)
+	}
+}
+
+// TestTokenize_SelfClosing <br/> 형태
+func TestTokenize_SelfClosing(t *testing.T) {
+	got := Tokenize(`<img src="a.png"/>`)
+	if len(got) != 1 || got[0].Type != SelfClosingTagToken || got[0].Data != "img" {
+		t.Errorf("Tokenize() = %+v; want one self-closing img token", got)
+	}
+}
+
+// TestTokenize_CommentAndDoctype 주석과 doctype
+func TestTokenize_CommentAndDoctype(t *testing.T) {
+	got := Tokenize("<!DOCTYPE html><!-- note -->x")
+	want := []Token{
+		{Type: DoctypeToken, Data: "html"},
+		{Type: CommentToken, Data: " note "},
+		{Type: TextToken, Data: "x"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v; want %+v", got, want)
+	}
+}
+
+// TestTokenize_ScriptRawText script 안의 '<'는 태그가 아님
+func TestTokenize_ScriptRawText(t *testing.T) {
+	got := Tokenize("<script>if (a < b) { x = '<p>'; }</script>")
+	want := []Token{
+		{Type: StartTagToken, Data: "script"},
+		{Type: TextToken, Data: "if (a < b) { x = '<p>'; }"},
+		{Type: EndTagToken, Data: "script"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v; want %+v", got, want)
+	}
+}
+
+// TestTokenize_LessThanText 태그가 아닌 '<'는 텍스트
+func TestTokenize_LessThanText(t *testing.T) {
+	got := Tokenize("1 < 2 &lt; 3")
+	want := []Token{{Type: TextToken, Data: "1 < 2 < 3"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v; want %+v", got, want)
+	}
+}
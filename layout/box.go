@@ -0,0 +1,166 @@
+// Package layout implements text layout for the terminal.
+// This file contains the block box model (margins, borders, padding).
+package layout
+
+import "strings"
+
+// Edges는 상자의 위, 오른쪽, 아래, 왼쪽 크기 (가로는 칸 수, 세로는 줄 수)
+type Edges struct {
+	Top, Right, Bottom, Left int
+}
+
+// Box는 블록 상자 하나
+//
+//	margin → border → padding → 내용
+//
+// 내용은 인라인 텍스트(Spans) 또는 자식 상자들(Children) 중 하나
+// 테두리는 폭과 상관없이 한 칸(한 줄)의 상자 그리기 문자로 그림
+type Box struct {
+	Margin  Edges
+	Border  Edges // 0보다 크면 그 방향에 테두리를 그림
+	Padding Edges
+	Width   int // 내용 폭 (0이면 부모 폭에 맞춤)
+
+	Fill       int // 패딩과 내용의 빈 칸에 붙일 속성 (배경색, 0이면 공백만)
+	BorderAttr int // 테두리 글자에 붙일 속성
+
+	Spans    []Span
+	Children []*Box
+}
+
+// 테두리 글자
+const (
+	borderHorizontal  = "─"
+	borderVertical    = "│"
+	cornerTopLeft     = "┌"
+	cornerTopRight    = "┐"
+	cornerBottomLeft  = "└"
+	cornerBottomRight = "┘"
+)
+
+// LayoutBox는 상자 트리를 opts.Width 칸에 배치한 줄들을 반환함
+//
+// 형제 상자 사이의 세로 여백은 겹침 (위 상자의 margin-bottom과 아래 상자의
+// margin-top 중 큰 값만 사용). opts.Borders가 false면 테두리는 없는 것으로 봄
+func LayoutBox(root *Box, opts Options) []Line {
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+	lines := emptyLines(root.Margin.Top)
+	lines = append(lines, layoutBox(root, opts.Width, 0, opts)...)
+	return append(lines, emptyLines(root.Margin.Bottom)...)
+}
+
+// emptyLines는 빈 줄 n개
+func emptyLines(n int) []Line {
+	return make([]Line, max(n, 0))
+}
+
+// layoutBox는 상자 b를 avail 칸 안에 배치함 (b 자신의 세로 margin은 부모가 처리)
+//
+// 왼쪽 margin은 부모의 배경(outer)으로 채움
+func layoutBox(b *Box, avail, outer int, opts Options) []Line {
+	border := b.Border
+	if !opts.Borders {
+		border = Edges{}
+	}
+	bl, br := min(border.Left, 1), min(border.Right, 1)
+	bt, bb := min(border.Top, 1), min(border.Bottom, 1)
+
+	inner := max(avail-b.Margin.Left-b.Margin.Right-bl-br-b.Padding.Left-b.Padding.Right, 1)
+	if b.Width > 0 {
+		inner = min(b.Width, inner)
+	}
+
+	var content []Line
+	if b.Children != nil {
+		prevBottom := 0
+		for i, c := range b.Children {
+			gap := c.Margin.Top
+			if i > 0 {
+				gap = max(prevBottom, c.Margin.Top)
+			}
+			content = append(content, emptyLines(gap)...)
+			content = append(content, layoutBox(c, inner, b.Fill, opts)...)
+			prevBottom = c.Margin.Bottom
+		}
+		content = append(content, emptyLines(prevBottom)...)
+	} else if len(b.Spans) > 0 {
+		wrapOpts := opts
+		wrapOpts.Width = inner
+		content = WrapSpans(b.Spans, wrapOpts)
+	}
+
+	// 배경이나 오른쪽 테두리가 있으면 줄 끝까지 채움 (아니면 줄 끝 공백을 만들지 않음)
+	boxed := b.Fill != 0 || br > 0
+	width := b.Padding.Left + inner + b.Padding.Right
+	padded := func(line Line) Line {
+		out := Line{}
+		if b.Margin.Left > 0 {
+			out = append(out, Span{Text: strings.Repeat(" ", b.Margin.Left), Attr: outer})
+		}
+		if bl > 0 {
+			out = append(out, Span{Text: borderVertical, Attr: b.BorderAttr})
+		}
+		if b.Padding.Left > 0 {
+			out = append(out, Span{Text: strings.Repeat(" ", b.Padding.Left), Attr: b.Fill})
+		}
+		out = append(out, line...)
+		if boxed {
+			if rest := width - b.Padding.Left - line.Width(); rest > 0 {
+				out = append(out, Span{Text: strings.Repeat(" ", rest), Attr: b.Fill})
+			}
+			if br > 0 {
+				out = append(out, Span{Text: borderVertical, Attr: b.BorderAttr})
+			}
+		}
+		return mergeSpans(out)
+	}
+	edge := func(left, right string) Line {
+		out := Line{}
+		if b.Margin.Left > 0 {
+			out = append(out, Span{Text: strings.Repeat(" ", b.Margin.Left), Attr: outer})
+		}
+		if bl == 0 {
+			left = ""
+		}
+		if br == 0 {
+			right = ""
+		}
+		return mergeSpans(append(out, Span{Text: left + strings.Repeat(borderHorizontal, width) + right, Attr: b.BorderAttr}))
+	}
+
+	var lines []Line
+	if bt > 0 {
+		lines = append(lines, edge(cornerTopLeft, cornerTopRight))
+	}
+	for range b.Padding.Top {
+		lines = append(lines, padded(nil))
+	}
+	for _, line := range content {
+		lines = append(lines, padded(line))
+	}
+	for range b.Padding.Bottom {
+		lines = append(lines, padded(nil))
+	}
+	if bb > 0 {
+		lines = append(lines, edge(cornerBottomLeft, cornerBottomRight))
+	}
+	return lines
+}
+
+// mergeSpans는 속성이 같은 이웃 조각을 합침 (빈 조각은 버림)
+func mergeSpans(line Line) Line {
+	var out Line
+	for _, s := range line {
+		if s.Text == "" {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].Attr == s.Attr {
+			out[n-1].Text += s.Text
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
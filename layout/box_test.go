@@ -0,0 +1,83 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// boxTexts는 줄마다의 텍스트
+func boxTexts(lines []Line) []string {
+	texts := make([]string, len(lines))
+	for i, l := range lines {
+		texts[i] = l.Text()
+	}
+	return texts
+}
+
+// TestLayoutBox_MarginCollapse 형제 상자 사이의 세로 margin은 큰 쪽 하나만 들어감
+func TestLayoutBox_MarginCollapse(t *testing.T) {
+	root := &Box{Children: []*Box{
+		{Margin: Edges{Bottom: 2}, Spans: []Span{{Text: "one"}}},
+		{Margin: Edges{Top: 1, Left: 3}, Spans: []Span{{Text: "two"}}},
+	}}
+
+	got := boxTexts(LayoutBox(root, Options{Width: 20}))
+	want := []string{"one", "", "", "   two"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LayoutBox() = %q; want %q", got, want)
+	}
+}
+
+// TestLayoutBox_Padding 패딩과 margin만큼 내용 폭이 줄어서 줄바꿈됨
+func TestLayoutBox_Padding(t *testing.T) {
+	root := &Box{
+		Margin:  Edges{Left: 1, Right: 1},
+		Padding: Edges{Left: 2, Right: 2},
+		Spans:   []Span{{Text: "aaa bbb"}},
+	}
+
+	got := boxTexts(LayoutBox(root, Options{Width: 10}))
+	want := []string{"   aaa", "   bbb"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LayoutBox() = %q; want %q", got, want)
+	}
+}
+
+// TestLayoutBox_Borders Borders가 켜져 있으면 상자 그리기 문자로 테두리를 그림
+func TestLayoutBox_Borders(t *testing.T) {
+	root := &Box{
+		Border:  Edges{Top: 1, Right: 1, Bottom: 1, Left: 1},
+		Padding: Edges{Left: 1, Right: 1},
+		Width:   3,
+		Spans:   []Span{{Text: "hi"}},
+	}
+
+	got := boxTexts(LayoutBox(root, Options{Width: 20, Borders: true}))
+	want := []string{"┌─────┐", "│ hi  │", "└─────┘"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LayoutBox() = %q; want %q", got, want)
+	}
+
+	got = boxTexts(LayoutBox(root, Options{Width: 20}))
+	want = []string{" hi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LayoutBox(Borders: false) = %q; want %q", got, want)
+	}
+}
+
+// TestLayoutBox_Fill 배경이 있으면 패딩과 남은 칸을 Fill 속성으로 채움
+func TestLayoutBox_Fill(t *testing.T) {
+	root := &Box{Padding: Edges{Top: 1, Left: 1}, Width: 4, Fill: 5, Spans: []Span{{Text: "ab", Attr: 5}}}
+
+	got := LayoutBox(root, Options{Width: 20})
+	want := []Line{
+		{{Text: "     ", Attr: 5}},
+		{{Text: " ab  ", Attr: 5}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LayoutBox() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+// Package layout implements text layout for the terminal.
+// This file contains soft hyphen handling and language-based hyphenation.
+package layout
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SoftHyphen은 &shy; (U+00AD): 줄바꿈이 일어날 때만 "-"로 보이는 숨은 하이픈
+const SoftHyphen = '\u00AD'
+
+// Hyphenator는 단어 안에서 하이픈을 넣고 줄바꿈할 수 있는 위치를 찾음
+//
+// 반환값은 바이트 오프셋 목록 (오름차순): 해당 위치 앞에서 "단어앞-" / "단어뒤"로 나눌 수 있음
+type Hyphenator interface {
+	Hyphenate(word string) []int
+}
+
+// hyphenators는 언어 코드별 Hyphenator 레지스트리
+var hyphenators = map[string]Hyphenator{
+	"en": &EnglishHyphenator{},
+}
+
+// HyphenatorFor는 언어 태그(예: "en-US", "ko")에 맞는 Hyphenator를 반환함
+//
+// 지원하지 않는 언어면 nil 반환 (한국어/중국어/일본어는 글자 사이에서 줄바꿈하므로 필요 없음)
+func HyphenatorFor(lang string) Hyphenator {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+		lang = lang[:idx]
+	}
+	return hyphenators[lang]
+}
+
+// 하이픈 앞뒤에 남겨야 하는 최소 글자 수 (TeX의 lefthyphenmin/righthyphenmin과 같은 값)
+const (
+	minHyphenPrefix = 2
+	minHyphenSuffix = 3
+)
+
+// EnglishHyphenator는 영어 음절 규칙으로 하이픈 위치를 찾음
+//
+// 사전 없이 모음/자음 패턴만 사용하는 근사치:
+//   - V-CV:   모음 사이 자음 하나는 뒤 음절로 (pa-per)
+//   - VC-CV:  모음 사이 자음 둘은 나눔 (bas-ket)
+//   - VC-CCV: 자음 셋은 첫 자음 뒤에서 나눔 (com-plete)
+//   - ch, sh, th, ph, wh, ck, qu 같은 이중 자음은 나누지 않음
+type EnglishHyphenator struct{}
+
+// englishDigraphs는 나누면 안 되는 자음 묶음
+var englishDigraphs = []string{"ch", "sh", "th", "ph", "wh", "ck", "gh", "qu"}
+
+// isEnglishVowel은 i번째 글자가 모음 역할인지 확인함 (단어 첫 글자가 아닌 y는 모음)
+func isEnglishVowel(word []rune, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	case 'y':
+		return i > 0
+	}
+	return false
+}
+
+// isDigraph는 word[i:i+2]가 나누면 안 되는 자음 묶음인지 확인함
+func isDigraph(word []rune, i int) bool {
+	if i+1 >= len(word) {
+		return false
+	}
+	pair := string(word[i : i+2])
+	for _, d := range englishDigraphs {
+		if pair == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Hyphenate는 영어 단어의 하이픈 위치(바이트 오프셋)를 반환함
+func (e *EnglishHyphenator) Hyphenate(word string) []int {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) < minHyphenPrefix+minHyphenSuffix+1 {
+		return nil
+	}
+	for _, r := range runes {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			// 숫자, 기호, 비영어 문자가 섞인 단어는 건드리지 않음
+			return nil
+		}
+	}
+
+	var runeBreaks []int
+	for i := 0; i < len(runes); {
+		if !isEnglishVowel(runes, i) {
+			i++
+			continue
+		}
+
+		// 다음 모음까지의 자음 개수 세기
+		j := i + 1
+		for j < len(runes) && !isEnglishVowel(runes, j) {
+			j++
+		}
+		if j >= len(runes) {
+			break
+		}
+
+		consonants := j - i - 1
+		var brk int
+		switch {
+		case consonants == 0:
+			// 모음 연속 (ea, io 등): 나누지 않음
+			i = j
+			continue
+		case consonants == 1:
+			brk = i + 1
+		case consonants == 2 && isDigraph(runes, i+1):
+			brk = i + 1
+		default:
+			brk = i + 2
+			if isDigraph(runes, i+1) {
+				brk = i + 3
+			}
+		}
+
+		if brk >= minHyphenPrefix && len(runes)-brk >= minHyphenSuffix {
+			runeBreaks = append(runeBreaks, brk)
+		}
+		i = j
+	}
+
+	return runeOffsetsToBytes(word, runeBreaks)
+}
+
+// runeOffsetsToBytes는 룬 인덱스 목록을 바이트 오프셋 목록으로 변환함
+func runeOffsetsToBytes(s string, runeIdx []int) []int {
+	if len(runeIdx) == 0 {
+		return nil
+	}
+	offsets := make([]int, 0, len(runeIdx))
+	n, k := 0, 0
+	for byteIdx := range s {
+		if k < len(runeIdx) && runeIdx[k] == n {
+			offsets = append(offsets, byteIdx)
+			k++
+		}
+		n++
+	}
+	return offsets
+}
+
+// splitSoftHyphens는 단어에서 &shy;를 제거하고 그 위치(바이트 오프셋)를 반환함
+func splitSoftHyphens(word string) (clean string, breaks []int) {
+	if !strings.ContainsRune(word, SoftHyphen) {
+		return word, nil
+	}
+	var b strings.Builder
+	for _, r := range word {
+		if r == SoftHyphen {
+			if b.Len() > 0 {
+				breaks = append(breaks, b.Len())
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	clean = b.String()
+	// 단어 끝의 soft hyphen은 줄바꿈 기회가 아님
+	if len(breaks) > 0 && breaks[len(breaks)-1] == len(clean) {
+		breaks = breaks[:len(breaks)-1]
+	}
+	return clean, breaks
+}
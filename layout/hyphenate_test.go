@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWrap_SoftHyphen soft hyphen 위치에서 줄바꿈하면 "-" 표시
+func TestWrap_SoftHyphen(t *testing.T) {
+	got := Wrap("see hy­phen­ation", 10)
+	want := []string{"see hy-", "phenation"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestWrap_SoftHyphenHidden 줄바꿈이 없으면 soft hyphen은 보이지 않음
+func TestWrap_SoftHyphenHidden(t *testing.T) {
+	got := Wrap("hy­phen", 20)
+	want := []string{"hyphen"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestEnglishHyphenator 영어 음절 규칙
+func TestEnglishHyphenator(t *testing.T) {
+	h := HyphenatorFor("en-US")
+	if h == nil {
+		t.Fatal("HyphenatorFor(\"en-US\") = nil")
+	}
+
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"basket", []string{"bas", "ket"}},
+		{"paper", nil}, // 접미 최소 길이(3) 때문에 나누지 않음
+		{"hyphenation", []string{"hy", "phe", "na", "tion"}},
+		{"computer", []string{"com", "pu", "ter"}},
+		{"Go1.25", nil},
+	}
+
+	for _, tt := range tests {
+		var parts []string
+		prev := 0
+		breaks := h.Hyphenate(tt.word)
+		for _, b := range breaks {
+			parts = append(parts, tt.word[prev:b])
+			prev = b
+		}
+		if breaks != nil {
+			parts = append(parts, tt.word[prev:])
+		}
+		if !reflect.DeepEqual(parts, tt.want) {
+			t.Errorf("Hyphenate(%q) parts = %q; want %q", tt.word, parts, tt.want)
+		}
+	}
+}
+
+// TestWrapWith_Hyphenator 자동 하이픈으로 줄 끝 빈 공간 줄이기
+func TestWrapWith_Hyphenator(t *testing.T) {
+	opts := Options{Width: 12, Hyphenator: HyphenatorFor("en")}
+	got := WrapWith("a hyphenation test", opts)
+	want := []string{"a hyphena-", "tion test"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WrapWith() = %q; want %q", got, want)
+	}
+}
+
+// TestHyphenatorFor_Unsupported 지원하지 않는 언어는 nil
+func TestHyphenatorFor_Unsupported(t *testing.T) {
+	if h := HyphenatorFor("ko"); h != nil {
+		t.Errorf("HyphenatorFor(\"ko\") = %v; want nil", h)
+	}
+}
@@ -0,0 +1,90 @@
+// Package layout implements text layout for the terminal.
+// This file contains line breaking for attributed text (spans).
+package layout
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Span은 같은 속성을 가진 텍스트 조각
+//
+// Attr의 의미는 호출하는 쪽이 정함 (링크 번호, 스타일 번호 등)
+type Span struct {
+	Text string
+	Attr int
+}
+
+// Line은 줄바꿈된 한 줄 (속성별 조각 목록)
+type Line []Span
+
+// Text는 줄의 텍스트 전체를 반환함
+func (l Line) Text() string {
+	var b strings.Builder
+	for _, s := range l {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// Width는 줄이 차지하는 칸 수를 반환함
+func (l Line) Width() int {
+	w := 0
+	for _, s := range l {
+		w += StringWidth(s.Text)
+	}
+	return w
+}
+
+// WrapSpans는 속성이 있는 텍스트를 WrapWith와 같은 규칙으로 줄바꿈함
+//
+// 줄바꿈은 속성과 상관없이 이어 붙인 텍스트 기준으로 하고,
+// 결과의 각 글자에는 원본 글자의 속성이 그대로 붙음
+// (하이픈 줄바꿈으로 추가된 "-"는 바로 앞 글자의 속성을 따름)
+func WrapSpans(spans []Span, opts Options) []Line {
+	var src strings.Builder
+	var attrs []int // 원본 룬별 속성
+	for _, s := range spans {
+		src.WriteString(s.Text)
+		for range s.Text {
+			attrs = append(attrs, s.Attr)
+		}
+	}
+	source := []rune(src.String())
+
+	var lines []Line
+	i := 0 // source 위치
+	for _, text := range WrapWith(src.String(), opts) {
+		var line Line
+		prev := 0
+		runes := []rune(text)
+		for k, r := range runes {
+			// 줄바꿈으로 사라진 공백, 숨겨진 soft hyphen 건너뛰기
+			for i < len(source) && source[i] != r && (unicode.IsSpace(source[i]) || source[i] == SoftHyphen) {
+				i++
+			}
+
+			attr := prev
+			if i < len(source) && source[i] == r {
+				attr = attrs[i]
+				i++
+			} else if !(r == '-' && k == len(runes)-1) {
+				// 원본과 맞지 않는 글자는 줄 끝의 하이픈뿐이어야 함
+				i++
+			}
+			line = appendRune(line, r, attr)
+			prev = attr
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// appendRune은 줄 끝 조각과 속성이 같으면 합치고, 다르면 새 조각을 추가함
+func appendRune(line Line, r rune, attr int) Line {
+	if n := len(line); n > 0 && line[n-1].Attr == attr {
+		line[n-1].Text += string(r)
+		return line
+	}
+	return append(line, Span{Text: string(r), Attr: attr})
+}
@@ -0,0 +1,59 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWrapSpans 줄바꿈 후에도 글자별 속성이 유지됨
+func TestWrapSpans(t *testing.T) {
+	spans := []Span{
+		{Text: "go to ", Attr: 0},
+		{Text: "next page", Attr: 1},
+		{Text: " now", Attr: 0},
+	}
+
+	got := WrapSpans(spans, Options{Width: 10})
+	want := []Line{
+		{{Text: "go to ", Attr: 0}, {Text: "next", Attr: 1}},
+		{{Text: "page", Attr: 1}, {Text: " now", Attr: 0}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WrapSpans() = %q; want %q", got, want)
+	}
+}
+
+// TestWrapSpans_Hyphen 하이픈 줄바꿈으로 추가된 "-"는 앞 글자의 속성
+func TestWrapSpans_Hyphen(t *testing.T) {
+	spans := []Span{{Text: "a bas\u00ADket", Attr: 2}, {Text: "!", Attr: 0}}
+
+	got := WrapSpans(spans, Options{Width: 6})
+	want := []Line{
+		{{Text: "a bas-", Attr: 2}},
+		{{Text: "ket", Attr: 2}, {Text: "!", Attr: 0}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WrapSpans() = %q; want %q", got, want)
+	}
+}
+
+// TestWrapSpans_Newlines 원본 줄바꿈과 넓은 문자
+func TestWrapSpans_Newlines(t *testing.T) {
+	spans := []Span{{Text: "제목", Attr: 3}, {Text: "\n\n본문", Attr: 0}}
+
+	got := WrapSpans(spans, Options{Width: 80})
+	want := []Line{
+		{{Text: "제목", Attr: 3}},
+		nil,
+		{{Text: "본문", Attr: 0}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WrapSpans() = %q; want %q", got, want)
+	}
+	if got[0].Width() != 4 || got[2].Text() != "본문" {
+		t.Errorf("Width() = %d, Text() = %q; want 4, %q", got[0].Width(), got[2].Text(), "본문")
+	}
+}
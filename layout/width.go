@@ -0,0 +1,130 @@
+// Package layout implements text layout for the terminal.
+// This file contains East Asian Width aware column measurement.
+package layout
+
+import "unicode"
+
+// runeRange는 유니코드 코드 포인트 범위 [lo, hi]
+type runeRange struct {
+	lo, hi rune
+}
+
+// wideRanges는 East Asian Width가 Wide(W) 또는 Fullwidth(F)인 주요 범위
+//
+// 터미널에서 2칸을 차지하는 문자들 (한글, 한자, 가나, 전각 기호, 이모지 등)
+// UAX #11 (EastAsianWidth.txt)에서 자주 쓰이는 범위만 추림
+var wideRanges = []runeRange{
+	{0x1100, 0x115F},   // 한글 자모 (초성)
+	{0x231A, 0x231B},   // 시계, 모래시계
+	{0x2329, 0x232A},   // 꺾쇠 괄호
+	{0x23E9, 0x23EC},   // 미디어 제어 기호
+	{0x23F0, 0x23F0},   // 알람 시계
+	{0x23F3, 0x23F3},   // 모래시계
+	{0x25FD, 0x25FE},   // 작은 사각형
+	{0x2614, 0x2615},   // 우산, 커피
+	{0x2648, 0x2653},   // 별자리
+	{0x267F, 0x267F},   // 휠체어
+	{0x2693, 0x2693},   // 닻
+	{0x26A1, 0x26A1},   // 번개
+	{0x26AA, 0x26AB},   // 원
+	{0x26BD, 0x26BE},   // 축구공, 야구공
+	{0x26C4, 0x26C5},   // 눈사람, 해
+	{0x26CE, 0x26CE},   // 뱀주인자리
+	{0x26D4, 0x26D4},   // 진입 금지
+	{0x26EA, 0x26EA},   // 교회
+	{0x26F2, 0x26F3},   // 분수, 골프
+	{0x26F5, 0x26F5},   // 돛단배
+	{0x26FA, 0x26FA},   // 텐트
+	{0x26FD, 0x26FD},   // 주유소
+	{0x2705, 0x2705},   // 체크 표시
+	{0x270A, 0x270B},   // 주먹, 손
+	{0x2728, 0x2728},   // 반짝임
+	{0x274C, 0x274C},   // X 표시
+	{0x274E, 0x274E},   // X 표시 (사각형)
+	{0x2753, 0x2755},   // 물음표, 느낌표
+	{0x2757, 0x2757},   // 느낌표
+	{0x2795, 0x2797},   // 더하기, 빼기, 나누기
+	{0x27B0, 0x27B0},   // 고리
+	{0x27BF, 0x27BF},   // 이중 고리
+	{0x2B1B, 0x2B1C},   // 큰 사각형
+	{0x2B50, 0x2B50},   // 별
+	{0x2B55, 0x2B55},   // 원
+	{0x2E80, 0x303E},   // CJK 부수, 강희 부수, CJK 기호 및 구두점
+	{0x3041, 0x33FF},   // 히라가나, 가타카나, 한글 호환 자모, CJK 호환
+	{0x3400, 0x4DBF},   // CJK 통합 한자 확장 A
+	{0x4E00, 0x9FFF},   // CJK 통합 한자
+	{0xA000, 0xA4CF},   // 이 문자
+	{0xA960, 0xA97F},   // 한글 자모 확장 A
+	{0xAC00, 0xD7A3},   // 한글 음절
+	{0xF900, 0xFAFF},   // CJK 호환 한자
+	{0xFE10, 0xFE19},   // 세로쓰기 형태
+	{0xFE30, 0xFE6F},   // CJK 호환 형태, 작은 형태
+	{0xFF00, 0xFF60},   // 전각 ASCII
+	{0xFFE0, 0xFFE6},   // 전각 기호
+	{0x1F300, 0x1F64F}, // 기타 기호 및 그림 문자, 이모티콘
+	{0x1F680, 0x1F6FF}, // 교통 및 지도 기호
+	{0x1F900, 0x1F9FF}, // 보충 기호 및 그림 문자
+	{0x20000, 0x2FFFD}, // CJK 통합 한자 확장 B 이후
+	{0x30000, 0x3FFFD}, // CJK 통합 한자 확장 G 이후
+}
+
+// inRanges는 r이 정렬된 범위 목록 중 하나에 속하는지 이진 탐색으로 확인함
+func inRanges(r rune, ranges []runeRange) bool {
+	lo, hi := 0, len(ranges)-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < ranges[mid].lo:
+			hi = mid - 1
+		case r > ranges[mid].hi:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// RuneWidth는 룬 하나가 터미널에서 차지하는 칸 수를 반환함
+//
+//   - 0: 제어 문자, soft hyphen, 결합 문자(악센트 등), 한글 중성/종성 자모, 폭 없는 공백
+//   - 2: East Asian Wide/Fullwidth 문자 (한글, 한자, 가나, 이모지 등)
+//   - 1: 그 외
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0) || r == 0xAD:
+		// 제어 문자, soft hyphen (줄바꿈 위치에서만 "-"로 표시)
+		return 0
+	case r < 0x300:
+		// 라틴 문자 등 자주 쓰이는 범위는 빠르게 처리
+		return 1
+	case r == 0x200B || r == 0x200C || r == 0x200D || r == 0xFEFF:
+		// 폭 없는 공백, ZWNJ, ZWJ, BOM
+		return 0
+	case r >= 0x1160 && r <= 0x11FF:
+		// 한글 자모 중성/종성: 앞 글자와 결합됨
+		return 0
+	case r >= 0xFE00 && r <= 0xFE0F:
+		// 이체자 선택자
+		return 0
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+// StringWidth는 문자열이 터미널에서 차지하는 전체 칸 수를 반환함
+func StringWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// IsWide는 r이 2칸 문자인지 확인함 (줄바꿈 기회 판단에 사용)
+func IsWide(r rune) bool {
+	return RuneWidth(r) == 2
+}
@@ -0,0 +1,51 @@
+package layout
+
+import "testing"
+
+// TestRuneWidth 문자 종류별 터미널 칸 수
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want int
+	}{
+		{'a', 1},
+		{' ', 1},
+		{'\t', 0},
+		{'é', 1},
+		{'́', 0}, // 결합 악센트
+		{'한', 2},
+		{'ㄱ', 2}, // 한글 호환 자모
+		{'中', 2},
+		{'あ', 2},
+		{'Ａ', 2}, // 전각 A
+		{'ｱ', 1}, // 반각 가타카나
+		{'​', 0},
+		{'😀', 2},
+	}
+
+	for _, tt := range tests {
+		if got := RuneWidth(tt.r); got != tt.want {
+			t.Errorf("RuneWidth(%q) = %d; want %d", tt.r, got, tt.want)
+		}
+	}
+}
+
+// TestStringWidth 혼합 문자열의 칸 수
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"안녕하세요", 10},
+		{"Go 언어", 7},
+		{"日本語テキスト", 14},
+	}
+
+	for _, tt := range tests {
+		if got := StringWidth(tt.s); got != tt.want {
+			t.Errorf("StringWidth(%q) = %d; want %d", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Package layout implements text layout for the terminal.
+// This file contains greedy line breaking measured in terminal columns.
+package layout
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultWidth는 터미널 폭을 알 수 없을 때 사용하는 기본 줄 폭 (칸 수)
+const DefaultWidth = 80
+
+// segment는 줄바꿈 알고리즘이 다루는 최소 단위
+//
+// 단어(공백 없이 이어진 좁은 문자들), 넓은 문자 하나, 공백 묶음 중 하나
+type segment struct {
+	text    string
+	width   int
+	isSpace bool
+	breaks  []int // 하이픈 줄바꿈 가능 위치 (text 기준 바이트 오프셋, soft hyphen에서 추출)
+}
+
+// Options는 줄바꿈 설정
+type Options struct {
+	Width      int        // 줄 폭 (칸 수, 0 이하면 DefaultWidth)
+	Hyphenator Hyphenator // 단어가 넘칠 때 사용할 언어별 하이픈 규칙 (nil이면 soft hyphen만 사용)
+	Borders    bool       // LayoutBox에서 상자 테두리를 그림 (false면 테두리 폭을 0으로 봄)
+}
+
+// noBreakBefore는 줄 맨 앞에 오면 안 되는 문장 부호인지 확인함 (금칙 처리)
+//
+// 이런 문자는 앞 segment에 붙여서 함께 줄바꿈됨
+func noBreakBefore(r rune) bool {
+	return strings.ContainsRune(".,!?;:)]}%'\"、。，．！？：；）」』〉》】〕", r)
+}
+
+// segmentize는 한 줄의 텍스트를 segment 목록으로 나눔
+//
+// 줄바꿈 기회:
+//   - 공백 앞뒤
+//   - 넓은 문자(한글, 한자, 가나) 앞뒤 (CJK는 단어 중간에서도 줄바꿈 가능)
+func segmentize(line string) []segment {
+	var segs []segment
+	var cur strings.Builder
+	curWidth := 0
+	curSpace := false
+
+	flush := func() {
+		if cur.Len() > 0 {
+			seg := segment{text: cur.String(), width: curWidth, isSpace: curSpace}
+			if !curSpace {
+				seg.text, seg.breaks = splitSoftHyphens(seg.text)
+			}
+			segs = append(segs, seg)
+			cur.Reset()
+			curWidth = 0
+		}
+	}
+
+	for _, r := range line {
+		w := RuneWidth(r)
+		space := unicode.IsSpace(r) && r != '\u00A0' // NBSP는 줄바꿈 기회가 아님
+
+		switch {
+		case space:
+			if !curSpace {
+				flush()
+			}
+			curSpace = true
+			cur.WriteRune(r)
+			curWidth += w
+		case noBreakBefore(r) && !curSpace && (cur.Len() > 0 || len(segs) > 0):
+			// 금칙 문자: 현재 segment(또는 직전 넓은 문자)에 붙임
+			if cur.Len() == 0 {
+				last := &segs[len(segs)-1]
+				last.text += string(r)
+				last.width += w
+				continue
+			}
+			cur.WriteRune(r)
+			curWidth += w
+		case w == 2:
+			flush()
+			curSpace = false
+			segs = append(segs, segment{text: string(r), width: w})
+		default:
+			if curSpace {
+				flush()
+			}
+			curSpace = false
+			cur.WriteRune(r)
+			curWidth += w
+		}
+	}
+	flush()
+
+	return segs
+}
+
+// Wrap은 텍스트를 주어진 칸 수에 맞게 줄바꿈하여 줄 목록으로 반환함
+//
+// 폭은 룬 개수가 아니라 터미널 칸 수(StringWidth)로 계산하므로
+// 한글/한자처럼 2칸을 차지하는 문자가 섞여도 정렬이 맞음
+//
+// 규칙:
+//   - 원본의 줄바꿈(\n)은 그대로 유지
+//   - 공백과 넓은 문자 경계에서 줄바꿈 (greedy)
+//   - 줄바꿈 지점의 공백은 제거
+//   - soft hyphen(&shy;) 위치에서 줄바꿈하면 "-" 표시, 나머지 soft hyphen은 숨김
+//   - 한 단어가 폭보다 길면 강제로 자름
+func Wrap(text string, width int) []string {
+	return WrapWith(text, Options{Width: width})
+}
+
+// WrapWith는 Options에 따라 줄바꿈함
+//
+// Hyphenator가 있으면 남은 공간에 들어가지 않는 단어를 음절 단위로 나눠서
+// 좁은 화면에서 줄 끝에 큰 빈 공간이 생기지 않도록 함
+func WrapWith(text string, opts Options) []string {
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		lines = append(lines, wrapLine(para, opts)...)
+	}
+	return lines
+}
+
+// wrapLine은 줄바꿈 문자가 없는 한 줄을 폭에 맞게 나눔
+func wrapLine(line string, opts Options) []string {
+	width := opts.Width
+	var lines []string
+	var cur strings.Builder
+	curWidth := 0
+	wrapped := false // 자동 줄바꿈으로 시작된 줄인지 (앞 공백 제거용)
+
+	flush := func() {
+		lines = append(lines, strings.TrimRightFunc(cur.String(), unicode.IsSpace))
+		cur.Reset()
+		curWidth = 0
+		wrapped = true
+	}
+
+	for _, seg := range segmentize(line) {
+		if seg.isSpace {
+			if wrapped && curWidth == 0 {
+				continue
+			}
+			if curWidth+seg.width > width {
+				flush()
+				continue
+			}
+			cur.WriteString(seg.text)
+			curWidth += seg.width
+			continue
+		}
+
+		// 남은 공간에 들어가지 않으면 하이픈 위치에서 나눠서 앞부분을 현재 줄에 채움
+		for curWidth+seg.width > width {
+			prefix, rest, ok := hyphenateToFit(seg, width-curWidth, opts.Hyphenator)
+			if !ok {
+				break
+			}
+			cur.WriteString(prefix.text)
+			cur.WriteString("-")
+			flush()
+			seg = rest
+		}
+
+		if curWidth+seg.width > width && curWidth > 0 {
+			flush()
+			// 새 줄에서도 넘치면 다시 하이픈 시도
+			for seg.width > width {
+				prefix, rest, ok := hyphenateToFit(seg, width, opts.Hyphenator)
+				if !ok {
+					break
+				}
+				cur.WriteString(prefix.text)
+				cur.WriteString("-")
+				flush()
+				seg = rest
+			}
+		}
+
+		if seg.width > width {
+			// 폭보다 긴 단어: 룬 단위로 강제 분리
+			for _, r := range seg.text {
+				w := RuneWidth(r)
+				if curWidth+w > width && curWidth > 0 {
+					flush()
+				}
+				cur.WriteRune(r)
+				curWidth += w
+			}
+			continue
+		}
+
+		cur.WriteString(seg.text)
+		curWidth += seg.width
+	}
+
+	if cur.Len() > 0 || len(lines) == 0 {
+		lines = append(lines, strings.TrimRightFunc(cur.String(), unicode.IsSpace))
+	}
+	return lines
+}
+
+// hyphenateToFit은 segment를 하이픈 위치에서 나눠 앞부분+"-"가 avail 칸에 들어가게 함
+//
+// soft hyphen 위치를 우선 사용하고, 없으면 hyphenator로 자동 하이픈 위치를 찾음
+// 들어가는 위치 중 가장 뒤쪽을 선택함 (greedy)
+func hyphenateToFit(seg segment, avail int, h Hyphenator) (prefix, rest segment, ok bool) {
+	breaks := seg.breaks
+	if len(breaks) == 0 && h != nil {
+		breaks = h.Hyphenate(seg.text)
+	}
+
+	best := -1
+	for _, b := range breaks {
+		if StringWidth(seg.text[:b])+1 <= avail {
+			best = b
+		}
+	}
+	if best <= 0 {
+		return segment{}, segment{}, false
+	}
+
+	prefix = segment{text: seg.text[:best], width: StringWidth(seg.text[:best])}
+	rest = segment{text: seg.text[best:], width: StringWidth(seg.text[best:])}
+	for _, b := range seg.breaks {
+		if b > best {
+			rest.breaks = append(rest.breaks, b-best)
+		}
+	}
+	return prefix, rest, true
+}
+
+// PadRight는 s의 오른쪽을 공백으로 채워 width 칸으로 맞춤
+//
+// 표나 상태 줄처럼 열을 맞춰야 하는 출력에서 사용함
+func PadRight(s string, width int) string {
+	w := StringWidth(s)
+	if w >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-w)
+}
+
+// Truncate는 s를 width 칸 이내로 자름 (넓은 문자가 반으로 잘리지 않음)
+func Truncate(s string, width int) string {
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := RuneWidth(r)
+		if w+rw > width {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	return b.String()
+}
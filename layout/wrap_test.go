@@ -0,0 +1,72 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWrap_ASCII 영문은 공백에서 줄바꿈
+func TestWrap_ASCII(t *testing.T) {
+	got := Wrap("the quick brown fox jumps", 10)
+	want := []string{"the quick", "brown fox", "jumps"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestWrap_CJKWidth 한글은 2칸으로 계산해서 줄바꿈
+func TestWrap_CJKWidth(t *testing.T) {
+	got := Wrap("가나다라마바사", 6)
+	want := []string{"가나다", "라마바", "사"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+
+	for _, line := range got {
+		if w := StringWidth(line); w > 6 {
+			t.Errorf("line %q width = %d; exceeds 6", line, w)
+		}
+	}
+}
+
+// TestWrap_CJKMixed 한글과 영문 혼합, 금칙 문자는 줄 맨 앞에 오지 않음
+func TestWrap_CJKMixed(t *testing.T) {
+	got := Wrap("환영합니다! Go 브라우저", 10)
+	want := []string{"환영합니", "다! Go 브", "라우저"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestWrap_KeepsNewlines 원본 줄바꿈 유지
+func TestWrap_KeepsNewlines(t *testing.T) {
+	got := Wrap("a\n\nb", 10)
+	want := []string{"a", "", "b"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestWrap_LongWord 폭보다 긴 단어는 강제로 자름
+func TestWrap_LongWord(t *testing.T) {
+	got := Wrap("abcdefghij", 4)
+	want := []string{"abcd", "efgh", "ij"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q; want %q", got, want)
+	}
+}
+
+// TestTruncate 넓은 문자가 반으로 잘리지 않음
+func TestTruncate(t *testing.T) {
+	if got := Truncate("한글abc", 3); got != "한" {
+		t.Errorf("Truncate() = %q; want %q", got, "한")
+	}
+	if got := PadRight("한", 4); got != "한  " {
+		t.Errorf("PadRight() = %q; want %q", got, "한  ")
+	}
+}
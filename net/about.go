@@ -0,0 +1,63 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the about: scheme and its built-in pages.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/url"
+	"sort"
+	"strings"
+)
+
+// AboutFetcher: about: 스킴(브라우저 내부 페이지)을 처리하는 Fetcher 구현
+type AboutFetcher struct{}
+
+// aboutPage는 내부 페이지를 만드는 함수 (MIME 타입, 본문)
+type aboutPage func() (contentType, body string)
+
+// AboutPages: about: 뒤의 이름에 따른 내부 페이지 레지스트리
+var AboutPages = map[string]aboutPage{
+	"blank":         func() (string, string) { return MIMETextHTML, "" },
+	"net-internals": netInternalsPage,
+	"config":        configPage,
+}
+
+// Fetch: AboutFetcher의 Fetch 메서드 구현
+func (a *AboutFetcher) Fetch(u *url.URL) (*Response, error) {
+	page, ok := AboutPages[u.Path]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 내부 페이지: about:%s", u.Path)
+	}
+	contentType, body := page()
+	return newResponse(u, 200, Header{"content-type": contentType}, body), nil
+}
+
+// netInternalsPage: 연결 풀 통계를 호스트별로 표시
+func netInternalsPage() (string, string) {
+	stats := GlobalConnectionPool.Stats()
+
+	addresses := make([]string, 0, len(stats))
+	for address := range stats {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var b strings.Builder
+	b.WriteString("연결 풀\n\n")
+	if n := CoalescedRequests(); n > 0 {
+		fmt.Fprintf(&b, "동시 요청 합침: %d\n\n", n)
+	}
+	if len(addresses) == 0 {
+		b.WriteString("(사용한 연결 없음)\n")
+	}
+	for _, address := range addresses {
+		s := stats[address]
+		fmt.Fprintf(&b, "%s\n  idle=%d created=%d reused=%d closed=%d evicted=%d avg-reuse-latency=%s\n",
+			address, s.Idle, s.Created, s.Reused, s.Closed, s.Evicted, s.AvgReuseLatency)
+		if s.TLSFull+s.TLSResumed > 0 {
+			fmt.Fprintf(&b, "  tls-full=%d (avg %s) tls-resumed=%d (avg %s)\n",
+				s.TLSFull, s.AvgTLSFull, s.TLSResumed, s.AvgTLSResumed)
+		}
+	}
+	return MIMETextPlain, b.String()
+}
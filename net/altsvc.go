@@ -0,0 +1,226 @@
+// Package net implements HTTP networking for the browser.
+// This file contains Alt-Svc header parsing and the alternative service store (RFC 7838).
+package net
+
+import (
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAltSvcMaxAge는 ma 파라미터가 없을 때 대체 서비스를 기억하는 시간 (24시간, RFC 7838 3.1)
+const DefaultAltSvcMaxAge = 24 * time.Hour
+
+// AltService는 Alt-Svc 헤더가 알려준 대체 서비스 하나 (h2=":8443"; ma=3600)
+type AltService struct {
+	Protocol string    // ALPN 프로토콜 ID (예: h2, h3, http/1.1)
+	Host     string    // 대체 호스트 (비어 있으면 원래 호스트와 같음)
+	Port     int       // 대체 포트
+	Expires  time.Time // 이 시각이 지나면 사용하지 않음 (ma 파라미터)
+	Persist  bool      // persist=1 (네트워크가 바뀌어도 유지)
+}
+
+// Address는 대체 서비스의 "host:port" (Host가 비어 있으면 originHost 사용)
+func (s AltService) Address(originHost string) string {
+	host := s.Host
+	if host == "" {
+		host = originHost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(s.Port))
+}
+
+// altSvcProtocols는 대체 서비스로 사용할 수 있는 프로토콜
+//
+// HTTP/1.1만 구현했으므로 h2, h3 대체 서비스는 기록만 하고 사용하지 않음
+var altSvcProtocols = map[string]bool{
+	"http/1.1": true,
+}
+
+// ParseAltSvc는 Alt-Svc 헤더 값을 파싱함
+//
+// "clear"면 clear가 true (원래 서버의 대체 서비스를 모두 지움)
+// 문법에 맞지 않는 항목은 건너뜀
+//
+// 예: `h2=":443"; ma=2592000, http/1.1="alt.example.com:8443"; persist=1`
+func ParseAltSvc(value string, now time.Time) (services []AltService, clear bool) {
+	value = strings.TrimSpace(value)
+	if value == "clear" {
+		return nil, true
+	}
+
+	for _, item := range splitQuoted(value, ',') {
+		params := splitQuoted(item, ';')
+		protocol, authority, ok := strings.Cut(params[0], "=")
+		if !ok {
+			continue
+		}
+		protocol = strings.TrimSpace(protocol)
+		if unescaped, err := unescapeProtocolID(protocol); err == nil {
+			protocol = unescaped
+		}
+		authority = strings.Trim(strings.TrimSpace(authority), `"`)
+		host, portStr, err := net.SplitHostPort(authority)
+		if err != nil || protocol == "" {
+			continue
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port <= 0 || port > 65535 {
+			continue
+		}
+
+		svc := AltService{Protocol: protocol, Host: host, Port: port, Expires: now.Add(DefaultAltSvcMaxAge)}
+		for _, param := range params[1:] {
+			name, val, _ := strings.Cut(param, "=")
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "ma":
+				if seconds, err := strconv.Atoi(val); err == nil && seconds >= 0 {
+					svc.Expires = now.Add(time.Duration(seconds) * time.Second)
+				}
+			case "persist":
+				svc.Persist = val == "1"
+			}
+		}
+		services = append(services, svc)
+	}
+	return services, false
+}
+
+// unescapeProtocolID는 protocol-id의 퍼센트 인코딩(h2%3D 등)을 풂
+func unescapeProtocolID(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		n, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte(n))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// splitQuoted는 따옴표 밖의 sep로 s를 나눔 (각 조각은 앞뒤 공백 제거)
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// AltSvcStore는 원래 서버("host:port")별 대체 서비스 목록
+//
+// 만료 시각(ma)이 지난 항목은 Lookup에서 무시되고, 새 Alt-Svc 헤더를 받으면
+// 그 서버의 목록을 통째로 바꿈
+//
+// AltSvcStore는 thread-safe하며 여러 goroutine에서 동시에 사용 가능함
+type AltSvcStore struct {
+	mu       sync.Mutex
+	services map[string][]AltService
+}
+
+// NewAltSvcStore는 빈 AltSvcStore를 생성함
+func NewAltSvcStore() *AltSvcStore {
+	return &AltSvcStore{services: make(map[string][]AltService)}
+}
+
+// GlobalAltSvc는 HTTPFetcher가 https 응답의 Alt-Svc 헤더를 기록하고 연결할 때 참고하는 저장소
+var GlobalAltSvc = NewAltSvcStore()
+
+// Update는 origin에서 받은 Alt-Svc 헤더 값으로 대체 서비스 목록을 바꿈
+func (s *AltSvcStore) Update(origin, value string, now time.Time) {
+	services, clear := ParseAltSvc(value, now)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clear {
+		delete(s.services, origin)
+		return
+	}
+	if len(services) > 0 {
+		s.services[origin] = services
+	}
+}
+
+// Services는 origin의 만료되지 않은 대체 서비스 목록 (헤더에 적힌 순서)
+func (s *AltSvcStore) Services(origin string, now time.Time) []AltService {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var live []AltService
+	for _, svc := range s.services[origin] {
+		if now.Before(svc.Expires) {
+			live = append(live, svc)
+		}
+	}
+	return live
+}
+
+// Lookup은 origin 대신 연결할 수 있는 첫 번째 대체 서비스를 찾음
+//
+// 지원하는 프로토콜이고, 같은 호스트의 다른 포트인 것만 사용함
+// (다른 호스트는 TLS 서버 이름을 원래 호스트로 보내야 하는데 Dialer가 주소의 호스트를 쓰므로)
+func (s *AltSvcStore) Lookup(origin string, now time.Time) (AltService, bool) {
+	originHost, _, _ := net.SplitHostPort(origin)
+	for _, svc := range s.Services(origin, now) {
+		if altSvcProtocols[svc.Protocol] && (svc.Host == "" || svc.Host == originHost) {
+			return svc, true
+		}
+	}
+	return AltService{}, false
+}
+
+// Remove는 연결에 실패한 대체 주소를 origin의 목록에서 지움
+func (s *AltSvcStore) Remove(origin, address string) {
+	originHost, _, _ := net.SplitHostPort(origin)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var kept []AltService
+	for _, svc := range s.services[origin] {
+		if svc.Address(originHost) != address {
+			kept = append(kept, svc)
+		}
+	}
+	if len(kept) == 0 {
+		delete(s.services, origin)
+		return
+	}
+	s.services[origin] = kept
+}
+
+// alternative는 https 요청을 보낼 주소 (쓸 수 있는 대체 서비스가 있으면 그 주소, 없으면 origin)
+func alternative(origin string, scheme url.Scheme) string {
+	if scheme != url.SchemeHTTPS {
+		return origin
+	}
+	svc, ok := GlobalAltSvc.Lookup(origin, time.Now())
+	if !ok {
+		return origin
+	}
+	originHost, _, _ := net.SplitHostPort(origin)
+	address := svc.Address(originHost)
+	if address != origin {
+		logger.Logger.Printf("Alt-Svc: %s 대신 %s (%s)로 연결", origin, address, svc.Protocol)
+	}
+	return address
+}
@@ -0,0 +1,110 @@
+package net_test
+
+import (
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseAltSvc 프로토콜, 주소, ma, persist 파라미터와 clear
+func TestParseAltSvc(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value     string
+		want      []net.AltService
+		wantClear bool
+	}{
+		{`h2=":443"; ma=3600`, []net.AltService{
+			{Protocol: "h2", Port: 443, Expires: now.Add(time.Hour)},
+		}, false},
+		{`http/1.1="alt.example.com:8443"; persist=1, h3=":443"`, []net.AltService{
+			{Protocol: "http/1.1", Host: "alt.example.com", Port: 8443, Expires: now.Add(net.DefaultAltSvcMaxAge), Persist: true},
+			{Protocol: "h3", Port: 443, Expires: now.Add(net.DefaultAltSvcMaxAge)},
+		}, false},
+		{`w%3Dx%3Ay=":80"`, []net.AltService{
+			{Protocol: "w=x:y", Port: 80, Expires: now.Add(net.DefaultAltSvcMaxAge)},
+		}, false},
+		{`h2="nowhere", h2=":99999"`, nil, false},
+		{"clear", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, clear := net.ParseAltSvc(tt.value, now)
+		if !reflect.DeepEqual(got, tt.want) || clear != tt.wantClear {
+			t.Errorf("ParseAltSvc(%q) = %+v, %v; want %+v, %v", tt.value, got, clear, tt.want, tt.wantClear)
+		}
+	}
+}
+
+// TestAltSvcStore_Lookup 만료되지 않은 같은 호스트의 HTTP/1.1 대체 서비스만 사용함
+func TestAltSvcStore_Lookup(t *testing.T) {
+	now := time.Now()
+	store := net.NewAltSvcStore()
+	store.Update("example.com:443", `h2=":8443", http/1.1="other.example:443", http/1.1=":9443"; ma=60`, now)
+
+	svc, ok := store.Lookup("example.com:443", now)
+	if !ok || svc.Address("example.com") != "example.com:9443" {
+		t.Errorf("Lookup() = %+v, %v; want example.com:9443", svc, ok)
+	}
+	if _, ok := store.Lookup("example.com:443", now.Add(2*time.Minute)); ok {
+		t.Error("Lookup() after ma = found; want not found")
+	}
+
+	store.Remove("example.com:443", "example.com:9443")
+	if _, ok := store.Lookup("example.com:443", now); ok {
+		t.Error("Lookup() after Remove = found; want not found")
+	}
+	if got := len(store.Services("example.com:443", now)); got != 2 {
+		t.Errorf("len(Services()) = %d; want 2", got)
+	}
+
+	store.Update("example.com:443", "clear", now)
+	if got := store.Services("example.com:443", now); got != nil {
+		t.Errorf("Services() after clear = %+v; want nil", got)
+	}
+}
+
+// TestHTTPFetcher_AltSvc 다음 요청부터 대체 포트로 연결하고, 연결할 수 없으면 원래 서버로 돌아감
+func TestHTTPFetcher_AltSvc(t *testing.T) {
+	alt := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("alternative"))
+	}))
+	_, altPort, _ := stdnet.SplitHostPort(alt.Listener.Addr().String())
+
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", fmt.Sprintf(`http/1.1=":%s"; ma=60`, altPort))
+		w.Write([]byte("origin"))
+	}))
+	defer origin.Close()
+
+	tlsConfig := origin.Client().Transport.(*http.Transport).TLSClientConfig
+	fetcher := &net.HTTPFetcher{Dialer: &net.NetDialer{TLSConfig: tlsConfig}}
+	defer net.GlobalAltSvc.Update(origin.Listener.Addr().String(), "clear", time.Now())
+
+	for i, want := range []string{"origin", "alternative"} {
+		u, _ := url.NewURL(fmt.Sprintf("%s/altsvc-%d", origin.URL, i))
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%d) failed: %v", i, err)
+		}
+		if resp.Body != want {
+			t.Errorf("Fetch(%d) Body = %q; want %q", i, resp.Body, want)
+		}
+	}
+
+	alt.Close()
+	u, _ := url.NewURL(origin.URL + "/altsvc-fallback")
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() after alternative closed failed: %v", err)
+	}
+	if resp.Body != "origin" {
+		t.Errorf("Body = %q; want %q", resp.Body, "origin")
+	}
+}
@@ -0,0 +1,133 @@
+package net_test
+
+import (
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// 가져오기 파이프라인 벤치마크 (make bench)
+// ============================================
+
+// quietLogger: 벤치마크 동안 로그 출력을 끔 (요청마다 찍는 로그가 측정을 흐리지 않게)
+func quietLogger(b *testing.B) {
+	b.Helper()
+	prev := logger.Logger
+	logger.Logger = log.New(io.Discard, "", 0)
+	b.Cleanup(func() { logger.Logger = prev })
+}
+
+// benchServer: /keep-alive, /close, /cached, /no-store에 16KiB 본문으로 응답하는 서버
+func benchServer(b *testing.B) *httptest.Server {
+	b.Helper()
+	body := strings.Repeat("<p>benchmark</p>\n", 1<<10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/close":
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Cache-Control", "no-store")
+		case "/cached":
+			w.Header().Set("Cache-Control", "max-age=3600")
+		default:
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, body)
+	}))
+	b.Cleanup(srv.Close)
+	return srv
+}
+
+// benchFetch: u를 b.N번 가져옴
+func benchFetch(b *testing.B, rawURL string) {
+	b.Helper()
+	u, _ := url.NewURL(rawURL)
+	fetcher := isolatedFetcher(b)
+	if _, err := fetcher.Fetch(u); err != nil { // 첫 연결(과 캐시 저장)은 측정에서 뺌
+		b.Fatalf("Fetch(%q) error = %v", rawURL, err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fetcher.Fetch(u); err != nil {
+			b.Fatalf("Fetch(%q) error = %v", rawURL, err)
+		}
+	}
+}
+
+// BenchmarkFetch_KeepAlive 풀에 돌려준 연결을 다시 씀
+func BenchmarkFetch_KeepAlive(b *testing.B) {
+	quietLogger(b)
+	srv := benchServer(b)
+	benchFetch(b, srv.URL+"/keep-alive")
+}
+
+// BenchmarkFetch_FreshConnection 서버가 Connection: close로 응답해서 요청마다 새로 연결함
+func BenchmarkFetch_FreshConnection(b *testing.B) {
+	quietLogger(b)
+	srv := benchServer(b)
+	benchFetch(b, srv.URL+"/close")
+}
+
+// BenchmarkFetch_Cached 캐시에서 가져옴 (네트워크 없음)
+func BenchmarkFetch_Cached(b *testing.B) {
+	quietLogger(b)
+	srv := benchServer(b)
+	benchFetch(b, srv.URL+"/cached")
+}
+
+// BenchmarkFetch_Uncached Cache-Control: no-store라서 매번 네트워크로 가져옴 (연결은 재사용)
+func BenchmarkFetch_Uncached(b *testing.B) {
+	quietLogger(b)
+	srv := benchServer(b)
+	benchFetch(b, srv.URL+"/no-store")
+}
+
+// benchBodies: 같은 64KiB 본문을 Content-Length와 4KiB 청크로 보낸 응답
+func benchBodies() (contentLength, chunked string) {
+	body := strings.Repeat("x", 64<<10)
+	contentLength = fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Length: %d\r\n\r\n%s", len(body), body)
+
+	var sb strings.Builder
+	sb.WriteString("HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n")
+	for i := 0; i < len(body); i += 4 << 10 {
+		chunk := body[i:min(i+4<<10, len(body))]
+		fmt.Fprintf(&sb, "%x\r\n%s\r\n", len(chunk), chunk)
+	}
+	sb.WriteString("0\r\n\r\n")
+	return contentLength, sb.String()
+}
+
+// BenchmarkParseResponse_ContentLength Content-Length 본문 읽기
+func BenchmarkParseResponse_ContentLength(b *testing.B) {
+	quietLogger(b)
+	raw, _ := benchBodies()
+	benchParse(b, raw)
+}
+
+// BenchmarkParseResponse_Chunked 청크 본문 읽기
+func BenchmarkParseResponse_Chunked(b *testing.B) {
+	quietLogger(b)
+	_, raw := benchBodies()
+	benchParse(b, raw)
+}
+
+// benchParse: raw 응답을 b.N번 파싱함
+func benchParse(b *testing.B, raw string) {
+	b.Helper()
+	b.SetBytes(int64(len(raw)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := net.ParseResponse(strings.NewReader(raw)); err != nil {
+			b.Fatalf("ParseResponse() error = %v", err)
+		}
+	}
+}
@@ -4,9 +4,11 @@ package net
 
 import (
 	"go-web-browser/logger"
+	"log"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,10 +17,10 @@ import (
 // 응답 본문, 헤더, 캐시 저장 시간,
 // Cache-Control 헤더의 max-age 값을 저장함
 type CacheEntry struct {
-	Body      string            // 응답 본문
-	Headers   map[string]string // 응답 헤더
-	Timestamp int64             // 캐시 저장 시간 (Unix timestamp)
-	MaxAge    int               // max-age 값 (초 단위, 0 = max-age 없음, -1 = no-store)
+	Body      string // 응답 본문
+	Headers   Header // 응답 헤더
+	Timestamp int64  // 캐시 저장 시간 (Unix timestamp)
+	MaxAge    int    // max-age 값 (초 단위, 0 = max-age 없음, -1 = no-store)
 }
 
 // Cache는 HTTP 응답 캐싱을 관리함
@@ -28,17 +30,71 @@ type CacheEntry struct {
 //
 // 캐시는 thread-safe하며 여러 goroutine에서 동시에 사용 가능함
 type Cache struct {
-	entries map[string]*CacheEntry // URL → CacheEntry
-	mu      sync.Mutex             // entries map 보호
+	entries      map[string]*CacheEntry     // URL → CacheEntry
+	mu           sync.Mutex                 // entries map 보호
+	maxEntrySize int                        // 엔트리 하나의 최대 본문 크기 (바이트, 0 = 제한 없음)
+	skipped      int                        // 크기 제한 때문에 저장하지 않은 응답 수
+	log          atomic.Pointer[log.Logger] // 캐시 로그를 쓸 로거 (nil이면 logger.Logger)
+}
+
+// DefaultMaxEntrySize는 캐시에 저장하는 응답 본문의 기본 최대 크기 (10 MB)
+//
+// 동영상이나 거대한 JSON 같은 응답이 메모리를 차지하지 않도록 함
+const DefaultMaxEntrySize = 10 << 20
+
+// CacheStats는 캐시 상태 스냅샷
+type CacheStats struct {
+	Entries         int // 저장된 엔트리 수
+	Bytes           int // 저장된 본문 크기 합계
+	SkippedTooLarge int // 최대 크기를 넘어서 저장하지 않은 응답 수
 }
 
 // NewCache는 새 Cache 인스턴스를 생성함
 func NewCache() *Cache {
 	return &Cache{
-		entries: make(map[string]*CacheEntry),
+		entries:      make(map[string]*CacheEntry),
+		maxEntrySize: DefaultMaxEntrySize,
 	}
 }
 
+// SetMaxEntrySize는 엔트리 하나의 최대 본문 크기를 설정함 (0 이하면 제한 없음)
+//
+// SetMaxEntrySize는 동시 사용에 안전함
+func (c *Cache) SetMaxEntrySize(bytes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxEntrySize = max(bytes, 0)
+}
+
+// SetLogger는 캐시 로그를 쓸 로거를 설정함 (nil이면 logger.Logger)
+//
+// SetLogger는 동시 사용에 안전함
+func (c *Cache) SetLogger(l *log.Logger) {
+	c.log.Store(l)
+}
+
+// logger는 캐시 로그를 쓸 로거
+func (c *Cache) logger() *log.Logger {
+	if l := c.log.Load(); l != nil {
+		return l
+	}
+	return logger.Logger
+}
+
+// Stats는 현재 엔트리 수, 전체 크기, 건너뛴 응답 수를 반환함
+//
+// Stats는 동시 사용에 안전함
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{Entries: len(c.entries), SkippedTooLarge: c.skipped}
+	for _, entry := range c.entries {
+		stats.Bytes += len(entry.Body)
+	}
+	return stats
+}
+
 // Get은 주어진 URL에 대한 캐시 엔트리를 가져옴
 //
 // 엔트리가 존재하고 유효하면 (entry, true) 반환,
@@ -65,12 +121,12 @@ func (c *Cache) Get(url string) (*CacheEntry, bool) {
 		if elapsed > int64(entry.MaxAge) {
 			// 만료됨 - 캐시에서 제거
 			delete(c.entries, url)
-			logger.Logger.Printf("캐시 만료 (max-age=%ds, elapsed=%ds): %s", entry.MaxAge, elapsed, url)
+			c.logger().Printf("캐시 만료 (max-age=%ds, elapsed=%ds): %s", entry.MaxAge, elapsed, url)
 			return nil, false
 		}
 	}
 
-	logger.Logger.Printf("캐시에서 응답 반환: %s", url)
+	c.logger().Printf("캐시에서 응답 반환: %s", url)
 	return entry, true
 }
 
@@ -80,23 +136,24 @@ func (c *Cache) Get(url string) (*CacheEntry, bool) {
 //   - no-store: 캐시하지 않음
 //   - max-age=N: 만료 시간과 함께 캐시
 //   - Cache-Control 없음 또는 지원하지 않는 지시어: 캐시하지 않음 (보수적)
+//   - 본문이 최대 크기(SetMaxEntrySize)보다 크면 캐시하지 않음
 //
 // # HTTP 규격에 따라 GET 요청의 200 응답만 캐시함
 //
 // Put은 동시 사용에 안전함
-func (c *Cache) Put(url string, statusCode int, body string, headers map[string]string) {
+func (c *Cache) Put(url string, statusCode int, body string, headers Header) {
 	// GET 요청의 200 응답만 캐시
 	if statusCode != 200 {
 		return
 	}
 
 	// Cache-Control 헤더 파싱
-	cacheControl := headers["cache-control"]
+	cacheControl := headers.Get("Cache-Control")
 	noStore, maxAge := parseCacheControl(cacheControl)
 
 	// no-store인 경우 캐시하지 않음
 	if noStore {
-		logger.Logger.Printf("캐시하지 않음 (Cache-Control: no-store): %s", url)
+		c.logger().Printf("캐시하지 않음 (Cache-Control: no-store): %s", url)
 		return
 	}
 
@@ -104,16 +161,23 @@ func (c *Cache) Put(url string, statusCode int, body string, headers map[string]
 	// max-age가 있으면 사용
 	// 지원하지 않는 지시어가 있으면 (maxAge == -2) 캐시하지 않음
 	if maxAge == -2 {
-		logger.Logger.Printf("캐시하지 않음 (지원하지 않는 Cache-Control): %s", url)
+		c.logger().Printf("캐시하지 않음 (지원하지 않는 Cache-Control): %s", url)
 		return
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// 너무 큰 응답은 메모리에 저장하지 않음
+	if c.maxEntrySize > 0 && len(body) > c.maxEntrySize {
+		c.skipped++
+		c.logger().Printf("캐시하지 않음 (본문 %d 바이트 > 최대 %d 바이트): %s", len(body), c.maxEntrySize, url)
+		return
+	}
+
 	entry := &CacheEntry{
 		Body:      body,
-		Headers:   headers,
+		Headers:   headers.Clone(), // 응답을 받은 쪽이 헤더를 고쳐도 엔트리는 그대로 둠
 		Timestamp: time.Now().Unix(),
 		MaxAge:    maxAge, // max-age 없으면 0, max-age=N이면 N
 	}
@@ -121,13 +185,13 @@ func (c *Cache) Put(url string, statusCode int, body string, headers map[string]
 	c.entries[url] = entry
 
 	if maxAge > 0 {
-		logger.Logger.Printf("응답 캐시 저장 (max-age=%ds): %s", maxAge, url)
+		c.logger().Printf("응답 캐시 저장 (max-age=%ds): %s", maxAge, url)
 	} else {
-		logger.Logger.Printf("응답 캐시 저장 (무제한): %s", url)
+		c.logger().Printf("응답 캐시 저장 (무제한): %s", url)
 	}
 }
 
-// Clear는 캐시의 모든 엔트리를 제거함
+// Clear는 캐시의 모든 엔트리를 제거하고 통계(크기 제한으로 건너뛴 수)도 0으로 되돌림
 //
 // 테스트할 때 또는 강제로 새로 가져오고 싶을 때 유용함
 //
@@ -137,7 +201,8 @@ func (c *Cache) Clear() {
 	defer c.mu.Unlock()
 
 	c.entries = make(map[string]*CacheEntry)
-	logger.Logger.Println("캐시 전체 삭제")
+	c.skipped = 0
+	c.logger().Println("캐시 전체 삭제")
 }
 
 // parseCacheControl은 Cache-Control 헤더를 파싱하고 다음을 반환함:
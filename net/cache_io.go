@@ -0,0 +1,120 @@
+// Package net implements HTTP networking for the browser.
+// This file contains exporting and importing cache snapshots.
+package net
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// cacheSnapshotVersion은 Export 형식의 버전 (형식이 바뀌면 올림)
+const cacheSnapshotVersion = 1
+
+// cacheSnapshot은 Export/Import에 사용하는 JSON 형식
+type cacheSnapshot struct {
+	Version int                  `json:"version"`
+	Entries []cacheSnapshotEntry `json:"entries"`
+}
+
+// cacheSnapshotEntry는 스냅샷의 엔트리 하나
+type cacheSnapshotEntry struct {
+	URL       string `json:"url"`
+	Headers   Header `json:"headers"`
+	Body      string `json:"body"`
+	Timestamp int64  `json:"timestamp"`
+	MaxAge    int    `json:"max_age"`
+}
+
+// Export는 캐시 내용을 JSON 스냅샷으로 w에 씀
+//
+// CI 픽스처나 미리 채운 캐시를 배포할 때 사용함 (엔트리는 URL 순으로 정렬)
+//
+// Export는 동시 사용에 안전함
+func (c *Cache) Export(w io.Writer) error {
+	c.mu.Lock()
+	snapshot := cacheSnapshot{Version: cacheSnapshotVersion, Entries: make([]cacheSnapshotEntry, 0, len(c.entries))}
+	for url, entry := range c.entries {
+		snapshot.Entries = append(snapshot.Entries, cacheSnapshotEntry{
+			URL:       url,
+			Headers:   entry.Headers,
+			Body:      entry.Body,
+			Timestamp: entry.Timestamp,
+			MaxAge:    entry.MaxAge,
+		})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(snapshot.Entries, func(i, j int) bool {
+		return snapshot.Entries[i].URL < snapshot.Entries[j].URL
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		return fmt.Errorf("캐시 내보내기 실패: %w", err)
+	}
+	return nil
+}
+
+// Import는 Export로 만든 스냅샷을 읽어 캐시에 추가하고 추가한 엔트리 수를 반환함
+//
+// 다음 엔트리는 건너뜀:
+//   - max-age가 지나 이미 만료된 엔트리
+//   - 저장 시간이 미래인 엔트리 (잘못된 스냅샷)
+//   - 최대 크기(SetMaxEntrySize)를 넘는 엔트리
+//
+// 같은 URL의 기존 엔트리는 덮어씀
+// 헤더 이름은 대소문자를 섞어 써도 됨 (Header.Set으로 다시 넣음)
+//
+// Import는 동시 사용에 안전함
+func (c *Cache) Import(r io.Reader) (int, error) {
+	var snapshot cacheSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return 0, fmt.Errorf("캐시 스냅샷 파싱 실패: %w", err)
+	}
+	if snapshot.Version != cacheSnapshotVersion {
+		return 0, fmt.Errorf("지원하지 않는 캐시 스냅샷 버전: %d", snapshot.Version)
+	}
+
+	now := time.Now().Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	imported := 0
+	for _, e := range snapshot.Entries {
+		switch {
+		case e.URL == "" || e.MaxAge < 0:
+			c.logger().Printf("캐시 가져오기: 잘못된 엔트리 건너뜀: %q", e.URL)
+			continue
+		case e.Timestamp > now:
+			c.logger().Printf("캐시 가져오기: 저장 시간이 미래인 엔트리 건너뜀: %s", e.URL)
+			continue
+		case e.MaxAge > 0 && now-e.Timestamp > int64(e.MaxAge):
+			c.logger().Printf("캐시 가져오기: 만료된 엔트리 건너뜀 (max-age=%ds): %s", e.MaxAge, e.URL)
+			continue
+		case c.maxEntrySize > 0 && len(e.Body) > c.maxEntrySize:
+			c.skipped++
+			continue
+		}
+
+		// 손으로 쓴 픽스처의 "Content-Type" 같은 키도 Get으로 찾을 수 있도록 소문자 키로 다시 넣음
+		headers := make(Header, len(e.Headers))
+		for name, value := range e.Headers {
+			headers.Set(name, value)
+		}
+		c.entries[e.URL] = &CacheEntry{
+			Body:      e.Body,
+			Headers:   headers,
+			Timestamp: e.Timestamp,
+			MaxAge:    e.MaxAge,
+		}
+		imported++
+	}
+
+	c.logger().Printf("캐시 가져오기: %d/%d 엔트리", imported, len(snapshot.Entries))
+	return imported, nil
+}
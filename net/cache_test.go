@@ -0,0 +1,142 @@
+package net_test
+
+import (
+	"fmt"
+	"go-web-browser/net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================
+// Cache 단위 테스트
+// ============================================
+
+// TestCache_MaxEntrySize 최대 크기를 넘는 응답은 저장하지 않고 개수를 셈
+func TestCache_MaxEntrySize(t *testing.T) {
+	cache := net.NewCache()
+	cache.SetMaxEntrySize(10)
+
+	cache.Put("http://example.com/small", 200, "0123456789", nil)
+	cache.Put("http://example.com/huge", 200, strings.Repeat("x", 11), nil)
+
+	if _, found := cache.Get("http://example.com/small"); !found {
+		t.Error("Get(small) should hit (10 bytes <= limit)")
+	}
+	if _, found := cache.Get("http://example.com/huge"); found {
+		t.Error("Get(huge) should miss (11 bytes > limit)")
+	}
+
+	stats := cache.Stats()
+	want := net.CacheStats{Entries: 1, Bytes: 10, SkippedTooLarge: 1}
+	if stats != want {
+		t.Errorf("Stats() = %+v; want %+v", stats, want)
+	}
+
+	// 0이면 제한 없음
+	cache.SetMaxEntrySize(0)
+	cache.Put("http://example.com/huge", 200, strings.Repeat("x", 11), nil)
+	if _, found := cache.Get("http://example.com/huge"); !found {
+		t.Error("Get(huge) should hit after removing the limit")
+	}
+
+	// Clear는 엔트리와 함께 건너뛴 수도 지움
+	cache.Clear()
+	if stats := cache.Stats(); stats != (net.CacheStats{}) {
+		t.Errorf("Stats() after Clear() = %+v; want zero", stats)
+	}
+}
+
+// TestCache_ExportImport 내보낸 스냅샷을 다른 캐시로 가져오기
+func TestCache_ExportImport(t *testing.T) {
+	src := net.NewCache()
+	src.Put("http://example.com/a", 200, "A", map[string]string{"content-type": "text/html"})
+	src.Put("http://example.com/b", 200, "B", map[string]string{"cache-control": "max-age=3600"})
+
+	var buf strings.Builder
+	if err := src.Export(&buf); err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+
+	dst := net.NewCache()
+	n, err := dst.Import(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Import() = %d; want 2", n)
+	}
+
+	entry, found := dst.Get("http://example.com/a")
+	if !found || entry.Body != "A" || entry.Headers["content-type"] != "text/html" {
+		t.Errorf("Get(a) = %+v, %v; want body %q with headers", entry, found, "A")
+	}
+	entry, found = dst.Get("http://example.com/b")
+	if !found || entry.MaxAge != 3600 {
+		t.Errorf("Get(b) = %+v, %v; want MaxAge 3600", entry, found)
+	}
+}
+
+// TestCache_ImportFreshness 만료되었거나 미래 시각인 엔트리는 가져오지 않음
+func TestCache_ImportFreshness(t *testing.T) {
+	now := time.Now().Unix()
+	snapshot := fmt.Sprintf(`{"version": 1, "entries": [
+		{"url": "http://example.com/fresh", "body": "F", "timestamp": %d, "max_age": 60},
+		{"url": "http://example.com/stale", "body": "S", "timestamp": %d, "max_age": 60},
+		{"url": "http://example.com/forever", "body": "E", "timestamp": %d, "max_age": 0},
+		{"url": "http://example.com/future", "body": "X", "timestamp": %d, "max_age": 0}
+	]}`, now-10, now-120, now-100000, now+3600)
+
+	cache := net.NewCache()
+	n, err := cache.Import(strings.NewReader(snapshot))
+	if err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Import() = %d; want 2", n)
+	}
+	for _, u := range []string{"http://example.com/fresh", "http://example.com/forever"} {
+		if _, found := cache.Get(u); !found {
+			t.Errorf("Get(%q) should hit", u)
+		}
+	}
+	for _, u := range []string{"http://example.com/stale", "http://example.com/future"} {
+		if _, found := cache.Get(u); found {
+			t.Errorf("Get(%q) should miss", u)
+		}
+	}
+}
+
+// TestCache_ImportMixedCaseHeaders 픽스처의 헤더 이름을 대소문자를 섞어 써도 Get으로 찾음
+func TestCache_ImportMixedCaseHeaders(t *testing.T) {
+	snapshot := fmt.Sprintf(`{"version": 1, "entries": [
+		{"url": "http://example.com/a", "headers": {"Content-Type": "text/html", "ETag": "\"v1\""},
+		 "body": "A", "timestamp": %d, "max_age": 0}
+	]}`, time.Now().Unix())
+
+	cache := net.NewCache()
+	if _, err := cache.Import(strings.NewReader(snapshot)); err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	entry, found := cache.Get("http://example.com/a")
+	if !found {
+		t.Fatal("Get(a) should hit")
+	}
+	if got := entry.Headers.Get("content-type"); got != "text/html" {
+		t.Errorf("Headers.Get(content-type) = %q; want %q", got, "text/html")
+	}
+	if got := entry.Headers.Get("Etag"); got != `"v1"` {
+		t.Errorf("Headers.Get(Etag) = %q; want %q", got, `"v1"`)
+	}
+}
+
+// TestCache_ImportInvalid 잘못된 스냅샷은 에러
+func TestCache_ImportInvalid(t *testing.T) {
+	inputs := []string{`not json`, `{"version": 99, "entries": []}`}
+
+	for _, input := range inputs {
+		if _, err := net.NewCache().Import(strings.NewReader(input)); err == nil {
+			t.Errorf("Import(%q) should return error", input)
+		}
+	}
+}
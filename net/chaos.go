@@ -0,0 +1,253 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the chaos dialer that injects network faults for robustness tests.
+package net
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FaultKind는 ChaosDialer가 응답에 일으키는 장애의 종류
+type FaultKind int
+
+// 장애 종류
+const (
+	FaultNone       FaultKind = iota // 장애 없음 (지연만 적용)
+	FaultTruncate                    // 본문 After 바이트 뒤에서 연결을 정상적으로 닫음 (EOF)
+	FaultDisconnect                  // 본문 After 바이트 뒤에서 연결이 끊김 (ECONNRESET)
+	FaultBadChunk                    // 본문 After 번째 바이트를 청크 크기로 읽을 수 없는 'z'로 바꿈
+	FaultChunkSize                   // 본문 After 번째 바이트부터 줄 끝까지의 청크 크기를 Size로 바꿈 (음수, 너무 큰 크기)
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case FaultTruncate:
+		return "truncate"
+	case FaultDisconnect:
+		return "disconnect"
+	case FaultBadChunk:
+		return "bad-chunk"
+	case FaultChunkSize:
+		return "chunk-size"
+	}
+	return "none"
+}
+
+// Fault는 연결 하나에 일으킬 장애
+//
+// After는 응답 헤더가 끝난 뒤("\r\n\r\n" 다음)부터 센 본문 바이트 수
+type Fault struct {
+	Kind  FaultKind
+	After int
+	Size  string // FaultChunkSize가 청크 크기 자리에 넣는 글 (예: "-1", "7fffffffffffffff")
+}
+
+// ChaosDialer는 실제 연결(또는 테스트 서버 연결)을 감싸서 지연과 장애를 일으키는 테스트용 Dialer
+//
+// readBody, readChunkedBody와 재시도 정책이 망가진 응답을 제대로 다루는지 확인할 때 씀
+// Faults는 새 연결마다 앞에서부터 하나씩 적용하고, 다 쓰면 이후 연결은 정상으로 둠
+// (첫 연결만 끊고 재시도는 성공하는 상황을 만들 수 있음)
+type ChaosDialer struct {
+	Dialer  Dialer        // 실제로 연결할 Dialer (nil이면 DefaultDialer)
+	Latency time.Duration // 응답의 첫 바이트를 읽기 전에 기다리는 시간
+	Faults  []Fault
+
+	mu    sync.Mutex
+	dials int
+}
+
+// Dials는 지금까지 만든 연결 수
+func (d *ChaosDialer) Dials() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dials
+}
+
+// DialContext: ChaosDialer의 평문 연결 구현
+func (d *ChaosDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := d.dialer().DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return d.wrap(conn), nil
+}
+
+// DialTLSContext: ChaosDialer의 TLS 연결 구현 (TLS 연결 정보는 그대로 보임)
+func (d *ChaosDialer) DialTLSContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := d.dialer().DialTLSContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	cc := d.wrap(conn)
+	if tc, ok := conn.(interface{ ConnectionState() tls.ConnectionState }); ok {
+		return &chaosTLSConn{chaosConn: cc, state: tc.ConnectionState}, nil
+	}
+	return cc, nil
+}
+
+// dialer는 실제로 연결할 Dialer (nil이면 DefaultDialer)
+func (d *ChaosDialer) dialer() Dialer {
+	if d.Dialer == nil {
+		return DefaultDialer
+	}
+	return d.Dialer
+}
+
+// wrap은 다음 차례의 장애를 적용하는 chaosConn으로 conn을 감쌈
+func (d *ChaosDialer) wrap(conn net.Conn) *chaosConn {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var fault Fault
+	if d.dials < len(d.Faults) {
+		fault = d.Faults[d.dials]
+	}
+	d.dials++
+	return &chaosConn{Conn: conn, fault: fault, latency: d.Latency}
+}
+
+// headerEnd는 응답 헤더의 끝
+var headerEnd = []byte("\r\n\r\n")
+
+// chaosConn은 읽는 쪽에 장애를 일으키는 연결
+type chaosConn struct {
+	net.Conn
+	fault   Fault
+	latency time.Duration
+
+	delayed bool
+	tail    []byte // 헤더 끝을 찾으려고 기억하는 직전에 읽은 바이트 (최대 3바이트)
+	inBody  bool
+	body    int   // 지금까지 읽은 본문 바이트 수
+	err     error // 장애가 일어난 뒤 계속 돌려줄 에러
+
+	held      []byte // FaultChunkSize: 청크 크기 줄 끝을 찾을 때까지 모아 둔 본문
+	pending   []byte // FaultChunkSize: 크기를 바꾼 뒤 아직 돌려주지 않은 본문
+	rewritten bool   // FaultChunkSize: 청크 크기를 이미 바꿨는지
+}
+
+// Read는 헤더 뒤의 본문 바이트를 세면서 장애 지점에서 읽기를 멈추거나 바이트를 바꿈
+func (c *chaosConn) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if !c.delayed {
+		c.delayed = true
+		time.Sleep(c.latency)
+	}
+	if c.fault.Kind == FaultNone {
+		return c.Conn.Read(p)
+	}
+	if len(c.pending) > 0 {
+		n := copy(p, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+
+	// 장애 지점을 넘어서 읽지 않도록 본문에서는 남은 만큼만 읽음
+	if c.inBody && c.fault.Kind != FaultBadChunk && c.fault.Kind != FaultChunkSize {
+		remaining := c.fault.After - c.body
+		if remaining <= 0 {
+			return 0, c.fail()
+		}
+		if len(p) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	n, err := c.Conn.Read(p)
+	start := 0
+	if !c.inBody {
+		window := append(c.tail, p[:n]...)
+		i := bytes.Index(window, headerEnd)
+		if i < 0 {
+			if len(window) > len(headerEnd)-1 {
+				window = window[len(window)-(len(headerEnd)-1):]
+			}
+			c.tail = append([]byte(nil), window...)
+			return n, err
+		}
+		c.inBody = true
+		start = i + len(headerEnd) - len(c.tail)
+	}
+
+	body := p[start:n]
+	if c.fault.Kind == FaultChunkSize {
+		return c.rewriteChunkSize(p, start, n, err)
+	}
+	if c.fault.Kind == FaultBadChunk {
+		if i := c.fault.After - c.body; i >= 0 && i < len(body) {
+			body[i] = 'z'
+		}
+		c.body += len(body)
+		return n, err
+	}
+
+	// 헤더와 본문이 한 번에 들어오면 본문은 장애 지점까지만 돌려줌
+	if extra := c.body + len(body) - c.fault.After; extra > 0 {
+		n -= extra
+		body = body[:len(body)-extra]
+	}
+	c.body += len(body)
+	if n == 0 && c.body >= c.fault.After {
+		return 0, c.fail()
+	}
+	return n, err
+}
+
+// rewriteChunkSize는 p[start:n]의 본문을 모아 두었다가 After 바이트부터 줄 끝까지를 Size로 바꿔서 돌려줌
+//
+// 크기 줄의 길이가 달라지므로 줄 끝을 찾을 때까지 본문을 돌려주지 않고, 다 못 돌려준 본문은 다음 Read에서 줌
+func (c *chaosConn) rewriteChunkSize(p []byte, start, n int, err error) (int, error) {
+	c.body += n - start
+	if c.rewritten {
+		return n, err
+	}
+	c.held = append(c.held, p[start:n]...)
+	if len(c.held) > c.fault.After {
+		if i := bytes.Index(c.held[c.fault.After:], []byte("\r\n")); i >= 0 {
+			rest := c.held[c.fault.After+i:]
+			c.pending = append(append(c.held[:c.fault.After:c.fault.After], c.fault.Size...), rest...)
+			c.held, c.rewritten = nil, true
+		}
+	}
+	if !c.rewritten && err != nil {
+		c.pending, c.held = c.held, nil
+	}
+
+	m := copy(p[start:], c.pending)
+	c.pending = c.pending[m:]
+	if start+m == 0 && err == nil {
+		return c.Read(p)
+	}
+	if len(c.pending) > 0 {
+		return start + m, nil
+	}
+	return start + m, err
+}
+
+// fail은 장애 종류에 맞는 에러를 만들고, 연결을 닫아서 남은 응답을 버림
+func (c *chaosConn) fail() error {
+	c.err = io.EOF
+	if c.fault.Kind == FaultDisconnect {
+		c.err = &net.OpError{Op: "read", Net: "tcp", Addr: c.RemoteAddr(), Err: syscall.ECONNRESET}
+	}
+	c.Conn.Close()
+	return c.err
+}
+
+// chaosTLSConn은 TLS 연결 정보(인증서, 핀 확인용)를 그대로 보여주는 chaosConn
+type chaosTLSConn struct {
+	*chaosConn
+	state func() tls.ConnectionState
+}
+
+// ConnectionState는 감싼 TLS 연결의 상태
+func (c *chaosTLSConn) ConnectionState() tls.ConnectionState {
+	return c.state()
+}
@@ -0,0 +1,103 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// chaosServer: 길이가 정해진 본문(/fixed)과 청크 본문(/chunked)을 보내는 테스트 서버
+func chaosServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			io.WriteString(w, "hello ")
+			w.(http.Flusher).Flush()
+			io.WriteString(w, "world")
+			return
+		}
+		w.Header().Set("Content-Length", "11")
+		io.WriteString(w, "hello world")
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// chaosFetch: ChaosDialer를 거쳐서 srv의 path를 가져옴
+func chaosFetch(t *testing.T, d *net.ChaosDialer, srv *httptest.Server, path string) (*net.Response, error) {
+	t.Helper()
+	u, err := url.NewURL(srv.URL + path)
+	if err != nil {
+		t.Fatalf("NewURL() failed: %v", err)
+	}
+	return (&net.HTTPFetcher{Dialer: d}).Fetch(u)
+}
+
+// TestChaosDialer_Faults 잘린 본문, 망가진 청크 크기(숫자가 아님, 음수, 너무 큼), 본문 중간의 연결 끊김은 에러가 됨
+func TestChaosDialer_Faults(t *testing.T) {
+	srv := chaosServer(t)
+	tests := []struct {
+		name  string
+		path  string
+		fault net.Fault
+	}{
+		{"Content-Length보다 짧은 본문", "/fixed", net.Fault{Kind: net.FaultTruncate, After: 5}},
+		{"청크 본문 중간에 닫힘", "/chunked", net.Fault{Kind: net.FaultTruncate, After: 4}},
+		{"Content-Length 본문 중간에 끊김", "/fixed", net.Fault{Kind: net.FaultDisconnect, After: 3}},
+		{"청크 크기가 숫자가 아님", "/chunked", net.Fault{Kind: net.FaultBadChunk}},
+		{"청크 크기가 음수", "/chunked", net.Fault{Kind: net.FaultChunkSize, Size: "-1"}},
+		{"청크 크기가 너무 큼", "/chunked", net.Fault{Kind: net.FaultChunkSize, Size: "7fffffffffffffff"}},
+		{"두 번째 청크 크기가 너무 큼", "/chunked", net.Fault{Kind: net.FaultChunkSize, After: 11, Size: "10000000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &net.ChaosDialer{Faults: []net.Fault{tt.fault}}
+			if resp, err := chaosFetch(t, d, srv, tt.path); err == nil {
+				t.Errorf("Fetch(%s) with %v = %q; want error", tt.path, tt.fault.Kind, resp.Body)
+			}
+		})
+	}
+}
+
+// TestChaosDialer_NoFault 장애가 없으면 응답이 그대로 오고, Latency만큼 늦어짐
+func TestChaosDialer_NoFault(t *testing.T) {
+	srv := chaosServer(t)
+	d := &net.ChaosDialer{Latency: 20 * time.Millisecond}
+
+	for _, path := range []string{"/fixed", "/chunked"} {
+		start := time.Now()
+		resp, err := chaosFetch(t, d, srv, path)
+		if err != nil {
+			t.Fatalf("Fetch(%s) failed: %v", path, err)
+		}
+		if resp.Body != "hello world" {
+			t.Errorf("Fetch(%s).Body = %q; want %q", path, resp.Body, "hello world")
+		}
+		if elapsed := time.Since(start); path == "/fixed" && elapsed < d.Latency {
+			t.Errorf("Fetch(%s) took %v; want at least %v", path, elapsed, d.Latency)
+		}
+	}
+}
+
+// TestChaosDialer_RetryAfterDisconnect 첫 연결이 본문 중간에 끊겨도 재시도 정책이 있으면 새 연결로 성공함
+func TestChaosDialer_RetryAfterDisconnect(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+	srv := chaosServer(t)
+	d := &net.ChaosDialer{Faults: []net.Fault{{Kind: net.FaultDisconnect, After: 2}}}
+
+	resp, err := chaosFetch(t, d, srv, "/fixed")
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "hello world" {
+		t.Errorf("Fetch().Body = %q; want %q", resp.Body, "hello world")
+	}
+	if got := d.Dials(); got != 2 {
+		t.Errorf("Dials() = %d; want 2", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the mailto: and tel: schemes, shown as a summary page.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/url"
+	"strings"
+)
+
+// ContactFetcher: mailto:, tel: 스킴을 처리하는 Fetcher 구현
+//
+// 메일을 보내거나 전화를 걸 수는 없으므로 받는 사람, 제목, 번호를 정리한 텍스트 페이지를 반환함
+// (대화형 모드는 설정 파일에 외부 프로그램이 있으면 이 페이지 대신 그 프로그램으로 넘김)
+type ContactFetcher struct{}
+
+// Fetch: ContactFetcher의 Fetch 메서드 구현
+func (c *ContactFetcher) Fetch(u *url.URL) (*Response, error) {
+	var b strings.Builder
+	switch u.Scheme {
+	case url.SchemeMailto:
+		m, err := u.Mailto()
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("메일 보내기\n\n")
+		for _, field := range []struct {
+			name   string
+			values []string
+		}{{"받는 사람", m.To}, {"참조", m.Cc}, {"숨은 참조", m.Bcc}} {
+			if len(field.values) > 0 {
+				fmt.Fprintf(&b, "%s: %s\n", field.name, strings.Join(field.values, ", "))
+			}
+		}
+		if m.Subject != "" {
+			fmt.Fprintf(&b, "제목: %s\n", m.Subject)
+		}
+		if m.Body != "" {
+			fmt.Fprintf(&b, "\n%s\n", m.Body)
+		}
+	case url.SchemeTel:
+		number, err := u.Telephone()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "전화 걸기\n\n번호: %s\n", number)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 프로토콜: %s", u.Scheme)
+	}
+	return newResponse(u, 200, Header{"content-type": MIMETextPlain + "; charset=utf-8"}, b.String()), nil
+}
@@ -0,0 +1,385 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the cookie jar that stores Set-Cookie and sends Cookie headers.
+package net
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"io/fs"
+	stdnet "net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CookiesFileName은 프로필의 쿠키 디렉토리 안의 쿠키 파일 이름
+const CookiesFileName = "cookies.json"
+
+// Cookie는 쿠키 저장소에 있는 쿠키 하나 (RFC 6265)
+type Cookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`              // 쿠키를 보내는 도메인 (소문자, 앞의 "." 없음)
+	HostOnly bool      `json:"host_only,omitempty"` // Domain 속성 없이 받아서 Domain과 정확히 같은 호스트에만 보냄
+	Path     string    `json:"path"`
+	Expires  time.Time `json:"expires,omitzero"` // 만료 시각 (zero면 세션 쿠키, 파일에 저장하지 않음)
+	Secure   bool      `json:"secure,omitempty"`
+	HttpOnly bool      `json:"http_only,omitempty"`
+	SameSite string    `json:"same_site,omitempty"` // "Strict", "Lax", "None" ("" 이면 속성 없음)
+	Created  time.Time `json:"created"`
+
+	// Partition은 쿠키를 받을 때의 최상위 사이트 (FirstPartyIsolation이 꺼져 있을 때 받았으면 "")
+	Partition string `json:"partition,omitempty"`
+}
+
+// Session은 브라우저를 끄면 사라지는 세션 쿠키인지 확인함
+func (c Cookie) Session() bool {
+	return c.Expires.IsZero()
+}
+
+// expired는 now에 만료된 쿠키인지 확인함
+func (c Cookie) expired(now time.Time) bool {
+	return !c.Expires.IsZero() && !c.Expires.After(now)
+}
+
+// sameKey는 c와 o가 같은 쿠키 자리(최상위 사이트, 이름, 도메인, 경로)인지 확인함 (새 쿠키가 옛 쿠키를 덮어씀)
+func (c Cookie) sameKey(o Cookie) bool {
+	return c.Partition == o.Partition && c.Name == o.Name && c.Domain == o.Domain && c.Path == o.Path
+}
+
+// CookieJar는 응답의 Set-Cookie를 기록하고 요청에 Cookie 헤더를 붙이는 쿠키 저장소
+//
+// 만료 시각이 있는 쿠키만 파일에 저장함 (세션 쿠키는 메모리에만)
+// path가 비어 있으면 파일에 저장하지 않음 (테스트, 임시 사용)
+// FirstPartyIsolation이 켜져 있으면 요청마다 넘긴 최상위 페이지의 사이트별로 쿠키를 나눠 주고받음
+// CookieJar는 thread-safe하며 여러 goroutine에서 동시에 사용 가능함
+type CookieJar struct {
+	mu      sync.Mutex
+	path    string
+	cookies []Cookie
+}
+
+// GlobalCookieJar는 HTTPFetcher.Cookies가 nil일 때 쓰는 쿠키 저장소 (nil이면 쿠키를 주고받지 않음)
+var GlobalCookieJar *CookieJar
+
+// NewCookieJar는 파일에 저장하지 않는 빈 쿠키 저장소를 만듦
+func NewCookieJar() *CookieJar {
+	return &CookieJar{}
+}
+
+// OpenCookieJar는 path의 쿠키 파일을 읽어 CookieJar를 만듦 (파일이 없으면 빈 저장소, 만료된 쿠키는 버림)
+func OpenCookieJar(path string) (*CookieJar, error) {
+	j := &CookieJar{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("쿠키 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &j.cookies); err != nil {
+		return nil, fmt.Errorf("쿠키 파일 형식 오류 (%s): %w", path, err)
+	}
+	now := time.Now()
+	j.cookies = slices.DeleteFunc(j.cookies, func(c Cookie) bool { return c.expired(now) || c.Session() })
+	return j, nil
+}
+
+// SetCookies는 u에서 받은 응답의 Set-Cookie 헤더 값(들)을 기록함
+//
+// Header는 같은 이름의 헤더를 ", "로 합치므로 Expires 날짜 안의 쉼표와 구분해서 나눔
+// 다른 도메인을 가리키는 Domain 속성, http로 받은 Secure 쿠키는 무시함
+// 만료 시각이 지난 쿠키(Max-Age=0 등)는 같은 자리의 쿠키를 지움
+// u를 주소창에서 연 것으로 봄 (하위 리소스의 응답은 SetCookiesFor)
+func (j *CookieJar) SetCookies(u *url.URL, setCookie string) {
+	j.SetCookiesFor(nil, u, setCookie)
+}
+
+// SetCookiesFor는 topLevel 페이지가 불러온 u의 응답의 Set-Cookie를 기록함 (topLevel이 nil이면 SetCookies와 같음)
+//
+// FirstPartyIsolation이 켜져 있으면 topLevel의 사이트 몫에 둠
+func (j *CookieJar) SetCookiesFor(topLevel, u *url.URL, setCookie string) {
+	if j == nil || setCookie == "" || u.Host == "" {
+		return
+	}
+	host := strings.ToLower(u.Host)
+	now := time.Now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	changed := false
+	for _, line := range splitSetCookie(setCookie) {
+		parsed, err := http.ParseSetCookie(line)
+		if err != nil {
+			continue
+		}
+		c, ok := newCookie(parsed, u, host, now)
+		if !ok {
+			continue
+		}
+		c.Partition = partition(topLevel, host)
+		persistent := !c.Session()
+		i := slices.IndexFunc(j.cookies, c.sameKey)
+		if i >= 0 {
+			persistent = persistent || !j.cookies[i].Session()
+			c.Created = j.cookies[i].Created
+			j.cookies = slices.Delete(j.cookies, i, i+1)
+		}
+		if !c.expired(now) {
+			j.cookies = append(j.cookies, c)
+		}
+		changed = changed || persistent
+	}
+	if changed {
+		if err := j.save(); err != nil {
+			logger.Logger.Printf("쿠키 저장 실패: %v", err)
+		}
+	}
+}
+
+// newCookie는 host(u의 호스트)에서 받은 parsed를 저장할 Cookie로 바꿈 (받아들일 수 없으면 false)
+func newCookie(parsed *http.Cookie, u *url.URL, host string, now time.Time) (Cookie, bool) {
+	c := Cookie{
+		Name:     parsed.Name,
+		Value:    parsed.Value,
+		Domain:   host,
+		HostOnly: true,
+		Path:     parsed.Path,
+		Secure:   parsed.Secure,
+		HttpOnly: parsed.HttpOnly,
+		Created:  now,
+	}
+	if parsed.Secure && u.Scheme != url.SchemeHTTPS {
+		return Cookie{}, false
+	}
+	if domain := strings.TrimPrefix(strings.ToLower(parsed.Domain), "."); domain != "" {
+		// 최상위 도메인("com")이나 다른 사이트, IP 주소의 상위에는 쿠키를 둘 수 없음
+		if !domainMatch(host, domain) || !strings.Contains(domain, ".") || (domain != host && stdnet.ParseIP(host) != nil) {
+			return Cookie{}, false
+		}
+		c.Domain, c.HostOnly = domain, false
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		c.Path = defaultCookiePath(u.Path)
+	}
+	switch {
+	case parsed.MaxAge < 0:
+		c.Expires = now.Add(-time.Second)
+	case parsed.MaxAge > 0:
+		c.Expires = now.Add(time.Duration(parsed.MaxAge) * time.Second)
+	case !parsed.Expires.IsZero():
+		c.Expires = parsed.Expires
+	}
+	switch parsed.SameSite {
+	case http.SameSiteStrictMode:
+		c.SameSite = "Strict"
+	case http.SameSiteLaxMode:
+		c.SameSite = "Lax"
+	case http.SameSiteNoneMode:
+		c.SameSite = "None"
+	}
+	return c, true
+}
+
+// splitSetCookie는 ", "로 합쳐진 Set-Cookie 값을 쿠키 하나씩 나눔
+//
+// 쉼표 뒤에서 첫 ";"까지가 "이름=값" 꼴일 때만 새 쿠키로 봄
+// ("Expires=Wed, 21 Oct 2026 07:28:00 GMT"의 쉼표는 나누지 않음)
+func splitSetCookie(value string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] != ',' {
+			continue
+		}
+		rest := value[i+1:]
+		pair, _, _ := strings.Cut(rest, ";")
+		name, _, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(name) != "" && !strings.ContainsAny(strings.TrimSpace(name), " \t,") {
+			lines = append(lines, strings.TrimSpace(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(lines, strings.TrimSpace(value[start:]))
+}
+
+// defaultCookiePath는 Path 속성이 없을 때의 경로 (요청 경로의 마지막 "/"까지, RFC 6265 5.1.4)
+func defaultCookiePath(requestPath string) string {
+	requestPath, _, _ = strings.Cut(requestPath, "?")
+	i := strings.LastIndex(requestPath, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return requestPath[:i]
+}
+
+// domainMatch는 host가 domain이거나 그 하위 도메인인지 확인함
+func domainMatch(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// pathMatch는 요청 경로가 쿠키 경로 아래인지 확인함 (RFC 6265 5.1.4)
+func pathMatch(requestPath, cookiePath string) bool {
+	requestPath, _, _ = strings.Cut(requestPath, "?")
+	if !strings.HasPrefix(requestPath, cookiePath) {
+		return false
+	}
+	return len(requestPath) == len(cookiePath) || strings.HasSuffix(cookiePath, "/") || requestPath[len(cookiePath)] == '/'
+}
+
+// Cookies는 u로 보내는 요청에 붙일 쿠키들 (경로가 긴 것, 먼저 만든 것 순서)
+func (j *CookieJar) Cookies(u *url.URL) []Cookie {
+	return j.CookiesFor(nil, u)
+}
+
+// CookiesFor는 topLevel 페이지가 u로 보내는 요청에 붙일 쿠키들 (topLevel이 nil이면 Cookies와 같음)
+func (j *CookieJar) CookiesFor(topLevel, u *url.URL) []Cookie {
+	if j == nil || u.Host == "" {
+		return nil
+	}
+	host := strings.ToLower(u.Host)
+	now := time.Now()
+
+	site := partition(topLevel, host)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var matched []Cookie
+	for _, c := range j.cookies {
+		if c.Partition != site || c.expired(now) || (c.Secure && u.Scheme != url.SchemeHTTPS) || !pathMatch(u.Path, c.Path) {
+			continue
+		}
+		if (c.HostOnly && host != c.Domain) || (!c.HostOnly && !domainMatch(host, c.Domain)) {
+			continue
+		}
+		matched = append(matched, c)
+	}
+	slices.SortStableFunc(matched, func(a, b Cookie) int {
+		if n := cmp.Compare(len(b.Path), len(a.Path)); n != 0 {
+			return n
+		}
+		return a.Created.Compare(b.Created)
+	})
+	return matched
+}
+
+// partition은 topLevel 페이지가 host로 보내는 요청이 쓰는 쿠키 몫
+//
+// FirstPartyIsolation이 꺼져 있으면 "", topLevel이 nil이면 host 자신의 사이트
+// 탐색 중의 리다이렉트는 처음 연 주소를 topLevel로 넘겨서 그 사이트 몫으로 셈
+func partition(topLevel *url.URL, host string) string {
+	if !FirstPartyIsolation {
+		return ""
+	}
+	if topLevel != nil && topLevel.Host != "" {
+		return Site(topLevel.Host)
+	}
+	return Site(host)
+}
+
+// CookieHeader는 u로 보내는 요청의 Cookie 헤더 값 (보낼 쿠키가 없으면 "")
+func (j *CookieJar) CookieHeader(u *url.URL) string {
+	return j.CookieHeaderFor(nil, u)
+}
+
+// CookieHeaderFor는 topLevel 페이지가 u로 보내는 요청의 Cookie 헤더 값 (topLevel이 nil이면 CookieHeader와 같음)
+func (j *CookieJar) CookieHeaderFor(topLevel, u *url.URL) string {
+	var pairs []string
+	for _, c := range j.CookiesFor(topLevel, u) {
+		pairs = append(pairs, c.Name+"="+c.Value)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// All은 만료되지 않은 모든 쿠키 (도메인, 최상위 사이트, 경로, 이름 순서)
+func (j *CookieJar) All() []Cookie {
+	if j == nil {
+		return nil
+	}
+	now := time.Now()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	all := slices.DeleteFunc(slices.Clone(j.cookies), func(c Cookie) bool { return c.expired(now) })
+	slices.SortFunc(all, func(a, b Cookie) int {
+		return cmp.Or(cmp.Compare(a.Domain, b.Domain), cmp.Compare(a.Partition, b.Partition),
+			cmp.Compare(a.Path, b.Path), cmp.Compare(a.Name, b.Name))
+	})
+	return all
+}
+
+// Delete는 domain, path의 name 쿠키를 모든 최상위 사이트 몫에서 지우고 저장함 (없으면 false)
+func (j *CookieJar) Delete(domain, path, name string) (bool, error) {
+	return j.deleteFunc(func(c Cookie) bool { return c.Domain == domain && c.Path == path && c.Name == name })
+}
+
+// DeleteDomain은 domain과 그 하위 도메인의 쿠키를 모두 지우고 저장함 (지운 쿠키 수를 반환함)
+func (j *CookieJar) DeleteDomain(domain string) (int, error) {
+	domain = strings.TrimPrefix(strings.ToLower(domain), ".")
+	n := 0
+	_, err := j.deleteFunc(func(c Cookie) bool {
+		if domainMatch(c.Domain, domain) {
+			n++
+			return true
+		}
+		return false
+	})
+	return n, err
+}
+
+// Clear는 모든 쿠키를 지우고 저장함 (지운 쿠키 수를 반환함)
+func (j *CookieJar) Clear() (int, error) {
+	n := 0
+	_, err := j.deleteFunc(func(Cookie) bool { n++; return true })
+	return n, err
+}
+
+// deleteFunc는 del이 true인 쿠키를 지우고, 지운 것이 있으면 저장함
+func (j *CookieJar) deleteFunc(del func(c Cookie) bool) (bool, error) {
+	if j == nil {
+		return false, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	before := len(j.cookies)
+	j.cookies = slices.DeleteFunc(j.cookies, del)
+	if len(j.cookies) == before {
+		return false, nil
+	}
+	return true, j.save()
+}
+
+// save는 만료 시각이 있는 쿠키를 파일에 씀 (j.mu를 잡은 상태에서 호출)
+//
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰는 도중 종료되어도 기존 파일이 깨지지 않음
+func (j *CookieJar) save() error {
+	if j.path == "" {
+		return nil
+	}
+	now := time.Now()
+	persistent := slices.DeleteFunc(slices.Clone(j.cookies), func(c Cookie) bool { return c.Session() || c.expired(now) })
+	if persistent == nil {
+		persistent = []Cookie{}
+	}
+	data, err := json.MarshalIndent(persistent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("쿠키 저장 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return fmt.Errorf("쿠키 저장 실패: %w", err)
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("쿠키 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("쿠키 저장 실패: %w", err)
+	}
+	return nil
+}
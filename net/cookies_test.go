@@ -0,0 +1,230 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCookieJar 도메인, 경로, Secure에 맞는 쿠키만 보내고, ", "로 합친 Set-Cookie를 쿠키마다 나눠 기록함
+func TestCookieJar(t *testing.T) {
+	jar := net.NewCookieJar()
+	from, _ := url.NewURL("https://www.example.com/app/login")
+	expires := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	jar.SetCookies(from, "sid=1; Path=/; Secure; HttpOnly, lang=ko; Path=/; Domain=example.com; Expires="+expires+
+		", app=2, other=3; Domain=evil.test, tld=4; Domain=com")
+
+	tests := []struct {
+		rawURL string
+		want   string
+	}{
+		{"https://www.example.com/app/page", "app=2; sid=1; lang=ko"},
+		{"https://www.example.com/", "sid=1; lang=ko"},
+		{"http://www.example.com/app/x", "app=2; lang=ko"},
+		{"https://api.example.com/", "lang=ko"},
+		{"https://www.example.com/application", "sid=1; lang=ko"},
+		{"https://evil.test/", ""},
+	}
+	for _, tt := range tests {
+		u, _ := url.NewURL(tt.rawURL)
+		if got := jar.CookieHeader(u); got != tt.want {
+			t.Errorf("CookieHeader(%q) = %q; want %q", tt.rawURL, got, tt.want)
+		}
+	}
+
+	// Max-Age=0이면 같은 자리의 쿠키를 지움
+	jar.SetCookies(from, "sid=; Path=/; Max-Age=0; Secure")
+	u, _ := url.NewURL("https://www.example.com/")
+	if got := jar.CookieHeader(u); got != "lang=ko" {
+		t.Errorf("CookieHeader() after Max-Age=0 = %q; want %q", got, "lang=ko")
+	}
+}
+
+// TestCookieJar_Persist 만료 시각이 있는 쿠키만 파일에 남고, 도메인째 또는 하나씩 지울 수 있음
+func TestCookieJar_Persist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies", net.CookiesFileName)
+	jar, err := net.OpenCookieJar(path)
+	if err != nil {
+		t.Fatalf("OpenCookieJar() failed: %v", err)
+	}
+	from, _ := url.NewURL("http://a.example.com/")
+	jar.SetCookies(from, "keep=1; Max-Age=3600, session=2")
+	other, _ := url.NewURL("http://other.test/")
+	jar.SetCookies(other, "x=1; Max-Age=3600, y=2; Max-Age=3600")
+
+	reopened, err := net.OpenCookieJar(path)
+	if err != nil {
+		t.Fatalf("OpenCookieJar() failed: %v", err)
+	}
+	all := reopened.All()
+	if len(all) != 3 || all[0].Domain != "a.example.com" || all[0].Name != "keep" {
+		t.Fatalf("All() after reopening = %+v; want keep, x, y", all)
+	}
+
+	if n, err := reopened.DeleteDomain("example.com"); n != 1 || err != nil {
+		t.Errorf("DeleteDomain(example.com) = %d, %v; want 1, nil", n, err)
+	}
+	if ok, err := reopened.Delete("other.test", "/", "x"); !ok || err != nil {
+		t.Errorf("Delete(other.test, /, x) = %v, %v; want true, nil", ok, err)
+	}
+	reopened, _ = net.OpenCookieJar(path)
+	if all := reopened.All(); len(all) != 1 || all[0].Name != "y" {
+		t.Errorf("All() after deleting = %+v; want y only", all)
+	}
+}
+
+// TestHTTPFetcher_Cookies 응답의 Set-Cookie를 기록해서 다음 요청(리다이렉트 포함)에 보냄
+func TestHTTPFetcher_Cookies(t *testing.T) {
+	t.Parallel()
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc", Path: "/"})
+			http.Redirect(w, r, "/home", http.StatusFound)
+		case "/home":
+			got = r.Header.Get("Cookie")
+			w.Header().Set("Cache-Control", "no-store")
+			w.Write([]byte("home"))
+		}
+	}))
+	defer srv.Close()
+
+	fetcher := isolatedFetcher(t)
+	fetcher.Cookies = net.NewCookieJar()
+	u, _ := url.NewURL(srv.URL + "/login")
+	if _, err := fetcher.Fetch(u); err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if got != "sid=abc" {
+		t.Errorf("Cookie header after redirect = %q; want %q", got, "sid=abc")
+	}
+}
+
+// TestCookieJar_FirstPartyIsolation 켜면 최상위 사이트마다 쿠키를 따로 두어서 다른 사이트에서 받은 제3자 쿠키를 보내지 않음
+func TestCookieJar_FirstPartyIsolation(t *testing.T) {
+	net.FirstPartyIsolation = true
+	defer func() { net.FirstPartyIsolation = false }()
+
+	jar := net.NewCookieJar()
+	tracker, _ := url.NewURL("https://tracker.test/pixel")
+	siteA, _ := url.NewURL("https://www.a.example/")
+	siteB, _ := url.NewURL("https://b.example/")
+
+	jar.SetCookiesFor(siteA, tracker, "id=a; Path=/")
+	if got := jar.CookieHeaderFor(siteA, tracker); got != "id=a" {
+		t.Errorf("CookieHeaderFor(a.example) = %q; want %q", got, "id=a")
+	}
+	if got := jar.CookieHeaderFor(siteB, tracker); got != "" {
+		t.Errorf("CookieHeaderFor(b.example) = %q; want none", got)
+	}
+	if got := jar.CookieHeader(tracker); got != "" {
+		t.Errorf("CookieHeader() on tracker.test itself = %q; want none", got)
+	}
+	jar.SetCookiesFor(siteB, tracker, "id=b; Path=/")
+	if all := jar.All(); len(all) != 2 || all[0].Partition != "a.example" || all[1].Partition != "b.example" {
+		t.Errorf("All() = %+v; want id=a in a.example and id=b in b.example", all)
+	}
+
+	// 끄면 나누지 않고 받은 쿠키만 주고받음
+	net.FirstPartyIsolation = false
+	if got := jar.CookieHeaderFor(siteA, tracker); got != "" {
+		t.Errorf("CookieHeaderFor() without isolation = %q; want none", got)
+	}
+}
+
+// TestHTTPFetcher_TopLevel 쿠키 저장소를 함께 쓰는 HTTPFetcher들이 동시에 요청해도 각자의 최상위 사이트 몫의 쿠키만 주고받음
+func TestHTTPFetcher_TopLevel(t *testing.T) {
+	net.FirstPartyIsolation = true
+	defer func() { net.FirstPartyIsolation = false }()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		if r.URL.Path == "/start" {
+			http.Redirect(w, r, srv.URL+"/pixel?id=nav", http.StatusFound)
+			return
+		}
+		// id 쿼리를 새 쿠키로 주고 받은 쿠키를 돌려줌
+		http.SetCookie(w, &http.Cookie{Name: "id", Value: r.URL.Query().Get("id"), Path: "/"})
+		w.Write([]byte(r.Header.Get("Cookie")))
+	}))
+	defer srv.Close()
+
+	jar := net.NewCookieJar()
+	siteA, _ := url.NewURL("https://a.example/")
+	siteB, _ := url.NewURL("https://b.example/")
+	a, b := isolatedFetcher(t), isolatedFetcher(t)
+	a.Cookies, a.TopLevel = jar, siteA
+	b.Cookies, b.TopLevel = jar, siteB
+
+	var wg sync.WaitGroup
+	for _, tt := range []struct {
+		fetcher *net.HTTPFetcher
+		id      string
+	}{{a, "a"}, {b, "b"}} {
+		wg.Go(func() {
+			u, _ := url.NewURL(srv.URL + "/pixel?id=" + tt.id)
+			for i := range 20 {
+				body, err := requestWith(tt.fetcher, u)
+				if err != nil {
+					t.Errorf("Fetch(%s) failed: %v", tt.id, err)
+					return
+				}
+				if want := "id=" + tt.id; i > 0 && body != want {
+					t.Errorf("Cookie sent for %s.example = %q; want %q", tt.id, body, want)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	// TopLevel이 없으면 Fetch에 넘긴 주소가 최상위 페이지이고, 리다이렉트된 주소도 그 사이트 몫으로 셈
+	nav := isolatedFetcher(t)
+	nav.Cookies = jar
+	start, _ := url.NewURL(strings.Replace(srv.URL, "127.0.0.1", "localhost", 1) + "/start")
+	if _, err := nav.Fetch(start); err != nil {
+		t.Fatalf("Fetch(%s) failed: %v", start, err)
+	}
+	if nav.TopLevel != nil {
+		t.Errorf("TopLevel after Fetch() = %v; want nil (shared fetcher untouched)", nav.TopLevel)
+	}
+	var partitions []string
+	for _, c := range jar.All() {
+		partitions = append(partitions, c.Partition+":"+c.Value)
+	}
+	slices.Sort(partitions)
+	if want := []string{"a.example:a", "b.example:b", "localhost:nav"}; !slices.Equal(partitions, want) {
+		t.Errorf("partitions = %v; want %v", partitions, want)
+	}
+}
+
+// TestSite 호스트를 등록 가능한 도메인으로 묶음
+func TestSite(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"www.Example.com", "example.com"},
+		{"example.com.", "example.com"},
+		{"a.b.example.co.kr", "example.co.kr"},
+		{"example.co.uk", "example.co.uk"},
+		{"news.example.io", "example.io"},
+		{"localhost", "localhost"},
+		{"127.0.0.1", "127.0.0.1"},
+		{"::1", "::1"},
+	}
+	for _, tt := range tests {
+		if got := net.Site(tt.host); got != tt.want {
+			t.Errorf("Site(%q) = %q; want %q", tt.host, got, tt.want)
+		}
+	}
+}
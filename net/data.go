@@ -0,0 +1,106 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the percent-decoding and base64 rules for data: URLs.
+package net
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// parseDataURL은 data: URL의 본문("메타데이터,데이터")을 미디어 타입과 디코딩한 바이트로 나눔
+//
+// Fetch 표준의 data: URL 처리를 따름:
+//   - 데이터는 퍼센트 인코딩만 풀고 "+"는 그대로 둠 (쿼리 문자열이 아님)
+//   - 메타데이터가 ";base64"로 끝나면(대소문자 무시) 퍼센트 디코딩 후 공백을 빼고 base64로 풂
+//   - 미디어 타입이 없으면 text/plain, charset 등의 파라미터는 그대로 Content-Type으로 씀
+//
+// strict가 false면 잘못된 퍼센트 인코딩("%zz")은 글자 그대로 두고, base64의 빠진 패딩을 허용함
+func parseDataURL(s string, strict bool) (mediaType string, data []byte, err error) {
+	metadata, encoded, ok := strings.Cut(s, ",")
+	if !ok {
+		return "", nil, fmt.Errorf("data 스킴 형식이 잘못되었습니다 (쉼표 없음)")
+	}
+
+	mediaType = strings.TrimSpace(metadata)
+	isBase64 := false
+	if i := strings.LastIndex(mediaType, ";"); i != -1 && strings.EqualFold(strings.TrimSpace(mediaType[i+1:]), "base64") {
+		mediaType, isBase64 = strings.TrimSpace(mediaType[:i]), true
+	}
+	if mediaType == "" || strings.HasPrefix(mediaType, ";") {
+		mediaType = MIMETextPlain + mediaType
+	}
+
+	data, err = percentDecode(encoded, strict)
+	if err != nil {
+		return "", nil, err
+	}
+	if isBase64 {
+		if data, err = decodeDataBase64(data, strict); err != nil {
+			return "", nil, err
+		}
+	}
+	return mediaType, data, nil
+}
+
+// percentDecode는 "%XX"를 바이트로 바꿈 ("+"는 공백으로 바꾸지 않음)
+//
+// strict가 false면 뒤에 16진수 두 자리가 오지 않는 "%"는 그대로 둠 (URL 표준의 percent-decode)
+func percentDecode(s string, strict bool) ([]byte, error) {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' {
+			if i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+				out = append(out, unhex(s[i+1])<<4|unhex(s[i+2]))
+				i += 2
+				continue
+			}
+			if strict {
+				end := min(i+3, len(s))
+				return nil, fmt.Errorf("data URL의 퍼센트 인코딩이 잘못되었습니다: %q", s[i:end])
+			}
+		}
+		out = append(out, s[i])
+	}
+	return out, nil
+}
+
+// decodeDataBase64는 data: URL의 base64 데이터를 풂
+//
+// 공백(ASCII whitespace)은 빼고 읽고, strict가 아니면 "=" 패딩이 없어도 됨
+func decodeDataBase64(data []byte, strict bool) ([]byte, error) {
+	s := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\f', '\r':
+			return -1
+		}
+		return r
+	}, string(data))
+
+	enc := base64.StdEncoding
+	if !strict {
+		enc = base64.RawStdEncoding
+		s = strings.TrimRight(s, "=")
+	}
+	decoded, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode failed: %v", err)
+	}
+	return decoded, nil
+}
+
+// isHex는 16진수 숫자인지 확인함
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// unhex는 16진수 숫자 하나의 값
+func unhex(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c <= 'F':
+		return c - 'A' + 10
+	}
+	return c - 'a' + 10
+}
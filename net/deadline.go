@@ -0,0 +1,129 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the idle and total deadlines for request/response exchanges.
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// DefaultIdleTimeout은 GlobalTimeouts의 기본 유휴 시간 (이 시간 동안 한 바이트도 오지 않으면 포기함)
+const DefaultIdleTimeout = 30 * time.Second
+
+// Timeouts는 요청 하나를 주고받는 동안의 시간 제한
+//
+// Idle은 읽기(쓰기) 한 번마다 다시 시작하므로 느려도 꾸준히 오는 큰 본문은 끊지 않고,
+// 1분에 한 바이트씩 보내는 서버처럼 멈춰 있는 연결만 잡아냄
+// Total은 요청을 보내기 시작해서 본문을 다 읽을 때까지의 전체 시간
+// 연결(TCP, TLS 핸드셰이크) 시간은 Dialer의 타임아웃이 따로 맡음
+type Timeouts struct {
+	Idle  time.Duration // 읽기/쓰기 한 번을 기다리는 최대 시간 (0이면 제한 없음)
+	Total time.Duration // 요청 하나의 전체 시간 (0이면 제한 없음)
+}
+
+// GlobalTimeouts는 HTTPFetcher가 요청마다 거는 시간 제한
+var GlobalTimeouts = Timeouts{Idle: DefaultIdleTimeout}
+
+// TimeoutError는 Timeouts의 제한에 걸려서 요청을 포기했다는 에러
+//
+// net.Error의 Timeout()이 true라서 재시도 정책에서는 일시적인 실패로 봄
+type TimeoutError struct {
+	Idle    bool          // true면 유휴 시간 초과, false면 전체 시간 초과
+	Limit   time.Duration // 걸린 제한
+	Elapsed time.Duration // 요청을 시작한 뒤 흐른 시간
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Idle {
+		return fmt.Sprintf("서버가 %s 동안 응답하지 않음 (유휴 시간 초과, %s 경과)", e.Limit, e.Elapsed.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("요청이 %s 안에 끝나지 않음 (전체 시간 초과)", e.Limit)
+}
+
+// Timeout: net.Error 구현
+func (e *TimeoutError) Timeout() bool { return true }
+
+// Temporary: net.Error 구현
+func (e *TimeoutError) Temporary() bool { return true }
+
+// Unwrap은 os.ErrDeadlineExceeded (errors.Is로 확인할 수 있게 함)
+func (e *TimeoutError) Unwrap() error { return os.ErrDeadlineExceeded }
+
+// deadlineConn은 읽기/쓰기 한 번마다 Timeouts에 맞춰 데드라인을 다시 거는 연결
+//
+// 요청 하나 동안만 쓰고, 연결을 풀에 돌려주기 전에 clear로 데드라인을 지워야 함
+type deadlineConn struct {
+	net.Conn
+	timeouts Timeouts
+	start    time.Time
+}
+
+// withDeadlines는 conn을 t의 제한을 거는 deadlineConn으로 감쌈 (제한이 없으면 nil)
+func withDeadlines(conn net.Conn, t Timeouts) *deadlineConn {
+	if t.Idle <= 0 && t.Total <= 0 {
+		return nil
+	}
+	return &deadlineConn{Conn: conn, timeouts: t, start: time.Now()}
+}
+
+// deadline은 지금 걸어야 할 데드라인과 그것이 유휴 제한인지
+func (c *deadlineConn) deadline() (time.Time, bool) {
+	now := time.Now()
+	var d time.Time
+	idle := false
+	if c.timeouts.Idle > 0 {
+		d, idle = now.Add(c.timeouts.Idle), true
+	}
+	if c.timeouts.Total > 0 {
+		if total := c.start.Add(c.timeouts.Total); d.IsZero() || total.Before(d) {
+			d, idle = total, false
+		}
+	}
+	return d, idle
+}
+
+// Read는 유휴/전체 데드라인을 다시 걸고 읽음
+func (c *deadlineConn) Read(p []byte) (int, error) {
+	d, idle := c.deadline()
+	if err := c.Conn.SetReadDeadline(d); err != nil {
+		return 0, err
+	}
+	n, err := c.Conn.Read(p)
+	return n, c.wrapErr(err, idle)
+}
+
+// Write는 유휴/전체 데드라인을 다시 걸고 씀
+func (c *deadlineConn) Write(p []byte) (int, error) {
+	d, idle := c.deadline()
+	if err := c.Conn.SetWriteDeadline(d); err != nil {
+		return 0, err
+	}
+	n, err := c.Conn.Write(p)
+	return n, c.wrapErr(err, idle)
+}
+
+// wrapErr은 데드라인 초과를 TimeoutError로 바꿈
+func (c *deadlineConn) wrapErr(err error, idle bool) error {
+	if err == nil || !errors.Is(err, os.ErrDeadlineExceeded) {
+		return err
+	}
+	limit := c.timeouts.Total
+	if idle {
+		limit = c.timeouts.Idle
+	}
+	return &TimeoutError{Idle: idle, Limit: limit, Elapsed: time.Since(c.start)}
+}
+
+// limit은 지금부터 d 안에 끝나도록 전체 시간 제한을 다시 걸음 (남은 본문을 버릴 때)
+func (c *deadlineConn) limit(d time.Duration) {
+	c.start = time.Now()
+	c.timeouts.Total = d
+}
+
+// clear는 걸어 둔 데드라인을 지움 (풀에서 다음 요청이 쓸 수 있게)
+func (c *deadlineConn) clear() {
+	c.Conn.SetDeadline(time.Time{})
+}
@@ -0,0 +1,78 @@
+package net_test
+
+import (
+	"errors"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// setTimeouts: 테스트 동안만 GlobalTimeouts를 바꿈
+func setTimeouts(t *testing.T, timeouts net.Timeouts) {
+	t.Helper()
+	old := net.GlobalTimeouts
+	net.GlobalTimeouts = timeouts
+	t.Cleanup(func() { net.GlobalTimeouts = old })
+}
+
+// trickleServer: 본문을 interval마다 한 바이트씩 보내는 서버
+func trickleServer(t *testing.T, body string, interval time.Duration) *url.URL {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "5")
+		w.WriteHeader(200)
+		for i := 0; i < len(body); i++ {
+			io.WriteString(w, body[i:i+1])
+			w.(http.Flusher).Flush()
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.NewURL(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("NewURL() failed: %v", err)
+	}
+	return u
+}
+
+// TestTimeouts_Idle 바이트가 꾸준히 오면 유휴 시간보다 오래 걸려도 받고, 멈추면 유휴 시간 초과
+func TestTimeouts_Idle(t *testing.T) {
+	setTimeouts(t, net.Timeouts{Idle: 100 * time.Millisecond})
+
+	// 30ms마다 한 바이트: 전체로는 유휴 시간을 넘지만 한 번도 멈추지 않음
+	resp, err := (&net.HTTPFetcher{}).Fetch(trickleServer(t, "hello", 30*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "hello" {
+		t.Errorf("Fetch().Body = %q; want %q", resp.Body, "hello")
+	}
+
+	_, err = (&net.HTTPFetcher{}).Fetch(trickleServer(t, "hello", time.Second))
+	var timeout *net.TimeoutError
+	if !errors.As(err, &timeout) || !timeout.Idle {
+		t.Fatalf("Fetch() from stalled server error = %v; want idle TimeoutError", err)
+	}
+}
+
+// TestTimeouts_Total 꾸준히 오더라도 전체 시간을 넘으면 포기함
+func TestTimeouts_Total(t *testing.T) {
+	setTimeouts(t, net.Timeouts{Idle: time.Second, Total: 60 * time.Millisecond})
+
+	_, err := (&net.HTTPFetcher{}).Fetch(trickleServer(t, "hello", 30*time.Millisecond))
+	var timeout *net.TimeoutError
+	if !errors.As(err, &timeout) || timeout.Idle {
+		t.Fatalf("Fetch() error = %v; want total TimeoutError", err)
+	}
+	if timeout.Limit != 60*time.Millisecond {
+		t.Errorf("TimeoutError.Limit = %v; want %v", timeout.Limit, 60*time.Millisecond)
+	}
+}
@@ -0,0 +1,77 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the Dialer abstraction used by HTTPFetcher to open connections.
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// Dialer는 HTTPFetcher가 새 연결을 여는 방법을 추상화함
+//
+// 테스트용 TLS 설정, 프록시 같은 커스텀 전송, Unix 소켓 연결 등을
+// HTTPFetcher 코드를 바꾸지 않고 끼워 넣을 수 있음
+type Dialer interface {
+	// DialContext는 평문 연결(http)을 엶
+	DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+	// DialTLSContext는 TLS 핸드셰이크까지 마친 연결(https)을 엶
+	DialTLSContext(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// DefaultDialTimeout은 DefaultDialer의 연결 타임아웃
+const DefaultDialTimeout = 30 * time.Second
+
+// DefaultSessionCacheSize는 DefaultSessionCache가 기억하는 TLS 세션 수
+const DefaultSessionCacheSize = 256
+
+// DefaultSessionCache는 NetDialer.SessionCache가 nil일 때 쓰는 TLS 세션 캐시
+//
+// 서버가 준 세션 티켓을 기억해 두었다가 같은 서버에 다시 연결할 때 보내서
+// 인증서 교환과 키 합의를 건너뛰는 짧은 핸드셰이크(세션 재개)를 함
+var DefaultSessionCache = tls.NewLRUClientSessionCache(DefaultSessionCacheSize)
+
+// NetDialer는 표준 net.Dialer와 tls.Dialer를 사용하는 기본 Dialer 구현
+type NetDialer struct {
+	Timeout   time.Duration // 연결 타임아웃 (0이면 제한 없음)
+	TLSConfig *tls.Config   // https 연결에 사용할 TLS 설정 (nil이면 기본값, ServerName은 addr의 호스트)
+
+	// SessionCache는 TLS 세션 재개에 쓸 캐시 (nil이면 DefaultSessionCache)
+	// TLSConfig.ClientSessionCache가 있으면 그것을 씀
+	SessionCache tls.ClientSessionCache
+}
+
+// DialContext: NetDialer의 평문 연결 구현
+func (d *NetDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: d.Timeout}
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// DialTLSContext: NetDialer의 TLS 연결 구현
+func (d *NetDialer) DialTLSContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: d.Timeout},
+		Config:    d.tlsConfig(),
+	}
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// tlsConfig는 TLSConfig에 세션 캐시를 채운 설정 (TLSConfig는 바꾸지 않음)
+func (d *NetDialer) tlsConfig() *tls.Config {
+	if d.TLSConfig != nil && d.TLSConfig.ClientSessionCache != nil {
+		return d.TLSConfig
+	}
+	config := &tls.Config{}
+	if d.TLSConfig != nil {
+		config = d.TLSConfig.Clone()
+	}
+	config.ClientSessionCache = d.SessionCache
+	if config.ClientSessionCache == nil {
+		config.ClientSessionCache = DefaultSessionCache
+	}
+	return config
+}
+
+// DefaultDialer는 HTTPFetcher.Dialer가 nil일 때 사용하는 Dialer
+var DefaultDialer Dialer = &NetDialer{Timeout: DefaultDialTimeout}
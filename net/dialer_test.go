@@ -0,0 +1,140 @@
+package net_test
+
+import (
+	"context"
+	"crypto/tls"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// ============================================
+// Dialer 주입 테스트
+// ============================================
+
+// countingDialer: 연결 요청 횟수를 세는 Dialer
+type countingDialer struct {
+	net.NetDialer
+	plain, secure atomic.Int32
+}
+
+func (d *countingDialer) DialContext(ctx context.Context, network, addr string) (stdnet.Conn, error) {
+	d.plain.Add(1)
+	return d.NetDialer.DialContext(ctx, network, addr)
+}
+
+func (d *countingDialer) DialTLSContext(ctx context.Context, network, addr string) (stdnet.Conn, error) {
+	d.secure.Add(1)
+	return d.NetDialer.DialTLSContext(ctx, network, addr)
+}
+
+// TestHTTPFetcher_CustomDialerHTTPS 테스트 서버 인증서를 신뢰하는 Dialer로 실제 HTTPS 요청
+func TestHTTPFetcher_CustomDialerHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+	dialer := &countingDialer{NetDialer: net.NetDialer{TLSConfig: tlsConfig}}
+	fetcher := &net.HTTPFetcher{Dialer: dialer}
+
+	u, err := url.NewURL(server.URL + "/tls")
+	if err != nil {
+		t.Fatalf("url.NewURL(%q) failed: %v", server.URL, err)
+	}
+
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "secure" {
+		t.Errorf("Body = %q; want %q", resp.Body, "secure")
+	}
+	if dialer.secure.Load() != 1 || dialer.plain.Load() != 0 {
+		t.Errorf("dials = %d TLS, %d plain; want 1, 0", dialer.secure.Load(), dialer.plain.Load())
+	}
+}
+
+// TestHTTPFetcher_DefaultDialerRejectsUnknownCA 기본 Dialer는 신뢰하지 않는 인증서를 거부
+func TestHTTPFetcher_DefaultDialerRejectsUnknownCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/untrusted")
+	if _, err := (&net.HTTPFetcher{}).Fetch(u); err == nil {
+		t.Error("Fetch() should fail for a certificate signed by an unknown CA")
+	}
+}
+
+// TestHTTPFetcher_TLSResumption 같은 서버에 새로 연결하면 세션을 재개하고 풀 통계에 기록함
+func TestHTTPFetcher_TLSResumption(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+
+	fetcher := isolatedFetcher(t)
+	fetcher.Dialer = &net.NetDialer{
+		TLSConfig:    server.Client().Transport.(*http.Transport).TLSClientConfig,
+		SessionCache: tls.NewLRUClientSessionCache(8),
+	}
+	address := server.Listener.Addr().String()
+	u, _ := url.NewURL(server.URL + "/resume")
+
+	var resumed []bool
+	for i := 0; i < 2; i++ {
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch() #%d failed: %v", i+1, err)
+		}
+		resumed = append(resumed, resp.Security.Resumed)
+		fetcher.Pool.Close(address) // 다음 요청은 새 연결로
+	}
+
+	if resumed[0] || !resumed[1] {
+		t.Errorf("Security.Resumed = %v; want [false true]", resumed)
+	}
+	s := fetcher.Pool.Stats()[address]
+	if s.TLSFull != 1 || s.TLSResumed != 1 {
+		t.Errorf("Stats()[%q] TLSFull, TLSResumed = %d, %d; want 1, 1", address, s.TLSFull, s.TLSResumed)
+	}
+}
+
+// TestHTTPFetcher_AllowInsecureCertificate 허용하면 검증에 실패한 인증서로도 연결하고 SecurityInfo에 표시함
+func TestHTTPFetcher_AllowInsecureCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("self-signed"))
+	}))
+	defer server.Close()
+
+	var asked []string
+	net.AllowInsecureCertificate = func(u *url.URL, err error) bool {
+		asked = append(asked, u.Path)
+		return u.Path == "/allowed"
+	}
+	defer func() { net.AllowInsecureCertificate = nil }()
+
+	denied, _ := url.NewURL(server.URL + "/denied")
+	if _, err := isolatedFetcher(t).Fetch(denied); err == nil {
+		t.Error("Fetch() should fail when the certificate is not allowed")
+	}
+	allowed, _ := url.NewURL(server.URL + "/allowed")
+	resp, err := isolatedFetcher(t).Fetch(allowed)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "self-signed" || resp.Security == nil || !resp.Security.Unverified {
+		t.Errorf("Body = %q, Security = %+v; want the body and Unverified", resp.Body, resp.Security)
+	}
+	if len(asked) != 2 {
+		t.Errorf("AllowInsecureCertificate called for %v; want /denied, /allowed", asked)
+	}
+}
@@ -0,0 +1,110 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the internal HTML error pages shown when a page cannot be displayed.
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"go-web-browser/url"
+	"html/template"
+	"strings"
+)
+
+// ErrorKind는 에러 페이지의 종류
+type ErrorKind int
+
+// 에러 페이지 종류
+const (
+	ErrorKindNetwork     ErrorKind = iota // 연결, DNS, 시간 초과 등 네트워크 문제
+	ErrorKindTLS                          // 인증서 검증, 핀 불일치, 폐기된 인증서
+	ErrorKindNotFound                     // 404 Not Found
+	ErrorKindHTTP                         // 그 외 4xx, 5xx 응답
+	ErrorKindUnsupported                  // 표시할 수 없는 콘텐츠 (이미지, 압축 파일 등)
+	ErrorKindLookalike                    // 잘 알려진 사이트를 흉내 낸 것처럼 보이는 주소 (LookalikeError)
+)
+
+// errorTitles는 종류별 제목과 안내 문구
+var errorTitles = map[ErrorKind][2]string{
+	ErrorKindNetwork:     {"사이트에 연결할 수 없음", "서버에 연결하지 못했습니다. 주소가 맞는지, 네트워크가 연결되어 있는지 확인하세요."},
+	ErrorKindTLS:         {"안전하게 연결할 수 없음", "서버의 인증서를 신뢰할 수 없어서 연결을 중단했습니다. 주고받는 내용을 다른 사람이 볼 수 있습니다."},
+	ErrorKindNotFound:    {"페이지를 찾을 수 없음", "서버에 이 주소의 페이지가 없습니다 (404). 주소가 바뀌었거나 삭제되었을 수 있습니다."},
+	ErrorKindHTTP:        {"페이지를 표시할 수 없음", "서버가 에러로 응답했습니다."},
+	ErrorKindUnsupported: {"표시할 수 없는 콘텐츠", "이 브라우저는 이 형식의 콘텐츠를 표시할 수 없습니다. 파일로 저장하거나 외부 프로그램으로 여세요."},
+	ErrorKindLookalike:   {"가짜 사이트일 수 있음", "이 주소는 잘 알려진 사이트와 비슷해 보여서 열기 전에 멈췄습니다. 주소를 다시 확인하세요. 믿을 수 있는 사이트면 about:site-settings에서 이 사이트의 비슷한 주소 경고 무시를 허용한 뒤 다시 시도하세요."},
+}
+
+// errorTemplate은 모든 에러 페이지가 쓰는 HTML 틀 (다른 문서와 같은 파싱, 레이아웃, 렌더링을 거침)
+var errorTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Message}}</p>
+{{if .Detail}}<p><code>{{.Detail}}</code></p>
+{{end}}<p>주소: {{.URL}}</p>
+{{if .Retry}}<p><a href="{{.Href}}">다시 시도</a></p>
+{{end}}</body>
+</html>
+`))
+
+// ClassifyError는 Fetch 에러를 에러 페이지 종류로 나눔 (TLS, 비슷한 주소 경고가 아니면 ErrorKindNetwork)
+func ClassifyError(err error) ErrorKind {
+	var (
+		lookalike        *LookalikeError
+		unknownAuthority x509.UnknownAuthorityError
+		invalid          x509.CertificateInvalidError
+		hostname         x509.HostnameError
+		verification     *tls.CertificateVerificationError
+		header           tls.RecordHeaderError
+		mismatch         *PinMismatchError
+		revoked          *RevokedError
+	)
+	switch {
+	case errors.As(err, &lookalike):
+		return ErrorKindLookalike
+	case errors.As(err, &unknownAuthority), errors.As(err, &invalid), errors.As(err, &hostname),
+		errors.As(err, &verification), errors.As(err, &header), errors.As(err, &mismatch), errors.As(err, &revoked):
+		return ErrorKindTLS
+	}
+	return ErrorKindNetwork
+}
+
+// ErrorPage는 u를 가져오다 err로 실패했을 때 보여줄 내부 HTML 문서 (다시 시도 링크 포함)
+func ErrorPage(u *url.URL, err error) *Response {
+	return errorPage(u, ClassifyError(err), err.Error(), true)
+}
+
+// StatusPage는 resp가 본문 없는 4xx, 5xx 응답이면 대신 보여줄 에러 페이지를 만듦
+//
+// 서버가 보낸 에러 페이지가 있으면 그것을 보여줘야 하므로 false
+func StatusPage(resp *Response) (*Response, bool) {
+	if resp.StatusCode < 400 || strings.TrimSpace(resp.Body) != "" {
+		return nil, false
+	}
+	kind := ErrorKindHTTP
+	if resp.StatusCode == 404 {
+		kind = ErrorKindNotFound
+	}
+	page := errorPage(resp.URL, kind, fmt.Sprintf("HTTP %d", resp.StatusCode), resp.StatusCode >= 500)
+	page.StatusCode = resp.StatusCode
+	return page, true
+}
+
+// UnsupportedPage는 표시할 수 없는 콘텐츠(resp) 대신 보여줄 에러 페이지
+func UnsupportedPage(resp *Response) *Response {
+	return errorPage(resp.URL, ErrorKindUnsupported, fmt.Sprintf("%s, %d 바이트", resp.ContentType, len(resp.Body)), false)
+}
+
+// errorPage는 에러 페이지 Response를 만듦 (URL은 요청한 주소 그대로)
+func errorPage(u *url.URL, kind ErrorKind, detail string, retry bool) *Response {
+	title := errorTitles[kind]
+	var b strings.Builder
+	errorTemplate.Execute(&b, struct {
+		Title, Message, Detail, URL string
+		Href                        template.URL // 사용자가 요청한 주소라서 file:, about: 등도 그대로 씀
+		Retry                       bool
+	}{title[0], title[1], detail, u.Redacted(), template.URL(u.String()), retry})
+	return newResponse(u, 0, Header{"content-type": MIMETextHTML + "; charset=utf-8"}, b.String())
+}
@@ -0,0 +1,77 @@
+package net_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// TestClassifyError 인증서 관련 에러는 TLS, 나머지는 네트워크 에러 페이지
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want net.ErrorKind
+	}{
+		{"unknown authority", fmt.Errorf("dial: %w", x509.UnknownAuthorityError{}), net.ErrorKindTLS},
+		{"hostname", x509.HostnameError{Host: "example.com", Certificate: &x509.Certificate{}}, net.ErrorKindTLS},
+		{"pin mismatch", &net.PinMismatchError{Address: "example.com:443"}, net.ErrorKindTLS},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), net.ErrorKindNetwork},
+		{"timeout", &net.TimeoutError{Idle: true}, net.ErrorKindNetwork},
+	}
+	for _, tt := range tests {
+		if got := net.ClassifyError(tt.err); got != tt.want {
+			t.Errorf("ClassifyError(%s) = %d; want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestErrorPage 에러 페이지는 HTML 문서이고 제목, 에러 내용, 다시 시도 링크를 담음
+func TestErrorPage(t *testing.T) {
+	u, _ := url.NewURL("http://example.com/a?b=<c>")
+	resp := net.ErrorPage(u, errors.New("connection refused <x>"))
+
+	if resp.ContentType != net.MIMETextHTML || resp.URL != u {
+		t.Errorf("ErrorPage() ContentType, URL = %q, %v; want %q, %v", resp.ContentType, resp.URL, net.MIMETextHTML, u)
+	}
+	for _, want := range []string{
+		"<title>사이트에 연결할 수 없음</title>",
+		"connection refused &lt;x&gt;",
+		`<a href="http://example.com/a?b=%3cc%3e">다시 시도</a>`,
+	} {
+		if !strings.Contains(resp.Body, want) {
+			t.Errorf("ErrorPage() body missing %q:\n%s", want, resp.Body)
+		}
+	}
+}
+
+// TestStatusPage 본문 없는 4xx, 5xx 응답만 에러 페이지로 바꿈
+func TestStatusPage(t *testing.T) {
+	u, _ := url.NewURL("http://example.com/missing")
+	tests := []struct {
+		status    int
+		body      string
+		wantOK    bool
+		wantTitle string
+	}{
+		{404, "", true, "페이지를 찾을 수 없음"},
+		{503, " \n", true, "페이지를 표시할 수 없음"},
+		{404, "<h1>custom 404</h1>", false, ""},
+		{200, "", false, ""},
+	}
+	for _, tt := range tests {
+		page, ok := net.StatusPage(&net.Response{URL: u, StatusCode: tt.status, Body: tt.body})
+		if ok != tt.wantOK {
+			t.Errorf("StatusPage(%d, %q) ok = %v; want %v", tt.status, tt.body, ok, tt.wantOK)
+			continue
+		}
+		if ok && (page.StatusCode != tt.status || !strings.Contains(page.Body, "<h1>"+tt.wantTitle+"</h1>")) {
+			t.Errorf("StatusPage(%d) = %d %q; want status %d and title %q", tt.status, page.StatusCode, page.Body, tt.status, tt.wantTitle)
+		}
+	}
+}
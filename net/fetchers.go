@@ -3,28 +3,32 @@
 package net
 
 import (
-	"encoding/base64"
 	"fmt"
 	"go-web-browser/logger"
 	"go-web-browser/url"
-	stdurl "net/url"
+	"mime"
 	"os"
-	"strings"
+	"path/filepath"
 )
 
 // Fetcher 인터페이스: URL에서 콘텐츠를 가져오는 역할을 추상화
 type Fetcher interface {
-	Fetch(u *url.URL) (string, error)
+	Fetch(u *url.URL) (*Response, error)
 }
 
 // FileFetcher: file:// 스킴을 처리하는 Fetcher 구현
 type FileFetcher struct{}
 
 // DataFetcher: data:// 스킴을 처리하는 Fetcher 구현
-type DataFetcher struct{}
+type DataFetcher struct {
+	Strict bool // true면 잘못된 퍼센트 인코딩과 패딩이 빠진 base64를 에러로 처리함
+}
 
 // ViewSourceFetcher: view-source:// 스킴을 처리하는 Fetcher 구현
-type ViewSourceFetcher struct{}
+type ViewSourceFetcher struct {
+	// Headers가 true면 소스 앞에 상태 줄, 응답 헤더, 리다이렉트된 최종 URL을 주석으로 붙임 (프로토콜 디버깅용)
+	Headers bool
+}
 
 // FetcherRegistry: scheme에 따른 Fetcher를 등록하는 레지스트리
 var FetcherRegistry = map[url.Scheme]Fetcher{
@@ -32,20 +36,35 @@ var FetcherRegistry = map[url.Scheme]Fetcher{
 	url.SchemeData:       &DataFetcher{},
 	url.SchemeHTTP:       &HTTPFetcher{},
 	url.SchemeHTTPS:      &HTTPFetcher{},
+	url.SchemeHTTPUnix:   &HTTPFetcher{},
 	url.SchemeViewSource: &ViewSourceFetcher{},
+	url.SchemeAbout:      &AboutFetcher{},
+	url.SchemeMailto:     &ContactFetcher{},
+	url.SchemeTel:        &ContactFetcher{},
+	url.SchemeIPFS:       &IPFSFetcher{},
+	url.SchemeIPNS:       &IPFSFetcher{},
 }
 
-// Request: URL에서 콘텐츠를 가져오는 함수
-func Request(u *url.URL) (string, error) {
+// Fetch: URL에서 응답(상태 코드, 헤더, 본문, MIME 타입)을 가져오는 함수
+func Fetch(u *url.URL) (*Response, error) {
 	fetcher, ok := FetcherRegistry[u.Scheme]
 	if !ok {
-		return "", fmt.Errorf("지원하지 않는 프로토콜: %s", u.Scheme)
+		return nil, fmt.Errorf("지원하지 않는 프로토콜: %s", u.Scheme)
 	}
 	return fetcher.Fetch(u)
 }
 
+// Request: URL에서 콘텐츠(본문)만 가져오는 함수
+func Request(u *url.URL) (string, error) {
+	resp, err := Fetch(u)
+	if err != nil {
+		return "", err
+	}
+	return resp.Body, nil
+}
+
 // Fetch: FileFetcher의 Fetch 메서드 구현
-func (f *FileFetcher) Fetch(u *url.URL) (string, error) {
+func (f *FileFetcher) Fetch(u *url.URL) (*Response, error) {
 	filePath := u.Path
 
 	// Windows 절대 경로 처리: /C:/path → C:/path
@@ -55,57 +74,46 @@ func (f *FileFetcher) Fetch(u *url.URL) (string, error) {
 
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file: %v", err)
+		return nil, fmt.Errorf("failed to read file: %v", err)
 	}
 
 	logger.Logger.Printf("Read file: %s", filePath)
-	return string(content), nil
-}
-
-// Fetch: DataFetcher의 Fetch 메서드 구현
-func (d *DataFetcher) Fetch(u *url.URL) (string, error) {
-	dataStr := u.Path
 
-	commaIdx := strings.Index(dataStr, ",")
-	if commaIdx == -1 {
-		return "", fmt.Errorf("data 스킴 형식이 잘못되었습니다 (쉼표 없음)")
+	// 파일은 헤더가 없으므로 확장자로 Content-Type 추정 (모르면 스니핑)
+	headers := make(Header)
+	if contentType := mime.TypeByExtension(filepath.Ext(filePath)); contentType != "" {
+		headers.Set("Content-Type", contentType)
 	}
+	return newResponse(u, 200, headers, string(content)), nil
+}
 
-	metadata := dataStr[:commaIdx]
-	data := dataStr[commaIdx+1:]
-
-	if strings.Contains(metadata, ";base64") {
-		decoded, err := base64.StdEncoding.DecodeString(data)
-		if err != nil {
-			return "", fmt.Errorf("base64 decode failed: %v", err)
-		}
-		data = string(decoded)
-		logger.Logger.Println("Decoded base64 data URL")
-	} else {
-		decoded, err := stdurl.QueryUnescape(data)
-		if err != nil {
-			decoded = data
-		}
-		data = decoded
-		logger.Logger.Println("Decoded URL-encoded data URL")
+// Fetch: DataFetcher의 Fetch 메서드 구현
+//
+// 본문 바이트는 미디어 타입의 charset 파라미터(예: text/html;charset=euc-kr)에 따라 newResponse가 디코딩함
+func (d *DataFetcher) Fetch(u *url.URL) (*Response, error) {
+	mediaType, data, err := parseDataURL(u.Path, d.Strict)
+	if err != nil {
+		return nil, err
 	}
+	logger.Logger.Printf("Decoded data URL (%s, %d bytes)", mediaType, len(data))
 
-	return data, nil
+	headers := Header{"content-type": mediaType}
+	return newResponse(u, 200, headers, string(data)), nil
 }
 
 // Fetch: ViewSourceFetcher의 Fetch 메서드 구현
-func (v *ViewSourceFetcher) Fetch(u *url.URL) (string, error) {
+func (v *ViewSourceFetcher) Fetch(u *url.URL) (*Response, error) {
 	// Path에는 내부 URL 전체가 들어있음 (예: "http://example.org/")
 	innerURLStr := u.Path
 
 	if innerURLStr == "" {
-		return "", fmt.Errorf("view-source: 내부 URL이 없습니다")
+		return nil, fmt.Errorf("view-source: 내부 URL이 없습니다")
 	}
 
 	// 내부 URL 파싱
 	innerURL, err := url.NewURL(innerURLStr)
 	if err != nil {
-		return "", fmt.Errorf("view-source: 내부 URL 파싱 실패: %v", err)
+		return nil, fmt.Errorf("view-source: 내부 URL 파싱 실패: %v", err)
 	}
 
 	// 내부 URL로 콘텐츠 가져오기 (원본 그대로 반환)
@@ -114,14 +122,19 @@ func (v *ViewSourceFetcher) Fetch(u *url.URL) (string, error) {
 	// 해결책: Request()를 별도로 처리하거나, ViewSourceFetcher가 직접 FetcherRegistry 사용
 	fetcher, ok := FetcherRegistry[innerURL.Scheme]
 	if !ok {
-		return "", fmt.Errorf("지원하지 않는 프로토콜: %s", innerURL.Scheme)
+		return nil, fmt.Errorf("지원하지 않는 프로토콜: %s", innerURL.Scheme)
 	}
 
-	content, err := fetcher.Fetch(innerURL)
+	resp, err := fetcher.Fetch(innerURL)
 	if err != nil {
-		return "", fmt.Errorf("view-source: inner URL request failed: %v", err)
+		return nil, fmt.Errorf("view-source: inner URL request failed: %v", err)
 	}
 
 	logger.Logger.Println("view-source: returning raw source")
-	return content, nil
+	if v.Headers {
+		withPreamble := *resp
+		withPreamble.Body = sourcePreamble(innerURL.Redacted(), resp) + resp.Body
+		return &withPreamble, nil
+	}
+	return resp, nil
 }
@@ -0,0 +1,95 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the Header type that normalizes header name casing.
+package net
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+// Header는 HTTP 헤더 (이름 → 값)
+//
+// 이름은 대소문자를 구분하지 않으므로 키는 항상 CanonicalHeaderKey(소문자)로 저장함
+// 같은 이름이 여러 번 오면 ", "로 합친 한 값으로 둠 (RFC 9110 5.3, 목록이 아닌 헤더는 addField 참고)
+// 값을 읽고 쓸 때는 Get/Set/Add/Del을 쓰고, 키를 직접 넣을 때는 소문자로 넣어야 함
+type Header map[string]string
+
+// CanonicalHeaderKey는 헤더 이름을 Header의 키 형태(앞뒤 공백 제거, 소문자)로 바꿈
+//
+// 예: "Content-Type", "content-TYPE" → "content-type"
+func CanonicalHeaderKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Get은 name 헤더의 값 (없으면 "")
+func (h Header) Get(name string) string {
+	return h[CanonicalHeaderKey(name)]
+}
+
+// Has는 name 헤더가 있는지 확인함 (값이 ""여도 true)
+func (h Header) Has(name string) bool {
+	_, ok := h[CanonicalHeaderKey(name)]
+	return ok
+}
+
+// Set은 name 헤더를 value로 바꿈
+func (h Header) Set(name, value string) {
+	h[CanonicalHeaderKey(name)] = value
+}
+
+// Add는 name 헤더에 value를 덧붙임 (이미 있으면 ", "로 합침)
+func (h Header) Add(name, value string) {
+	key := CanonicalHeaderKey(name)
+	if prev, ok := h[key]; ok {
+		value = prev + ", " + value
+	}
+	h[key] = value
+}
+
+// singleValueHeaders는 목록으로 합칠 수 없는 헤더 (", "로 합치면 값이 깨짐)
+var singleValueHeaders = map[string]bool{
+	"content-length": true,
+	"content-type":   true,
+	"location":       true,
+	"host":           true,
+}
+
+// addField는 응답에서 읽은 헤더 줄 하나를 더함
+//
+// 목록 헤더는 Add처럼 ", "로 합치고, singleValueHeaders는 마지막 값만 남김 (같은 값이 되풀이되면 하나로)
+// 값이 다른 Content-Length가 여러 번 오면 본문 길이를 알 수 없으므로 에러 (RFC 9112 6.3)
+func (h Header) addField(name, value string) error {
+	key := CanonicalHeaderKey(name)
+	if !singleValueHeaders[key] {
+		h.Add(key, value)
+		return nil
+	}
+	if prev, ok := h[key]; ok && key == "content-length" && prev != value {
+		return fmt.Errorf("conflicting Content-Length: %q, %q", prev, value)
+	}
+	h[key] = value
+	return nil
+}
+
+// Del은 name 헤더를 지움
+func (h Header) Del(name string) {
+	delete(h, CanonicalHeaderKey(name))
+}
+
+// Clone은 h의 복사본 (h가 nil이면 nil)
+func (h Header) Clone() Header {
+	if h == nil {
+		return nil
+	}
+	out := make(Header, len(h))
+	for key, value := range h {
+		out[key] = value
+	}
+	return out
+}
+
+// wireHeaderKey는 요청 메시지에 쓸 헤더 이름 ("if-none-match" → "If-None-Match")
+func wireHeaderKey(key string) string {
+	return textproto.CanonicalMIMEHeaderKey(key)
+}
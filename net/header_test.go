@@ -0,0 +1,93 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"strings"
+	"testing"
+)
+
+// TestHeader 이름의 대소문자와 상관없이 같은 헤더를 읽고 쓰고 합침
+func TestHeader(t *testing.T) {
+	h := net.Header{}
+	h.Set("Content-Type", "text/html")
+	h.Add("set-COOKIE", "a=1")
+	h.Add("Set-Cookie", "b=2")
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"content-type", "text/html"},
+		{"CONTENT-TYPE", "text/html"},
+		{" Content-Type ", "text/html"},
+		{"Set-Cookie", "a=1, b=2"},
+		{"X-Missing", ""},
+	}
+	for _, tt := range tests {
+		if got := h.Get(tt.name); got != tt.want {
+			t.Errorf("Get(%q) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+
+	h.Del("CONTENT-type")
+	if h.Has("Content-Type") {
+		t.Errorf("Has(%q) after Del = true; want false", "Content-Type")
+	}
+}
+
+// TestParseResponse_HeaderCase 대소문자가 섞인 응답 헤더도 본문 길이와 청크 판단에 쓰임
+func TestParseResponse_HeaderCase(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"content-Length", "HTTP/1.1 200 OK\r\ncontent-Length: 5\r\n\r\nhello", "hello"},
+		{"transfer-Encoding", "HTTP/1.1 200 OK\r\ntransfer-Encoding: chunked\r\n\r\n5\r\nhello\r\n0\r\n\r\n", "hello"},
+	}
+	for _, tt := range tests {
+		_, body, headers, err := net.ParseResponse(strings.NewReader(tt.raw))
+		if err != nil {
+			t.Errorf("ParseResponse(%s) error = %v", tt.name, err)
+			continue
+		}
+		if body != tt.want {
+			t.Errorf("ParseResponse(%s) body = %q; want %q", tt.name, body, tt.want)
+		}
+		if !headers.Has(tt.name) {
+			t.Errorf("ParseResponse(%s) headers.Has(%q) = false; want true", tt.name, tt.name)
+		}
+	}
+}
+
+// TestParseResponse_RepeatedHeaders 목록이 아닌 헤더가 되풀이되면 마지막 값만 남기고, 값이 다른 Content-Length는 에러
+func TestParseResponse_RepeatedHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		header string
+		want   string
+		body   string
+	}{
+		{"same Content-Length", "HTTP/1.1 200 OK\r\nContent-Length: 5\r\nContent-Length: 5\r\n\r\nhello", "Content-Length", "5", "hello"},
+		{"repeated Location", "HTTP/1.1 302 Found\r\nLocation: /a\r\nLocation: /a\r\nContent-Length: 0\r\n\r\n", "Location", "/a", ""},
+		{"last Content-Type", "HTTP/1.1 200 OK\r\nContent-Type: text/plain\r\nContent-Type: text/html\r\nContent-Length: 2\r\n\r\nhi", "Content-Type", "text/html", "hi"},
+		{"repeated Host", "HTTP/1.1 200 OK\r\nHost: a.example\r\nHost: b.example\r\nContent-Length: 0\r\n\r\n", "Host", "b.example", ""},
+		{"list header", "HTTP/1.1 200 OK\r\nVary: Accept\r\nVary: Cookie\r\nContent-Length: 0\r\n\r\n", "Vary", "Accept, Cookie", ""},
+	}
+	for _, tt := range tests {
+		_, body, headers, err := net.ParseResponse(strings.NewReader(tt.raw))
+		if err != nil {
+			t.Errorf("ParseResponse(%s) error = %v", tt.name, err)
+			continue
+		}
+		if got := headers.Get(tt.header); got != tt.want || body != tt.body {
+			t.Errorf("ParseResponse(%s) %s = %q, body = %q; want %q, %q", tt.name, tt.header, got, body, tt.want, tt.body)
+		}
+	}
+
+	raw := "HTTP/1.1 200 OK\r\nContent-Length: 5\r\nContent-Length: 6\r\n\r\nhello!"
+	if _, _, _, err := net.ParseResponse(strings.NewReader(raw)); err == nil {
+		t.Error("ParseResponse() with conflicting Content-Length should return error")
+	}
+}
@@ -4,6 +4,7 @@ package net
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"go-web-browser/logger"
 	"io"
@@ -11,6 +12,42 @@ import (
 	"strings"
 )
 
+// ErrTruncatedBody is returned when the connection ends before the whole body
+// (as declared by Content-Length or the chunked framing) has arrived.
+//
+// It is reported together with io.ErrUnexpectedEOF, so the retry policy still
+// treats it as a transient failure.
+var ErrTruncatedBody = errors.New("response body truncated")
+
+// ErrExcessBody is returned along with the body when the server sent more bytes
+// than the framing declared (e.g., a Content-Length that is too small).
+//
+// The extra bytes are discarded and the body up to the declared length is kept,
+// but the connection is left in an undefined state and must not be reused.
+var ErrExcessBody = errors.New("response body longer than declared")
+
+// truncated reports an EOF in the middle of a body as ErrTruncatedBody.
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w (%w)", ErrTruncatedBody, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// excess checks for bytes left in reader after a complete body and discards them.
+//
+// Only bytes that already arrived can be seen; they are reported as ErrExcessBody.
+// Bytes that arrive later are caught when the idle connection is taken from the
+// pool (ConnectionPool.Get), so the connection is not reused either way.
+func excess(reader *bufio.Reader) error {
+	extra := reader.Buffered()
+	if extra == 0 {
+		return nil
+	}
+	reader.Discard(extra)
+	return fmt.Errorf("%w: discarded %d extra bytes", ErrExcessBody, extra)
+}
+
 // readChunkedBody reads an HTTP response body with Transfer-Encoding: chunked.
 //
 // Chunked encoding format:
@@ -20,8 +57,12 @@ import (
 //	<hex-size>\r\n
 //	<data>\r\n
 //	0\r\n
+//	<trailer fields>\r\n
 //	\r\n
 //
+// Header fields after the last chunk (the trailer section, e.g. Server-Timing
+// sent by a CDN once the body is done) are parsed like headers with opts.
+//
 // Example:
 //
 //	5\r\n
@@ -35,51 +76,122 @@ import (
 //
 // Returns:
 //   - body bytes
+//   - trailer fields (empty if there are none)
 //   - error if chunk parsing fails
-func readChunkedBody(reader *bufio.Reader) ([]byte, error) {
+func readChunkedBody(reader *bufio.Reader, opts ParseOptions) ([]byte, Header, error) {
 	var body []byte
 
 	for {
 		// 1. Read chunk size line (hex number + \r\n)
 		sizeLine, err := reader.ReadString('\n')
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunk size: %w", err)
+			return nil, nil, fmt.Errorf("failed to read chunk size: %w", truncated(err))
 		}
 
 		// 2. Parse hex size to decimal
 		sizeLine = strings.TrimSpace(sizeLine)
 		chunkSize, err := strconv.ParseInt(sizeLine, 16, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid chunk size %q: %w", sizeLine, err)
+			return nil, nil, fmt.Errorf("invalid chunk size %q: %w", sizeLine, err)
+		}
+		if chunkSize < 0 || chunkSize > maxChunkSize {
+			return nil, nil, fmt.Errorf("invalid chunk size %q", sizeLine)
 		}
 
 		logger.Logger.Printf("Read chunk size: %d (0x%s)", chunkSize, sizeLine)
 
-		// 3. If chunk size is 0, we're done
+		// 3. If chunk size is 0, we're done after the trailer section
+		// (ending with an empty line, which is all there is without trailers)
 		if chunkSize == 0 {
-			// Read trailing \r\n
-			reader.ReadString('\n')
-			break
+			trailers, err := readHeaders(reader, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read chunked trailer: %w", truncated(err))
+			}
+			return body, trailers, nil
 		}
 
 		// 4. Read chunk data (exactly chunkSize bytes)
 		chunkData := make([]byte, chunkSize)
 		_, err = io.ReadFull(reader, chunkData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunk data: %w", err)
+			return nil, nil, fmt.Errorf("failed to read chunk data: %w", truncated(err))
 		}
 
 		// 5. Read trailing \r\n after chunk data
 		_, err = reader.ReadString('\n')
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunk trailing CRLF: %w", err)
+			return nil, nil, fmt.Errorf("failed to read chunk trailing CRLF: %w", truncated(err))
 		}
 
 		// 6. Append to body
 		body = append(body, chunkData...)
 	}
+}
+
+// maxChunkSize is the largest chunk size accepted from a server.
+// readChunkedBody allocates the whole chunk up front, so a larger size line is
+// treated as malformed instead of allocating that much memory.
+const maxChunkSize = 64 << 20
+
+// chunkedReader decodes a Transfer-Encoding: chunked body as it arrives.
+//
+// Unlike readChunkedBody it does not wait for the last chunk, so it can be used
+// for streams that never end (e.g. text/event-stream).
+type chunkedReader struct {
+	r         *bufio.Reader
+	remaining int64 // bytes left in the current chunk
+	done      bool  // the last (zero-size) chunk has been read
+}
+
+// Read implements io.Reader.
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.done {
+		return 0, io.EOF
+	}
+	if c.remaining == 0 {
+		sizeLine, err := c.r.ReadString('\n')
+		if err != nil {
+			return 0, fmt.Errorf("failed to read chunk size: %w", truncated(err))
+		}
+		// Chunk extensions (";name=value") are ignored
+		sizeLine, _, _ = strings.Cut(strings.TrimSpace(sizeLine), ";")
+		size, err := strconv.ParseInt(sizeLine, 16, 64)
+		if err != nil || size < 0 || size > maxChunkSize {
+			return 0, fmt.Errorf("invalid chunk size %q", sizeLine)
+		}
+		if size == 0 {
+			// Skip the trailer section up to the final empty line,
+			// so the connection is positioned at the next response
+			for {
+				line, err := c.r.ReadString('\n')
+				if err != nil {
+					return 0, fmt.Errorf("failed to read chunked trailer: %w", truncated(err))
+				}
+				if strings.TrimSpace(line) == "" {
+					break
+				}
+			}
+			c.done = true
+			return 0, io.EOF
+		}
+		c.remaining = size
+	}
 
-	return body, nil
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if err == io.EOF {
+		return n, fmt.Errorf("failed to read chunk data: %w", truncated(err))
+	}
+	if c.remaining == 0 && err == nil {
+		// Trailing \r\n after chunk data
+		if _, err = c.r.ReadString('\n'); err != nil {
+			return n, fmt.Errorf("failed to read chunk trailing CRLF: %w", truncated(err))
+		}
+	}
+	return n, err
 }
 
 // readHeaders reads HTTP response headers from reader.
@@ -88,16 +200,24 @@ func readChunkedBody(reader *bufio.Reader) ([]byte, error) {
 // which signals the end of headers. Each header is parsed as "Key: Value"
 // and stored in a map.
 //
+// Reading stops with a *HeaderLimitError if a line, the total size or the
+// number of headers exceeds opts.Limits, and with a *SyntaxError in strict mode.
+//
 // Returns:
 //   - headers: map of header names to values
 //   - error: if header reading fails
-func readHeaders(reader *bufio.Reader) (map[string]string, error) {
-	headers := make(map[string]string)
+func readHeaders(reader *bufio.Reader, opts ParseOptions) (Header, error) {
+	headers := make(Header)
+	limits := opts.Limits
+	total, count := 0, 0
 
 	for {
-		line, err := reader.ReadString('\n')
+		line, err := readLine(reader, limits.MaxLineBytes)
 		if err != nil {
 			if err == io.EOF {
+				if opts.Strict {
+					return nil, &SyntaxError{Line: line, Reason: "빈 줄 없이 헤더가 끝남"}
+				}
 				break
 			}
 			return nil, fmt.Errorf("failed to read header: %w", err)
@@ -105,22 +225,44 @@ func readHeaders(reader *bufio.Reader) (map[string]string, error) {
 
 		// Empty line signals end of headers
 		if line == "\r\n" || line == "\n" {
+			if opts.Strict {
+				if err := checkLineEnding(line); err != nil {
+					return nil, err
+				}
+			}
 			break
 		}
 
+		if opts.Strict {
+			if err := checkHeaderLine(line); err != nil {
+				return nil, err
+			}
+		}
+
+		total += len(line)
+		count++
+		if limits.MaxTotalBytes > 0 && total > limits.MaxTotalBytes {
+			return nil, &HeaderLimitError{Err: ErrHeadersTooLarge, Limit: limits.MaxTotalBytes}
+		}
+		if limits.MaxCount > 0 && count > limits.MaxCount {
+			return nil, &HeaderLimitError{Err: ErrTooManyHeaders, Limit: limits.MaxCount}
+		}
+
 		// Parse "Key: Value" format
 		line = strings.TrimSpace(line)
 		colonIdx := strings.Index(line, ":")
 		if colonIdx > 0 {
-			key := strings.TrimSpace(line[:colonIdx])
-			value := strings.TrimSpace(line[colonIdx+1:])
-			// Normalize header names to lowercase (HTTP headers are case-insensitive)
-			headers[strings.ToLower(key)] = value
+			// addField normalizes the name (HTTP headers are case-insensitive) and
+			// combines repeated list headers into one comma-separated value (RFC 9110 5.3);
+			// single-value headers like Content-Length keep the last value
+			if err := headers.addField(line[:colonIdx], strings.TrimSpace(line[colonIdx+1:])); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// Log Connection header for Keep-Alive debugging
-	if connHeader, ok := headers["connection"]; ok {
+	if connHeader := headers.Get("Connection"); connHeader != "" {
 		logger.Logger.Printf("Server Connection header: %s", connHeader)
 	}
 
@@ -144,49 +286,113 @@ func readHeaders(reader *bufio.Reader) (map[string]string, error) {
 // Strategies 1 and 2 allow connection reuse (Keep-Alive).
 // Strategy 3 closes the connection.
 //
+// A body that ends early fails with ErrTruncatedBody.
+//
 // Returns:
 //   - body bytes
+//   - trailer fields (only a chunked body can have them, nil otherwise)
 //   - error: if body reading fails
-func readBody(reader *bufio.Reader, headers map[string]string) ([]byte, error) {
+func readBody(reader *bufio.Reader, headers Header, opts ParseOptions) ([]byte, Header, error) {
 	// Priority 1: Transfer-Encoding: chunked
-	if transferEncoding, ok := headers["transfer-encoding"]; ok && transferEncoding == "chunked" {
-		bodyBytes, err := readChunkedBody(reader)
+	if headers.Get("Transfer-Encoding") == "chunked" {
+		bodyBytes, trailers, err := readChunkedBody(reader, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunked body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read chunked body: %w", err)
 		}
 		logger.Logger.Println("Read chunked body, connection reusable")
-		return bodyBytes, nil
+		return bodyBytes, trailers, nil
 	}
 
 	// Priority 2: Content-Length
-	if contentLengthStr, ok := headers["content-length"]; ok {
+	if headers.Has("Content-Length") {
+		contentLengthStr := headers.Get("Content-Length")
 		contentLength, parseErr := strconv.Atoi(contentLengthStr)
 		if parseErr != nil || contentLength < 0 {
-			return nil, fmt.Errorf("invalid Content-Length: %v", parseErr)
+			return nil, nil, fmt.Errorf("invalid Content-Length: %v", parseErr)
 		}
 
 		bodyBytes := make([]byte, contentLength)
-		_, err := io.ReadFull(reader, bodyBytes)
+		n, err := io.ReadFull(reader, bodyBytes)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read body (Content-Length: %d): %w", contentLength, err)
+			return nil, nil, fmt.Errorf("failed to read body (Content-Length: %d, got %d): %w", contentLength, n, truncated(err))
 		}
 
 		logger.Logger.Printf("Read %d bytes (Content-Length), connection reusable", contentLength)
-		return bodyBytes, nil
+		return bodyBytes, nil, nil
 	}
 
 	// Priority 3: No explicit length → read until EOF
 	logger.Logger.Println("No Content-Length or Transfer-Encoding header, reading until EOF")
 	bodyBytes, err := io.ReadAll(reader)
 	if err != nil && err != io.EOF {
-		return nil, fmt.Errorf("failed to read body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	return bodyBytes, nil, nil
+}
+
+// readStatusLine reads the status line (e.g., "HTTP/1.1 200 OK") and returns the status code.
+func readStatusLine(reader *bufio.Reader, opts ParseOptions) (int, error) {
+	statusLine, err := readLine(reader, opts.Limits.MaxLineBytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read status line: %w", err)
+	}
+	if opts.Strict {
+		if err := checkStatusLine(statusLine); err != nil {
+			return 0, err
+		}
 	}
-	return bodyBytes, nil
+
+	// Format: "HTTP/1.1 200 OK\r\n"
+	statusLine = strings.TrimSpace(statusLine)
+	parts := strings.SplitN(statusLine, " ", 3)
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("invalid status line: %q", statusLine)
+	}
+
+	statusCode, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid status code in status line %q: %w", statusLine, err)
+	}
+
+	logger.Logger.Printf("Status: %d %s", statusCode, statusLine)
+	return statusCode, nil
+}
+
+// isInterimStatus reports whether statusCode is an informational 1xx response
+// that precedes the final response (100 Continue, 102 Processing, 103 Early Hints).
+//
+// 101 Switching Protocols is final: the connection no longer speaks HTTP/1.1 after it.
+func isInterimStatus(statusCode int) bool {
+	return statusCode >= 100 && statusCode < 200 && statusCode != 101
+}
+
+// hasBody reports whether a final response with statusCode can have a body.
+//
+// 204 No Content and 304 Not Modified end after the headers (RFC 9112 section 6.3).
+func hasBody(statusCode int) bool {
+	return statusCode != StatusNoContent && statusCode != StatusNotModified
+}
+
+// reusable reports whether the connection can carry another request after a
+// response with statusCode and headers has been read in full.
+//
+// It cannot if the server asked to close it, or if the body had no framing and
+// was read until the server closed the connection.
+func reusable(statusCode int, headers Header) bool {
+	if strings.EqualFold(headers.Get("Connection"), "close") {
+		return false
+	}
+	if !hasBody(statusCode) || headers.Get("Transfer-Encoding") == "chunked" {
+		return true
+	}
+	return headers.Has("Content-Length")
 }
 
 // ParseResponse parses an HTTP response and returns the status code, body and headers.
 //
 // It reads the status line, parses headers, and reads the body.
+// Informational 1xx responses (e.g., "HTTP/1.1 100 Continue") sent before the
+// final response are skipped.
 // This function orchestrates the parsing process by delegating to:
 //   - readHeaders() for header parsing
 //   - readBody() for body reading with appropriate strategy
@@ -195,42 +401,71 @@ func readBody(reader *bufio.Reader, headers map[string]string) ([]byte, error) {
 //   - statusCode: HTTP status code (e.g., 200, 302, 404)
 //   - body: response body as string
 //   - headers: map of header names to values
-//   - error: any error encountered during parsing
-func ParseResponse(r io.Reader) (statusCode int, body string, headers map[string]string, err error) {
-	reader := bufio.NewReader(r)
+//   - error: any error encountered during parsing (with ErrExcessBody the body
+//     is still returned, cut at the declared length)
+func ParseResponse(r io.Reader) (statusCode int, body string, headers Header, err error) {
+	statusCode, body, headers, _, err = parseResponse(r, GlobalParseOptions, nil)
+	return statusCode, body, headers, err
+}
 
-	// 1. Read status line (e.g., "HTTP/1.1 200 OK")
-	statusLine, err := reader.ReadString('\n')
-	if err != nil {
-		return 0, "", nil, fmt.Errorf("failed to read status line: %w", err)
-	}
+// ParseResponseWith is ParseResponse with explicit parse options (e.g., strict mode).
+func ParseResponseWith(r io.Reader, opts ParseOptions) (statusCode int, body string, headers Header, err error) {
+	statusCode, body, headers, _, err = parseResponse(r, opts, nil)
+	return statusCode, body, headers, err
+}
 
-	// Parse status code from status line
-	// Format: "HTTP/1.1 200 OK\r\n"
-	statusLine = strings.TrimSpace(statusLine)
-	parts := strings.SplitN(statusLine, " ", 3)
-	if len(parts) < 2 {
-		return 0, "", nil, fmt.Errorf("invalid status line: %q", statusLine)
+// parseResponse is ParseResponse with a callback for interim 1xx responses.
+//
+// onInterim (if not nil) is called with the status code and headers of each
+// interim response, e.g. to start preloading from 103 Early Hints.
+// It also returns the trailer fields of a chunked body (nil if there are none).
+func parseResponse(r io.Reader, opts ParseOptions, onInterim func(statusCode int, headers Header)) (statusCode int, body string, headers, trailers Header, err error) {
+	reader := bufio.NewReader(r)
+	statusCode, body, headers, trailers, err = readResponse(reader, opts, onInterim)
+	if err == nil && hasBody(statusCode) && !isEventStream(headers) {
+		// Anything left after a complete body does not belong to this response
+		err = excess(reader)
 	}
+	return statusCode, body, headers, trailers, err
+}
 
-	statusCode, err = strconv.Atoi(parts[1])
-	if err != nil {
-		return 0, "", nil, fmt.Errorf("invalid status code in status line %q: %w", statusLine, err)
-	}
+// readResponse reads one response from reader, leaving any bytes after it
+// buffered (e.g., the next response on a pipelined connection).
+func readResponse(reader *bufio.Reader, opts ParseOptions, onInterim func(statusCode int, headers Header)) (statusCode int, body string, headers, trailers Header, err error) {
+	// 1-2. Read status line and headers, skipping interim 1xx responses
+	for {
+		statusCode, err = readStatusLine(reader, opts)
+		if err != nil {
+			return 0, "", nil, nil, err
+		}
 
-	logger.Logger.Printf("Status: %d %s", statusCode, statusLine)
+		headers, err = readHeaders(reader, opts)
+		if err != nil {
+			return statusCode, "", nil, nil, err
+		}
 
-	// 2. Parse headers
-	headers, err = readHeaders(reader)
-	if err != nil {
-		return statusCode, "", nil, err
+		if !isInterimStatus(statusCode) {
+			break
+		}
+		// 1xx 응답은 본문이 없으므로 헤더까지만 읽고 최종 응답을 기다림
+		logger.Logger.Printf("중간 응답 %d, 최종 응답 대기", statusCode)
+		if onInterim != nil {
+			onInterim(statusCode, headers)
+		}
 	}
 
-	// 3. Read body
-	bodyBytes, err := readBody(reader, headers)
+	// 3. Read body (204, 304 responses never have one, whatever the headers say;
+	// an event stream never ends, so it is left unread for EventSource)
+	if !hasBody(statusCode) || isEventStream(headers) {
+		return statusCode, "", headers, nil, nil
+	}
+	bodyBytes, trailers, err := readBody(reader, headers, opts)
 	if err != nil {
-		return statusCode, "", headers, err
+		return statusCode, "", headers, nil, err
+	}
+	if len(trailers) == 0 {
+		trailers = nil
 	}
 
-	return statusCode, string(bodyBytes), headers, nil
+	return statusCode, string(bodyBytes), headers, trailers, nil
 }
@@ -3,12 +3,16 @@
 package net
 
 import (
-	"crypto/tls"
+	"errors"
 	"fmt"
 	"go-web-browser/logger"
 	"go-web-browser/url"
+	"io"
+	"log"
 	"net"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // HTTP protocol constants
@@ -30,148 +34,461 @@ const (
 )
 
 // HTTPFetcher: http://, https:// 스킴을 처리하는 Fetcher 구현
-type HTTPFetcher struct{}
+type HTTPFetcher struct {
+	Dialer Dialer // 새 연결을 여는 방법 (nil이면 DefaultDialer)
+
+	// RedirectPolicy는 리다이렉트를 따라가기 전마다 불림 (nil이면 DefaultRedirectPolicy)
+	RedirectPolicy RedirectPolicy
+
+	// MaxRedirects는 한 번의 Fetch에서 따라가는 최대 리다이렉트 횟수 (0이면 DefaultMaxRedirects)
+	MaxRedirects int
+
+	// Cache, Pool, Logger는 이 HTTPFetcher만 쓰는 캐시, 연결 풀, 로거 (nil이면 GlobalCache,
+	// GlobalConnectionPool, logger.Logger)
+	// 테스트나 세션마다 따로 만들면 서로의 상태를 건드리지 않아서 동시에 쓸 수 있음
+	Cache  *Cache
+	Pool   *ConnectionPool
+	Logger *log.Logger
+
+	// Trace는 연결, 요청 단계와 받은 바이트 수를 알려받는 훅 (nil이면 GlobalTrace)
+	Trace *Trace
+
+	// Cookies는 Set-Cookie를 기록하고 요청에 Cookie 헤더를 붙이는 쿠키 저장소 (nil이면 GlobalCookieJar)
+	Cookies *CookieJar
+
+	// TopLevel은 이 HTTPFetcher로 불러오는 하위 리소스의 최상위 페이지 주소 (FirstPartyIsolation)
+	// nil이면 Fetch에 넘긴 주소를 최상위 페이지로 보고, 리다이렉트를 따라가도 그 사이트 몫의 쿠키를 주고받음
+	TopLevel *url.URL
+}
 
 // Fetch: HTTPFetcher의 Fetch 메서드 구현
-func (h *HTTPFetcher) Fetch(u *url.URL) (string, error) {
-	// 캐시에서 먼저 확인
-	urlStr := u.String()
-	if entry, found := GlobalCache.Get(urlStr); found {
-		return entry.Body, nil
+func (h *HTTPFetcher) Fetch(u *url.URL) (*Response, error) {
+	if h.TopLevel == nil && FirstPartyIsolation {
+		// 공유하는 HTTPFetcher를 고치지 않도록 이번 요청만 쓰는 복사본에 최상위 페이지를 적음
+		top := *h
+		top.TopLevel = u
+		h = &top
+	}
+	if upgradable(u) {
+		return h.fetchHTTPSFirst(u)
+	}
+	return h.fetch(u)
+}
+
+// fetch는 캐시를 확인하고 리다이렉트를 따라가며 u를 가져옴
+func (h *HTTPFetcher) fetch(u *url.URL) (*Response, error) {
+	// 캐시에서 먼저 확인 (정규화한 URL을 키로 사용해서 같은 자원은 한 번만 저장)
+	urlStr := u.Normalize().Redacted()
+	if entry, found := h.cache().Get(urlStr); found {
+		// 받은 쪽이 헤더를 고쳐도 캐시에 남은 엔트리는 그대로 두도록 복사본을 줌
+		resp := newResponse(u, 200, entry.Headers.Clone(), entry.Body)
+		resp.FromCache = true
+		return resp, nil
 	}
+	// 프리페치와 탐색처럼 같은 URL을 동시에 가져오면 요청 하나의 결과를 나눠 받음
+	return coalesce(h.flightKey("GET", u), func() (*Response, error) {
+		return h.fetchNetwork(u, nil)
+	})
+}
 
-	const maxRedirects = 10
+// fetchNetwork는 캐시를 보지 않고 u를 요청해서 리다이렉트를 따라가고, 결과를 캐시에 저장함
+//
+// extra는 덧붙이는 요청 헤더 (조건부 요청의 If-None-Match, 범위 요청의 Range 등, nil이면 없음)
+// 조건부 요청이고 304 Not Modified를 받으면 본문 없는 304 응답을 그대로 반환함
+// 범위 요청의 206 Partial Content는 자원 전체가 아니므로 캐시에 저장하지 않음
+func (h *HTTPFetcher) fetchNetwork(u *url.URL, extra Header) (*Response, error) {
+	urlStr := u.Normalize().Redacted()
 	currentURL := u
+	var hops []RedirectHop
 
-	// 리다이렉트 루프: 최대 10번까지 리다이렉트를 따라감
-	for i := 0; i < maxRedirects; i++ {
-		statusCode, body, headers, err := h.doRequest(currentURL)
+	// 리다이렉트 루프: 최대 MaxRedirects번까지 리다이렉트를 따라감
+	for i := 0; ; i++ {
+		start := time.Now()
+		res, err := h.doRequestWithRetry(currentURL, extra)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		elapsed := time.Since(start)
+		// 여러 부분으로 나눠 온 206 응답은 렌더러에 boundary가 보이지 않도록 범위 하나로 이어 붙임
+		if res, err = decodeByteranges(res); err != nil {
+			return nil, err
+		}
+
+		if res.statusCode == StatusNotModified && extra != nil {
+			resp := newResponse(currentURL, res.statusCode, res.headers, "")
+			resp.Redirects = hops
+			return resp, nil
 		}
+		// 리다이렉트된 주소에는 원래 주소의 검증자(validator)를 보내지 않음
+		extra = withoutValidators(extra)
 
 		// 리다이렉트가 아니면 성공
-		if statusCode < 300 || statusCode >= 400 {
+		if res.statusCode < 300 || res.statusCode >= 400 {
 			// 응답을 캐시에 저장한 후 반환
-			GlobalCache.Put(urlStr, statusCode, body, headers)
-			return body, nil
+			resp := h.complete(urlStr, currentURL, res)
+			resp.Redirects = hops
+			return resp, nil
 		}
 
 		// 리다이렉트 처리 (300-399)
-		location := headers["location"]
+		location := res.headers.Get("Location")
 		if location == "" {
-			return "", fmt.Errorf("리다이렉트 응답에 Location 헤더가 없습니다 (status %d)", statusCode)
+			return nil, fmt.Errorf("리다이렉트 응답에 Location 헤더가 없습니다 (status %d)", res.statusCode)
 		}
 
-		logger.Logger.Printf("리다이렉트 %d: %d -> %s", i+1, statusCode, location)
-
 		// Location을 절대 URL로 변환
 		nextURL, err := resolveURL(currentURL, location)
 		if err != nil {
-			return "", fmt.Errorf("리다이렉트 URL 변환 실패 %q: %w", location, err)
+			return nil, fmt.Errorf("리다이렉트 URL 변환 실패 %q: %w", location, err)
+		}
+		hop := RedirectHop{URL: currentURL, StatusCode: res.statusCode, Location: nextURL, Duration: elapsed}
+		h.logger().Printf("리다이렉트 %d: %s", i+1, hop)
+
+		if i >= h.maxRedirects() {
+			return nil, &TooManyRedirectsError{Max: h.maxRedirects(), Last: nextURL}
+		}
+		if err := h.redirectPolicy()(currentURL, nextURL, res.statusCode); err != nil {
+			if errors.Is(err, ErrUseLastResponse) {
+				resp := newResponse(currentURL, res.statusCode, res.headers, res.body)
+				resp.Redirects = hops
+				return resp, nil
+			}
+			return nil, err
 		}
 
+		// http가 아닌 스킴(정책이 허용한 about:, data: 등)은 그 스킴의 Fetcher로 넘김
+		hops = append(hops, hop)
+		if !isHTTPScheme(nextURL.Scheme) {
+			return fetchRedirected(nextURL, hops)
+		}
 		currentURL = nextURL
 	}
+}
 
-	return "", fmt.Errorf("최대 리다이렉트 횟수 초과 (최대 %d회)", maxRedirects)
+// complete는 리다이렉트가 아닌 최종 응답을 캐시(key)와 스풀에 저장하고 Response로 만듦
+//
+// 본문 없이 돌려주는 이벤트 스트림, 일부만 받은 응답은 캐시에 저장하지 않음
+func (h *HTTPFetcher) complete(key string, u *url.URL, res result) *Response {
+	if !isEventStream(res.headers) && res.statusCode != StatusPartialContent {
+		h.cache().Put(key, res.statusCode, res.body, res.headers)
+	}
+	spool(u, res.body)
+	resp := newResponse(u, res.statusCode, res.headers, res.body)
+	resp.Trailers = res.trailers
+	resp.Security = res.security
+	return resp
 }
 
-// resolveURL resolves a potentially relative URL against a base URL.
+// resolveURL resolves a potentially relative Location against a base URL.
 //
-// If location is an absolute URL (starts with http:// or https://), it is parsed directly.
-// If location is a relative URL (starts with /), it uses the base URL's scheme and host.
+// Absolute URLs of any supported scheme (including opaque ones like about: and
+// data:) are parsed directly; relative references ("/new", "next.html", "?q=1",
+// "//host/x") are resolved like links (url.URL.Resolve).
 //
 // Examples:
 //   - resolveURL("http://example.com/page", "https://other.com/new") -> "https://other.com/new"
-//   - resolveURL("http://example.com/page", "/new") -> "http://example.com/new"
+//   - resolveURL("http://example.com/a/page", "next") -> "http://example.com/a/next"
+//   - resolveURL("http://example.com/page", "about:blank") -> "about:blank"
 func resolveURL(base *url.URL, location string) (*url.URL, error) {
-	// Absolute URL: parse directly
-	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
-		return url.NewURL(location)
+	return base.Resolve(location)
+}
+
+// dialer returns the fetcher's Dialer, or DefaultDialer if none is set
+func (h *HTTPFetcher) dialer() Dialer {
+	if h == nil || h.Dialer == nil {
+		return DefaultDialer
+	}
+	return h.Dialer
+}
+
+// cache는 h가 쓰는 캐시 (nil이면 GlobalCache)
+func (h *HTTPFetcher) cache() *Cache {
+	if h == nil || h.Cache == nil {
+		return GlobalCache
+	}
+	return h.Cache
+}
+
+// pool은 h가 쓰는 연결 풀 (nil이면 GlobalConnectionPool)
+func (h *HTTPFetcher) pool() *ConnectionPool {
+	if h == nil || h.Pool == nil {
+		return GlobalConnectionPool
+	}
+	return h.Pool
+}
+
+// cookies는 h가 쓰는 쿠키 저장소 (nil이면 GlobalCookieJar, 둘 다 nil이면 쿠키를 주고받지 않음)
+func (h *HTTPFetcher) cookies() *CookieJar {
+	if h == nil || h.Cookies == nil {
+		return GlobalCookieJar
+	}
+	return h.Cookies
+}
+
+// withCookies는 u로 보낼 쿠키가 있으면 extra에 Cookie 헤더를 더한 복사본을 반환함
+func (h *HTTPFetcher) withCookies(u *url.URL, extra Header) Header {
+	cookie := h.cookies().CookieHeaderFor(h.TopLevel, u)
+	if cookie == "" {
+		return extra
+	}
+	out := extra.Clone()
+	if out == nil {
+		out = make(Header)
+	}
+	out.Set("Cookie", cookie)
+	return out
+}
+
+// logger는 h가 쓰는 로거 (nil이면 logger.Logger)
+func (h *HTTPFetcher) logger() *log.Logger {
+	if h == nil || h.Logger == nil {
+		return logger.Logger
 	}
+	return h.Logger
+}
+
+// trace는 h가 쓰는 Trace (h.Trace, GlobalTrace 모두 nil이면 h의 로거에 남기는 LogTrace)
+func (h *HTTPFetcher) trace() *Trace {
+	switch {
+	case h != nil && h.Trace != nil:
+		return h.Trace
+	case GlobalTrace != nil:
+		return GlobalTrace
+	}
+	return LogTrace(h.logger())
+}
 
-	// Relative URL: use base URL's scheme and host
-	if strings.HasPrefix(location, "/") {
-		// Construct absolute URL: scheme://host:port/path
-		var absoluteURL string
-		if base.Scheme == url.SchemeHTTPS && base.Port == url.DefaultHTTPSPort {
-			// HTTPS default port: omit :443
-			absoluteURL = fmt.Sprintf("https://%s%s", base.Host, location)
-		} else if base.Scheme == url.SchemeHTTP && base.Port == url.DefaultHTTPPort {
-			// HTTP default port: omit :80
-			absoluteURL = fmt.Sprintf("http://%s%s", base.Host, location)
-		} else {
-			// Non-default port: include it
-			absoluteURL = fmt.Sprintf("%s://%s:%d%s", base.Scheme, base.Host, base.Port, location)
+// dialTarget returns the network and address to dial for the URL.
+//
+// http/https use "tcp" and "host:port"; http+unix uses "unix" and the decoded socket path.
+// The address is also used as the ConnectionPool key.
+func dialTarget(u *url.URL) (network, address string, err error) {
+	if u.Scheme == url.SchemeHTTPUnix {
+		path, err := u.SocketPath()
+		if err != nil {
+			return "", "", err
 		}
-		return url.NewURL(absoluteURL)
+		return "unix", path, nil
 	}
+	return "tcp", net.JoinHostPort(u.Host, strconv.Itoa(u.Port)), nil
+}
+
+// dial opens a new connection (TLS for https) to address, which is the URL's
+// host and port (or an Alt-Svc alternative) or Unix socket
+func (h *HTTPFetcher) dial(u *url.URL, address string) (net.Conn, error) {
+	network, _, err := dialTarget(u)
+	if err != nil {
+		return nil, err
+	}
+	secure := u.Scheme == url.SchemeHTTPS
+	ctx := h.trace().dialContext(address, secure)
+
+	var conn net.Conn
+	if secure {
+		start := time.Now()
+		conn, err = h.dialer().DialTLSContext(ctx, network, address)
+		unverified := false
+		if err != nil && certificateError(err) {
+			conn, err = h.dialUnverified(ctx, u, network, address, err)
+			unverified = err == nil
+		}
+		if err == nil {
+			if pinErr := checkPin(conn, address); pinErr != nil {
+				conn.Close()
+				return nil, pinErr
+			}
+			conn, err = withSecurity(conn, address)
+		}
+		if info := connSecurity(conn); err == nil && info != nil {
+			info.Unverified = unverified
+			h.pool().recordTLS(address, info.Resumed, time.Since(start))
+		}
+	} else {
+		conn, err = h.dialer().DialContext(ctx, network, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h.pool().recordCreated(address)
+	return conn, nil
+}
 
-	return nil, fmt.Errorf("지원하지 않는 Location 형식: %q (절대 URL 또는 상대 경로가 아님)", location)
+// hostHeader returns the Host header value for the URL.
+// Unix sockets have no host name, so "localhost" is sent (like curl --unix-socket).
+// IPv6 addresses are sent in brackets ("[::1]").
+func hostHeader(u *url.URL) string {
+	if u.Scheme == url.SchemeHTTPUnix {
+		return "localhost"
+	}
+	return u.HostLiteral()
+}
+
+// result는 요청 한 번의 결과 (상태 코드, 본문, 헤더, https 연결의 보안 정보)
+type result struct {
+	statusCode int
+	body       string
+	headers    Header
+	trailers   Header        // chunked 본문 뒤의 트레일러 (없으면 nil)
+	security   *SecurityInfo // https가 아니면 nil
+}
+
+// doRequestWithRetry: GlobalRetryPolicy가 설정되어 있으면 일시적인 실패를 재시도함
+//
+// 마지막 시도의 결과(5xx 응답 또는 에러)를 그대로 반환함
+func (h *HTTPFetcher) doRequestWithRetry(u *url.URL, extra Header) (result, error) {
+	policy := GlobalRetryPolicy
+	for attempt := 1; ; attempt++ {
+		res, err := h.doRequest(u, extra)
+		if policy == nil || attempt >= policy.MaxAttempts {
+			return res, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !isTransientError(err) {
+				return res, err
+			}
+			delay = policy.Backoff(attempt)
+			h.logger().Printf("일시적인 에러, %s 후 재시도 (%d/%d): %v", delay, attempt+1, policy.MaxAttempts, err)
+		case isRetryableStatus(res.statusCode):
+			var ok bool
+			if delay, ok = policy.retryDelay(attempt, res.statusCode, res.headers); !ok {
+				return res, err
+			}
+			h.logger().Printf("상태 코드 %d, %s 후 재시도 (%d/%d)", res.statusCode, delay, attempt+1, policy.MaxAttempts)
+		default:
+			return res, err
+		}
+
+		time.Sleep(delay)
+	}
+}
+
+// requestMessage는 u를 가져오는 GET 요청 메시지를 만듦 (extra는 덧붙이는 요청 헤더)
+func requestMessage(u *url.URL, extra Header) string {
+	headers := Header{}
+	headers.Set(HeaderHost, hostHeader(u))
+	// Connection: close 헤더 제거!
+	// → HTTP/1.1의 기본 동작이 keep-alive이므로 생략
+	userAgent, _ := GlobalUserAgents.For(u.Host)
+	headers.Set(HeaderUserAgent, userAgent)
+	setPrivacyHeaders(headers)
+	for key, value := range extra {
+		headers.Set(key, value)
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "GET %s %s\r\n", u.Path, HTTPVersion)
+	for key, value := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", wireHeaderKey(key), value)
+	}
+	message.WriteString("\r\n")
+	return message.String()
 }
 
 // doRequest performs a single HTTP request and returns status code, body, headers
-func (h *HTTPFetcher) doRequest(u *url.URL) (int, string, map[string]string, error) {
-	address := fmt.Sprintf("%s:%d", u.Host, u.Port)
+// (and the TLS security info for https)
+//
+// extra holds additional request headers (nil for none).
+func (h *HTTPFetcher) doRequest(u *url.URL, extra Header) (result, error) {
+	_, origin, err := dialTarget(u)
+	if err != nil {
+		return result{}, err
+	}
+	address := alternative(origin, u.Scheme)
 
 	// 1. ConnectionPool에서 기존 연결 찾기
-	conn, found := GlobalConnectionPool.Get(address)
+	conn, found := h.pool().Get(address)
 
 	if !found {
 		// 2. Create new connection if not in pool
-		logger.Logger.Printf("Creating new connection to %s", address)
 		var err error
-
-		if u.Scheme == url.SchemeHTTPS {
-			conn, err = tls.Dial("tcp", address, nil)
-		} else {
-			conn, err = net.Dial("tcp", address)
+		conn, err = h.dial(u, address)
+		if err != nil && address != origin {
+			// 대체 서비스에 연결할 수 없으면 잊고 원래 서버로
+			h.logger().Printf("Alt-Svc: %s 연결 실패, %s로 다시 연결: %v", address, origin, err)
+			GlobalAltSvc.Remove(origin, address)
+			return h.doRequest(u, extra)
 		}
-
 		if err != nil {
-			return 0, "", nil, err
+			return result{}, err
 		}
 	}
 
-	// HTTP 요청 메시지 만들기
-	headers := map[string]string{
-		HeaderHost: u.Host,
-		// Connection: close 헤더 제거!
-		// → HTTP/1.1의 기본 동작이 keep-alive이므로 생략
-		HeaderUserAgent: UserAgent,
+	if userAgent, ok := GlobalUserAgents.For(u.Host); ok {
+		h.logger().Printf("User-Agent 덮어쓰기 (%s): %s", u.Host, userAgent)
 	}
+	request := requestMessage(u, h.withCookies(u, extra))
 
-	requestLine := fmt.Sprintf("GET %s %s\r\n", u.Path, HTTPVersion)
-
-	var headerLines strings.Builder
-	headerLines.WriteString(requestLine)
-	for key, value := range headers {
-		headerLines.WriteString(fmt.Sprintf("%s: %s\r\n", key, value))
+	// 읽기/쓰기마다 유휴 시간과 전체 시간 제한을 검 (풀에 돌려주기 전에 지움)
+	var rw io.ReadWriter = conn
+	deadlines := withDeadlines(conn, GlobalTimeouts)
+	if deadlines != nil {
+		rw = deadlines
 	}
 
-	headerLines.WriteString("\r\n")
-
-	request := headerLines.String()
-
 	// 서버에 메시지 보내기
-	_, err := conn.Write([]byte(request))
+	_, err = rw.Write([]byte(request))
 	if err != nil {
-		conn.Close() // 전송 실패 시 연결 닫기
-		return 0, "", nil, err
+		h.pool().Discard(address, conn) // 전송 실패 시 연결 닫기
+		if found && isTransientError(err) {
+			h.logger().Printf("재사용한 연결이 닫혀 있음, 다시 요청: %s", address)
+			return h.doRequest(u, extra)
+		}
+		return result{}, err
 	}
 
 	// Read and parse HTTP response
-	logger.Logger.Printf("Request sent to %s:%d", u.Host, u.Port)
+	trace := h.trace()
+	trace.Report(PhaseWaiting, address)
 
-	statusCode, body, respHeaders, err := ParseResponse(conn)
+	statusCode, body, respHeaders, trailers, err := parseResponse(trace.reader(rw, address), GlobalParseOptions, func(status int, hints Header) {
+		if status == StatusEarlyHints {
+			GlobalPreloader.HandleEarlyHints(u, hints)
+		}
+	})
+	if errors.Is(err, ErrExcessBody) {
+		// 본문은 선언된 길이까지 쓰고, 남은 바이트를 알 수 없는 연결은 재사용하지 않음
+		h.logger().Printf("응답이 선언된 길이보다 김, 연결을 닫음 (%s): %v", u.Redacted(), err)
+		h.pool().Discard(address, conn)
+		return result{statusCode: statusCode, body: body, headers: respHeaders, trailers: trailers, security: connSecurity(conn)}, nil
+	}
 	if err != nil {
-		conn.Close() // Close on parse error
-		return 0, "", nil, err
+		h.pool().Discard(address, conn) // Close on parse error
+		if found && statusCode == 0 && isTransientError(err) {
+			// 풀에 있던 연결을 서버가 이미 닫은 경우: 응답을 하나도 받지 못했으므로 새 연결로 다시 보냄
+			h.logger().Printf("재사용한 연결이 닫혀 있음, 다시 요청: %s", address)
+			return h.doRequest(u, extra)
+		}
+		return result{}, err
 	}
 
 	// 3. Return connection to pool for reuse
-	GlobalConnectionPool.Put(address, conn)
+	// (an event stream's body is still unread, so that connection cannot be reused)
+	if isEventStream(respHeaders) {
+		h.logger().Printf("이벤트 스트림 응답, 연결을 닫음 (EventSource로 다시 받아야 함): %s", u.Redacted())
+		h.pool().Discard(address, conn)
+	} else if !reusable(statusCode, respHeaders) {
+		h.logger().Printf("응답 뒤에 연결을 재사용할 수 없음, 연결을 닫음: %s", u.Redacted())
+		h.pool().Discard(address, conn)
+	} else {
+		if deadlines != nil {
+			deadlines.clear()
+		}
+		h.pool().Put(address, conn)
+	}
+
+	if value := respHeaders.Get("Alt-Svc"); value != "" && u.Scheme == url.SchemeHTTPS {
+		GlobalAltSvc.Update(origin, value, time.Now())
+	}
+	trace.response(address, respHeaders)
+	h.cookies().SetCookiesFor(h.TopLevel, u, respHeaders.Get("Set-Cookie"))
+	if trailers != nil {
+		h.logger().Printf("트레일러 %d개 (%s)", len(trailers), u.Redacted())
+		trace.trailers(address, trailers)
+	}
 
-	return statusCode, body, respHeaders, nil
+	return result{statusCode: statusCode, body: body, headers: respHeaders, trailers: trailers, security: connSecurity(conn)}, nil
 }
@@ -0,0 +1,105 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the HTTPS-first mode (try https:// before http://).
+package net
+
+import (
+	"context"
+	"errors"
+	"go-web-browser/url"
+	"net"
+	"time"
+)
+
+// HTTPSFirst가 true면 http:// URL을 https://로 먼저 시도하고, TLS 연결에 실패하면 http://로 가져옴 (opt-in)
+var HTTPSFirst bool
+
+// HTTPSFirstTimeout은 HTTPS-first 모드에서 https 연결(TCP + TLS 핸드셰이크)을 기다리는 시간
+//
+// https를 지원하지 않는 서버는 443 포트가 막혀 있는 경우가 많으므로 짧게 잡음
+var HTTPSFirstTimeout = 3 * time.Second
+
+// HTTPSFirstExempt가 true를 반환하는 URL은 HTTPS-first 모드에서도 http 그대로 가져옴
+// (사이트별 설정의 "http 허용" 등, nil이면 예외 없음)
+var HTTPSFirstExempt func(u *url.URL) bool
+
+// Upgrade는 HTTPS-first 모드에서 어느 쪽으로 연결했는지
+type Upgrade int
+
+// HTTPS-first 결과
+const (
+	UpgradeNone     Upgrade = iota // 업그레이드하지 않음 (HTTPS-first가 꺼져 있거나 대상이 아님)
+	UpgradeHTTPS                   // https://로 연결함
+	UpgradeFallback                // https:// 연결에 실패해서 http://로 연결함
+)
+
+func (u Upgrade) String() string {
+	switch u {
+	case UpgradeHTTPS:
+		return "https"
+	case UpgradeFallback:
+		return "http (https 실패)"
+	}
+	return "none"
+}
+
+// upgradable은 HTTPS-first로 https를 먼저 시도할 URL인지 확인함
+//
+// 기본 포트(80)의 http만 업그레이드함 (다른 포트는 https 포트를 알 수 없음)
+func upgradable(u *url.URL) bool {
+	if !HTTPSFirst || u.Scheme != url.SchemeHTTP || u.Port != url.DefaultHTTPPort {
+		return false
+	}
+	return HTTPSFirstExempt == nil || !HTTPSFirstExempt(u)
+}
+
+// fetchHTTPSFirst는 u의 https 버전을 짧은 타임아웃으로 먼저 시도하고, 실패하면 u를 그대로 가져옴
+//
+// 인증서 핀 불일치나 폐기된 인증서는 공격일 수 있으므로 http로 내려가지 않고 에러를 반환함
+func (h *HTTPFetcher) fetchHTTPSFirst(u *url.URL) (*Response, error) {
+	secure := *u
+	secure.Scheme = url.SchemeHTTPS
+	secure.Port = url.DefaultHTTPSPort
+
+	upgrader := *h
+	upgrader.Dialer = &timeoutDialer{Dialer: h.dialer(), Timeout: HTTPSFirstTimeout}
+	resp, err := upgrader.Fetch(&secure)
+	if err == nil {
+		h.logger().Printf("HTTPS-first: %s 대신 %s로 연결함", u.Redacted(), secure.Redacted())
+		resp.Upgrade = UpgradeHTTPS
+		return resp, nil
+	}
+
+	var mismatch *PinMismatchError
+	var revoked *RevokedError
+	if errors.As(err, &mismatch) || errors.As(err, &revoked) {
+		return nil, err
+	}
+
+	h.logger().Printf("HTTPS-first: %s 연결 실패, %s로 연결: %v", secure.Redacted(), u.Redacted(), err)
+	resp, err = h.fetch(u)
+	if err != nil {
+		return nil, err
+	}
+	resp.Upgrade = UpgradeFallback
+	return resp, nil
+}
+
+// timeoutDialer는 연결마다 타임아웃을 거는 Dialer
+type timeoutDialer struct {
+	Dialer
+	Timeout time.Duration
+}
+
+// DialContext: timeoutDialer의 평문 연결 구현
+func (d *timeoutDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+	return d.Dialer.DialContext(ctx, network, addr)
+}
+
+// DialTLSContext: timeoutDialer의 TLS 연결 구현
+func (d *timeoutDialer) DialTLSContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+	return d.Dialer.DialTLSContext(ctx, network, addr)
+}
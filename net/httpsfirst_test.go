@@ -0,0 +1,88 @@
+package net_test
+
+import (
+	"context"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// routeDialer: "호스트:포트"를 테스트 서버 주소로 바꿔서 연결하는 Dialer
+type routeDialer struct {
+	net.NetDialer
+	routes map[string]string
+}
+
+func (d *routeDialer) DialContext(ctx context.Context, network, addr string) (stdnet.Conn, error) {
+	return d.NetDialer.DialContext(ctx, network, d.routes[addr])
+}
+
+func (d *routeDialer) DialTLSContext(ctx context.Context, network, addr string) (stdnet.Conn, error) {
+	return d.NetDialer.DialTLSContext(ctx, network, d.routes[addr])
+}
+
+// closedAddr는 아무도 듣고 있지 않은 주소 (연결하면 바로 거부됨)
+func closedAddr(t *testing.T) string {
+	l, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+// TestHTTPFetcher_HTTPSFirst https가 되면 https로, TLS 연결에 실패하면 http로 가져오고 어느 쪽인지 알려줌
+func TestHTTPFetcher_HTTPSFirst(t *testing.T) {
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("https"))
+	}))
+	defer secure.Close()
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("http"))
+	}))
+	defer plain.Close()
+
+	net.HTTPSFirst = true
+	defer func() { net.HTTPSFirst = false }()
+
+	tlsConfig := secure.Client().Transport.(*http.Transport).TLSClientConfig
+	fetcher := &net.HTTPFetcher{Dialer: &routeDialer{
+		NetDialer: net.NetDialer{TLSConfig: tlsConfig},
+		routes: map[string]string{
+			"secure.test:443":   secure.Listener.Addr().String(),
+			"secure.test:80":    plain.Listener.Addr().String(),
+			"insecure.test:443": closedAddr(t),
+			"insecure.test:80":  plain.Listener.Addr().String(),
+		},
+	}}
+
+	tests := []struct {
+		input       string
+		wantBody    string
+		wantScheme  url.Scheme
+		wantUpgrade net.Upgrade
+	}{
+		{"secure.test/first", "https", url.SchemeHTTPS, net.UpgradeHTTPS},
+		{"insecure.test/first", "http", url.SchemeHTTP, net.UpgradeFallback},
+		{"https://secure.test/explicit", "https", url.SchemeHTTPS, net.UpgradeNone},
+	}
+
+	for _, tt := range tests {
+		u, err := url.FromUserInput(tt.input)
+		if err != nil {
+			t.Fatalf("FromUserInput(%q) failed: %v", tt.input, err)
+		}
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%q) failed: %v", tt.input, err)
+		}
+		if resp.Body != tt.wantBody || resp.URL.Scheme != tt.wantScheme || resp.Upgrade != tt.wantUpgrade {
+			t.Errorf("Fetch(%q) = %q, %s, %s; want %q, %s, %s", tt.input,
+				resp.Body, resp.URL.Scheme, resp.Upgrade, tt.wantBody, tt.wantScheme, tt.wantUpgrade)
+		}
+	}
+}
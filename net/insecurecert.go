@@ -0,0 +1,47 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the user-approved fallback for https servers whose certificate fails verification.
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"go-web-browser/url"
+	"net"
+)
+
+// AllowInsecureCertificate가 true를 반환하면 인증서 검증에 실패한 https 서버에 검증 없이 다시 연결함
+//
+// nil이면 항상 실패로 끝남 (기본값)
+// browser.go가 permissions.Manager로 사이트별 설정의 결정을 확인하거나 사용자에게 물음
+// 핀 검사(--tofu)와 OCSP 검증은 그대로 하고, SecurityInfo.Unverified로 표시함
+var AllowInsecureCertificate func(u *url.URL, err error) bool
+
+// certificateError는 err가 서버 인증서를 검증하지 못한 TLS 에러인지 확인함
+// (자체 서명, 만료, 호스트 이름 불일치 등, 핀 불일치와 폐기는 제외)
+func certificateError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalid          x509.CertificateInvalidError
+		hostname         x509.HostnameError
+		verification     *tls.CertificateVerificationError
+	)
+	return errors.As(err, &unknownAuthority) || errors.As(err, &invalid) ||
+		errors.As(err, &hostname) || errors.As(err, &verification)
+}
+
+// dialUnverified는 dialErr로 실패한 u의 TLS 연결을 허락을 받아 인증서 검증 없이 다시 맺음
+//
+// 허락받지 못했거나 Dialer가 NetDialer가 아니면 dialErr를 그대로 반환함
+func (h *HTTPFetcher) dialUnverified(ctx context.Context, u *url.URL, network, address string, dialErr error) (net.Conn, error) {
+	d, ok := h.dialer().(*NetDialer)
+	if !ok || AllowInsecureCertificate == nil || !certificateError(dialErr) || !AllowInsecureCertificate(u, dialErr) {
+		return nil, dialErr
+	}
+	unverified := *d
+	unverified.TLSConfig = d.tlsConfig().Clone()
+	unverified.TLSConfig.InsecureSkipVerify = true
+	h.logger().Printf("인증서 검증 없이 연결 (사용자가 허용함) %s: %v", address, dialErr)
+	return unverified.DialTLSContext(ctx, network, address)
+}
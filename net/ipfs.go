@@ -0,0 +1,55 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the ipfs:// and ipns:// fetcher that goes through an HTTP gateway.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"strings"
+)
+
+// DefaultIPFSGateway는 IPFSFetcher.Gateway가 비어 있을 때 쓰는 공개 HTTP 게이트웨이
+const DefaultIPFSGateway = "https://ipfs.io"
+
+// IPFSFetcher: ipfs://, ipns:// 스킴을 HTTP 게이트웨이 주소로 바꿔서 가져오는 Fetcher 구현
+//
+// ipfs://<CID>/a.html → <Gateway>/ipfs/<CID>/a.html (경로 방식 게이트웨이)
+// 응답의 URL은 원래 ipfs:// 주소로 돌려주므로 페이지 안의 상대 링크도 ipfs://로 이어짐
+type IPFSFetcher struct {
+	Gateway string // 게이트웨이 주소 (예: "http://127.0.0.1:8080", 비어 있으면 DefaultIPFSGateway)
+}
+
+// GatewayURL은 ipfs://, ipns:// URL을 게이트웨이의 http(s) URL로 바꿈
+func (f *IPFSFetcher) GatewayURL(u *url.URL) (*url.URL, error) {
+	if u.Scheme != url.SchemeIPFS && u.Scheme != url.SchemeIPNS {
+		return nil, fmt.Errorf("IPFS 주소가 아닙니다: %s", u)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%s 주소에 CID나 이름이 없습니다: %s", u.Scheme, u)
+	}
+	gateway := f.Gateway
+	if gateway == "" {
+		gateway = DefaultIPFSGateway
+	}
+	return url.NewURL(strings.TrimSuffix(gateway, "/") + "/" + string(u.Scheme) + "/" + u.Host + u.Path)
+}
+
+// Fetch: IPFSFetcher의 Fetch 메서드 구현
+func (f *IPFSFetcher) Fetch(u *url.URL) (*Response, error) {
+	gw, err := f.GatewayURL(u)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Printf("%s → 게이트웨이 %s", u, gw)
+
+	resp, err := Fetch(gw)
+	if err != nil {
+		return nil, fmt.Errorf("IPFS 게이트웨이 요청 실패 (%s): %w", gw, err)
+	}
+	// 게이트웨이가 다른 곳(서브도메인 게이트웨이 등)으로 보냈으면 그 주소를 그대로 둠
+	if resp.URL.String() == gw.String() {
+		resp.URL = u
+	}
+	return resp, nil
+}
@@ -0,0 +1,41 @@
+// Package net implements HTTP networking for the browser.
+// This file contains first-party isolation, which partitions client-side state by top-level site.
+package net
+
+import (
+	stdnet "net"
+	"strings"
+)
+
+// FirstPartyIsolation이 true면 쿠키 저장소(와 앞으로 생길 저장소)를 최상위 사이트별로 나눔 (opt-in, 설정 파일의 first_party_isolation)
+//
+// 페이지 a.example에 들어 있는 tracker.test 하위 리소스 요청은 a.example 몫의 tracker.test 쿠키만 보고,
+// b.example에서 받은 tracker.test 쿠키는 볼 수 없음
+var FirstPartyIsolation bool
+
+// secondLevelLabels는 그 아래 이름까지 합쳐야 한 사이트가 되는 2단계 도메인 이름 (example.co.kr, example.co.uk 등)
+//
+// Public Suffix List 전체 대신 흔한 국가 도메인 아래 이름만 다룸
+var secondLevelLabels = map[string]bool{
+	"co": true, "com": true, "ac": true, "go": true, "or": true, "ne": true,
+	"re": true, "pe": true, "net": true, "org": true, "gov": true, "edu": true,
+}
+
+// Site는 host가 속한 사이트 (등록 가능한 도메인, 예: "www.example.co.kr" → "example.co.kr")
+//
+// IP 주소와 점이 없는 호스트(localhost)는 그대로 사이트로 씀
+func Site(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if stdnet.ParseIP(strings.Trim(host, "[]")) != nil {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	n := 2
+	if len(labels) >= 3 && len(labels[len(labels)-1]) == 2 && secondLevelLabels[labels[len(labels)-2]] {
+		n = 3
+	}
+	if len(labels) <= n {
+		return host
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
@@ -0,0 +1,66 @@
+// Package net implements HTTP networking for the browser.
+// This file contains size and count limits for response headers.
+package net
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+)
+
+// 헤더 제한 초과 에러 (errors.Is로 종류를 확인할 수 있음)
+var (
+	ErrHeaderLineTooLong = errors.New("헤더 줄이 너무 깁니다")
+	ErrHeadersTooLarge   = errors.New("응답 헤더 전체 크기가 너무 큽니다")
+	ErrTooManyHeaders    = errors.New("응답 헤더 개수가 너무 많습니다")
+)
+
+// HeaderLimitError는 응답 헤더가 HeaderLimits를 넘었을 때 반환되는 에러
+//
+// 악의적인 서버가 끝없는 헤더로 메모리를 소진시키는 것을 막기 위해 읽기를 중단함
+type HeaderLimitError struct {
+	Err   error // ErrHeaderLineTooLong, ErrHeadersTooLarge, ErrTooManyHeaders 중 하나
+	Limit int   // 넘은 제한 값 (바이트 또는 개수)
+}
+
+// Error: error 인터페이스 구현
+func (e *HeaderLimitError) Error() string {
+	return fmt.Sprintf("%v (최대 %d)", e.Err, e.Limit)
+}
+
+// Unwrap: errors.Is(err, ErrTooManyHeaders) 등을 지원
+func (e *HeaderLimitError) Unwrap() error {
+	return e.Err
+}
+
+// HeaderLimits는 응답 상태 줄과 헤더에 대한 제한 (0이면 제한 없음)
+type HeaderLimits struct {
+	MaxLineBytes  int // 한 줄의 최대 바이트 수 (상태 줄 포함, 줄바꿈 포함)
+	MaxTotalBytes int // 한 응답의 헤더 전체 최대 바이트 수
+	MaxCount      int // 한 응답의 최대 헤더 개수
+}
+
+// DefaultHeaderLimits는 브라우저들이 사용하는 수준의 기본 제한
+var DefaultHeaderLimits = HeaderLimits{
+	MaxLineBytes:  8 << 10, // 8 KB
+	MaxTotalBytes: 1 << 20, // 1 MB
+	MaxCount:      200,
+}
+
+// readLine은 '\n'까지 한 줄을 읽되 max 바이트를 넘으면 중단함
+//
+// bufio.Reader.ReadString과 같이 입력이 끝나면 남은 부분과 io.EOF를 반환함
+func readLine(reader *bufio.Reader, max int) (string, error) {
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if max > 0 && len(line)+len(chunk) > max {
+			return "", &HeaderLimitError{Err: ErrHeaderLineTooLong, Limit: max}
+		}
+		line = append(line, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return string(line), err
+	}
+}
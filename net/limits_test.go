@@ -0,0 +1,54 @@
+package net_test
+
+import (
+	"errors"
+	"go-web-browser/net"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// 응답 헤더 제한 테스트
+// ============================================
+
+// TestParseResponse_HeaderLimits 줄 길이, 전체 크기, 개수 제한
+func TestParseResponse_HeaderLimits(t *testing.T) {
+	manyHeaders := strings.Repeat("X-A: b\r\n", net.DefaultHeaderLimits.MaxCount+1)
+	bigHeaders := strings.Repeat("X-Big: "+strings.Repeat("a", 8000)+"\r\n", 200)
+
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{"긴 헤더 줄", "HTTP/1.1 200 OK\r\nX-Long: " + strings.Repeat("a", 9000) + "\r\n\r\n", net.ErrHeaderLineTooLong},
+		{"긴 상태 줄", "HTTP/1.1 200 " + strings.Repeat("O", 9000) + "\r\n\r\n", net.ErrHeaderLineTooLong},
+		{"헤더 개수", "HTTP/1.1 200 OK\r\n" + manyHeaders + "\r\n", net.ErrTooManyHeaders},
+		{"전체 크기", "HTTP/1.1 200 OK\r\n" + bigHeaders + "\r\n", net.ErrHeadersTooLarge},
+	}
+
+	for _, tt := range tests {
+		_, _, _, err := net.ParseResponse(strings.NewReader(tt.raw))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: ParseResponse() error = %v; want %v", tt.name, err, tt.want)
+		}
+		var limitErr *net.HeaderLimitError
+		if !errors.As(err, &limitErr) {
+			t.Errorf("%s: error should be *HeaderLimitError, got %T", tt.name, err)
+		}
+	}
+}
+
+// TestParseResponse_HeaderWithinLimits 제한 이하의 헤더는 정상 처리
+func TestParseResponse_HeaderWithinLimits(t *testing.T) {
+	value := strings.Repeat("a", 8000)
+	raw := "HTTP/1.1 200 OK\r\nX-Big: " + value + "\r\nContent-Length: 2\r\n\r\nok"
+
+	_, body, headers, err := net.ParseResponse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseResponse() failed: %v", err)
+	}
+	if headers["x-big"] != value || body != "ok" {
+		t.Errorf("x-big len = %d, body = %q; want %d, %q", len(headers["x-big"]), body, len(value), "ok")
+	}
+}
@@ -0,0 +1,146 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the lookalike-domain heuristics that warn about likely phishing addresses before navigation.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/url"
+	stdnet "net"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// CheckLookalikes가 true면 주소창에서 연 주소가 잘 알려진 사이트를 흉내 낸 것처럼 보일 때 경고함 (opt-in, 설정 파일의 lookalike_warning)
+var CheckLookalikes bool
+
+// PopularSites는 비슷한 주소를 찾을 때 기준으로 삼는 잘 알려진 사이트 (net.Site 꼴)
+var PopularSites = []string{
+	"google.com", "youtube.com", "facebook.com", "instagram.com", "apple.com", "microsoft.com",
+	"amazon.com", "paypal.com", "netflix.com", "github.com", "wikipedia.org",
+	"naver.com", "daum.net", "kakao.com", "coupang.com",
+}
+
+// LookalikeError는 탐색하려는 주소가 잘 알려진 사이트를 흉내 낸 것처럼 보일 때의 에러 (경고 페이지로 보여줌)
+type LookalikeError struct {
+	Host    string   // 사람이 읽는 모양의 호스트 ("xn--" 라벨을 유니코드로 바꾼 것)
+	Reasons []string // 의심하는 이유 (CheckLookalike)
+}
+
+func (e *LookalikeError) Error() string {
+	return fmt.Sprintf("비슷한 주소 경고 (%s): %s", e.Host, strings.Join(e.Reasons, ", "))
+}
+
+// LookalikeCheck는 CheckLookalikes가 켜져 있고 u의 호스트가 의심스러우면 *LookalikeError를 반환함
+func LookalikeCheck(u *url.URL) error {
+	if !CheckLookalikes || u.Host == "" || !isHTTPScheme(u.Scheme) {
+		return nil
+	}
+	if reasons := CheckLookalike(u.Host); len(reasons) > 0 {
+		return &LookalikeError{Host: url.HostToUnicode(strings.ToLower(u.Host)), Reasons: reasons}
+	}
+	return nil
+}
+
+// confusableScripts는 라틴 문자와 모양이 비슷한 글자가 많은 문자 체계
+var confusableScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"키릴", unicode.Cyrillic},
+	{"그리스", unicode.Greek},
+	{"아르메니아", unicode.Armenian},
+	{"체로키", unicode.Cherokee},
+}
+
+// confusables는 라틴 문자와 헷갈리는 글자 → 그 라틴 문자 (숫자 0, 1 포함)
+var confusables = map[rune]string{
+	'а': "a", 'е': "e", 'о': "o", 'р': "p", 'с': "c", 'у': "y", 'х': "x", 'і': "i", 'ј': "j",
+	'ѕ': "s", 'ԁ': "d", 'ӏ': "l", 'һ': "h", 'ԛ': "q", 'ԝ': "w", 'ɡ': "g", 'ı': "i",
+	'α': "a", 'ο': "o", 'ρ': "p", 'ν': "v", 'ι': "i", 'κ': "k", 'τ': "t", 'υ': "u",
+	'օ': "o", 'ս': "u", 'ց': "g",
+	'0': "o", '1': "l",
+}
+
+// skeleton은 헷갈리는 글자를 라틴 문자로 바꾼 모양 ("раураl.com", "paypa1.com" → "paypal.com")
+func skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if latin, ok := confusables[r]; ok {
+			b.WriteString(latin)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return strings.NewReplacer("rn", "m", "vv", "w").Replace(b.String())
+}
+
+// CheckLookalike는 host가 잘 알려진 사이트를 흉내 낸 주소로 보이는 이유들 (의심스럽지 않으면 nil)
+//
+//   - 한 라벨에 라틴 문자와 키릴, 그리스 문자 등이 섞임 ("pаypal.com"의 а는 키릴 문자)
+//   - 헷갈리는 글자("xn--" 라벨 포함)나 숫자를 라틴 문자로 바꾸면 잘 알려진 사이트가 됨 ("аррӏе.com", "g00gle.com")
+//   - 잘 알려진 사이트 이름을 다른 사이트의 하위 도메인에 겹쳐 넣음 ("paypal.com.login.example.net")
+func CheckLookalike(host string) []string {
+	host = url.HostToUnicode(strings.TrimSuffix(strings.ToLower(host), "."))
+	if stdnet.ParseIP(strings.Trim(host, "[]")) != nil {
+		return nil
+	}
+	var reasons []string
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if scripts := mixedScripts(label); len(scripts) > 1 {
+			reasons = append(reasons, fmt.Sprintf("%q에 %s 문자가 섞여 있음", label, strings.Join(scripts, ", ")))
+		}
+	}
+
+	site := Site(host)
+	if !slices.Contains(PopularSites, site) {
+		look := skeleton(site)
+		for _, popular := range PopularSites {
+			if look == popular {
+				reasons = append(reasons, popular+"처럼 보임")
+			}
+		}
+	}
+
+	sub := labels[:len(labels)-len(strings.Split(site, "."))]
+	for _, popular := range PopularSites {
+		if site == popular {
+			continue
+		}
+		brand, tld, _ := strings.Cut(popular, ".")
+		i := slices.Index(sub, brand)
+		if i < 0 {
+			continue
+		}
+		// "paypal.com.xxx"처럼 사이트 주소를 통째로 넣었거나, 하위 도메인을 세 단계 이상 겹쳐서 뒤쪽을 가림
+		if (i+1 < len(sub) && sub[i+1] == tld) || len(sub) >= 3 {
+			reasons = append(reasons, fmt.Sprintf("하위 도메인에 %s 이름이 들어 있음", popular))
+		}
+	}
+	return reasons
+}
+
+// mixedScripts는 label에 라틴 문자와 섞인 헷갈리는 문자 체계 이름들 (라틴 문자 포함, 한 가지뿐이면 그대로 하나)
+func mixedScripts(label string) []string {
+	var scripts []string
+	add := func(name string) {
+		if !slices.Contains(scripts, name) {
+			scripts = append(scripts, name)
+		}
+	}
+	for _, r := range label {
+		if r < unicode.MaxASCII {
+			if unicode.IsLetter(r) {
+				add("라틴")
+			}
+			continue
+		}
+		for _, s := range confusableScripts {
+			if unicode.Is(s.table, r) {
+				add(s.name)
+			}
+		}
+	}
+	return scripts
+}
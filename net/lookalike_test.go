@@ -0,0 +1,56 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"strings"
+	"testing"
+)
+
+// TestCheckLookalike 섞인 문자 체계, 헷갈리는 글자, 하위 도메인에 넣은 사이트 이름을 찾고 평범한 주소는 넘어감
+func TestCheckLookalike(t *testing.T) {
+	tests := []struct {
+		host string
+		want string // 이유에 들어 있어야 하는 글 ("" 이면 이유 없음)
+	}{
+		{"www.google.com", ""},
+		{"example.com", ""},
+		{"한글도메인.kr", ""},
+		{"127.0.0.1", ""},
+		{"news.naver.com", ""},
+		{"pаypal.com", "라틴, 키릴"},
+		{"xn--80ak6aa92e.com", "apple.com처럼 보임"},
+		{"g00gle.com", "google.com처럼 보임"},
+		{"rnicrosoft.com", "microsoft.com처럼 보임"},
+		{"paypal.com.login.example.net", "하위 도메인에 paypal.com"},
+		{"secure.github.account.verify.example.org", "하위 도메인에 github.com"},
+		{"github.example.org", ""},
+	}
+	for _, tt := range tests {
+		reasons := strings.Join(net.CheckLookalike(tt.host), "; ")
+		if (tt.want == "") != (reasons == "") || !strings.Contains(reasons, tt.want) {
+			t.Errorf("CheckLookalike(%q) = %q; want %q", tt.host, reasons, tt.want)
+		}
+	}
+}
+
+// TestLookalikeCheck 켜져 있을 때만 경고하고, 경고는 비슷한 주소 에러 페이지로 보여줌
+func TestLookalikeCheck(t *testing.T) {
+	u, _ := url.NewURL("http://xn--80ak6aa92e.com/")
+	if err := net.LookalikeCheck(u); err != nil {
+		t.Errorf("LookalikeCheck() while disabled = %v; want nil", err)
+	}
+
+	net.CheckLookalikes = true
+	defer func() { net.CheckLookalikes = false }()
+	err := net.LookalikeCheck(u)
+	if err == nil || !strings.Contains(err.Error(), "аррӏе.com") {
+		t.Fatalf("LookalikeCheck() = %v; want a warning with the Unicode host", err)
+	}
+	if kind := net.ClassifyError(err); kind != net.ErrorKindLookalike {
+		t.Errorf("ClassifyError() = %v; want ErrorKindLookalike", kind)
+	}
+	if page := net.ErrorPage(u, err); !strings.Contains(page.Body, "가짜 사이트일 수 있음") {
+		t.Errorf("ErrorPage() body = %q; want the lookalike title", page.Body)
+	}
+}
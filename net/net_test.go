@@ -1,12 +1,16 @@
 package net_test
 
 import (
+	"errors"
 	"go-web-browser/net"
 	"go-web-browser/url"
+	"io"
+	"log"
 	stdnet "net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -195,6 +199,51 @@ func TestDataFetcher_ComplexHTML(t *testing.T) {
 	}
 }
 
+// TestDataFetcher_Decoding "+"는 글자 그대로, 잘못된 퍼센트 인코딩과 패딩 없는 base64는 strict일 때만 에러, charset 파라미터로 디코딩
+func TestDataFetcher_Decoding(t *testing.T) {
+	tests := []struct {
+		raw         string
+		strict      bool
+		want        string
+		contentType string
+		wantErr     bool
+	}{
+		{"data:,1+1=2", false, "1+1=2", "text/plain", false},
+		{"data:text/plain,100%25+%zz", false, "100%+%zz", "text/plain", false},
+		{"data:text/plain,100%25+%zz", true, "", "", true},
+		{"data:text/plain,50%", true, "", "", true},
+		{"data:;charset=utf-8,%ED%95%9C", false, "한", "text/plain;charset=utf-8", false},
+		{"data:text/html;charset=euc-kr,%C7%D1%B1%DB", false, "한글", "text/html;charset=euc-kr", false},
+		{"data:text/plain;BASE64,SGk%3D", true, "Hi", "text/plain", false},
+		{"data:text/plain;base64,SG k", false, "Hi", "text/plain", false},
+		{"data:text/plain;base64,SGk", true, "", "", true},
+	}
+
+	for _, tt := range tests {
+		u, err := url.NewURL(tt.raw)
+		if err != nil {
+			t.Fatalf("url.NewURL(%q) failed: %v", tt.raw, err)
+		}
+		resp, err := (&net.DataFetcher{Strict: tt.strict}).Fetch(u)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Fetch(%q) strict=%v = %q; want error", tt.raw, tt.strict, resp.Body)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Fetch(%q) strict=%v failed: %v", tt.raw, tt.strict, err)
+			continue
+		}
+		if resp.Body != tt.want {
+			t.Errorf("Fetch(%q).Body = %q; want %q", tt.raw, resp.Body, tt.want)
+		}
+		if got := resp.Headers["content-type"]; got != tt.contentType {
+			t.Errorf("Fetch(%q) content-type = %q; want %q", tt.raw, got, tt.contentType)
+		}
+	}
+}
+
 // TestDataFetcher_MissingComma 쉼표 없는 잘못된 data URL
 func TestDataFetcher_MissingComma(t *testing.T) {
 	urlStr := "data:text/html"
@@ -225,6 +274,35 @@ func TestDataFetcher_InvalidBase64(t *testing.T) {
 	}
 }
 
+// ============================================
+// ContactFetcher 테스트
+// ============================================
+
+// TestContactFetcher mailto:, tel: 링크는 받는 사람, 제목, 번호를 정리한 텍스트 페이지
+func TestContactFetcher(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"mailto:a@example.com?cc=b@example.com&subject=Hi%20there",
+			"메일 보내기\n\n받는 사람: a@example.com\n참조: b@example.com\n제목: Hi there\n"},
+		{"tel:+1-201-555-0123", "전화 걸기\n\n번호: +1-201-555-0123\n"},
+	}
+	for _, tt := range tests {
+		u, err := url.NewURL(tt.input)
+		if err != nil {
+			t.Fatalf("url.NewURL(%q) failed: %v", tt.input, err)
+		}
+		resp, err := net.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%q) failed: %v", tt.input, err)
+		}
+		if resp.Body != tt.want || resp.ContentType != net.MIMETextPlain {
+			t.Errorf("Fetch(%q) = %q (%s); want %q (%s)", tt.input, resp.Body, resp.ContentType, tt.want, net.MIMETextPlain)
+		}
+	}
+}
+
 // ============================================
 // HTTPFetcher 테스트
 // ============================================
@@ -315,6 +393,25 @@ func TestHTTPFetcher_EmptyResponse(t *testing.T) {
 	}
 }
 
+// TestHTTPFetcher_EUCKRFallback 헤더와 <meta>에 charset이 없는 EUC-KR 페이지는 본문으로 추측해서 UTF-8로 변환함
+func TestHTTPFetcher_EUCKRFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		// "<p>안녕하세요</p>"의 EUC-KR 바이트
+		w.Write([]byte("<p>\xbe\xc8\xb3\xe7\xc7\xcf\xbc\xbc\xbf\xe4</p>"))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/euc-kr")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "<p>안녕하세요</p>" || resp.Charset != "euc-kr" {
+		t.Errorf("Body, Charset = %q, %q; want %q, %q", resp.Body, resp.Charset, "<p>안녕하세요</p>", "euc-kr")
+	}
+}
+
 // TestHTTPFetcher_HTTPS HTTPS URL 파싱 검증
 // 참고: 실제 HTTPS 연결 테스트는 인증서 검증 문제로 mock 서버로 어려움
 // 실제 프로덕션 환경에서는 정상 작동함
@@ -338,6 +435,63 @@ func TestHTTPFetcher_HTTPS(t *testing.T) {
 	t.Skip("Skipping actual HTTPS request test - would require valid certificate or mock setup")
 }
 
+// TestHTTPFetcher_IPv6 "[::1]:포트" 주소로 연결하고 Host 헤더에도 대괄호를 붙임
+func TestHTTPFetcher_IPv6(t *testing.T) {
+	t.Parallel()
+	listener, err := stdnet.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 loopback not available: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	u, err := url.NewURL(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.NewURL(%q) failed: %v", server.URL, err)
+	}
+	content, err := requestWith(isolatedFetcher(t), u)
+	if err != nil {
+		t.Fatalf("Fetch(%q) failed: %v", u, err)
+	}
+	if content != "[::1]" {
+		t.Errorf("Host header = %q; want %q", content, "[::1]")
+	}
+}
+
+// TestHTTPFetcher_UserinfoRedacted 주소의 비밀번호는 로그와 캐시 키(내보낸 캐시)에 남기지 않음
+func TestHTTPFetcher_UserinfoRedacted(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Connection", "close")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var logs strings.Builder
+	fetcher := isolatedFetcher(t)
+	fetcher.Logger = log.New(&logs, "", 0)
+	u, _ := url.NewURL(strings.Replace(server.URL, "http://", "http://user:secret@", 1) + "/")
+	if _, err := fetcher.Fetch(u); err != nil {
+		t.Fatalf("Fetch(%q) failed: %v", u.Redacted(), err)
+	}
+
+	var exported strings.Builder
+	if err := fetcher.Cache.Export(&exported); err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	if strings.Contains(logs.String(), "secret") || strings.Contains(exported.String(), "secret") {
+		t.Errorf("password leaked: logs = %q, exported cache = %q", logs.String(), exported.String())
+	}
+	if _, found := fetcher.Cache.Get(u.Normalize().Redacted()); !found {
+		t.Errorf("Cache.Get(%q) should hit", u.Normalize().Redacted())
+	}
+}
+
 // TestHTTPFetcher_InvalidHost 존재하지 않는 호스트
 func TestHTTPFetcher_InvalidHost(t *testing.T) {
 	urlStr := "http://invalid-host-that-does-not-exist-12345.com/"
@@ -405,6 +559,41 @@ func TestViewSourceFetcher_HTTP(t *testing.T) {
 	}
 }
 
+// TestViewSourceFetcher_Headers Headers면 상태 줄, 응답 헤더, 리다이렉트된 최종 URL을 주석으로 앞에 붙임
+func TestViewSourceFetcher_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Note", "a-->b")
+		w.Write([]byte("<p>new</p>"))
+	}))
+	defer server.Close()
+
+	u, err := url.NewURL("view-source:" + server.URL + "/old")
+	if err != nil {
+		t.Fatalf("url.NewURL() failed: %v", err)
+	}
+	resp, err := (&net.ViewSourceFetcher{Headers: true}).Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<!--\nHTTP/1.1 200 OK\n",
+		"최종 URL: " + server.URL + "/new (리다이렉트됨)\n",
+		"content-type: text/html\n",
+		"x-note: a- ->b\n",
+		"-->\n<p>new</p>",
+	} {
+		if !strings.Contains(resp.Body, want) {
+			t.Errorf("Fetch().Body = %q; want it to contain %q", resp.Body, want)
+		}
+	}
+}
+
 // TestViewSourceFetcher_File view-source:file URL 테스트
 func TestViewSourceFetcher_File(t *testing.T) {
 	urlStr := "view-source:file://testdata/simple.html"
@@ -900,11 +1089,31 @@ func TestHTTPFetcher_Redirect301(t *testing.T) {
 }
 
 // ============================================
-// Caching 테스트
+// Caching 테스트 (테스트마다 따로 만든 캐시, 연결 풀을 써서 동시에 돌림)
 // ============================================
 
+// isolatedFetcher: 전역 캐시, 연결 풀, 로거를 건드리지 않는 HTTPFetcher
+func isolatedFetcher(t testing.TB) *net.HTTPFetcher {
+	t.Helper()
+	cache := net.NewCache()
+	cache.SetLogger(log.New(io.Discard, "", 0))
+	pool := net.NewConnectionPool()
+	pool.SetLogger(log.New(io.Discard, "", 0))
+	return &net.HTTPFetcher{Cache: cache, Pool: pool, Logger: log.New(io.Discard, "", 0)}
+}
+
+// requestWith: net.Request처럼 본문만 반환하되 f로 가져옴
+func requestWith(f *net.HTTPFetcher, u *url.URL) (string, error) {
+	resp, err := f.Fetch(u)
+	if err != nil {
+		return "", err
+	}
+	return resp.Body, nil
+}
+
 // TestHTTPFetcher_CacheBasic: 기본 캐싱 동작 - 동일한 URL 두 번 요청
 func TestHTTPFetcher_CacheBasic(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -913,8 +1122,7 @@ func TestHTTPFetcher_CacheBasic(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u, err := url.NewURL(server.URL)
 	if err != nil {
@@ -922,7 +1130,7 @@ func TestHTTPFetcher_CacheBasic(t *testing.T) {
 	}
 
 	// First request - should hit server
-	content1, err := net.Request(u)
+	content1, err := requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("First Request() failed: %v", err)
 	}
@@ -932,7 +1140,7 @@ func TestHTTPFetcher_CacheBasic(t *testing.T) {
 	}
 
 	// Second request - should hit cache
-	content2, err := net.Request(u)
+	content2, err := requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("Second Request() failed: %v", err)
 	}
@@ -952,8 +1160,36 @@ func TestHTTPFetcher_CacheBasic(t *testing.T) {
 	}
 }
 
+// TestHTTPFetcher_CacheHeadersCopied: 응답의 헤더를 고쳐도 캐시 엔트리와 다음 캐시 응답의 헤더는 그대로
+func TestHTTPFetcher_CacheHeadersCopied(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Origin", "server")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	fetcher := isolatedFetcher(t)
+	u, _ := url.NewURL(server.URL)
+	for i := range 3 {
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch() #%d failed: %v", i+1, err)
+		}
+		if got := resp.Headers.Get("X-Origin"); got != "server" {
+			t.Errorf("Fetch() #%d X-Origin = %q; want %q (FromCache = %v)", i+1, got, "server", resp.FromCache)
+		}
+		resp.Headers.Set("X-Origin", "modified")
+		resp.Headers.Del("Content-Type")
+	}
+	if entry, _ := fetcher.Cache.Get(u.Normalize().Redacted()); entry == nil || entry.Headers.Get("Content-Type") == "" {
+		t.Errorf("cache entry headers = %v; want the server's headers", entry)
+	}
+}
+
 // TestHTTPFetcher_CacheNoStore: Cache-Control: no-store는 캐시하지 않음
 func TestHTTPFetcher_CacheNoStore(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -963,8 +1199,7 @@ func TestHTTPFetcher_CacheNoStore(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u, err := url.NewURL(server.URL)
 	if err != nil {
@@ -972,13 +1207,13 @@ func TestHTTPFetcher_CacheNoStore(t *testing.T) {
 	}
 
 	// First request
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("First Request() failed: %v", err)
 	}
 
 	// Second request - should still hit server (no-store)
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("Second Request() failed: %v", err)
 	}
@@ -990,6 +1225,7 @@ func TestHTTPFetcher_CacheNoStore(t *testing.T) {
 
 // TestHTTPFetcher_CacheMaxAge: Cache-Control: max-age=60은 캐시함
 func TestHTTPFetcher_CacheMaxAge(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -999,8 +1235,7 @@ func TestHTTPFetcher_CacheMaxAge(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u, err := url.NewURL(server.URL)
 	if err != nil {
@@ -1008,13 +1243,13 @@ func TestHTTPFetcher_CacheMaxAge(t *testing.T) {
 	}
 
 	// First request
-	content1, err := net.Request(u)
+	content1, err := requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("First Request() failed: %v", err)
 	}
 
 	// Second request - should hit cache (within max-age)
-	content2, err := net.Request(u)
+	content2, err := requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("Second Request() failed: %v", err)
 	}
@@ -1030,6 +1265,7 @@ func TestHTTPFetcher_CacheMaxAge(t *testing.T) {
 
 // TestHTTPFetcher_CacheExpired: max-age 초과 시 캐시 무효화
 func TestHTTPFetcher_CacheExpired(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -1040,8 +1276,7 @@ func TestHTTPFetcher_CacheExpired(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u, err := url.NewURL(server.URL)
 	if err != nil {
@@ -1049,7 +1284,7 @@ func TestHTTPFetcher_CacheExpired(t *testing.T) {
 	}
 
 	// First request
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("First Request() failed: %v", err)
 	}
@@ -1058,7 +1293,7 @@ func TestHTTPFetcher_CacheExpired(t *testing.T) {
 	time.Sleep(2 * time.Second)
 
 	// Second request - should hit server (cache expired)
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("Second Request() failed: %v", err)
 	}
@@ -1070,6 +1305,7 @@ func TestHTTPFetcher_CacheExpired(t *testing.T) {
 
 // TestHTTPFetcher_CacheOtherDirectives: 다른 Cache-Control 값은 캐시하지 않음
 func TestHTTPFetcher_CacheOtherDirectives(t *testing.T) {
+	t.Parallel()
 	testCases := []struct {
 		name         string
 		cacheControl string
@@ -1082,6 +1318,7 @@ func TestHTTPFetcher_CacheOtherDirectives(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
 			requestCount := 0
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				requestCount++
@@ -1091,8 +1328,7 @@ func TestHTTPFetcher_CacheOtherDirectives(t *testing.T) {
 			}))
 			defer server.Close()
 
-			// Clear cache before test
-			net.GlobalCache.Clear()
+			fetcher := isolatedFetcher(t)
 
 			u, err := url.NewURL(server.URL)
 			if err != nil {
@@ -1100,13 +1336,13 @@ func TestHTTPFetcher_CacheOtherDirectives(t *testing.T) {
 			}
 
 			// First request
-			_, err = net.Request(u)
+			_, err = requestWith(fetcher, u)
 			if err != nil {
 				t.Fatalf("First Request() failed: %v", err)
 			}
 
 			// Second request - should still hit server (unknown directive)
-			_, err = net.Request(u)
+			_, err = requestWith(fetcher, u)
 			if err != nil {
 				t.Fatalf("Second Request() failed: %v", err)
 			}
@@ -1120,6 +1356,7 @@ func TestHTTPFetcher_CacheOtherDirectives(t *testing.T) {
 
 // TestHTTPFetcher_CacheNoCacheControl: Cache-Control 헤더 없으면 기본 캐시
 func TestHTTPFetcher_CacheNoCacheControl(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -1129,8 +1366,7 @@ func TestHTTPFetcher_CacheNoCacheControl(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u, err := url.NewURL(server.URL)
 	if err != nil {
@@ -1138,13 +1374,13 @@ func TestHTTPFetcher_CacheNoCacheControl(t *testing.T) {
 	}
 
 	// First request
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("First Request() failed: %v", err)
 	}
 
 	// Second request - should hit cache (default cacheable)
-	_, err = net.Request(u)
+	_, err = requestWith(fetcher, u)
 	if err != nil {
 		t.Fatalf("Second Request() failed: %v", err)
 	}
@@ -1156,6 +1392,7 @@ func TestHTTPFetcher_CacheNoCacheControl(t *testing.T) {
 
 // TestHTTPFetcher_CacheDifferentURLs: 다른 URL은 별도로 캐시
 func TestHTTPFetcher_CacheDifferentURLs(t *testing.T) {
+	t.Parallel()
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
@@ -1164,8 +1401,7 @@ func TestHTTPFetcher_CacheDifferentURLs(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Clear cache before test
-	net.GlobalCache.Clear()
+	fetcher := isolatedFetcher(t)
 
 	u1, err := url.NewURL(server.URL + "/page1")
 	if err != nil {
@@ -1178,13 +1414,13 @@ func TestHTTPFetcher_CacheDifferentURLs(t *testing.T) {
 	}
 
 	// Request url1
-	content1, err := net.Request(u1)
+	content1, err := requestWith(fetcher, u1)
 	if err != nil {
 		t.Fatalf("Request() failed: %v", err)
 	}
 
 	// Request url2 - different URL, should hit server
-	content2, err := net.Request(u2)
+	content2, err := requestWith(fetcher, u2)
 	if err != nil {
 		t.Fatalf("Request() failed: %v", err)
 	}
@@ -1198,7 +1434,7 @@ func TestHTTPFetcher_CacheDifferentURLs(t *testing.T) {
 	}
 
 	// Request url1 again - should hit cache
-	_, err = net.Request(u1)
+	_, err = requestWith(fetcher, u1)
 	if err != nil {
 		t.Fatalf("Request() failed: %v", err)
 	}
@@ -1207,3 +1443,274 @@ func TestHTTPFetcher_CacheDifferentURLs(t *testing.T) {
 		t.Errorf("Second request to url1 should hit cache, expected 2 total requests, got %d", requestCount)
 	}
 }
+
+// ============================================
+// 1xx 중간 응답 테스트
+// ============================================
+
+// TestParseResponse_SkipsInterim 100 Continue, 102 Processing 뒤의 최종 응답을 읽음
+func TestParseResponse_SkipsInterim(t *testing.T) {
+	raw := "HTTP/1.1 100 Continue\r\n\r\n" +
+		"HTTP/1.1 102 Processing\r\n\r\n" +
+		"HTTP/1.1 200 OK\r\nContent-Length: 5\r\nX-Final: yes\r\n\r\nHello"
+
+	statusCode, body, headers, err := net.ParseResponse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseResponse() failed: %v", err)
+	}
+	if statusCode != 200 {
+		t.Errorf("statusCode = %d; want 200", statusCode)
+	}
+	if body != "Hello" {
+		t.Errorf("body = %q; want %q", body, "Hello")
+	}
+	if headers["x-final"] != "yes" {
+		t.Errorf("headers[x-final] = %q; want %q", headers["x-final"], "yes")
+	}
+}
+
+// TestParseResponse_SwitchingProtocols 101은 최종 응답으로 취급
+func TestParseResponse_SwitchingProtocols(t *testing.T) {
+	raw := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nContent-Length: 0\r\n\r\n"
+
+	statusCode, _, _, err := net.ParseResponse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseResponse() failed: %v", err)
+	}
+	if statusCode != 101 {
+		t.Errorf("statusCode = %d; want 101", statusCode)
+	}
+}
+
+// TestHTTPFetcher_100Continue 서버가 100 Continue를 먼저 보내도 최종 응답을 반환
+func TestHTTPFetcher_100Continue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, _ := w.(http.Hijacker).Hijack()
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 100 Continue\r\n\r\n")
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+		buf.Flush()
+	}))
+	defer server.Close()
+
+	u, err := url.NewURL(server.URL + "/continue")
+	if err != nil {
+		t.Fatalf("url.NewURL(%q) failed: %v", server.URL, err)
+	}
+
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != 200 || resp.Body != "ok" {
+		t.Errorf("Fetch() = %d %q; want 200 %q", resp.StatusCode, resp.Body, "ok")
+	}
+}
+
+// TestHTTPFetcher_CacheNormalizedKey 같은 자원을 가리키는 URL은 캐시를 공유
+func TestHTTPFetcher_CacheNormalizedKey(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Write([]byte("same"))
+	}))
+	defer server.Close()
+
+	// server.URL은 "http://127.0.0.1:port" (경로 없음)
+	variants := []string{
+		server.URL + "/norm?b=2&a=1",
+		server.URL + "/norm?a=1&b=2",
+		strings.Replace(server.URL, "http://", "HTTP://", 1) + "/norm?a=1&b=2#frag",
+	}
+	for _, v := range variants {
+		u, err := url.NewURL(v)
+		if err != nil {
+			t.Fatalf("url.NewURL(%q) failed: %v", v, err)
+		}
+		if _, err := net.Request(u); err != nil {
+			t.Fatalf("Request(%q) failed: %v", v, err)
+		}
+	}
+
+	if requestCount != 1 {
+		t.Errorf("requestCount = %d; want 1 (normalized URLs share one cache entry)", requestCount)
+	}
+}
+
+// ============================================
+// 본문 길이 불일치 테스트
+// ============================================
+
+// TestParseResponse_BodyLength Content-Length나 청크보다 짧은 본문은 ErrTruncatedBody, 긴 본문은 ErrExcessBody
+func TestParseResponse_BodyLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		wantBody string
+		wantErr  error
+	}{
+		{"정확한 길이", "HTTP/1.1 200 OK\r\nContent-Length: 5\r\n\r\nHello", "Hello", nil},
+		{"짧은 본문", "HTTP/1.1 200 OK\r\nContent-Length: 10\r\n\r\nHello", "", net.ErrTruncatedBody},
+		{"빈 본문", "HTTP/1.1 200 OK\r\nContent-Length: 10\r\n\r\n", "", net.ErrTruncatedBody},
+		{"잘린 청크", "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nHel", "", net.ErrTruncatedBody},
+		{"마지막 청크 없음", "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nHello\r\n", "", net.ErrTruncatedBody},
+		{"긴 본문", "HTTP/1.1 200 OK\r\nContent-Length: 5\r\n\r\nHello, world", "Hello", net.ErrExcessBody},
+		{"청크 뒤의 바이트", "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nHello\r\n0\r\n\r\njunk", "Hello", net.ErrExcessBody},
+	}
+
+	for _, tt := range tests {
+		_, body, _, err := net.ParseResponse(strings.NewReader(tt.raw))
+		if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+			t.Errorf("%s: ParseResponse() error = %v; want %v", tt.name, err, tt.wantErr)
+		}
+		if body != tt.wantBody {
+			t.Errorf("%s: ParseResponse() body = %q; want %q", tt.name, body, tt.wantBody)
+		}
+		if tt.wantErr == net.ErrTruncatedBody && !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: ParseResponse() error = %v; want it to wrap io.ErrUnexpectedEOF", tt.name, err)
+		}
+	}
+}
+
+// TestHTTPFetcher_ExcessBodyNotPooled Content-Length보다 긴 응답은 선언된 길이까지 보여주고 그 연결은 재사용하지 않음
+func TestHTTPFetcher_ExcessBodyNotPooled(t *testing.T) {
+	var mu sync.Mutex
+	var conns []stdnet.Conn
+	addrs := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, _ := w.(http.Hijacker).Hijack()
+		// 응답을 쓰기 전에 기록함 (쓰고 나면 클라이언트가 이미 다음 요청을 보낼 수 있음)
+		mu.Lock()
+		conns = append(conns, conn) // 연결을 열어 둠 (재사용하면 응답이 오지 않음)
+		addrs[r.RemoteAddr] = true
+		mu.Unlock()
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 5\r\n\r\nHello, world")
+		buf.Flush()
+	}))
+	defer server.Close()
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+
+	fetcher := &net.HTTPFetcher{}
+	for _, path := range []string{"/a", "/b"} {
+		u, _ := url.NewURL(server.URL + path)
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%s) failed: %v", path, err)
+		}
+		if resp.Body != "Hello" {
+			t.Errorf("Fetch(%s).Body = %q; want %q", path, resp.Body, "Hello")
+		}
+	}
+	mu.Lock()
+	seen := len(addrs)
+	mu.Unlock()
+	if seen != 2 {
+		t.Errorf("server saw %d connections; want 2 (excess body must not be reused)", seen)
+	}
+}
+
+// TestHTTPFetcher_LateExcessBodyNotReused 응답을 다 읽은 뒤에 따로 도착한 초과 본문이 있는 연결은 다시 쓰지 않음
+func TestHTTPFetcher_LateExcessBodyNotReused(t *testing.T) {
+	var mu sync.Mutex
+	var conns []stdnet.Conn
+	addrs := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, _ := w.(http.Hijacker).Hijack()
+		mu.Lock()
+		conns = append(conns, conn) // 연결을 열어 둠 (재사용하면 응답이 오지 않음)
+		addrs[r.RemoteAddr] = true
+		mu.Unlock()
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 5\r\nCache-Control: no-store\r\n\r\nHello")
+		buf.Flush()
+		// 클라이언트가 응답을 다 읽고 연결을 풀에 돌려준 뒤에 남은 본문을 보냄
+		time.Sleep(20 * time.Millisecond)
+		buf.WriteString(", world")
+		buf.Flush()
+	}))
+	defer server.Close()
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+
+	fetcher := isolatedFetcher(t)
+	for i, path := range []string{"/a", "/b"} {
+		if i > 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+		u, _ := url.NewURL(server.URL + path)
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%s) failed: %v", path, err)
+		}
+		if resp.Body != "Hello" {
+			t.Errorf("Fetch(%s).Body = %q; want %q", path, resp.Body, "Hello")
+		}
+	}
+	mu.Lock()
+	seen := len(addrs)
+	mu.Unlock()
+	if seen != 2 {
+		t.Errorf("server saw %d connections; want 2 (connection with late excess body must not be reused)", seen)
+	}
+	if s := fetcher.Pool.Stats()[strings.TrimPrefix(server.URL, "http://")]; s.Reused != 0 {
+		t.Errorf("Stats().Reused = %d; want 0", s.Reused)
+	}
+}
+
+// ============================================
+// IPFSFetcher 테스트
+// ============================================
+
+// TestIPFSFetcher ipfs://, ipns:// 주소를 게이트웨이 경로로 바꿔서 가져오고 응답 URL은 원래 주소로 둠
+func TestIPFSFetcher(t *testing.T) {
+	var requested []string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<a href="b.html">b</a>`))
+	}))
+	defer gateway.Close()
+	fetcher := &net.IPFSFetcher{Gateway: gateway.URL + "/"}
+
+	tests := []struct {
+		raw      string
+		wantPath string
+	}{
+		{"ipfs://bafyabc/docs/a.html", "/ipfs/bafyabc/docs/a.html"},
+		{"ipns://example.org", "/ipns/example.org/"},
+	}
+	for _, tt := range tests {
+		u, err := url.NewURL(tt.raw)
+		if err != nil {
+			t.Fatalf("url.NewURL(%q) failed: %v", tt.raw, err)
+		}
+		resp, err := fetcher.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%q) failed: %v", tt.raw, err)
+		}
+		if got := requested[len(requested)-1]; got != tt.wantPath {
+			t.Errorf("Fetch(%q) requested %q; want %q", tt.raw, got, tt.wantPath)
+		}
+		if resp.URL.String() != u.String() {
+			t.Errorf("Fetch(%q).URL = %q; want %q", tt.raw, resp.URL, u)
+		}
+	}
+
+	// 상대 링크도 ipfs:// 주소로 이어짐
+	base, _ := url.NewURL("ipfs://bafyabc/docs/a.html")
+	next, err := base.Resolve("b.html")
+	if err != nil || next.String() != "ipfs://bafyabc/docs/b.html" {
+		t.Errorf("Resolve(%q) = %v, %v; want %q", "b.html", next, err, "ipfs://bafyabc/docs/b.html")
+	}
+}
@@ -0,0 +1,283 @@
+// Package net implements HTTP networking for the browser.
+// This file contains parsing and verification of stapled OCSP responses (RFC 6960).
+package net
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha1" // CertID 해시 (대부분의 OCSP 응답이 SHA-1을 씀)
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RevocationStatus는 인증서의 폐기 여부
+type RevocationStatus int
+
+// 폐기 상태
+const (
+	RevocationUnknown RevocationStatus = iota // 확인하지 않았거나 확인할 수 없음
+	RevocationGood                            // OCSP 응답이 유효하다고 함
+	RevocationRevoked                         // OCSP 응답이 폐기되었다고 함
+)
+
+func (s RevocationStatus) String() string {
+	switch s {
+	case RevocationGood:
+		return "good"
+	case RevocationRevoked:
+		return "revoked"
+	}
+	return "unknown"
+}
+
+// OCSPResponse는 파싱한 OCSP 응답의 첫 번째 SingleResponse
+type OCSPResponse struct {
+	Status       RevocationStatus
+	SerialNumber *big.Int
+	ProducedAt   time.Time
+	ThisUpdate   time.Time
+	NextUpdate   time.Time // 없으면 zero (응답에 유효 기간 끝이 없음)
+	RevokedAt    time.Time // Status가 RevocationRevoked일 때만
+
+	certID    ocspCertID
+	tbs       []byte // 서명 대상 (ResponseData의 DER)
+	sigAlg    x509.SignatureAlgorithm
+	signature []byte
+	certs     []*x509.Certificate // 응답에 포함된 인증서 (위임된 응답자)
+}
+
+// RevokedError는 OCSP 응답이 서버 인증서가 폐기되었다고 할 때의 에러
+type RevokedError struct {
+	Address   string // "host:port"
+	RevokedAt time.Time
+}
+
+func (e *RevokedError) Error() string {
+	return fmt.Sprintf("%s의 인증서는 %s에 폐기되었습니다 (OCSP)", e.Address, e.RevokedAt.Format(time.RFC3339))
+}
+
+// ocspMaxSkew는 thisUpdate/nextUpdate를 비교할 때 허용하는 시계 차이
+const ocspMaxSkew = 5 * time.Minute
+
+// idPKIXOCSPBasic은 ResponseBytes.responseType의 id-pkix-ocsp-basic
+var idPKIXOCSPBasic = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+
+// RFC 6960 4.2.1의 ASN.1 구조
+type ocspResponseASN1 struct {
+	Status   asn1.Enumerated
+	Response ocspResponseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type ocspBasicResponse struct {
+	TBSResponseData    ocspResponseData
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+	Certificates       []asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseData struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,default:0,explicit,tag:0"`
+	RawResponderID     asn1.RawValue
+	ProducedAt         time.Time `asn1:"generalized"`
+	Responses          []ocspSingleResponse
+	ResponseExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspSingleResponse struct {
+	CertID           ocspCertID
+	Good             asn1.Flag        `asn1:"tag:0,optional"`
+	Revoked          ocspRevokedInfo  `asn1:"tag:1,optional"`
+	Unknown          asn1.Flag        `asn1:"tag:2,optional"`
+	ThisUpdate       time.Time        `asn1:"generalized"`
+	NextUpdate       time.Time        `asn1:"generalized,explicit,tag:0,optional"`
+	SingleExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspRevokedInfo struct {
+	RevocationTime time.Time       `asn1:"generalized"`
+	Reason         asn1.Enumerated `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+// ocspHashes는 CertID에 쓰이는 해시 알고리즘 OID
+var ocspHashes = map[string]crypto.Hash{
+	"1.3.14.3.2.26":          crypto.SHA1,
+	"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+	"2.16.840.1.101.3.4.2.2": crypto.SHA384,
+	"2.16.840.1.101.3.4.2.3": crypto.SHA512,
+}
+
+// ocspSignatureAlgorithms는 응답 서명 알고리즘 OID (RSA-PSS는 지원하지 않음)
+var ocspSignatureAlgorithms = map[string]x509.SignatureAlgorithm{
+	"1.2.840.113549.1.1.5":  x509.SHA1WithRSA,
+	"1.2.840.113549.1.1.11": x509.SHA256WithRSA,
+	"1.2.840.113549.1.1.12": x509.SHA384WithRSA,
+	"1.2.840.113549.1.1.13": x509.SHA512WithRSA,
+	"1.2.840.10045.4.1":     x509.ECDSAWithSHA1,
+	"1.2.840.10045.4.3.2":   x509.ECDSAWithSHA256,
+	"1.2.840.10045.4.3.3":   x509.ECDSAWithSHA384,
+	"1.2.840.10045.4.3.4":   x509.ECDSAWithSHA512,
+	"1.3.101.112":           x509.PureEd25519,
+}
+
+// ParseOCSPResponse는 DER로 인코딩된 OCSP 응답(TLS staple)을 파싱함
+//
+// 서명과 인증서 일치 여부는 확인하지 않음 (Verify 사용)
+// 응답 상태가 successful이 아니거나 basic 응답이 아니면 에러
+func ParseOCSPResponse(der []byte) (*OCSPResponse, error) {
+	var resp ocspResponseASN1
+	rest, err := asn1.Unmarshal(der, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("OCSP 응답 파싱 실패: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("OCSP 응답 뒤에 남은 데이터가 있습니다")
+	}
+	if resp.Status != 0 {
+		return nil, fmt.Errorf("OCSP 응답 상태가 실패입니다 (%d)", resp.Status)
+	}
+	if !resp.Response.ResponseType.Equal(idPKIXOCSPBasic) {
+		return nil, fmt.Errorf("지원하지 않는 OCSP 응답 형식: %v", resp.Response.ResponseType)
+	}
+
+	var basic ocspBasicResponse
+	if _, err := asn1.Unmarshal(resp.Response.Response, &basic); err != nil {
+		return nil, fmt.Errorf("OCSP 응답 파싱 실패: %w", err)
+	}
+	if len(basic.TBSResponseData.Responses) == 0 {
+		return nil, errors.New("OCSP 응답에 인증서 상태가 없습니다")
+	}
+
+	single := basic.TBSResponseData.Responses[0]
+	r := &OCSPResponse{
+		SerialNumber: single.CertID.SerialNumber,
+		ProducedAt:   basic.TBSResponseData.ProducedAt,
+		ThisUpdate:   single.ThisUpdate,
+		NextUpdate:   single.NextUpdate,
+		certID:       single.CertID,
+		tbs:          basic.TBSResponseData.Raw,
+		sigAlg:       ocspSignatureAlgorithms[basic.SignatureAlgorithm.Algorithm.String()],
+		signature:    basic.Signature.RightAlign(),
+	}
+	switch {
+	case bool(single.Good):
+		r.Status = RevocationGood
+	case bool(single.Unknown):
+		r.Status = RevocationUnknown
+	default:
+		r.Status = RevocationRevoked
+		r.RevokedAt = single.Revoked.RevocationTime
+	}
+
+	for _, raw := range basic.Certificates {
+		cert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			return nil, fmt.Errorf("OCSP 응답자 인증서 파싱 실패: %w", err)
+		}
+		r.certs = append(r.certs, cert)
+	}
+	return r, nil
+}
+
+// Verify는 응답이 leaf 인증서에 대한 것이고, issuer(또는 issuer가 위임한 응답자)가
+// 서명했고, now 기준으로 유효 기간 안인지 확인함
+func (r *OCSPResponse) Verify(leaf, issuer *x509.Certificate, now time.Time) error {
+	if err := r.matches(leaf, issuer); err != nil {
+		return err
+	}
+
+	if now.Add(ocspMaxSkew).Before(r.ThisUpdate) {
+		return fmt.Errorf("OCSP 응답이 아직 유효하지 않습니다 (thisUpdate %s)", r.ThisUpdate.Format(time.RFC3339))
+	}
+	if !r.NextUpdate.IsZero() && now.Add(-ocspMaxSkew).After(r.NextUpdate) {
+		return fmt.Errorf("OCSP 응답이 만료되었습니다 (nextUpdate %s)", r.NextUpdate.Format(time.RFC3339))
+	}
+
+	if r.sigAlg == x509.UnknownSignatureAlgorithm {
+		return errors.New("OCSP 응답의 서명 알고리즘을 지원하지 않습니다")
+	}
+	signer, err := r.signer(issuer)
+	if err != nil {
+		return err
+	}
+	if err := signer.CheckSignature(r.sigAlg, r.tbs, r.signature); err != nil {
+		return fmt.Errorf("OCSP 응답 서명이 올바르지 않습니다: %w", err)
+	}
+	return nil
+}
+
+// matches는 CertID가 leaf 인증서(일련번호, 발급자 이름과 공개키 해시)를 가리키는지 확인함
+func (r *OCSPResponse) matches(leaf, issuer *x509.Certificate) error {
+	if r.SerialNumber == nil || r.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		return errors.New("OCSP 응답이 다른 인증서에 대한 것입니다 (일련번호 불일치)")
+	}
+	hash, ok := ocspHashes[r.certID.HashAlgorithm.Algorithm.String()]
+	if !ok || !hash.Available() {
+		return fmt.Errorf("지원하지 않는 OCSP CertID 해시: %v", r.certID.HashAlgorithm.Algorithm)
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return fmt.Errorf("발급자 공개키 파싱 실패: %w", err)
+	}
+
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+
+	if !bytes.Equal(nameHash, r.certID.NameHash) || !bytes.Equal(keyHash, r.certID.IssuerKeyHash) {
+		return errors.New("OCSP 응답이 다른 발급자의 인증서에 대한 것입니다")
+	}
+	return nil
+}
+
+// signer는 응답에 서명한 인증서를 고름
+//
+// 응답에 인증서가 없으면 발급자가 직접 서명한 것이고, 있으면 발급자가 서명하고
+// OCSPSigning 용도가 있는 위임 응답자 인증서여야 함
+func (r *OCSPResponse) signer(issuer *x509.Certificate) (*x509.Certificate, error) {
+	for _, cert := range r.certs {
+		if bytes.Equal(cert.Raw, issuer.Raw) {
+			return issuer, nil
+		}
+	}
+	if len(r.certs) == 0 {
+		return issuer, nil
+	}
+
+	responder := r.certs[0]
+	if err := responder.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("OCSP 응답자 인증서가 발급자의 서명이 아닙니다: %w", err)
+	}
+	for _, usage := range responder.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			return responder, nil
+		}
+	}
+	return nil, errors.New("OCSP 응답자 인증서에 OCSP 서명 용도가 없습니다")
+}
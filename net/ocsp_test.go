@@ -0,0 +1,260 @@
+package net_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"math/big"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testPKI는 OCSP 테스트용 CA와 그 CA가 발급한 서버 인증서
+type testPKI struct {
+	ca      *x509.Certificate
+	caKey   *ecdsa.PrivateKey
+	leaf    *x509.Certificate
+	leafKey *ecdsa.PrivateKey
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("CA 인증서 생성 실패: %v", err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []stdnet.IP{stdnet.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("서버 인증서 생성 실패: %v", err)
+	}
+	leaf, _ := x509.ParseCertificate(leafDER)
+	return &testPKI{ca: ca, caKey: caKey, leaf: leaf, leafKey: leafKey}
+}
+
+// 테스트에서 OCSP 응답을 만드는 ASN.1 구조 (RFC 6960)
+type testCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type testRevokedInfo struct {
+	RevocationTime time.Time `asn1:"generalized"`
+}
+
+type testSingleResponse struct {
+	CertID     testCertID
+	Good       asn1.Flag       `asn1:"tag:0,optional"`
+	Revoked    testRevokedInfo `asn1:"tag:1,optional"`
+	ThisUpdate time.Time       `asn1:"generalized"`
+	NextUpdate time.Time       `asn1:"generalized,explicit,tag:0,optional"`
+}
+
+type testResponseData struct {
+	ResponderID asn1.RawValue
+	ProducedAt  time.Time `asn1:"generalized"`
+	Responses   []testSingleResponse
+}
+
+type testBasicResponse struct {
+	TBSResponseData    asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+}
+
+type testResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type testOCSPResponse struct {
+	Status   asn1.Enumerated
+	Response testResponseBytes `asn1:"explicit,tag:0"`
+}
+
+// ocspStaple은 CA 키로 서명한 서버 인증서의 OCSP 응답 (revoked면 한 시간 전에 폐기됨)
+func (p *testPKI) ocspStaple(t *testing.T, revoked bool, thisUpdate, nextUpdate time.Time) []byte {
+	t.Helper()
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	asn1.Unmarshal(p.ca.RawSubjectPublicKeyInfo, &spki)
+	nameHash := sha1.Sum(p.ca.RawSubject)
+	keyHash := sha1.Sum(spki.PublicKey.RightAlign())
+
+	single := testSingleResponse{
+		CertID: testCertID{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}},
+			NameHash:      nameHash[:],
+			IssuerKeyHash: keyHash[:],
+			SerialNumber:  p.leaf.SerialNumber,
+		},
+		ThisUpdate: thisUpdate.UTC().Truncate(time.Second),
+		NextUpdate: nextUpdate.UTC().Truncate(time.Second),
+	}
+	if revoked {
+		single.Revoked = testRevokedInfo{RevocationTime: time.Now().Add(-time.Hour).UTC().Truncate(time.Second)}
+	} else {
+		single.Good = true
+	}
+
+	byKey, _ := asn1.Marshal(keyHash[:])
+	tbs, err := asn1.Marshal(testResponseData{
+		ResponderID: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: byKey},
+		ProducedAt:  time.Now().UTC().Truncate(time.Second),
+		Responses:   []testSingleResponse{single},
+	})
+	if err != nil {
+		t.Fatalf("ResponseData 인코딩 실패: %v", err)
+	}
+	digest := sha256.Sum256(tbs)
+	sig, _ := p.caKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+
+	basic, _ := asn1.Marshal(testBasicResponse{
+		TBSResponseData:    asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+		Signature:          asn1.BitString{Bytes: sig, BitLength: len(sig) * 8},
+	})
+	der, err := asn1.Marshal(testOCSPResponse{
+		Response: testResponseBytes{ResponseType: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}, Response: basic},
+	})
+	if err != nil {
+		t.Fatalf("OCSP 응답 인코딩 실패: %v", err)
+	}
+	return der
+}
+
+// TestOCSPResponse_Verify 발급자가 서명한 유효 기간 안의 응답만 통과함
+func TestOCSPResponse_Verify(t *testing.T) {
+	pki := newTestPKI(t)
+	now := time.Now()
+
+	resp, err := net.ParseOCSPResponse(pki.ocspStaple(t, false, now.Add(-time.Hour), now.Add(time.Hour)))
+	if err != nil {
+		t.Fatalf("ParseOCSPResponse() failed: %v", err)
+	}
+	if resp.Status != net.RevocationGood {
+		t.Errorf("Status = %v; want %v", resp.Status, net.RevocationGood)
+	}
+	if err := resp.Verify(pki.leaf, pki.ca, now); err != nil {
+		t.Errorf("Verify() failed: %v", err)
+	}
+
+	// 다른 CA가 발급자라면 CertID가 맞지 않음
+	other := newTestPKI(t)
+	if err := resp.Verify(pki.leaf, other.ca, now); err == nil {
+		t.Error("Verify() with other issuer = nil; want error")
+	}
+	// nextUpdate가 지난 응답
+	if err := resp.Verify(pki.leaf, pki.ca, now.Add(2*time.Hour)); err == nil {
+		t.Error("Verify() after nextUpdate = nil; want error")
+	}
+
+	revoked, err := net.ParseOCSPResponse(pki.ocspStaple(t, true, now.Add(-time.Hour), now.Add(time.Hour)))
+	if err != nil {
+		t.Fatalf("ParseOCSPResponse() revoked failed: %v", err)
+	}
+	if revoked.Status != net.RevocationRevoked || revoked.RevokedAt.IsZero() {
+		t.Errorf("Status = %v, RevokedAt %v; want revoked with time", revoked.Status, revoked.RevokedAt)
+	}
+
+	if _, err := net.ParseOCSPResponse([]byte("not der")); err == nil {
+		t.Error("ParseOCSPResponse(garbage) = nil error; want error")
+	}
+}
+
+// TestHTTPFetcher_OCSPStaple 서버가 보낸 staple을 검증해서 Response.Security에 폐기 상태를 기록함
+func TestHTTPFetcher_OCSPStaple(t *testing.T) {
+	pki := newTestPKI(t)
+	now := time.Now()
+	roots := x509.NewCertPool()
+	roots.AddCert(pki.ca)
+
+	serve := func(staple []byte) *httptest.Server {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("stapled"))
+		}))
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{{
+			Certificate: [][]byte{pki.leaf.Raw, pki.ca.Raw},
+			PrivateKey:  pki.leafKey,
+			OCSPStaple:  staple,
+		}}}
+		server.StartTLS()
+		return server
+	}
+	fetcher := &net.HTTPFetcher{Dialer: &net.NetDialer{TLSConfig: &tls.Config{RootCAs: roots}}}
+
+	good := serve(pki.ocspStaple(t, false, now.Add(-time.Hour), now.Add(time.Hour)))
+	defer good.Close()
+	u, _ := url.NewURL(good.URL + "/ocsp-good")
+
+	// 검증하지 않으면 staple이 있어도 폐기 상태는 모름
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if sec := resp.Security; sec == nil || !sec.OCSPStapled || sec.Revocation != net.RevocationUnknown {
+		t.Fatalf("Security = %+v; want stapled, unknown", sec)
+	}
+	if got, want := resp.Security.Indicator(), "보안 연결 (폐기 상태 모름)"; got != want {
+		t.Errorf("Indicator() = %q; want %q", got, want)
+	}
+
+	net.VerifyStapledOCSP = true
+	defer func() { net.VerifyStapledOCSP = false }()
+
+	u, _ = url.NewURL(good.URL + "/ocsp-verified")
+	net.GlobalConnectionPool.Close(good.Listener.Addr().String()) // 검증은 연결할 때 하므로 새 연결로
+	resp, err = fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() with verification failed: %v", err)
+	}
+	if got, want := resp.Security.Indicator(), "보안 연결 (유효)"; got != want {
+		t.Errorf("Indicator() = %q; want %q (OCSPError %v)", got, want, resp.Security.OCSPError)
+	}
+
+	revoked := serve(pki.ocspStaple(t, true, now.Add(-time.Hour), now.Add(time.Hour)))
+	defer revoked.Close()
+	u, _ = url.NewURL(revoked.URL + "/ocsp-revoked")
+	var revokedErr *net.RevokedError
+	if _, err := fetcher.Fetch(u); !errors.As(err, &revokedErr) {
+		t.Errorf("Fetch() revoked = %v; want RevokedError", err)
+	}
+}
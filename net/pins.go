@@ -0,0 +1,194 @@
+// Package net implements HTTP networking for the browser.
+// This file contains trust-on-first-use (TOFU) certificate pinning.
+package net
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-web-browser/logger"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PinsFileName은 프로필 디렉토리 안의 TOFU 핀 파일 이름
+const PinsFileName = "pins.json"
+
+// PinMode는 인증서가 기록된 핀과 다를 때의 동작
+type PinMode int
+
+// TOFU 동작
+const (
+	PinWarn  PinMode = iota // 경고만 로그로 남기고 연결함
+	PinBlock                // 연결을 끊고 PinMismatchError를 반환함
+)
+
+// ParsePinMode는 --tofu 옵션 값("warn", "block")을 PinMode로 바꿈
+func ParsePinMode(s string) (PinMode, error) {
+	switch s {
+	case "warn":
+		return PinWarn, nil
+	case "block":
+		return PinBlock, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 TOFU 모드: %q (warn 또는 block)", s)
+}
+
+// Pin은 호스트에서 처음 본 인증서의 공개키
+type Pin struct {
+	SPKI      string    `json:"spki"`       // 서버 인증서 공개키(SubjectPublicKeyInfo)의 SHA-256, base64
+	FirstSeen time.Time `json:"first_seen"` // 처음 연결한 시각
+}
+
+// PinStore는 호스트("host:port")별 핀과 저장 위치
+//
+// 처음 연결한 호스트의 공개키를 기록해두고(trust on first use), 다음 연결부터
+// 공개키가 바뀌면 Mode에 따라 경고하거나 연결을 막음
+// 공개키를 비교하므로 같은 키로 인증서만 갱신한 경우는 바뀐 것으로 보지 않음
+// path가 비어 있으면 파일에 저장하지 않음 (테스트, 임시 사용)
+type PinStore struct {
+	Mode PinMode
+	Warn func(err error) // PinWarn 모드에서 불일치를 알리는 방법 (nil이면 logger에 기록)
+
+	mu   sync.Mutex
+	path string
+	pins map[string]Pin
+}
+
+// GlobalPinStore는 HTTPFetcher가 https 연결마다 확인하는 핀 저장소 (nil이면 TOFU 끔, opt-in)
+var GlobalPinStore *PinStore
+
+// PinMismatchError는 PinBlock 모드에서 공개키가 기록된 핀과 다를 때의 에러
+type PinMismatchError struct {
+	Address   string // "host:port"
+	Want, Got string // 기록된 핀, 이번 연결의 공개키 해시
+}
+
+func (e *PinMismatchError) Error() string {
+	return fmt.Sprintf("%s의 인증서 공개키가 처음 연결했을 때와 다릅니다 (기록: %s, 현재: %s); 정상적인 교체라면 %s에서 지우세요",
+		e.Address, e.Want, e.Got, PinsFileName)
+}
+
+// OpenPinStore는 path의 핀 파일을 읽어 PinStore를 만듦 (파일이 없으면 빈 저장소)
+func OpenPinStore(path string, mode PinMode) (*PinStore, error) {
+	s := &PinStore{Mode: mode, path: path, pins: make(map[string]Pin)}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("핀 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.pins); err != nil {
+		return nil, fmt.Errorf("핀 파일 형식 오류 (%s): %w", path, err)
+	}
+	return s, nil
+}
+
+// Lookup은 address의 핀을 반환함
+func (s *PinStore) Lookup(address string) (Pin, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pin, ok := s.pins[address]
+	return pin, ok
+}
+
+// Forget은 address의 핀을 지우고 파일에 저장함 (정상적으로 키를 바꾼 서버를 다시 신뢰할 때)
+func (s *PinStore) Forget(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pins, address)
+	return s.save()
+}
+
+// Check는 address에서 받은 공개키 해시 spki를 핀과 비교함
+//
+// 처음 보는 호스트면 핀으로 기록하고 저장함
+// 다르면 PinWarn 모드는 로그만 남기고 nil, PinBlock 모드는 PinMismatchError를 반환함
+// (어느 쪽이든 기록된 핀은 바꾸지 않음)
+func (s *PinStore) Check(address, spki string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pin, ok := s.pins[address]
+	if !ok {
+		s.pins[address] = Pin{SPKI: spki, FirstSeen: time.Now()}
+		logger.Logger.Printf("TOFU: %s의 공개키를 기록함 (%s)", address, spki)
+		return s.save()
+	}
+	if pin.SPKI == spki {
+		return nil
+	}
+	err := &PinMismatchError{Address: address, Want: pin.SPKI, Got: spki}
+	if s.Mode == PinBlock {
+		return err
+	}
+	if s.Warn != nil {
+		s.Warn(err)
+	} else {
+		logger.Logger.Printf("경고: %v", err)
+	}
+	return nil
+}
+
+// save는 핀을 파일에 씀 (s.mu를 잡은 상태에서 호출)
+//
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰는 도중 종료되어도 기존 파일이 깨지지 않음
+func (s *PinStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.pins, "", "  ")
+	if err != nil {
+		return fmt.Errorf("핀 저장 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("핀 저장 실패: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("핀 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("핀 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// SPKIHash는 인증서 공개키(SubjectPublicKeyInfo)의 SHA-256을 base64로 (HPKP의 pin-sha256과 같은 형식)
+func SPKIHash(state tls.ConnectionState) (string, bool) {
+	if len(state.PeerCertificates) == 0 {
+		return "", false
+	}
+	sum := sha256.Sum256(state.PeerCertificates[0].RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:]), true
+}
+
+// checkPin은 GlobalPinStore가 있으면 TLS 연결의 공개키를 핀과 비교함
+//
+// TLS 연결이 아니면(테스트용 Dialer 등) 확인하지 않음
+func checkPin(conn net.Conn, address string) error {
+	if GlobalPinStore == nil {
+		return nil
+	}
+	tc, ok := conn.(interface{ ConnectionState() tls.ConnectionState })
+	if !ok {
+		return nil
+	}
+	spki, ok := SPKIHash(tc.ConnectionState())
+	if !ok {
+		return nil
+	}
+	return GlobalPinStore.Check(address, spki)
+}
@@ -0,0 +1,85 @@
+package net_test
+
+import (
+	"errors"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// TestPinStore_Check 처음 본 공개키는 기록하고, 바뀌면 모드에 따라 경고 또는 에러
+func TestPinStore_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), net.PinsFileName)
+	store, err := net.OpenPinStore(path, net.PinBlock)
+	if err != nil {
+		t.Fatalf("OpenPinStore() failed: %v", err)
+	}
+
+	if err := store.Check("example.com:443", "AAAA"); err != nil {
+		t.Fatalf("Check() first use failed: %v", err)
+	}
+	if err := store.Check("example.com:443", "AAAA"); err != nil {
+		t.Errorf("Check() same key failed: %v", err)
+	}
+	var mismatch *net.PinMismatchError
+	if err := store.Check("example.com:443", "BBBB"); !errors.As(err, &mismatch) || mismatch.Want != "AAAA" {
+		t.Errorf("Check() changed key = %v; want PinMismatchError", err)
+	}
+
+	// 파일에서 다시 읽어도 같은 핀, 경고 모드는 알리기만 하고 핀을 바꾸지 않음
+	reopened, err := net.OpenPinStore(path, net.PinWarn)
+	if err != nil {
+		t.Fatalf("OpenPinStore() reopen failed: %v", err)
+	}
+	var warned error
+	reopened.Warn = func(err error) { warned = err }
+	if err := reopened.Check("example.com:443", "BBBB"); err != nil || warned == nil {
+		t.Errorf("Check() in warn mode = %v, warned %v; want nil, warning", err, warned)
+	}
+	if pin, _ := reopened.Lookup("example.com:443"); pin.SPKI != "AAAA" {
+		t.Errorf("pin after warning = %q; want %q", pin.SPKI, "AAAA")
+	}
+
+	if err := reopened.Forget("example.com:443"); err != nil {
+		t.Fatalf("Forget() failed: %v", err)
+	}
+	if err := reopened.Check("example.com:443", "BBBB"); err != nil {
+		t.Errorf("Check() after Forget failed: %v", err)
+	}
+}
+
+// TestHTTPFetcher_PinBlock 기록된 핀과 다른 인증서의 서버는 PinBlock 모드에서 연결하지 않음
+func TestHTTPFetcher_PinBlock(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+	fetcher := &net.HTTPFetcher{Dialer: &net.NetDialer{TLSConfig: tlsConfig}}
+	u, _ := url.NewURL(server.URL + "/pinned")
+
+	store, _ := net.OpenPinStore("", net.PinBlock)
+	store.Check(server.Listener.Addr().String(), "c29tZSBvdGhlciBrZXk=")
+	net.GlobalPinStore = store
+	defer func() { net.GlobalPinStore = nil }()
+
+	var mismatch *net.PinMismatchError
+	if _, err := fetcher.Fetch(u); !errors.As(err, &mismatch) {
+		t.Fatalf("Fetch() = %v; want PinMismatchError", err)
+	}
+
+	store.Forget(mismatch.Address)
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() after Forget failed: %v", err)
+	}
+	if resp.Body != "secure" {
+		t.Errorf("Body = %q; want %q", resp.Body, "secure")
+	}
+	if pin, ok := store.Lookup(mismatch.Address); !ok || pin.SPKI != mismatch.Got {
+		t.Errorf("pin = %+v; want recorded %q", pin, mismatch.Got)
+	}
+}
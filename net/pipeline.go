@@ -0,0 +1,217 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the experimental HTTP/1.1 pipelining mode for same-host batches.
+package net
+
+import (
+	"bufio"
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pipelining이 true면 FetchBatch가 같은 서버로 가는 요청들을 한 연결에 몰아서 보냄 (실험적, opt-in)
+//
+// 요청 N개를 응답을 기다리지 않고 먼저 다 보낸 뒤, 응답 N개를 보낸 순서대로 읽음
+// 중간 프록시나 서버가 파이프라이닝을 제대로 지원하지 않는 경우가 있어 기본으로는 꺼 둠
+var Pipelining bool
+
+// BatchResult는 FetchBatch의 요청 하나의 결과
+type BatchResult struct {
+	Response *Response
+	Err      error
+}
+
+// PipelineStats는 파이프라이닝 실험의 누적 통계 (FetchBatch 간 비교용)
+type PipelineStats struct {
+	Batches   int           // 파이프라이닝으로 보낸 묶음 수
+	Pipelined int           // 파이프라이닝으로 받은 응답 수
+	Fallbacks int           // 문제가 생겨서 하나씩 다시 가져온 요청 수
+	Disabled  []string      // 문제가 생겨서 파이프라이닝을 끈 서버 주소
+	Elapsed   time.Duration // 파이프라이닝한 묶음들에 걸린 시간의 합
+}
+
+// pipelineState는 서버별로 파이프라이닝을 껐는지와 누적 통계
+var pipelineState = struct {
+	sync.Mutex
+	disabled map[string]bool
+	stats    PipelineStats
+}{disabled: make(map[string]bool)}
+
+// GlobalPipelineStats는 지금까지의 파이프라이닝 통계
+func GlobalPipelineStats() PipelineStats {
+	pipelineState.Lock()
+	defer pipelineState.Unlock()
+	s := pipelineState.stats
+	s.Disabled = append([]string(nil), s.Disabled...)
+	return s
+}
+
+// disablePipelining은 address에 파이프라이닝 문제가 있었다고 기록함 (이후 그 서버는 하나씩 요청함)
+func disablePipelining(address string, reason error) {
+	pipelineState.Lock()
+	defer pipelineState.Unlock()
+	if pipelineState.disabled[address] {
+		return
+	}
+	pipelineState.disabled[address] = true
+	pipelineState.stats.Disabled = append(pipelineState.stats.Disabled, address)
+	logger.Logger.Printf("파이프라이닝: %s에서 끔: %v", address, reason)
+}
+
+// pipelinable은 address에 파이프라이닝을 써도 되는지 확인함
+func pipelinable(address string) bool {
+	pipelineState.Lock()
+	defer pipelineState.Unlock()
+	return Pipelining && !pipelineState.disabled[address]
+}
+
+// FetchBatch는 하위 리소스처럼 한꺼번에 필요한 URL들을 가져옴 (결과는 urls와 같은 순서)
+//
+// Pipelining이 켜져 있으면 캐시에 없는 같은 서버의 요청 2개 이상을 한 연결로 파이프라이닝하고,
+// 꺼져 있거나 http/https가 아니면 Fetch를 하나씩 부름
+// 파이프라이닝 중 에러, 연결 닫힘, 길이를 알 수 없는 응답 같은 문제가 생기면
+// 그 서버는 파이프라이닝을 끄고 남은 요청을 하나씩 다시 가져옴
+// 리다이렉트 응답도 Fetch로 다시 가져와서 따라감
+func (h *HTTPFetcher) FetchBatch(urls []*url.URL) []BatchResult {
+	trace := h.trace()
+	for range urls {
+		trace.subresource(false)
+	}
+	results := make([]BatchResult, len(urls))
+	defer func() {
+		for range urls {
+			trace.subresource(true)
+		}
+	}()
+	groups := make(map[string][]int) // 주소 → urls의 인덱스
+	var order []string
+	for i, u := range urls {
+		_, address, err := dialTarget(u)
+		_, cached := h.cache().Get(u.Normalize().Redacted())
+		if err != nil || cached || upgradable(u) || (u.Scheme != url.SchemeHTTP && u.Scheme != url.SchemeHTTPS) {
+			results[i].Response, results[i].Err = h.Fetch(u)
+			continue
+		}
+		if _, ok := groups[address]; !ok {
+			order = append(order, address)
+		}
+		groups[address] = append(groups[address], i)
+	}
+
+	for _, address := range order {
+		indexes := groups[address]
+		done := 0
+		if len(indexes) > 1 && pipelinable(address) {
+			batch := make([]*url.URL, len(indexes))
+			for j, i := range indexes {
+				batch[j] = urls[i]
+			}
+			responses := h.pipeline(address, batch)
+			for j, resp := range responses {
+				results[indexes[j]].Response = resp
+			}
+			done = len(responses)
+		}
+		for _, i := range indexes[done:] {
+			results[i].Response, results[i].Err = h.Fetch(urls[i])
+		}
+		for _, i := range indexes[:done] {
+			if resp := results[i].Response; resp.StatusCode >= 300 && resp.StatusCode < 400 {
+				results[i].Response, results[i].Err = h.Fetch(urls[i])
+			}
+		}
+	}
+	return results
+}
+
+// pipeline은 urls(모두 address로 가는 요청)를 한 연결로 보내고, 문제없이 받은 앞쪽 응답들을 반환함
+//
+// 반환한 응답이 urls보다 적으면 나머지는 호출한 쪽이 하나씩 다시 가져와야 함
+func (h *HTTPFetcher) pipeline(address string, urls []*url.URL) []*Response {
+	start := time.Now()
+	conn, found := h.pool().Get(address)
+	if !found {
+		var err error
+		if conn, err = h.dial(urls[0], address); err != nil {
+			h.logger().Printf("파이프라이닝: %s 연결 실패: %v", address, err)
+			return nil
+		}
+	}
+
+	var rw io.ReadWriter = conn
+	deadlines := withDeadlines(conn, GlobalTimeouts)
+	if deadlines != nil {
+		rw = deadlines
+	}
+
+	var requests strings.Builder
+	for _, u := range urls {
+		requests.WriteString(requestMessage(u, h.withCookies(u, nil)))
+	}
+	if _, err := io.WriteString(rw, requests.String()); err != nil {
+		h.pool().Discard(address, conn)
+		if !found {
+			disablePipelining(address, err)
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(rw)
+	var responses []*Response
+	var hiccup error
+	for i, u := range urls {
+		statusCode, body, headers, trailers, err := readResponse(reader, GlobalParseOptions, nil)
+		if err != nil {
+			hiccup = err
+			break
+		}
+		if isEventStream(headers) {
+			hiccup = fmt.Errorf("이벤트 스트림은 파이프라이닝할 수 없음 (%s)", u.Redacted())
+			break
+		}
+		h.cookies().SetCookiesFor(h.TopLevel, u, headers.Get("Set-Cookie"))
+		// 리다이렉트는 FetchBatch가 Fetch로 다시 가져오므로 캐시에 넣지 않음
+		resp := newResponse(u, statusCode, headers, body)
+		if statusCode < 300 || statusCode >= 400 {
+			res := result{statusCode: statusCode, body: body, headers: headers, trailers: trailers, security: connSecurity(conn)}
+			resp = h.complete(u.Normalize().Redacted(), u, res)
+		}
+		responses = append(responses, resp)
+		if !reusable(statusCode, headers) {
+			if i < len(urls)-1 {
+				hiccup = fmt.Errorf("응답 %d/%d 뒤에 연결이 끝남", i+1, len(urls))
+			}
+			break
+		}
+	}
+	if hiccup == nil && reader.Buffered() > 0 {
+		hiccup = fmt.Errorf("%w: 마지막 응답 뒤에 %d바이트가 남음", ErrExcessBody, reader.Buffered())
+	}
+
+	if hiccup != nil || !reusable(responses[len(responses)-1].StatusCode, responses[len(responses)-1].Headers) {
+		h.pool().Discard(address, conn)
+	} else {
+		if deadlines != nil {
+			deadlines.clear()
+		}
+		h.pool().Put(address, conn)
+	}
+	// 풀에 있던 연결이 이미 닫혀 있었던 것은 파이프라이닝 문제가 아님
+	if hiccup != nil && !(found && len(responses) == 0 && isTransientError(hiccup)) {
+		disablePipelining(address, hiccup)
+	}
+
+	elapsed := time.Since(start)
+	pipelineState.Lock()
+	pipelineState.stats.Batches++
+	pipelineState.stats.Pipelined += len(responses)
+	pipelineState.stats.Fallbacks += len(urls) - len(responses)
+	pipelineState.stats.Elapsed += elapsed
+	pipelineState.Unlock()
+	h.logger().Printf("파이프라이닝: %s에 요청 %d개, 응답 %d개, %s", address, len(urls), len(responses), elapsed)
+	return responses
+}
@@ -0,0 +1,133 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// setPipelining: 테스트 동안만 Pipelining을 바꿈
+func setPipelining(t *testing.T, on bool) {
+	t.Helper()
+	old := net.Pipelining
+	net.Pipelining = on
+	t.Cleanup(func() { net.Pipelining = old })
+}
+
+// pipelineServer: 경로를 본문으로 돌려주고 연결 수를 세는 서버 (closeAfter 경로는 Connection: close로 응답)
+func pipelineServer(t *testing.T, closeAfter string) (*httptest.Server, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	conns := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		conns[r.RemoteAddr] = true
+		mu.Unlock()
+		if r.URL.Path == closeAfter {
+			w.Header().Set("Connection", "close")
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		io.WriteString(w, "body of "+r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(conns)
+	}
+}
+
+// batchURLs: srv의 paths를 URL로 바꿈
+func batchURLs(t *testing.T, srv *httptest.Server, paths ...string) []*url.URL {
+	t.Helper()
+	var urls []*url.URL
+	for _, p := range paths {
+		u, err := url.NewURL(srv.URL + p)
+		if err != nil {
+			t.Fatalf("NewURL() failed: %v", err)
+		}
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// checkBatch: 결과가 요청 순서대로 각 경로의 본문인지 확인함
+func checkBatch(t *testing.T, results []net.BatchResult, paths ...string) {
+	t.Helper()
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("FetchBatch()[%d] failed: %v", i, r.Err)
+			continue
+		}
+		if want := "body of " + paths[i]; r.Response.Body != want {
+			t.Errorf("FetchBatch()[%d].Body = %q; want %q", i, r.Response.Body, want)
+		}
+	}
+}
+
+// TestHTTPFetcher_FetchBatchPipelined 같은 서버의 요청들을 한 연결로 보내고 순서대로 받음
+func TestHTTPFetcher_FetchBatchPipelined(t *testing.T) {
+	setPipelining(t, true)
+	srv, connCount := pipelineServer(t, "")
+	paths := []string{"/a.css", "/b.js", "/c.png"}
+	before := net.GlobalPipelineStats()
+
+	results := (&net.HTTPFetcher{}).FetchBatch(batchURLs(t, srv, paths...))
+	checkBatch(t, results, paths...)
+
+	after := net.GlobalPipelineStats()
+	if got := after.Pipelined - before.Pipelined; got != 3 {
+		t.Errorf("pipelined responses = %d; want 3", got)
+	}
+	if got := connCount(); got != 1 {
+		t.Errorf("server saw %d connections; want 1", got)
+	}
+	net.GlobalConnectionPool.Close(srv.Listener.Addr().String())
+}
+
+// TestHTTPFetcher_FetchBatchHiccup 중간에 연결이 닫히면 나머지를 하나씩 가져오고 그 서버는 파이프라이닝을 끔
+func TestHTTPFetcher_FetchBatchHiccup(t *testing.T) {
+	setPipelining(t, true)
+	srv, _ := pipelineServer(t, "/a")
+	paths := []string{"/a", "/b", "/c"}
+	address := srv.Listener.Addr().String()
+	before := net.GlobalPipelineStats()
+
+	results := (&net.HTTPFetcher{}).FetchBatch(batchURLs(t, srv, paths...))
+	checkBatch(t, results, paths...)
+
+	after := net.GlobalPipelineStats()
+	if got := after.Fallbacks - before.Fallbacks; got != 2 {
+		t.Errorf("fallbacks = %d; want 2", got)
+	}
+	if !slices.Contains(after.Disabled, address) {
+		t.Errorf("Disabled = %v; want it to contain %s", after.Disabled, address)
+	}
+
+	// 한 번 끈 서버는 다시 파이프라이닝하지 않음
+	results = (&net.HTTPFetcher{}).FetchBatch(batchURLs(t, srv, "/d", "/e"))
+	checkBatch(t, results, "/d", "/e")
+	if got := net.GlobalPipelineStats().Batches; got != after.Batches {
+		t.Errorf("Batches after disable = %d; want %d", got, after.Batches)
+	}
+	net.GlobalConnectionPool.Close(address)
+}
+
+// TestHTTPFetcher_FetchBatchOff Pipelining이 꺼져 있으면 하나씩 가져옴
+func TestHTTPFetcher_FetchBatchOff(t *testing.T) {
+	setPipelining(t, false)
+	srv, _ := pipelineServer(t, "")
+	before := net.GlobalPipelineStats()
+
+	results := (&net.HTTPFetcher{}).FetchBatch(batchURLs(t, srv, "/a", "/b"))
+	checkBatch(t, results, "/a", "/b")
+	if got := net.GlobalPipelineStats().Batches; got != before.Batches {
+		t.Errorf("Batches = %d; want %d", got, before.Batches)
+	}
+	net.GlobalConnectionPool.Close(srv.Listener.Addr().String())
+}
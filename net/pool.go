@@ -3,15 +3,25 @@
 package net
 
 import (
+	"errors"
 	"go-web-browser/logger"
+	"log"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // MaxConnectionsPerHost is the maximum number of idle Keep-Alive connections
 // per host, as recommended by HTTP/1.1 (RFC 2616).
 const MaxConnectionsPerHost = 6
 
+// MaxIdleConnections is the default cap on idle connections across all hosts.
+//
+// Without it a crawl over many distinct hosts would keep up to
+// MaxConnectionsPerHost sockets open for every host it has ever visited.
+const MaxIdleConnections = 64
+
 // ConnectionPool manages persistent HTTP connections for Keep-Alive.
 //
 // It maintains a pool of idle connections per server address, allowing
@@ -20,52 +30,227 @@ const MaxConnectionsPerHost = 6
 //
 // The pool is thread-safe and can be used concurrently from multiple goroutines.
 type ConnectionPool struct {
-	connections map[string][]net.Conn // "host:port" → []net.Conn
-	mu          sync.Mutex            // protects connections map
-	maxPerHost  int                   // maximum idle connections per host
+	connections map[string][]idleConn      // "host:port" → idle connections
+	stats       map[string]*hostCounter    // "host:port" → counters for Stats
+	mu          sync.Mutex                 // protects connections, stats and the LRU state
+	maxPerHost  int                        // maximum idle connections per host
+	maxIdle     int                        // maximum idle connections across all hosts (0 = no limit)
+	idle        int                        // idle connections across all hosts
+	lastUsed    map[string]uint64          // "host:port" → tick of the last Get or Put (for LRU eviction)
+	tick        uint64                     // monotonic counter for lastUsed
+	log         atomic.Pointer[log.Logger] // logger for pool events (nil means logger.Logger)
+}
+
+// idleConn is an idle connection and the time it was returned to the pool.
+type idleConn struct {
+	conn      net.Conn
+	idleSince time.Time
+}
+
+// hostCounter accumulates per-host counters.
+type hostCounter struct {
+	created   int
+	reused    int
+	closed    int
+	evicted   int
+	idleTotal time.Duration // sum of idle time of reused connections
+
+	tlsFull, tlsResumed           int           // TLS handshakes, full and resumed
+	tlsFullTotal, tlsResumedTotal time.Duration // sum of dial times (TCP + TLS) of each kind
+}
+
+// HostStats is a snapshot of the pool's counters for one address.
+type HostStats struct {
+	Idle            int           // connections currently idle in the pool
+	Created         int           // new connections dialed
+	Reused          int           // connections taken from the pool
+	Closed          int           // connections closed (pool full, Close, or discarded after an error)
+	Evicted         int           // idle connections closed because the host was least recently used when the pool hit its global cap
+	AvgReuseLatency time.Duration // average time a connection sat idle before being reused
+
+	TLSFull       int           // TLS connections that needed a full handshake
+	TLSResumed    int           // TLS connections that resumed a previous session
+	AvgTLSFull    time.Duration // average dial time (TCP + TLS) of full handshakes
+	AvgTLSResumed time.Duration // average dial time (TCP + TLS) of resumed handshakes
 }
 
 // NewConnectionPool creates a new ConnectionPool with default settings.
 //
 // The pool will maintain up to MaxConnectionsPerHost idle connections
-// per server address. Connections exceeding this limit are closed immediately.
+// per server address and MaxIdleConnections across all addresses.
+// Connections exceeding the per-host limit are closed immediately; when the
+// global limit is reached, the least recently used host's idle connections
+// are closed to make room.
 func NewConnectionPool() *ConnectionPool {
 	return &ConnectionPool{
-		connections: make(map[string][]net.Conn),
+		connections: make(map[string][]idleConn),
+		stats:       make(map[string]*hostCounter),
 		maxPerHost:  MaxConnectionsPerHost,
+		maxIdle:     MaxIdleConnections,
+		lastUsed:    make(map[string]uint64),
+	}
+}
+
+// SetMaxIdle sets the cap on idle connections across all hosts (0 or less means no limit).
+//
+// If the pool already holds more, least recently used hosts are evicted now.
+//
+// SetMaxIdle is safe for concurrent use.
+func (pool *ConnectionPool) SetMaxIdle(n int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.maxIdle = max(n, 0)
+	pool.evict("")
+}
+
+// touch marks address as the most recently used host (and makes it show up in Stats).
+// The caller must hold pool.mu.
+func (pool *ConnectionPool) touch(address string) {
+	pool.tick++
+	pool.lastUsed[address] = pool.tick
+	pool.counter(address)
+}
+
+// evict closes the idle connections of least recently used hosts (other than keep)
+// until the pool is within its global cap.
+// The caller must hold pool.mu.
+func (pool *ConnectionPool) evict(keep string) {
+	for pool.maxIdle > 0 && pool.idle > pool.maxIdle {
+		victim, oldest := "", uint64(0)
+		for address, conns := range pool.connections {
+			if address == keep || len(conns) == 0 {
+				continue
+			}
+			if used := pool.lastUsed[address]; victim == "" || used < oldest {
+				victim, oldest = address, used
+			}
+		}
+		if victim == "" {
+			return
+		}
+		conns := pool.connections[victim]
+		for _, idle := range conns {
+			idle.conn.Close()
+		}
+		c := pool.counter(victim)
+		c.closed += len(conns)
+		c.evicted += len(conns)
+		pool.idle -= len(conns)
+		delete(pool.connections, victim)
+		delete(pool.lastUsed, victim)
+		pool.logger().Printf("Pool over %d idle connections, evicted least recently used %s (%d connections)", pool.maxIdle, victim, len(conns))
 	}
 }
 
+// SetLogger sets the logger for pool events (nil means logger.Logger).
+//
+// SetLogger is safe for concurrent use.
+func (pool *ConnectionPool) SetLogger(l *log.Logger) {
+	pool.log.Store(l)
+}
+
+// logger returns the logger for pool events.
+func (pool *ConnectionPool) logger() *log.Logger {
+	if l := pool.log.Load(); l != nil {
+		return l
+	}
+	return logger.Logger
+}
+
+// counter returns the counters for address, creating them if needed.
+// The caller must hold pool.mu.
+func (pool *ConnectionPool) counter(address string) *hostCounter {
+	c, ok := pool.stats[address]
+	if !ok {
+		c = &hostCounter{}
+		pool.stats[address] = c
+	}
+	return c
+}
+
 // Get retrieves an idle connection from the pool for the given address.
 //
 // It returns (conn, true) if an idle connection is available, or (nil, false)
 // if the pool is empty for this address. The retrieved connection is removed
 // from the pool (check-out pattern) and should be returned with Put after use.
 //
+// Idle connections that received bytes or were closed by the server while in
+// the pool (e.g., the rest of a body longer than its Content-Length that
+// arrived after the response was read) are closed and skipped.
+//
 // Get is safe for concurrent use.
 func (pool *ConnectionPool) Get(address string) (net.Conn, bool) {
+	for {
+		idle, ok := pool.pop(address)
+		if !ok {
+			return nil, false
+		}
+		if stale(idle.conn) {
+			pool.Discard(address, idle.conn)
+			pool.logger().Printf("Idle connection to %s received data or was closed, discarding", address)
+			continue
+		}
+
+		pool.mu.Lock()
+		c := pool.counter(address)
+		c.reused++
+		c.idleTotal += time.Since(idle.idleSince)
+		remaining := len(pool.connections[address])
+		pool.mu.Unlock()
+
+		pool.logger().Printf("Reusing connection to %s (remaining: %d)", address, remaining)
+		return idle.conn, true
+	}
+}
+
+// pop removes the most recently used idle connection for address (LIFO).
+func (pool *ConnectionPool) pop(address string) (idleConn, bool) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
 	conns := pool.connections[address]
 	if len(conns) == 0 {
-		return nil, false
+		return idleConn{}, false
 	}
-
-	// Pop last connection (LIFO - most recently used)
 	lastIdx := len(conns) - 1
-	conn := conns[lastIdx]
+	idle := conns[lastIdx]
 	pool.connections[address] = conns[:lastIdx]
+	pool.idle--
+	pool.touch(address)
+	return idle, true
+}
 
-	logger.Logger.Printf("Reusing connection to %s (remaining: %d)", address, len(conns)-1)
-	return conn, true
+// staleProbe is how long stale waits for bytes on an idle connection.
+//
+// A deadline already in the past fails before reading, so the probe needs a
+// short wait to see bytes that are sitting in the socket buffer.
+const staleProbe = time.Millisecond
+
+// stale reports whether an idle connection has unread bytes or hit EOF.
+//
+// Nothing should arrive on an idle HTTP/1.1 connection, so any byte means the
+// previous response was longer than declared and the stream is out of sync.
+// Connections that do not support deadlines are assumed to be usable.
+func stale(conn net.Conn) bool {
+	if err := conn.SetReadDeadline(time.Now().Add(staleProbe)); err != nil {
+		return false
+	}
+	defer conn.SetReadDeadline(time.Time{})
+	var b [1]byte
+	n, err := conn.Read(b[:])
+	if n > 0 {
+		return true
+	}
+	var netErr net.Error
+	return err != nil && (!errors.As(err, &netErr) || !netErr.Timeout())
 }
 
 // Put returns a connection to the pool for future reuse.
 //
 // If the pool already contains maxPerHost connections for this address,
 // the connection is closed immediately to prevent resource leaks.
-// Otherwise, the connection is stored for reuse by future requests.
+// Otherwise, the connection is stored for reuse by future requests, and if
+// that puts the pool over its global cap, least recently used hosts are evicted.
 //
 // Put is safe for concurrent use.
 func (pool *ConnectionPool) Put(address string, conn net.Conn) {
@@ -75,11 +260,15 @@ func (pool *ConnectionPool) Put(address string, conn net.Conn) {
 	conns := pool.connections[address]
 
 	if len(conns) < pool.maxPerHost {
-		pool.connections[address] = append(conns, conn)
-		logger.Logger.Printf("Stored connection to %s (total: %d/%d)", address, len(conns)+1, pool.maxPerHost)
+		pool.connections[address] = append(conns, idleConn{conn: conn, idleSince: time.Now()})
+		pool.idle++
+		pool.touch(address)
+		pool.logger().Printf("Stored connection to %s (total: %d/%d)", address, len(conns)+1, pool.maxPerHost)
+		pool.evict(address)
 	} else {
 		conn.Close()
-		logger.Logger.Printf("Pool full, closed connection to %s (%d/%d)", address, pool.maxPerHost, pool.maxPerHost)
+		pool.counter(address).closed++
+		pool.logger().Printf("Pool full, closed connection to %s (%d/%d)", address, pool.maxPerHost, pool.maxPerHost)
 	}
 }
 
@@ -94,11 +283,79 @@ func (pool *ConnectionPool) Close(address string) {
 	defer pool.mu.Unlock()
 
 	conns := pool.connections[address]
-	for _, conn := range conns {
-		conn.Close()
+	for _, idle := range conns {
+		idle.conn.Close()
 	}
+	pool.counter(address).closed += len(conns)
+	pool.idle -= len(conns)
 	delete(pool.connections, address)
-	logger.Logger.Printf("Closed all connections to %s (%d connections)", address, len(conns))
+	delete(pool.lastUsed, address)
+	pool.logger().Printf("Closed all connections to %s (%d connections)", address, len(conns))
+}
+
+// Discard closes a checked-out connection that cannot be reused (e.g., after an error).
+//
+// Discard is safe for concurrent use.
+func (pool *ConnectionPool) Discard(address string, conn net.Conn) {
+	conn.Close()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.counter(address).closed++
+}
+
+// recordCreated counts a newly dialed connection to address.
+func (pool *ConnectionPool) recordCreated(address string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.counter(address).created++
+}
+
+// recordTLS counts a new TLS connection to address and how long dialing it took.
+func (pool *ConnectionPool) recordTLS(address string, resumed bool, took time.Duration) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	c := pool.counter(address)
+	if resumed {
+		c.tlsResumed++
+		c.tlsResumedTotal += took
+	} else {
+		c.tlsFull++
+		c.tlsFullTotal += took
+	}
+}
+
+// Stats returns a snapshot of per-address counters.
+//
+// Addresses that have never been used are not included.
+//
+// Stats is safe for concurrent use.
+func (pool *ConnectionPool) Stats() map[string]HostStats {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	result := make(map[string]HostStats, len(pool.stats))
+	for address, c := range pool.stats {
+		s := HostStats{
+			Idle:    len(pool.connections[address]),
+			Created: c.created,
+			Reused:  c.reused,
+			Closed:  c.closed,
+			Evicted: c.evicted,
+		}
+		if c.reused > 0 {
+			s.AvgReuseLatency = c.idleTotal / time.Duration(c.reused)
+		}
+		s.TLSFull, s.TLSResumed = c.tlsFull, c.tlsResumed
+		if c.tlsFull > 0 {
+			s.AvgTLSFull = c.tlsFullTotal / time.Duration(c.tlsFull)
+		}
+		if c.tlsResumed > 0 {
+			s.AvgTLSResumed = c.tlsResumedTotal / time.Duration(c.tlsResumed)
+		}
+		result[address] = s
+	}
+	return result
 }
 
 // GlobalConnectionPool is the global ConnectionPool instance used by the HTTP fetcher
@@ -0,0 +1,122 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================
+// ConnectionPool 통계 테스트
+// ============================================
+
+// TestConnectionPool_Stats Idle, Reused, Closed 카운터
+func TestConnectionPool_Stats(t *testing.T) {
+	pool := net.NewConnectionPool()
+	address := "example.com:80"
+
+	if len(pool.Stats()) != 0 {
+		t.Errorf("Stats() of new pool = %v; want empty", pool.Stats())
+	}
+
+	for i := 0; i < 8; i++ {
+		pool.Put(address, &mockConn{id: i})
+	}
+	time.Sleep(5 * time.Millisecond)
+	conn, _ := pool.Get(address)
+	pool.Discard(address, conn)
+
+	s := pool.Stats()[address]
+	want := net.HostStats{Idle: 5, Reused: 1, Closed: 3}
+	if s.Idle != want.Idle || s.Reused != want.Reused || s.Closed != want.Closed || s.Created != 0 {
+		t.Errorf("Stats()[%q] = %+v; want %+v", address, s, want)
+	}
+	if s.AvgReuseLatency < 5*time.Millisecond {
+		t.Errorf("AvgReuseLatency = %v; want >= 5ms", s.AvgReuseLatency)
+	}
+
+	pool.Close(address)
+	s = pool.Stats()[address]
+	if s.Idle != 0 || s.Closed != 8 {
+		t.Errorf("after Close: Idle = %d, Closed = %d; want 0, 8", s.Idle, s.Closed)
+	}
+}
+
+// TestHTTPFetcher_PoolStats HTTP 요청이 Created/Reused를 기록
+func TestHTTPFetcher_PoolStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	for _, path := range []string{"/stats1", "/stats2", "/stats3"} {
+		u, _ := url.NewURL(server.URL + path)
+		if _, err := net.Fetch(u); err != nil {
+			t.Fatalf("Fetch(%q) failed: %v", path, err)
+		}
+	}
+
+	s := net.GlobalConnectionPool.Stats()[address]
+	if s.Created != 1 || s.Reused != 2 || s.Idle != 1 {
+		t.Errorf("Stats()[%q] = %+v; want Created=1 Reused=2 Idle=1", address, s)
+	}
+
+	// about:net-internals에 호스트별 통계가 표시됨
+	u, _ := url.NewURL("about:net-internals")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch(about:net-internals) failed: %v", err)
+	}
+	if !strings.Contains(resp.Body, address) || !strings.Contains(resp.Body, "reused=2") {
+		t.Errorf("about:net-internals = %q; want stats for %s", resp.Body, address)
+	}
+}
+
+// TestAboutFetcher_Unknown 없는 내부 페이지는 에러
+func TestAboutFetcher_Unknown(t *testing.T) {
+	u, _ := url.NewURL("about:nope")
+	if _, err := net.Fetch(u); err == nil {
+		t.Error("Fetch(about:nope) should return error")
+	}
+}
+
+// TestConnectionPool_MaxIdle 전체 유휴 연결이 상한을 넘으면 가장 오래 쓰지 않은 호스트의 연결을 닫음
+func TestConnectionPool_MaxIdle(t *testing.T) {
+	pool := net.NewConnectionPool()
+	pool.SetMaxIdle(4)
+
+	a := []*mockConn{{id: 0}, {id: 1}}
+	b := []*mockConn{{id: 2}, {id: 3}}
+	pool.Put("a:80", a[0])
+	pool.Put("a:80", a[1])
+	pool.Put("b:80", b[0])
+	pool.Put("b:80", b[1])
+
+	// a를 다시 쓰면 b가 가장 오래 쓰지 않은 호스트가 됨
+	conn, _ := pool.Get("a:80")
+	pool.Put("a:80", conn)
+	pool.Put("c:80", &mockConn{id: 4})
+
+	stats := pool.Stats()
+	if stats["b:80"].Idle != 0 || stats["b:80"].Evicted != 2 {
+		t.Errorf("Stats()[b:80] = %+v; want Idle=0 Evicted=2", stats["b:80"])
+	}
+	if !b[0].closed || !b[1].closed {
+		t.Errorf("evicted connections closed = %v, %v; want true, true", b[0].closed, b[1].closed)
+	}
+	if stats["a:80"].Idle != 2 || stats["c:80"].Idle != 1 {
+		t.Errorf("Idle of a:80, c:80 = %d, %d; want 2, 1", stats["a:80"].Idle, stats["c:80"].Idle)
+	}
+
+	// 상한을 줄이면 바로 정리함
+	pool.SetMaxIdle(1)
+	stats = pool.Stats()
+	if stats["a:80"].Idle != 0 || stats["c:80"].Idle != 1 {
+		t.Errorf("after SetMaxIdle(1): Idle a:80 = %d, c:80 = %d; want 0, 1", stats["a:80"].Idle, stats["c:80"].Idle)
+	}
+}
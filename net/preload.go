@@ -0,0 +1,161 @@
+// Package net implements HTTP networking for the browser.
+// This file contains Link header parsing and preconnect/prefetch for 103 Early Hints.
+package net
+
+import (
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StatusEarlyHints는 103 Early Hints 상태 코드
+const StatusEarlyHints = 103
+
+// LinkHint는 Link 헤더의 링크 하나 (<url>; rel=preload; as=style)
+type LinkHint struct {
+	URL string // <...> 안의 URL (상대 URL일 수 있음)
+	Rel string // rel 파라미터 (소문자, 예: preload, preconnect)
+	As  string // as 파라미터 (소문자, 예: style, script)
+}
+
+// ParseLinkHeader는 Link 헤더 값을 LinkHint 목록으로 파싱함
+//
+// 여러 링크는 쉼표로 구분됨 (URL 안의 쉼표는 <> 안에 있으므로 구분자가 아님)
+//
+// 예: `</style.css>; rel=preload; as=style, <https://cdn.example>; rel=preconnect`
+func ParseLinkHeader(value string) []LinkHint {
+	var hints []LinkHint
+	rest := value
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start == -1 {
+			return hints
+		}
+		end := strings.IndexByte(rest[start:], '>')
+		if end == -1 {
+			return hints
+		}
+		hint := LinkHint{URL: strings.TrimSpace(rest[start+1 : start+end])}
+		rest = rest[start+end+1:]
+
+		// 파라미터: 다음 링크(쉼표 뒤 '<') 전까지
+		params := rest
+		if next := strings.Index(rest, "<"); next != -1 {
+			params = rest[:next]
+		}
+		for _, param := range strings.Split(params, ";") {
+			name, val, ok := strings.Cut(param, "=")
+			if !ok {
+				continue
+			}
+			val = strings.ToLower(strings.Trim(strings.TrimSpace(val), `",`))
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "rel":
+				hint.Rel = val
+			case "as":
+				hint.As = val
+			}
+		}
+		hints = append(hints, hint)
+	}
+}
+
+// Preloader는 최종 응답 전에 하위 리소스 로딩을 미리 시작함
+//
+//   - preconnect: 연결을 미리 맺어 ConnectionPool에 넣어둠
+//   - preload, prefetch: 리소스를 백그라운드로 가져와 GlobalCache에 넣어둠
+//
+// 같은 대상은 한 번만 처리함
+type Preloader struct {
+	mu   sync.Mutex
+	seen map[string]bool // 이미 처리한 "rel url"
+	wg   sync.WaitGroup
+}
+
+// NewPreloader는 새 Preloader를 생성함
+func NewPreloader() *Preloader {
+	return &Preloader{seen: make(map[string]bool)}
+}
+
+// HandleEarlyHints는 103 응답 헤더의 Link 헤더를 읽어 preconnect/preload를 시작함
+//
+// 상대 URL은 요청 URL(base) 기준으로 변환하고, http(s)가 아닌 링크는 무시함
+func (p *Preloader) HandleEarlyHints(base *url.URL, headers Header) {
+	for _, hint := range ParseLinkHeader(headers.Get("Link")) {
+		target, err := base.Resolve(hint.URL)
+		if err != nil || (target.Scheme != url.SchemeHTTP && target.Scheme != url.SchemeHTTPS) {
+			logger.Logger.Printf("Early Hints 링크 무시: %q", hint.URL)
+			continue
+		}
+
+		switch hint.Rel {
+		case "preconnect":
+			p.Preconnect(target)
+		case "preload", "prefetch", "modulepreload":
+			p.Prefetch(target)
+		}
+	}
+}
+
+// Preconnect는 u의 호스트로 연결을 미리 맺어 등록된 HTTPFetcher의 연결 풀에 넣음
+func (p *Preloader) Preconnect(u *url.URL) {
+	address := net.JoinHostPort(u.Host, strconv.Itoa(u.Port))
+	if !p.markSeen("preconnect " + address) {
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		// 등록된 HTTPFetcher의 Dialer와 연결 풀을 사용 (없으면 DefaultDialer, GlobalConnectionPool)
+		fetcher, _ := FetcherRegistry[u.Scheme].(*HTTPFetcher)
+		conn, err := fetcher.dial(u, address)
+		if err != nil {
+			logger.Logger.Printf("preconnect 실패 %s: %v", address, err)
+			return
+		}
+		fetcher.pool().Put(address, conn)
+	}()
+}
+
+// Prefetch는 u를 백그라운드로 가져와 캐시에 넣음 (캐시 가능한 응답만 저장됨)
+func (p *Preloader) Prefetch(u *url.URL) {
+	if !p.markSeen("prefetch " + u.Normalize().Redacted()) {
+		return
+	}
+
+	// 등록된 HTTPFetcher의 Trace로 하위 리소스 수를 알림
+	fetcher, _ := FetcherRegistry[u.Scheme].(*HTTPFetcher)
+	trace := fetcher.trace()
+	trace.subresource(false)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer trace.subresource(true)
+		if _, err := Fetch(u); err != nil {
+			logger.Logger.Printf("prefetch 실패 %s: %v", u.Redacted(), err)
+		}
+	}()
+}
+
+// Wait는 시작한 preconnect/prefetch가 모두 끝날 때까지 기다림
+func (p *Preloader) Wait() {
+	p.wg.Wait()
+}
+
+// markSeen은 key를 처음 보면 기록하고 true를 반환함
+func (p *Preloader) markSeen(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen[key] {
+		return false
+	}
+	p.seen[key] = true
+	return true
+}
+
+// GlobalPreloader는 HTTPFetcher가 103 Early Hints를 받았을 때 사용하는 Preloader
+var GlobalPreloader = NewPreloader()
@@ -0,0 +1,91 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// ============================================
+// 103 Early Hints / Link 헤더 테스트
+// ============================================
+
+// TestParseLinkHeader Link 헤더 파싱
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []net.LinkHint
+	}{
+		{
+			`</style.css>; rel=preload; as=style`,
+			[]net.LinkHint{{URL: "/style.css", Rel: "preload", As: "style"}},
+		},
+		{
+			`</a.js>; rel="preload"; as="script", <https://cdn.example>; rel=preconnect`,
+			[]net.LinkHint{
+				{URL: "/a.js", Rel: "preload", As: "script"},
+				{URL: "https://cdn.example", Rel: "preconnect"},
+			},
+		},
+		{
+			`</x?a=1,2>; REL=Prefetch`,
+			[]net.LinkHint{{URL: "/x?a=1,2", Rel: "prefetch"}},
+		},
+		{``, nil},
+		{`no brackets; rel=preload`, nil},
+	}
+
+	for _, tt := range tests {
+		got := net.ParseLinkHeader(tt.input)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLinkHeader(%q) = %+v; want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestHTTPFetcher_EarlyHintsPreload 103 응답의 preload 링크를 미리 가져와 캐시에 넣음
+func TestHTTPFetcher_EarlyHintsPreload(t *testing.T) {
+	var styleHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/early.css" {
+			styleHits.Add(1)
+			w.Write([]byte("body{}"))
+			return
+		}
+
+		conn, buf, _ := w.(http.Hijacker).Hijack()
+		defer conn.Close()
+		buf.WriteString("HTTP/1.1 103 Early Hints\r\nLink: </early.css>; rel=preload; as=style\r\n\r\n")
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 4\r\n\r\npage")
+		buf.Flush()
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/early")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "page" {
+		t.Errorf("Body = %q; want %q", resp.Body, "page")
+	}
+
+	net.GlobalPreloader.Wait()
+	if styleHits.Load() != 1 {
+		t.Fatalf("styleHits = %d; want 1 (preload)", styleHits.Load())
+	}
+
+	// preload한 리소스는 캐시에서 가져옴
+	styleURL, _ := url.NewURL(server.URL + "/early.css")
+	body, err := net.Request(styleURL)
+	if err != nil {
+		t.Fatalf("Request() failed: %v", err)
+	}
+	if body != "body{}" || styleHits.Load() != 1 {
+		t.Errorf("Request() = %q, styleHits = %d; want %q, 1", body, styleHits.Load(), "body{}")
+	}
+}
@@ -0,0 +1,71 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the privacy preference headers (DNT, Sec-GPC) and the about:config page.
+package net
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// 개인정보 보호 선호를 알리는 요청 헤더
+const (
+	HeaderDNT    = "DNT"     // Do Not Track
+	HeaderSecGPC = "Sec-GPC" // Global Privacy Control
+)
+
+// DoNotTrack이 true면 모든 요청에 "DNT: 1"을 보냄 (opt-in, 설정 파일의 do_not_track)
+var DoNotTrack bool
+
+// GlobalPrivacyControl이 true면 모든 요청에 "Sec-GPC: 1"을 보냄 (opt-in, 설정 파일의 global_privacy_control)
+var GlobalPrivacyControl bool
+
+// setPrivacyHeaders는 켜진 개인정보 보호 헤더를 headers에 넣음
+func setPrivacyHeaders(headers Header) {
+	if DoNotTrack {
+		headers.Set(HeaderDNT, "1")
+	}
+	if GlobalPrivacyControl {
+		headers.Set(HeaderSecGPC, "1")
+	}
+}
+
+// writePrivacyHeaders는 켜진 개인정보 보호 헤더를 요청 메시지에 씀 (Stream, EventSource용)
+func writePrivacyHeaders(request *strings.Builder) {
+	headers := Header{}
+	setPrivacyHeaders(headers)
+	for key, value := range headers {
+		fmt.Fprintf(request, "%s: %s\r\n", wireHeaderKey(key), value)
+	}
+}
+
+// configSettings는 about:config에 보여줄 네트워크 설정 (설정 파일 이름, 지금 값, 설명)
+func configSettings() [][3]string {
+	agents := "(없음)"
+	if len(GlobalUserAgents) > 0 {
+		var rules []string
+		for _, host := range slices.Sorted(maps.Keys(GlobalUserAgents)) {
+			rules = append(rules, host+" → "+GlobalUserAgents[host])
+		}
+		agents = strings.Join(rules, ", ")
+	}
+	return [][3]string{
+		{"do_not_track", fmt.Sprint(DoNotTrack), "모든 요청에 DNT: 1 헤더를 보냄"},
+		{"global_privacy_control", fmt.Sprint(GlobalPrivacyControl), "모든 요청에 Sec-GPC: 1 헤더를 보냄"},
+		{"first_party_isolation", fmt.Sprint(FirstPartyIsolation), "쿠키를 최상위 사이트별로 나눠서 사이트 간 추적을 막음"},
+		{"lookalike_warning", fmt.Sprint(CheckLookalikes), "잘 알려진 사이트와 비슷해 보이는 주소를 열기 전에 경고함"},
+		{"user_agents", agents, "호스트별 User-Agent (\"firefox\", \"chrome\" 프리셋)"},
+		{"--https-first", fmt.Sprint(HTTPSFirst), "http:// 주소를 https://로 먼저 시도함"},
+	}
+}
+
+// configPage: 설정 파일(config.json)로 바꿀 수 있는 네트워크 설정과 지금 값
+func configPage() (string, string) {
+	var b strings.Builder
+	b.WriteString("네트워크 설정 (설정 파일 config.json의 항목, --로 시작하면 명령줄 옵션)\n\n")
+	for _, s := range configSettings() {
+		fmt.Fprintf(&b, "%s = %s\n  %s\n", s[0], s[1], s[2])
+	}
+	return MIMETextPlain, b.String()
+}
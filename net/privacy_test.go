@@ -0,0 +1,47 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPrivacyHeaders 설정을 켜면 요청에 DNT: 1, Sec-GPC: 1을 보내고, about:config에 켜진 값이 보임
+func TestPrivacyHeaders(t *testing.T) {
+	var dnt, gpc string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dnt, gpc = r.Header.Get("DNT"), r.Header.Get("Sec-GPC")
+	}))
+	defer srv.Close()
+	u, _ := url.NewURL(srv.URL + "/")
+
+	if _, err := isolatedFetcher(t).Fetch(u); err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if dnt != "" || gpc != "" {
+		t.Errorf("DNT, Sec-GPC = %q, %q by default; want none", dnt, gpc)
+	}
+
+	net.DoNotTrack, net.GlobalPrivacyControl = true, true
+	defer func() { net.DoNotTrack, net.GlobalPrivacyControl = false, false }()
+	if _, err := isolatedFetcher(t).Fetch(u); err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if dnt != "1" || gpc != "1" {
+		t.Errorf("DNT, Sec-GPC = %q, %q; want \"1\", \"1\"", dnt, gpc)
+	}
+
+	about, _ := url.NewURL("about:config")
+	resp, err := net.Fetch(about)
+	if err != nil {
+		t.Fatalf("Fetch(about:config) failed: %v", err)
+	}
+	for _, want := range []string{"do_not_track = true", "global_privacy_control = true"} {
+		if !strings.Contains(resp.Body, want) {
+			t.Errorf("about:config = %q; want %q", resp.Body, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Package net implements HTTP networking for the browser.
+// This file contains byte range requests (Range, 206 Partial Content).
+package net
+
+import (
+	"cmp"
+	"fmt"
+	"go-web-browser/url"
+	"io"
+	"mime"
+	"mime/multipart"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 범위 요청의 상태 코드
+const (
+	StatusPartialContent      = 206
+	StatusRangeNotSatisfiable = 416
+)
+
+// ContentRange는 Content-Range 헤더 ("bytes 0-99/1234")
+type ContentRange struct {
+	First, Last int64 // 받은 바이트 범위 (양 끝 포함)
+	Size        int64 // 자원 전체 크기 (모르면 -1, "*")
+}
+
+// ParseContentRange는 Content-Range 헤더 값을 해석함
+//
+// 예시:
+//   - "bytes 0-99/1234" → {0, 99, 1234}
+//   - "bytes 100-199/*" → {100, 199, -1}
+func ParseContentRange(value string) (ContentRange, error) {
+	unit, spec, ok := strings.Cut(strings.TrimSpace(value), " ")
+	if !ok || !strings.EqualFold(unit, "bytes") {
+		return ContentRange{}, fmt.Errorf("지원하지 않는 Content-Range: %q", value)
+	}
+	span, size, ok := strings.Cut(spec, "/")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("잘못된 Content-Range: %q", value)
+	}
+
+	cr := ContentRange{Size: -1}
+	if size != "*" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || n < 0 {
+			return ContentRange{}, fmt.Errorf("잘못된 Content-Range 크기: %q", value)
+		}
+		cr.Size = n
+	}
+
+	first, last, ok := strings.Cut(span, "-")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("잘못된 Content-Range 범위: %q", value)
+	}
+	var err1, err2 error
+	cr.First, err1 = strconv.ParseInt(first, 10, 64)
+	cr.Last, err2 = strconv.ParseInt(last, 10, 64)
+	if err1 != nil || err2 != nil || cr.First < 0 || cr.Last < cr.First || (cr.Size >= 0 && cr.Last >= cr.Size) {
+		return ContentRange{}, fmt.Errorf("잘못된 Content-Range 범위: %q", value)
+	}
+	return cr, nil
+}
+
+// MIMEByteranges는 범위 여러 개를 부분(part)으로 나눠 담은 206 응답의 Content-Type
+const MIMEByteranges = "multipart/byteranges"
+
+// byterangesPart는 multipart/byteranges 응답의 부분 하나
+type byterangesPart struct {
+	contentType string
+	rng         ContentRange
+	data        string
+}
+
+// decodeByteranges는 multipart/byteranges로 온 206 응답의 부분들을 바이트 순서대로 이어 붙여
+// Content-Range 하나짜리 206 응답으로 바꿈 (multipart 응답이 아니면 res를 그대로 반환함)
+//
+// 서버는 요청한 범위 하나를 여러 부분으로 나누거나 순서를 바꿔 보낼 수 있으므로 First 순으로 정렬하고
+// 겹치는 바이트는 한 번만 씀, 부분 사이가 비어 있으면 이어 붙일 수 없으므로 에러를 반환함
+func decodeByteranges(res result) (result, error) {
+	mediaType, params, err := mime.ParseMediaType(res.headers.Get("Content-Type"))
+	if res.statusCode != StatusPartialContent || err != nil || mediaType != MIMEByteranges {
+		return res, nil
+	}
+	if params["boundary"] == "" {
+		return result{}, fmt.Errorf("%s 응답에 boundary가 없습니다", MIMEByteranges)
+	}
+
+	var parts []byterangesPart
+	reader := multipart.NewReader(strings.NewReader(res.body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result{}, fmt.Errorf("%s 응답 해석 실패: %w", MIMEByteranges, err)
+		}
+		cr, err := ParseContentRange(part.Header.Get("Content-Range"))
+		if err != nil {
+			return result{}, err
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return result{}, fmt.Errorf("%s 응답 해석 실패: %w", MIMEByteranges, err)
+		}
+		if int64(len(data)) != cr.Last-cr.First+1 {
+			return result{}, fmt.Errorf("%s 부분의 길이(%d)가 Content-Range와 다릅니다: bytes %d-%d", MIMEByteranges, len(data), cr.First, cr.Last)
+		}
+		parts = append(parts, byterangesPart{contentType: part.Header.Get("Content-Type"), rng: cr, data: string(data)})
+	}
+	if len(parts) == 0 {
+		return result{}, fmt.Errorf("%s 응답에 부분이 없습니다", MIMEByteranges)
+	}
+
+	slices.SortFunc(parts, func(a, b byterangesPart) int { return cmp.Compare(a.rng.First, b.rng.First) })
+	merged := parts[0].rng
+	var body strings.Builder
+	body.WriteString(parts[0].data)
+	for _, p := range parts[1:] {
+		if p.rng.First > merged.Last+1 {
+			return result{}, fmt.Errorf("%s 부분 사이가 비어 있습니다 (bytes %d-%d 다음 %d-%d)",
+				MIMEByteranges, merged.First, merged.Last, p.rng.First, p.rng.Last)
+		}
+		if p.rng.Last > merged.Last {
+			body.WriteString(p.data[merged.Last+1-p.rng.First:])
+			merged.Last = p.rng.Last
+		}
+	}
+
+	headers := res.headers.Clone()
+	headers.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", merged.First, merged.Last, formatRangeSize(merged.Size)))
+	if parts[0].contentType != "" {
+		headers.Set("Content-Type", parts[0].contentType)
+	} else {
+		headers.Del("Content-Type")
+	}
+	headers.Set("Content-Length", strconv.Itoa(body.Len()))
+	res.headers, res.body = headers, body.String()
+	return res, nil
+}
+
+// formatRangeSize는 Content-Range의 전체 크기 자리 (모르면 "*")
+func formatRangeSize(size int64) string {
+	if size < 0 {
+		return "*"
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// RangeNotSatisfiableError는 요청한 범위가 자원 밖일 때 (416)의 에러
+type RangeNotSatisfiableError struct {
+	From int64
+	Size int64 // 서버가 알려준 전체 크기 (모르면 -1)
+}
+
+func (e *RangeNotSatisfiableError) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("요청한 범위(%d-)가 자원 밖입니다 (416)", e.From)
+	}
+	return fmt.Sprintf("요청한 범위(%d-)가 자원 밖입니다 (전체 %d바이트)", e.From, e.Size)
+}
+
+// RangeResponse는 범위 요청의 결과
+type RangeResponse struct {
+	*Response
+	Range   ContentRange // 본문이 자원의 어느 부분인지
+	Partial bool         // 서버가 요청한 범위만 보냈는지 (false면 Range를 무시하고 전체를 보낸 것)
+}
+
+// RequestRange는 u의 from번째 바이트부터 to번째 바이트까지(양 끝 포함)를 요청함 (to가 -1이면 끝까지)
+//
+// 206 응답의 본문은 문자 인코딩을 변환하지 않은 바이트 그대로이고 캐시에 저장하지 않음
+// 서버가 Range를 무시하고 200으로 전체를 보내면 Partial이 false이고 본문은 전체 자원임
+// 이어받기에 쓸 수 있도록 서버가 from이 아닌 곳부터 보내면 에러를 반환함
+func RequestRange(u *url.URL, from, to int64) (*RangeResponse, error) {
+	if from < 0 || (to >= 0 && to < from) || to < -1 {
+		return nil, fmt.Errorf("잘못된 범위: %d-%d", from, to)
+	}
+	h, ok := FetcherRegistry[u.Scheme].(*HTTPFetcher)
+	if !ok {
+		return nil, fmt.Errorf("범위 요청은 http(s)만 지원합니다: %s", u.Scheme)
+	}
+
+	spec := fmt.Sprintf("bytes=%d-", from)
+	if to >= 0 {
+		spec += strconv.FormatInt(to, 10)
+	}
+	resp, err := h.fetchNetwork(u, Header{"range": spec})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case StatusPartialContent:
+		cr, err := ParseContentRange(resp.Headers.Get("Content-Range"))
+		if err != nil {
+			return nil, err
+		}
+		if cr.First != from {
+			return nil, fmt.Errorf("요청한 범위(%s)와 다른 범위를 받았습니다: %s", spec, resp.Headers.Get("Content-Range"))
+		}
+		return &RangeResponse{Response: resp, Range: cr, Partial: true}, nil
+	case StatusRangeNotSatisfiable:
+		size := int64(-1)
+		if _, total, ok := strings.Cut(resp.Headers.Get("Content-Range"), "/"); ok {
+			if n, err := strconv.ParseInt(total, 10, 64); err == nil {
+				size = n
+			}
+		}
+		return nil, &RangeNotSatisfiableError{From: from, Size: size}
+	case 200:
+		n := int64(len(resp.Body))
+		return &RangeResponse{Response: resp, Range: ContentRange{First: 0, Last: n - 1, Size: n}}, nil
+	}
+	return nil, fmt.Errorf("범위 요청 실패 (status %d)", resp.StatusCode)
+}
@@ -0,0 +1,124 @@
+package net_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseContentRange 범위, 알 수 없는 크기(*), 잘못된 값
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    net.ContentRange
+		wantErr bool
+	}{
+		{"bytes 0-99/1234", net.ContentRange{First: 0, Last: 99, Size: 1234}, false},
+		{"bytes 100-199/*", net.ContentRange{First: 100, Last: 199, Size: -1}, false},
+		{"bytes 5-4/10", net.ContentRange{}, true},
+		{"bytes 0-10/10", net.ContentRange{}, true},
+		{"items 0-1/2", net.ContentRange{}, true},
+		{"bytes */1234", net.ContentRange{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := net.ParseContentRange(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseContentRange(%q) = %+v, %v; want %+v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestRequestRange 206이면 요청한 부분만 (인코딩 변환 없이), 범위 밖이면 416 에러
+func TestRequestRange(t *testing.T) {
+	content := "0123456789abcdef" + strings.Repeat("한", 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/range")
+	tests := []struct {
+		from, to int64
+		want     string
+	}{
+		{0, 3, "0123"},
+		{10, 15, "abcdef"},
+		{17, -1, content[17:]}, // 한글 중간에서 잘려도 바이트 그대로
+	}
+	for _, tt := range tests {
+		resp, err := net.RequestRange(u, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("RequestRange(%d, %d) failed: %v", tt.from, tt.to, err)
+		}
+		if !resp.Partial || resp.Body != tt.want || resp.Range.First != tt.from || resp.Range.Size != int64(len(content)) {
+			t.Errorf("RequestRange(%d, %d) = %q, %+v, partial %v; want %q", tt.from, tt.to, resp.Body, resp.Range, resp.Partial, tt.want)
+		}
+	}
+
+	// 일부만 받은 응답은 캐시에 남지 않음
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != content {
+		t.Errorf("Fetch() after RequestRange = %q; want %q", resp.Body, content)
+	}
+
+	_, err = net.RequestRange(u, 1000, -1)
+	var rangeErr *net.RangeNotSatisfiableError
+	if !errors.As(err, &rangeErr) || rangeErr.Size != int64(len(content)) {
+		t.Errorf("RequestRange(1000, -1) error = %v; want RangeNotSatisfiableError with size %d", err, len(content))
+	}
+}
+
+// TestRequestRange_Byteranges multipart/byteranges로 나눠 온 206 응답을 범위 하나로 이어 붙이고, 부분 사이가 비면 에러
+func TestRequestRange_Byteranges(t *testing.T) {
+	content := "0123456789abcdef"
+	gap := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		// 순서를 바꾸고 겹치게 보냄 (gap이면 6-7이 빠짐)
+		ranges := [][2]int{{4, 9}, {2, 5}}
+		if gap {
+			ranges = [][2]int{{8, 9}, {2, 5}}
+		}
+		for _, rng := range ranges {
+			part, _ := mw.CreatePart(textproto.MIMEHeader{
+				"Content-Type":  {"text/plain"},
+				"Content-Range": {fmt.Sprintf("bytes %d-%d/%d", rng[0], rng[1], len(content))},
+			})
+			part.Write([]byte(content[rng[0] : rng[1]+1]))
+		}
+		mw.Close()
+		w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(body.Bytes())
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/multi")
+	resp, err := net.RequestRange(u, 2, 9)
+	if err != nil {
+		t.Fatalf("RequestRange(2, 9) failed: %v", err)
+	}
+	if resp.Body != "23456789" || resp.Range != (net.ContentRange{First: 2, Last: 9, Size: 16}) || resp.ContentType != "text/plain" {
+		t.Errorf("RequestRange(2, 9) = %q, %+v, %q; want %q, bytes 2-9/16, text/plain", resp.Body, resp.Range, resp.ContentType, "23456789")
+	}
+
+	gap = true
+	if _, err := net.RequestRange(u, 2, 9); err == nil {
+		t.Errorf("RequestRange(2, 9) with a gap returned no error; want error")
+	}
+}
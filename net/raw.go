@@ -0,0 +1,91 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the raw exchange that sends bytes as-is and copies back what the server sends.
+package net
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// DefaultRawIdle은 RawRequest.Idle이 0일 때 응답이 끝났다고 보는 대기 시간
+//
+// keep-alive 서버는 응답 뒤에도 연결을 닫지 않으므로 잠시 조용하면 끝으로 봄
+const DefaultRawIdle = 2 * time.Second
+
+// RawRequest는 가공하지 않은 바이트를 그대로 주고받는 요청 (프로토콜을 관찰하거나 가르칠 때 씀)
+type RawRequest struct {
+	Address string        // "호스트:포트"
+	TLS     bool          // true면 TLS 핸드셰이크 뒤에 보냄 (인증서 핀 확인 포함)
+	Data    []byte        // 보낼 바이트 그대로 (요청 줄, 헤더, 빈 줄, 본문 모두 호출한 쪽이 만듦)
+	Idle    time.Duration // 이 시간 동안 아무것도 오지 않으면 끝으로 봄 (0이면 DefaultRawIdle)
+}
+
+// RawResult는 RawExchange의 결과
+type RawResult struct {
+	Received int64         // 받은 바이트 수
+	Elapsed  time.Duration // 연결부터 마지막 바이트까지 걸린 시간
+	Closed   bool          // 서버가 연결을 닫아서 끝났는지 (false면 Idle 동안 조용해서 끝냄)
+}
+
+// RawExchange는 Dialer(TLS 설정 포함)로 연결해서 req.Data를 그대로 보내고
+// 서버가 연결을 닫거나 req.Idle 동안 조용해질 때까지 받은 바이트를 그대로 w에 씀
+//
+// 응답을 해석하지 않으므로 연결 상태를 알 수 없어서 풀에 돌려주지 않음 (만든 연결 수는 풀 통계에 남음)
+func (h *HTTPFetcher) RawExchange(req RawRequest, w io.Writer) (RawResult, error) {
+	start := time.Now()
+	var conn net.Conn
+	var err error
+	if req.TLS {
+		conn, err = h.dialer().DialTLSContext(context.Background(), "tcp", req.Address)
+		if err == nil {
+			if pinErr := checkPin(conn, req.Address); pinErr != nil {
+				conn.Close()
+				return RawResult{}, pinErr
+			}
+		}
+	} else {
+		conn, err = h.dialer().DialContext(context.Background(), "tcp", req.Address)
+	}
+	if err != nil {
+		return RawResult{}, err
+	}
+	h.pool().recordCreated(req.Address)
+	defer h.pool().Discard(req.Address, conn)
+	h.logger().Printf("raw: %s에 %d바이트 보냄", req.Address, len(req.Data))
+
+	if _, err := conn.Write(req.Data); err != nil {
+		return RawResult{}, err
+	}
+
+	idle := req.Idle
+	if idle <= 0 {
+		idle = DefaultRawIdle
+	}
+	var result RawResult
+	buf := make([]byte, 32<<10)
+	for {
+		conn.SetReadDeadline(time.Now().Add(idle))
+		n, err := conn.Read(buf)
+		if n > 0 {
+			result.Received += int64(n)
+			result.Elapsed = time.Since(start)
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return result, werr
+			}
+		}
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			return result, nil
+		case errors.Is(err, io.EOF):
+			result.Closed = true
+			return result, nil
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,50 @@
+package net_test
+
+import (
+	"bytes"
+	"go-web-browser/net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHTTPFetcher_RawExchange 보낸 요청 바이트에 대한 응답 바이트를 해석하지 않고 그대로 돌려줌
+func TestHTTPFetcher_RawExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+	address := server.Listener.Addr().String()
+
+	tests := []struct {
+		name       string
+		data       string
+		wantPrefix string
+		wantClosed bool
+	}{
+		{"keep-alive", "HEAD / HTTP/1.1\r\nHost: x\r\n\r\n", "HTTP/1.1 200 OK\r\n", false},
+		{"Connection: close", "GET / HTTP/1.1\r\nHost: x\r\nConnection: close\r\n\r\n", "HTTP/1.1 200 OK\r\n", true},
+		{"잘못된 요청", "NONSENSE\r\n\r\n", "HTTP/1.1 400 Bad Request\r\n", true},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		req := net.RawRequest{Address: address, Data: []byte(tt.data), Idle: 100 * time.Millisecond}
+		result, err := (&net.HTTPFetcher{}).RawExchange(req, &out)
+		if err != nil {
+			t.Fatalf("%s: RawExchange() failed: %v", tt.name, err)
+		}
+		if !strings.HasPrefix(out.String(), tt.wantPrefix) {
+			t.Errorf("%s: RawExchange() wrote %q; want prefix %q", tt.name, out.String(), tt.wantPrefix)
+		}
+		if result.Received != int64(out.Len()) {
+			t.Errorf("%s: Received = %d; want %d", tt.name, result.Received, out.Len())
+		}
+		if result.Closed != tt.wantClosed {
+			t.Errorf("%s: Closed = %v; want %v", tt.name, result.Closed, tt.wantClosed)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the redirect policy hooks consulted before following a redirect.
+package net
+
+import (
+	"errors"
+	"fmt"
+	"go-web-browser/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRedirects는 HTTPFetcher.MaxRedirects가 0일 때 따라가는 최대 리다이렉트 횟수
+const DefaultMaxRedirects = 10
+
+// RedirectPolicy는 리다이렉트를 따라가기 전에 불리는 함수
+//
+// prev는 리다이렉트 응답을 보낸 주소, next는 Location을 절대 URL로 바꾼 다음 주소, status는 3xx 상태 코드
+// 에러를 반환하면 따라가지 않고 Fetch가 그 에러를 반환함 (ErrUseLastResponse면 3xx 응답을 그대로 반환)
+// 한 번의 Fetch에서 리다이렉트마다 차례로 불리므로 리다이렉트 체인을 기록하는 데도 쓸 수 있음
+type RedirectPolicy func(prev, next *url.URL, status int) error
+
+// ErrUseLastResponse를 RedirectPolicy가 반환하면 리다이렉트를 따라가지 않고 3xx 응답을 그대로 반환함 (캐시에는 저장하지 않음)
+var ErrUseLastResponse = errors.New("리다이렉트를 따라가지 않음")
+
+// ErrRedirectScheme은 허용하지 않은 스킴으로 가는 리다이렉트를 막았다는 에러
+var ErrRedirectScheme = errors.New("허용하지 않은 스킴으로 가는 리다이렉트")
+
+// DefaultRedirectPolicy는 HTTPFetcher.RedirectPolicy가 nil일 때 쓰는 정책 (http, https, http+unix로만 따라감)
+//
+// about:, data:, file: 등으로 가는 리다이렉트는 서버가 로컬 자원이나 임의의 내용을 보여줄 수 있어서 기본으로 막음
+var DefaultRedirectPolicy = AllowSchemes(url.SchemeHTTP, url.SchemeHTTPS, url.SchemeHTTPUnix)
+
+// ErrRedirectDowngrade는 ForbidDowngrade가 https → http 리다이렉트를 막았다는 에러
+var ErrRedirectDowngrade = errors.New("https에서 http로 가는 리다이렉트")
+
+// TooManyRedirectsError는 MaxRedirects보다 많이 리다이렉트되었다는 에러
+type TooManyRedirectsError struct {
+	Max  int
+	Last *url.URL // 따라가지 않은 마지막 Location
+}
+
+func (e *TooManyRedirectsError) Error() string {
+	return fmt.Sprintf("최대 리다이렉트 횟수 초과 (최대 %d회, 다음 주소: %s)", e.Max, e.Last.Redacted())
+}
+
+// RedirectHop은 최종 주소에 오기까지 거친 리다이렉트 하나
+type RedirectHop struct {
+	URL        *url.URL      // 리다이렉트 응답을 보낸 주소
+	StatusCode int           // 3xx 상태 코드
+	Location   *url.URL      // Location을 절대 URL로 바꾼 다음 주소
+	Duration   time.Duration // 요청을 보내고 이 응답을 다 받을 때까지 걸린 시간 (재시도 포함)
+}
+
+// String은 "301 http://a/ → https://a/ (12.3ms)" 꼴
+func (hop RedirectHop) String() string {
+	return fmt.Sprintf("%d %s → %s (%s)", hop.StatusCode, hop.URL.Redacted(), hop.Location.Redacted(), formatMillis(hop.Duration))
+}
+
+// RedirectChain은 Redirects를 한 줄에 하나씩 번호를 붙여 보여줌 (리다이렉트가 없으면 "")
+//
+// 예:
+//
+//  1. 301 http://example.com/ → https://example.com/ (12.3ms)
+//  2. 302 https://example.com/ → https://www.example.com/ (40.1ms)
+func (r *Response) RedirectChain() string {
+	var b strings.Builder
+	for i, hop := range r.Redirects {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, hop)
+	}
+	return b.String()
+}
+
+// ForbidDowngrade는 https 페이지가 http로 리다이렉트하는 것을 막는 RedirectPolicy
+func ForbidDowngrade(prev, next *url.URL, status int) error {
+	if prev.Scheme == url.SchemeHTTPS && next.Scheme == url.SchemeHTTP {
+		return fmt.Errorf("%w: %s → %s (status %d)", ErrRedirectDowngrade, prev, next, status)
+	}
+	return nil
+}
+
+// AllowSchemes는 next의 스킴이 schemes 중 하나일 때만 따라가는 RedirectPolicy
+//
+// 허용한 스킴이 http 계열이 아니면 FetcherRegistry에 등록된 그 스킴의 Fetcher로 가져옴
+func AllowSchemes(schemes ...url.Scheme) RedirectPolicy {
+	return func(prev, next *url.URL, status int) error {
+		if slices.Contains(schemes, next.Scheme) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s → %s (status %d)", ErrRedirectScheme, prev, next, status)
+	}
+}
+
+// ChainRedirectPolicies는 policies를 차례로 불러서 처음 나온 에러를 반환하는 RedirectPolicy (nil은 건너뜀)
+func ChainRedirectPolicies(policies ...RedirectPolicy) RedirectPolicy {
+	return func(prev, next *url.URL, status int) error {
+		for _, p := range policies {
+			if p == nil {
+				continue
+			}
+			if err := p(prev, next, status); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// redirectPolicy는 h의 RedirectPolicy (nil이면 DefaultRedirectPolicy)
+func (h *HTTPFetcher) redirectPolicy() RedirectPolicy {
+	if h.RedirectPolicy != nil {
+		return h.RedirectPolicy
+	}
+	return DefaultRedirectPolicy
+}
+
+// isHTTPScheme은 HTTPFetcher가 직접 요청하는 스킴인지 확인함
+func isHTTPScheme(scheme url.Scheme) bool {
+	return scheme == url.SchemeHTTP || scheme == url.SchemeHTTPS || scheme == url.SchemeHTTPUnix
+}
+
+// fetchRedirected는 http가 아닌 스킴으로 리다이렉트된 주소를 FetcherRegistry로 가져옴 (캐시에는 저장하지 않음)
+//
+// hops는 여기까지 거친 리다이렉트 (가져온 Response.Redirects 앞에 붙임)
+func fetchRedirected(u *url.URL, hops []RedirectHop) (*Response, error) {
+	fetcher, ok := FetcherRegistry[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("리다이렉트된 주소의 프로토콜을 지원하지 않습니다: %s", u.Scheme)
+	}
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		return nil, err
+	}
+	resp.Redirects = append(hops, resp.Redirects...)
+	return resp, nil
+}
+
+// maxRedirects는 h가 따라가는 최대 리다이렉트 횟수
+func (h *HTTPFetcher) maxRedirects() int {
+	if h.MaxRedirects > 0 {
+		return h.MaxRedirects
+	}
+	return DefaultMaxRedirects
+}
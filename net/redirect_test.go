@@ -0,0 +1,201 @@
+package net_test
+
+import (
+	"errors"
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// redirectServer: /r/N은 /r/N-1로, /r/0은 본문 "done"으로 응답하는 서버
+func redirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		fmt.Sscanf(r.URL.Path, "/r/%d", &n)
+		if n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/r/%d", n-1), http.StatusFound)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("done"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestHTTPFetcher_RedirectPolicy 리다이렉트마다 정책이 불리고, 에러를 반환하면 멈춤
+func TestHTTPFetcher_RedirectPolicy(t *testing.T) {
+	srv := redirectServer(t)
+	var chain []string
+	fetcher := &net.HTTPFetcher{RedirectPolicy: func(prev, next *url.URL, status int) error {
+		chain = append(chain, fmt.Sprintf("%d %s", status, next.Path))
+		if next.Path == "/r/0" {
+			return errors.New("stop")
+		}
+		return nil
+	}}
+
+	u, _ := url.NewURL(srv.URL + "/r/3")
+	_, err := fetcher.Fetch(u)
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("Fetch() error = %v; want %q", err, "stop")
+	}
+	want := []string{"302 /r/2", "302 /r/1", "302 /r/0"}
+	if fmt.Sprint(chain) != fmt.Sprint(want) {
+		t.Errorf("policy calls = %q; want %q", chain, want)
+	}
+}
+
+// TestHTTPFetcher_RedirectUseLastResponse ErrUseLastResponse면 3xx 응답을 그대로 반환함
+func TestHTTPFetcher_RedirectUseLastResponse(t *testing.T) {
+	srv := redirectServer(t)
+	fetcher := &net.HTTPFetcher{RedirectPolicy: func(prev, next *url.URL, status int) error {
+		return net.ErrUseLastResponse
+	}}
+
+	u, _ := url.NewURL(srv.URL + "/r/2")
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusFound || resp.Headers["location"] != "/r/1" {
+		t.Errorf("Fetch() = %d, Location %q; want 302, %q", resp.StatusCode, resp.Headers["location"], "/r/1")
+	}
+}
+
+// TestHTTPFetcher_RedirectHops 거친 리다이렉트를 주소, 상태 코드, 다음 주소, 걸린 시간과 함께 차례로 기록함
+func TestHTTPFetcher_RedirectHops(t *testing.T) {
+	srv := redirectServer(t)
+	fetcher := isolatedFetcher(t)
+
+	u, _ := url.NewURL(srv.URL + "/r/2")
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if len(resp.Redirects) != 2 {
+		t.Fatalf("Redirects = %v; want 2 hops", resp.Redirects)
+	}
+	for i, want := range [][2]string{{"/r/2", "/r/1"}, {"/r/1", "/r/0"}} {
+		hop := resp.Redirects[i]
+		if hop.URL.Path != want[0] || hop.Location.Path != want[1] || hop.StatusCode != http.StatusFound || hop.Duration <= 0 {
+			t.Errorf("Redirects[%d] = %v; want 302 %s → %s with a duration", i, hop, want[0], want[1])
+		}
+	}
+	chain := resp.RedirectChain()
+	if !strings.HasPrefix(chain, "1. 302 "+srv.URL+"/r/2 → "+srv.URL+"/r/1 (") || !strings.Contains(chain, "\n2. 302 ") {
+		t.Errorf("RedirectChain() = %q; want numbered hops", chain)
+	}
+
+	resp, err = fetcher.Fetch(resp.URL)
+	if err != nil || resp.Redirects != nil || resp.RedirectChain() != "" {
+		t.Errorf("Fetch(final URL) = %v, %v; want no redirects", resp.Redirects, err)
+	}
+}
+
+// TestHTTPFetcher_MaxRedirects MaxRedirects번까지는 따라가고 넘으면 TooManyRedirectsError
+func TestHTTPFetcher_MaxRedirects(t *testing.T) {
+	srv := redirectServer(t)
+	fetcher := &net.HTTPFetcher{MaxRedirects: 2}
+
+	u, _ := url.NewURL(srv.URL + "/r/2")
+	if resp, err := fetcher.Fetch(u); err != nil || resp.Body != "done" {
+		t.Errorf("Fetch(/r/2) = %v, %v; want body %q", resp, err, "done")
+	}
+
+	u, _ = url.NewURL(srv.URL + "/r/3")
+	_, err := fetcher.Fetch(u)
+	var tooMany *net.TooManyRedirectsError
+	if !errors.As(err, &tooMany) || tooMany.Max != 2 || tooMany.Last.Path != "/r/0" {
+		t.Errorf("Fetch(/r/3) error = %v; want TooManyRedirectsError{Max: 2, Last: /r/0}", err)
+	}
+}
+
+// TestForbidDowngrade https → http만 막음
+func TestForbidDowngrade(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		wantErr    bool
+	}{
+		{"https://a.example/", "http://a.example/", true},
+		{"https://a.example/", "https://b.example/", false},
+		{"http://a.example/", "https://a.example/", false},
+		{"http://a.example/", "http://b.example/", false},
+	}
+
+	policy := net.ChainRedirectPolicies(nil, net.ForbidDowngrade)
+	for _, tt := range tests {
+		prev, _ := url.NewURL(tt.prev)
+		next, _ := url.NewURL(tt.next)
+		err := policy(prev, next, http.StatusMovedPermanently)
+		if got := errors.Is(err, net.ErrRedirectDowngrade); got != tt.wantErr {
+			t.Errorf("ForbidDowngrade(%q, %q) = %v; want downgrade error = %v", tt.prev, tt.next, err, tt.wantErr)
+		}
+	}
+}
+
+// locationServer: ?to=의 값을 그대로 Location으로 보내는 서버 (/next는 본문 "next")
+func locationServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if to := r.URL.Query().Get("to"); to != "" {
+			w.Header().Set("Location", to)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("next"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestHTTPFetcher_CrossSchemeRedirect http가 아닌 스킴으로 가는 리다이렉트는 정책이 허용할 때만 그 스킴의 Fetcher로 가져옴
+func TestHTTPFetcher_CrossSchemeRedirect(t *testing.T) {
+	srv := locationServer(t)
+	allowAbout := net.AllowSchemes(url.SchemeHTTP, url.SchemeHTTPS, url.SchemeAbout, url.SchemeData)
+	tests := []struct {
+		name     string
+		policy   net.RedirectPolicy
+		location string
+		wantBody string
+		wantURL  string
+		wantErr  error // nil이 아니면 errors.Is로 확인
+		anyErr   bool
+	}{
+		{"relative path", nil, "next", "next", srv.URL + "/next", nil, false},
+		{"about blocked by default", nil, "about:blank", "", "", net.ErrRedirectScheme, true},
+		{"data blocked by default", nil, "data:,hi", "", "", net.ErrRedirectScheme, true},
+		{"about allowed", allowAbout, "about:blank", "", "about:blank", nil, false},
+		{"data allowed", allowAbout, "data:,hi", "hi", "", nil, false},
+		{"file not in allow list", allowAbout, "file:///etc/passwd", "", "", net.ErrRedirectScheme, true},
+		{"unsupported scheme", allowAbout, "ftp://example.com/a", "", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &net.HTTPFetcher{RedirectPolicy: tt.policy}
+			u, _ := url.NewURL(srv.URL + "/start?to=" + tt.location)
+			resp, err := fetcher.Fetch(u)
+			if tt.anyErr {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("Fetch(%q) error = %v; want %v", tt.location, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch(%q) error = %v", tt.location, err)
+			}
+			if tt.wantBody != "" && resp.Body != tt.wantBody {
+				t.Errorf("Fetch(%q) body = %q; want %q", tt.location, resp.Body, tt.wantBody)
+			}
+			if tt.wantURL != "" && resp.URL.String() != tt.wantURL {
+				t.Errorf("Fetch(%q) URL = %q; want %q", tt.location, resp.URL.String(), tt.wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the Response type returned by fetchers.
+package net
+
+import (
+	"go-web-browser/charset"
+	"go-web-browser/url"
+	"strings"
+)
+
+// Response는 Fetcher가 가져온 결과를 나타냄
+//
+// HTTP가 아닌 스킴(file, data 등)도 같은 구조로 돌려주기 때문에
+// 렌더러는 스킴과 상관없이 ContentType만 보고 표시 방법을 정할 수 있음
+type Response struct {
+	URL         *url.URL      // 최종 URL (리다이렉트 이후)
+	StatusCode  int           // HTTP 상태 코드 (http 이외 스킴은 200)
+	Headers     Header        // 응답 헤더 (Get으로 대소문자 상관없이 읽음)
+	Trailers    Header        // chunked 본문 뒤에 온 트레일러 (Server-Timing 등, 없거나 캐시에서 가져온 응답이면 nil)
+	Body        string        // 응답 본문 (텍스트는 UTF-8로 변환됨)
+	ContentType string        // 실제로 사용할 MIME 타입 (스니핑 결과 포함, 파라미터 제외)
+	Charset     string        // 본문의 원래 인코딩 (charset.UTF8 등, 텍스트가 아니면 "")
+	Security    *SecurityInfo // https 연결의 TLS 정보 (https가 아니거나 캐시에서 가져온 응답이면 nil)
+	FromCache   bool          // 네트워크 요청 없이 GlobalCache에서 가져온 응답인지
+	Upgrade     Upgrade       // HTTPS-first 모드에서 https로 연결했는지, http로 되돌아갔는지
+	Redirects   []RedirectHop // 이 응답에 오기까지 거친 리다이렉트 (차례대로, 없거나 캐시에서 가져온 응답이면 nil)
+}
+
+// newResponse는 상태 코드, 헤더, 본문으로 Response를 만들고 ContentType을 결정함
+func newResponse(u *url.URL, statusCode int, headers Header, body string) *Response {
+	if headers == nil {
+		headers = make(Header)
+	}
+	resp := &Response{
+		URL:         u,
+		StatusCode:  statusCode,
+		Headers:     headers,
+		Body:        body,
+		ContentType: DetermineContentType(headers.Get("Content-Type"), []byte(body)),
+	}
+	// 일부만 받은 본문(206)은 문자 중간에서 잘렸을 수 있으므로 바이트 그대로 둠
+	if isTextType(resp.ContentType) && statusCode != StatusPartialContent {
+		// 헤더와 <meta>에 인코딩이 없으면 본문으로 추측함 (EUC-KR로 된 오래된 한국어 사이트 등)
+		resp.Charset = charset.Detect(headers.Get("Content-Type"), []byte(body))
+		resp.Body = charset.Decode([]byte(body), resp.Charset)
+	}
+	return resp
+}
+
+// isTextType은 본문을 UTF-8로 변환해서 보여줄 MIME 타입인지 확인함
+func isTextType(mimeType string) bool {
+	switch {
+	case strings.HasPrefix(mimeType, "text/"), strings.HasSuffix(mimeType, "+xml"), strings.HasSuffix(mimeType, "+json"):
+		return true
+	}
+	switch mimeType {
+	case "application/xml", "application/json", "application/javascript":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the retry policy for transient HTTP failures.
+package net
+
+import (
+	"errors"
+	"go-web-browser/logger"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy는 일시적인 실패(연결 끊김, 502/503/504, 타임아웃)에 대한 재시도 정책
+//
+// 대기 시간은 BaseDelay부터 시도마다 두 배로 늘어나고 MaxDelay를 넘지 않음 (지수 백오프)
+// 여러 클라이언트가 동시에 재시도하지 않도록 Jitter 비율만큼 무작위로 줄임
+//
+// 재시도는 멱등(idempotent) 요청에만 적용함 (현재 브라우저는 GET만 보냄)
+type RetryPolicy struct {
+	MaxAttempts int           // 최대 시도 횟수 (첫 요청 포함, 1 이하면 재시도 안 함)
+	BaseDelay   time.Duration // 첫 재시도 전 대기 시간
+	MaxDelay    time.Duration // 대기 시간 상한 (Retry-After가 이보다 길면 재시도하지 않음)
+	Jitter      float64       // 0~1, 대기 시간을 최대 이 비율만큼 무작위로 줄임
+}
+
+// DefaultRetryPolicy는 --retry 옵션에서 사용하는 기본 정책
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.5,
+}
+
+// GlobalRetryPolicy는 HTTPFetcher가 사용하는 재시도 정책 (nil이면 재시도하지 않음, opt-in)
+var GlobalRetryPolicy *RetryPolicy
+
+// Backoff는 attempt번째 재시도(1부터 시작) 전에 기다릴 시간을 반환함
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// retryDelay는 응답 상태 코드와 헤더를 보고 재시도 전 대기 시간을 결정함
+//
+// 503, 429 응답에 Retry-After가 있으면 그 값을 따름
+// Retry-After가 MaxDelay보다 길면 (0, false)를 반환해서 재시도를 포기함
+func (p *RetryPolicy) retryDelay(attempt, statusCode int, headers Header) (time.Duration, bool) {
+	if statusCode == 503 || statusCode == 429 {
+		if d, ok := ParseRetryAfter(headers.Get("Retry-After"), time.Now()); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				logger.Logger.Printf("Retry-After(%s)가 최대 대기 시간(%s)보다 길어 재시도하지 않음", d, p.MaxDelay)
+				return 0, false
+			}
+			return d, true
+		}
+	}
+	return p.Backoff(attempt), true
+}
+
+// ParseRetryAfter는 Retry-After 헤더 값을 대기 시간으로 변환함
+//
+// 형식: 초 단위 정수("120") 또는 HTTP 날짜("Wed, 21 Oct 2015 07:28:00 GMT")
+// 과거 날짜는 0으로 취급함
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// isRetryableStatus는 재시도할 만한 상태 코드인지 확인함
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case 429, 502, 503, 504:
+		return true
+	}
+	return false
+}
+
+// isTransientError는 다시 시도하면 성공할 수 있는 네트워크 에러인지 확인함
+//
+// 연결 끊김(reset, broken pipe, 재사용한 연결의 EOF)과 타임아웃만 해당함
+// DNS 실패, 연결 거부, TLS 인증서 오류 등은 재시도해도 같은 결과라 제외함
+func isTransientError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,226 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ============================================
+// 재시도 정책 테스트
+// ============================================
+
+// setRetryPolicy: 테스트 동안만 GlobalRetryPolicy를 바꿈
+func setRetryPolicy(t *testing.T, p *net.RetryPolicy) {
+	t.Helper()
+	old := net.GlobalRetryPolicy
+	net.GlobalRetryPolicy = p
+	t.Cleanup(func() { net.GlobalRetryPolicy = old })
+}
+
+// fastRetry: 테스트용 짧은 대기 시간 정책
+var fastRetry = &net.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+// TestParseRetryAfter 초 단위와 HTTP 날짜 형식
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"120", 120 * time.Second, true},
+		{" 0 ", 0, true},
+		{"Wed, 21 Oct 2015 07:28:30 GMT", 30 * time.Second, true},
+		{"Wed, 21 Oct 2015 07:00:00 GMT", 0, true}, // 과거 날짜
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := net.ParseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestRetryPolicy_Backoff 시도마다 두 배, MaxDelay에서 멈춤
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &net.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 350 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 350 * time.Millisecond, 350 * time.Millisecond}
+
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %v; want %v", i+1, got, w)
+		}
+	}
+}
+
+// TestRetryPolicy_BackoffJitter 지터는 대기 시간을 줄이기만 함
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	p := &net.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		got := p.Backoff(1)
+		if got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("Backoff(1) = %v; want 50ms..100ms", got)
+		}
+	}
+}
+
+// TestHTTPFetcher_RetryOn503 503 응답 후 재시도해서 성공
+func TestHTTPFetcher_RetryOn503(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/retry503")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != 200 || resp.Body != "ok" {
+		t.Errorf("Fetch() = %d %q; want 200 %q", resp.StatusCode, resp.Body, "ok")
+	}
+	if hits.Load() != 3 {
+		t.Errorf("hits = %d; want 3", hits.Load())
+	}
+}
+
+// TestHTTPFetcher_RetryDisabled 정책이 없으면 재시도하지 않음
+func TestHTTPFetcher_RetryDisabled(t *testing.T) {
+	setRetryPolicy(t, nil)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/noretry")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != 502 {
+		t.Errorf("StatusCode = %d; want 502", resp.StatusCode)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("hits = %d; want 1", hits.Load())
+	}
+}
+
+// TestHTTPFetcher_RetryMaxAttempts 최대 시도 횟수를 넘지 않고 마지막 응답을 반환
+func TestHTTPFetcher_RetryMaxAttempts(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/always504")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != 504 {
+		t.Errorf("StatusCode = %d; want 504", resp.StatusCode)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("hits = %d; want 3", hits.Load())
+	}
+}
+
+// TestHTTPFetcher_RetryAfterTooLong Retry-After가 MaxDelay보다 길면 포기
+func TestHTTPFetcher_RetryAfterTooLong(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/ratelimited")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.StatusCode != 429 || hits.Load() != 1 {
+		t.Errorf("StatusCode = %d, hits = %d; want 429, 1", resp.StatusCode, hits.Load())
+	}
+}
+
+// TestHTTPFetcher_RetryAfterHonored Retry-After 만큼 기다린 후 재시도
+func TestHTTPFetcher_RetryAfterHonored(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/retryafter")
+	start := time.Now()
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "ok" {
+		t.Errorf("Body = %q; want %q", resp.Body, "ok")
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("elapsed = %v; want >= 1s (Retry-After)", elapsed)
+	}
+}
+
+// TestHTTPFetcher_RetryOnConnectionReset 응답 없이 연결이 끊기면 재시도
+func TestHTTPFetcher_RetryOnConnectionReset(t *testing.T) {
+	setRetryPolicy(t, fastRetry)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/reset")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "ok" || hits.Load() != 2 {
+		t.Errorf("Body = %q, hits = %d; want %q, 2", resp.Body, hits.Load(), "ok")
+	}
+}
@@ -0,0 +1,77 @@
+// Package net implements HTTP networking for the browser.
+// This file contains conditional requests that revalidate a previous response.
+package net
+
+import (
+	"fmt"
+)
+
+// 본문이 없는 최종 응답의 상태 코드
+const (
+	StatusNoContent   = 204
+	StatusNotModified = 304
+)
+
+// conditionalHeaders는 이전 응답 헤더의 검증자(ETag, Last-Modified)로 조건부 요청 헤더를 만듦
+//
+// 검증자가 없으면 nil (일반 요청)
+func conditionalHeaders(headers Header) Header {
+	var extra Header
+	if etag := headers.Get("ETag"); etag != "" {
+		extra = Header{}
+		extra.Set("If-None-Match", etag)
+	}
+	if modified := headers.Get("Last-Modified"); modified != "" {
+		if extra == nil {
+			extra = Header{}
+		}
+		extra.Set("If-Modified-Since", modified)
+	}
+	return extra
+}
+
+// withoutValidators는 extra에서 조건부 요청 헤더를 뺀 복사본 (다른 헤더가 없으면 nil)
+func withoutValidators(extra Header) Header {
+	out := extra.Clone()
+	out.Del("If-None-Match")
+	out.Del("If-Modified-Since")
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// Revalidate는 캐시의 신선도와 상관없이 prev의 URL을 다시 요청함
+//
+// prev에 ETag나 Last-Modified가 있으면 조건부 요청을 보내고, 304 Not Modified면
+// prev를 그대로 반환함 (본문을 다시 받지 않음)
+// modified는 본문이 prev와 달라졌는지
+func (h *HTTPFetcher) Revalidate(prev *Response) (resp *Response, modified bool, err error) {
+	resp, err = h.fetchNetwork(prev.URL, conditionalHeaders(prev.Headers))
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode == StatusNotModified {
+		h.logger().Printf("304 Not Modified: %s", prev.URL)
+		return prev, false, nil
+	}
+	return resp, resp.Body != prev.Body, nil
+}
+
+// Revalidate는 prev의 URL을 다시 가져와서 바뀌었는지 알려줌
+//
+// http(s)는 HTTPFetcher.Revalidate로 조건부 요청을 보내고, 다른 스킴은 다시 가져와서 본문을 비교함
+func Revalidate(prev *Response) (resp *Response, modified bool, err error) {
+	fetcher, ok := FetcherRegistry[prev.URL.Scheme]
+	if !ok {
+		return nil, false, fmt.Errorf("지원하지 않는 프로토콜: %s", prev.URL.Scheme)
+	}
+	if h, ok := fetcher.(*HTTPFetcher); ok {
+		return h.Revalidate(prev)
+	}
+	resp, err = fetcher.Fetch(prev.URL)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, resp.Body != prev.Body, nil
+}
@@ -0,0 +1,57 @@
+package net_test
+
+import (
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestRevalidate ETag가 같으면 304로 이전 응답을 그대로 쓰고, 바뀌면 새 본문
+func TestRevalidate(t *testing.T) {
+	var version, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte("<p>" + etag))
+	}))
+	defer server.Close()
+
+	u, _ := url.NewURL(server.URL + "/revalidate")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+
+	tests := []struct {
+		version      int32
+		wantModified bool
+		wantBody     string
+	}{
+		{0, false, `<p>"v0"`},
+		{1, true, `<p>"v1"`},
+		{1, false, `<p>"v1"`},
+	}
+	for i, tt := range tests {
+		version.Store(tt.version)
+		next, modified, err := net.Revalidate(resp)
+		if err != nil {
+			t.Fatalf("Revalidate(%d) failed: %v", i, err)
+		}
+		if modified != tt.wantModified || next.Body != tt.wantBody {
+			t.Errorf("Revalidate(%d) = %q, %v; want %q, %v", i, next.Body, modified, tt.wantBody, tt.wantModified)
+		}
+		resp = next
+	}
+	if got := notModified.Load(); got != 2 {
+		t.Errorf("304 응답 %d번; want 2", got)
+	}
+}
@@ -0,0 +1,142 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the TLS security info of https responses (version, cipher, revocation status).
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"go-web-browser/logger"
+	"net"
+	"time"
+)
+
+// VerifyStapledOCSP가 true면 TLS 핸드셰이크에서 받은 OCSP 응답(staple)을 검증함 (opt-in)
+//
+// 검증에 성공하면 SecurityInfo.Revocation에 결과를 기록하고, 폐기된 인증서면 연결을 끊음
+// false면 staple이 있는지만 기록하고 폐기 상태는 RevocationUnknown
+var VerifyStapledOCSP bool
+
+// SecurityInfo는 https 연결의 보안 정보
+type SecurityInfo struct {
+	Version     uint16 // tls.VersionTLS13 등
+	CipherSuite uint16
+	ServerName  string
+	SPKI        string // 서버 인증서 공개키 해시 (SPKIHash)
+	Resumed     bool   // 이전 세션을 재개해서 짧은 핸드셰이크로 연결했는지
+	Unverified  bool   // 인증서 검증에 실패했지만 사용자가 허용해서 검증 없이 연결했는지 (AllowInsecureCertificate)
+
+	OCSPStapled bool             // 서버가 OCSP 응답을 함께 보냈는지
+	Revocation  RevocationStatus // 검증한 OCSP 응답의 결과 (검증하지 않았거나 실패하면 RevocationUnknown)
+	OCSPError   error            // OCSP 응답 검증 실패 이유 (검증하지 않았거나 성공하면 nil)
+}
+
+// VersionName은 TLS 버전 이름 ("TLS 1.3" 등)
+func (s *SecurityInfo) VersionName() string {
+	return tls.VersionName(s.Version)
+}
+
+// CipherSuiteName은 암호 스위트 이름
+func (s *SecurityInfo) CipherSuiteName() string {
+	return tls.CipherSuiteName(s.CipherSuite)
+}
+
+// Indicator는 보안 표시에 쓸 짧은 문구
+//
+// 폐기 여부를 확인한 인증서("유효")와 확인하지 못한 인증서("폐기 상태 모름")를 구분함
+func (s *SecurityInfo) Indicator() string {
+	if s.Unverified {
+		return "검증되지 않은 인증서 (허용함)"
+	}
+	switch s.Revocation {
+	case RevocationGood:
+		return "보안 연결 (유효)"
+	case RevocationRevoked:
+		return "폐기된 인증서"
+	}
+	return "보안 연결 (폐기 상태 모름)"
+}
+
+// newSecurityInfo는 TLS 연결 상태로 SecurityInfo를 만듦
+//
+// VerifyStapledOCSP가 켜져 있으면 staple을 검증하고, 폐기된 인증서면 RevokedError를 반환함
+func newSecurityInfo(state tls.ConnectionState, address string, now time.Time) (*SecurityInfo, error) {
+	info := &SecurityInfo{
+		Version:     state.Version,
+		CipherSuite: state.CipherSuite,
+		ServerName:  state.ServerName,
+		Resumed:     state.DidResume,
+		OCSPStapled: len(state.OCSPResponse) > 0,
+	}
+	info.SPKI, _ = SPKIHash(state)
+
+	if !info.OCSPStapled || !VerifyStapledOCSP {
+		return info, nil
+	}
+	resp, err := verifyStaple(state, now)
+	if err != nil {
+		info.OCSPError = err
+		logger.Logger.Printf("OCSP: %s의 응답을 확인할 수 없음: %v", address, err)
+		return info, nil
+	}
+	info.Revocation = resp.Status
+	if resp.Status == RevocationRevoked {
+		return info, &RevokedError{Address: address, RevokedAt: resp.RevokedAt}
+	}
+	return info, nil
+}
+
+// verifyStaple은 연결 상태의 OCSP 응답을 파싱하고 서버 인증서와 발급자로 검증함
+func verifyStaple(state tls.ConnectionState, now time.Time) (*OCSPResponse, error) {
+	var leaf, issuer *x509.Certificate
+	switch {
+	case len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 1:
+		leaf, issuer = state.VerifiedChains[0][0], state.VerifiedChains[0][1]
+	case len(state.PeerCertificates) > 1:
+		leaf, issuer = state.PeerCertificates[0], state.PeerCertificates[1]
+	default:
+		return nil, errors.New("발급자 인증서가 없습니다")
+	}
+
+	resp, err := ParseOCSPResponse(state.OCSPResponse)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Verify(leaf, issuer, now); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// secureConn은 핸드셰이크 때 만든 SecurityInfo를 기억하는 TLS 연결
+//
+// 연결을 풀에서 재사용해도 같은 정보를 응답에 붙일 수 있음
+type secureConn struct {
+	net.Conn
+	info *SecurityInfo
+}
+
+// withSecurity는 TLS 연결이면 SecurityInfo를 만들어 연결에 붙임
+//
+// TLS 연결이 아니면(테스트용 Dialer 등) conn을 그대로 반환함
+// 폐기된 인증서면 연결을 닫고 에러를 반환함
+func withSecurity(conn net.Conn, address string) (net.Conn, error) {
+	tc, ok := conn.(interface{ ConnectionState() tls.ConnectionState })
+	if !ok {
+		return conn, nil
+	}
+	info, err := newSecurityInfo(tc.ConnectionState(), address, time.Now())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &secureConn{Conn: conn, info: info}, nil
+}
+
+// connSecurity는 연결의 SecurityInfo (TLS 연결이 아니면 nil)
+func connSecurity(conn net.Conn) *SecurityInfo {
+	if sc, ok := conn.(*secureConn); ok {
+		return sc.info
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the Server-Timing header parser.
+package net
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderServerTiming은 서버가 처리 단계별로 걸린 시간을 알려주는 헤더 (트레일러로도 옴)
+const HeaderServerTiming = "Server-Timing"
+
+// ServerTimingMetric은 Server-Timing 헤더의 항목 하나
+//
+//	Server-Timing: db;dur=53, cache;desc="Cache Read";dur=23.2, miss
+type ServerTimingMetric struct {
+	Name        string        // 항목 이름 (예: "db")
+	Duration    time.Duration // dur 값 (없으면 0)
+	Description string        // desc 값 (없으면 "")
+}
+
+// ParseServerTiming은 Server-Timing 헤더 값을 항목들로 나눔
+//
+// 이름이 없는 항목은 건너뛰고, 같은 파라미터가 여러 번 나오면 처음 것만 씀 (W3C Server Timing)
+func ParseServerTiming(value string) []ServerTimingMetric {
+	var metrics []ServerTimingMetric
+	for _, entry := range splitQuoted(value, ',') {
+		params := splitQuoted(entry, ';')
+		name := strings.TrimSpace(params[0])
+		if name == "" || strings.ContainsAny(name, `"=`) {
+			continue
+		}
+		metric := ServerTimingMetric{Name: name}
+		var seenDur, seenDesc bool
+		for _, param := range params[1:] {
+			key, val, _ := strings.Cut(param, "=")
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "dur":
+				if ms, err := strconv.ParseFloat(val, 64); err == nil && !seenDur {
+					metric.Duration = time.Duration(ms * float64(time.Millisecond))
+				}
+				seenDur = true
+			case "desc":
+				if !seenDesc {
+					metric.Description = val
+				}
+				seenDesc = true
+			}
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// String은 "이름 시간 (설명)" 꼴 (시간이 없으면 "-")
+func (m ServerTimingMetric) String() string {
+	text := m.Name + " -"
+	if m.Duration > 0 {
+		text = m.Name + " " + formatMillis(m.Duration)
+	}
+	if m.Description != "" {
+		text += " (" + m.Description + ")"
+	}
+	return text
+}
@@ -0,0 +1,31 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestParseServerTiming 이름, dur(밀리초), 따옴표로 감싼 desc를 읽고 이름 없는 항목은 건너뜀
+func TestParseServerTiming(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []net.ServerTimingMetric
+	}{
+		{"", nil},
+		{"miss", []net.ServerTimingMetric{{Name: "miss"}}},
+		{`db;dur=53, cache;desc="Cache Read, L2";dur=23.2`, []net.ServerTimingMetric{
+			{Name: "db", Duration: 53 * time.Millisecond},
+			{Name: "cache", Duration: 23200 * time.Microsecond, Description: "Cache Read, L2"},
+		}},
+		{"cpu;DUR=1;dur=2;desc=first;desc=second, ;dur=5", []net.ServerTimingMetric{
+			{Name: "cpu", Duration: time.Millisecond, Description: "first"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := net.ParseServerTiming(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseServerTiming(%q) = %v; want %v", tt.value, got, tt.want)
+		}
+	}
+}
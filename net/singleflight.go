@@ -0,0 +1,89 @@
+// Package net implements HTTP networking for the browser.
+// This file contains single-flight coalescing of concurrent identical requests.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// flight는 진행 중인 네트워크 요청 하나 (끝나면 done을 닫음)
+type flight struct {
+	done chan struct{}
+	resp *Response
+	err  error
+}
+
+// flights는 진행 중인 요청 (키 → flight)
+var flights = struct {
+	sync.Mutex
+	m map[string]*flight
+}{m: make(map[string]*flight)}
+
+// coalesced는 진행 중인 요청에 합쳐져서 네트워크 요청을 보내지 않은 횟수
+var coalesced atomic.Int64
+
+// CoalescedRequests는 지금까지 같은 요청이 진행 중이라서 그 결과를 나눠 받은 횟수
+//
+// 프리페치와 탐색이 같은 URL을 동시에 요청하면 1 늘어남 (about:net-internals에 표시)
+func CoalescedRequests() int64 {
+	return coalesced.Load()
+}
+
+// flightKey는 같은 요청으로 볼 키 (같은 캐시를 쓰는 HTTPFetcher끼리의 같은 메서드, 정규화한 URL)
+//
+// 캐시가 다른 HTTPFetcher(테스트, 다른 세션)의 요청과 다른 쿠키 몫(최상위 사이트)의 요청은 합치지 않음
+func (h *HTTPFetcher) flightKey(method string, u *url.URL) string {
+	return fmt.Sprintf("%p %s %s %s", h.cache(), partition(h.TopLevel, u.Host), method, u.Normalize().Redacted())
+}
+
+// coalesce는 key로 진행 중인 요청이 있으면 그 결과를 기다리고, 없으면 fn을 불러서 결과를 나눠 줌
+//
+// 요청을 보낸 쪽과 기다린 쪽 모두 Response의 복사본(헤더, 리다이렉트 목록도 복사)을 받으므로
+// 한쪽이 고쳐도(HTTPS-first의 Upgrade 등) 다른 쪽에 영향이 없음
+func coalesce(key string, fn func() (*Response, error)) (*Response, error) {
+	flights.Lock()
+	if f, ok := flights.m[key]; ok {
+		flights.Unlock()
+		coalesced.Add(1)
+		<-f.done
+		if f.err != nil {
+			return nil, f.err
+		}
+		return f.resp.clone(), nil
+	}
+	f := &flight{done: make(chan struct{})}
+	flights.m[key] = f
+	flights.Unlock()
+
+	defer func() {
+		flights.Lock()
+		delete(flights.m, key)
+		flights.Unlock()
+		close(f.done)
+	}()
+	f.resp, f.err = fn()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.resp.clone(), nil
+}
+
+// clone은 r의 복사본 (URL, 헤더, 트레일러, 리다이렉트 목록도 따로 둠, Security는 읽기만 하므로 나눠 씀)
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	if r.URL != nil {
+		u := *r.URL
+		c.URL = &u
+	}
+	c.Headers = r.Headers.Clone()
+	c.Trailers = r.Trailers.Clone()
+	c.Redirects = slices.Clone(r.Redirects)
+	return &c
+}
@@ -0,0 +1,78 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestHTTPFetcher_Coalesce 같은 URL을 동시에 가져오면 서버에는 한 번만 요청하고 둘 다 결과를 받음
+func TestHTTPFetcher_Coalesce(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("once"))
+	}))
+	defer srv.Close()
+
+	fetcher := isolatedFetcher(t)
+	u, _ := url.NewURL(srv.URL + "/same")
+	before := net.CoalescedRequests()
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 2)
+	resps := make([]*net.Response, 2)
+	errs := make([]error, 2)
+	for i := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := fetcher.Fetch(u)
+			if err == nil {
+				bodies[i] = resp.Body
+			}
+			resps[i], errs[i] = resp, err
+		}()
+	}
+
+	// 두 번째 요청이 첫 번째 요청에 합쳐질 때까지 기다린 뒤 응답을 보냄
+	deadline := time.Now().Add(2 * time.Second)
+	for net.CoalescedRequests() == before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	for i := range bodies {
+		if errs[i] != nil || bodies[i] != "once" {
+			t.Errorf("Fetch() #%d = %q, %v; want %q, nil", i+1, bodies[i], errs[i], "once")
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server hits = %d; want 1", got)
+	}
+	// 나눠 받은 응답은 서로 다른 복사본이라서 한쪽을 고쳐도 다른 쪽은 그대로임
+	if resps[0] != nil && resps[1] != nil {
+		resps[0].Headers.Set("X-Changed", "1")
+		resps[0].Upgrade = net.UpgradeHTTPS
+		if resps[1].Headers.Get("X-Changed") != "" || resps[1].Upgrade != net.UpgradeNone {
+			t.Errorf("changing one coalesced response changed the other: %+v", resps[1])
+		}
+	}
+
+	// 끝난 뒤의 요청은 다시 보냄 (no-store)
+	if _, err := fetcher.Fetch(u); err != nil {
+		t.Fatalf("Fetch() after flight failed: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hits after flight = %d; want 2", got)
+	}
+}
@@ -0,0 +1,215 @@
+// Package net implements HTTP networking for the browser.
+// This file contains MIME type sniffing for responses without a usable Content-Type.
+package net
+
+import (
+	"bytes"
+	"strings"
+)
+
+// sniffLen은 스니핑에 사용할 최대 바이트 수 (MIME Sniffing 규격의 resource header 크기)
+const sniffLen = 512
+
+// MIME 타입 상수
+const (
+	MIMETextHTML    = "text/html"
+	MIMETextPlain   = "text/plain"
+	MIMEOctetStream = "application/octet-stream"
+)
+
+// sniffSignature는 바이트 패턴 하나와 그에 해당하는 MIME 타입을 나타냄
+type sniffSignature struct {
+	pattern      []byte // 비교할 바이트 패턴
+	mask         []byte // 패턴과 AND 연산할 마스크 (nil이면 정확히 일치)
+	skipWS       bool   // 앞쪽 공백 바이트를 건너뛸지 여부
+	tagTerminate bool   // 패턴 뒤에 태그 종료 바이트(공백 또는 '>')가 와야 하는지 여부
+	mimeType     string // 일치할 때 반환할 MIME 타입
+}
+
+// htmlSignature는 대소문자 구분 없이 비교하는 HTML 시그니처를 만듦
+//
+// 마스크 0xDF는 ASCII 소문자를 대문자로 바꾸는 효과가 있음 (규격의 방식 그대로)
+func htmlSignature(pattern string) sniffSignature {
+	mask := make([]byte, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] >= 'A' && pattern[i] <= 'Z' {
+			mask[i] = 0xDF
+		} else {
+			mask[i] = 0xFF
+		}
+	}
+	return sniffSignature{
+		pattern:      []byte(pattern),
+		mask:         mask,
+		skipWS:       true,
+		tagTerminate: true,
+		mimeType:     MIMETextHTML,
+	}
+}
+
+// sniffSignatures는 MIME Sniffing 규격의 기본 표 (순서대로 검사함)
+var sniffSignatures = []sniffSignature{
+	// HTML (공백 무시, 대소문자 무시)
+	htmlSignature("<!DOCTYPE HTML"),
+	htmlSignature("<HTML"),
+	htmlSignature("<HEAD"),
+	htmlSignature("<SCRIPT"),
+	htmlSignature("<IFRAME"),
+	htmlSignature("<H1"),
+	htmlSignature("<DIV"),
+	htmlSignature("<FONT"),
+	htmlSignature("<TABLE"),
+	htmlSignature("<A"),
+	htmlSignature("<STYLE"),
+	htmlSignature("<TITLE"),
+	htmlSignature("<B"),
+	htmlSignature("<BODY"),
+	htmlSignature("<BR"),
+	htmlSignature("<P"),
+	htmlSignature("<!--"),
+
+	// XML, PDF, PostScript
+	{pattern: []byte("<?xml"), skipWS: true, mimeType: "text/xml"},
+	{pattern: []byte("%PDF-"), mimeType: "application/pdf"},
+	{pattern: []byte("%!PS-Adobe-"), mimeType: "application/postscript"},
+
+	// BOM이 있는 텍스트
+	{pattern: []byte{0xFE, 0xFF}, mimeType: MIMETextPlain},
+	{pattern: []byte{0xFF, 0xFE}, mimeType: MIMETextPlain},
+	{pattern: []byte{0xEF, 0xBB, 0xBF}, mimeType: MIMETextPlain},
+
+	// 이미지
+	{pattern: []byte("GIF87a"), mimeType: "image/gif"},
+	{pattern: []byte("GIF89a"), mimeType: "image/gif"},
+	{pattern: []byte("\x89PNG\r\n\x1A\n"), mimeType: "image/png"},
+	{pattern: []byte{0xFF, 0xD8, 0xFF}, mimeType: "image/jpeg"},
+	{pattern: []byte("BM"), mimeType: "image/bmp"},
+	{pattern: []byte{0x00, 0x00, 0x01, 0x00}, mimeType: "image/x-icon"},
+	{
+		pattern:  []byte("RIFF\x00\x00\x00\x00WEBPVP"),
+		mask:     []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		mimeType: "image/webp",
+	},
+
+	// 오디오/비디오
+	{pattern: []byte("OggS\x00"), mimeType: "application/ogg"},
+	{pattern: []byte("ID3"), mimeType: "audio/mpeg"},
+	{
+		pattern:  []byte("RIFF\x00\x00\x00\x00WAVE"),
+		mask:     []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF},
+		mimeType: "audio/wave",
+	},
+	{pattern: []byte{0x1A, 0x45, 0xDF, 0xA3}, mimeType: "video/webm"},
+
+	// 압축 파일
+	{pattern: []byte{0x1F, 0x8B, 0x08}, mimeType: "application/x-gzip"},
+	{pattern: []byte("PK\x03\x04"), mimeType: "application/zip"},
+	{pattern: []byte("Rar!\x1A\x07\x00"), mimeType: "application/x-rar-compressed"},
+}
+
+// isWhitespaceByte는 MIME Sniffing 규격의 공백 바이트인지 확인함 (TAB, LF, FF, CR, SP)
+func isWhitespaceByte(b byte) bool {
+	return b == '\t' || b == '\n' || b == '\x0C' || b == '\r' || b == ' '
+}
+
+// isBinaryDataByte는 텍스트에 나타나지 않아야 하는 제어 문자인지 확인함
+func isBinaryDataByte(b byte) bool {
+	return b <= 0x08 || b == 0x0B || (b >= 0x0E && b <= 0x1A) || (b >= 0x1C && b <= 0x1F)
+}
+
+// match는 data가 시그니처와 일치하는지 확인함
+func (s *sniffSignature) match(data []byte) bool {
+	if s.skipWS {
+		data = bytes.TrimLeftFunc(data, func(r rune) bool {
+			return r < 0x80 && isWhitespaceByte(byte(r))
+		})
+	}
+
+	if len(data) < len(s.pattern) {
+		return false
+	}
+
+	for i, p := range s.pattern {
+		b := data[i]
+		if s.mask != nil {
+			b &= s.mask[i]
+		}
+		if b != p {
+			return false
+		}
+	}
+
+	if s.tagTerminate {
+		// "<A"가 "<ABBR"에 일치하지 않도록 다음 바이트가 공백 또는 '>'여야 함
+		if len(data) == len(s.pattern) {
+			return false
+		}
+		next := data[len(s.pattern)]
+		if next != ' ' && next != '>' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SniffContentType은 본문 앞부분을 보고 MIME 타입을 추측함
+//
+// MIME Sniffing 규격의 "unknown MIME type" 판별 규칙을 따름:
+//  1. 기본 표의 시그니처(HTML, 이미지, 압축 파일 등)를 순서대로 비교
+//  2. 일치하는 시그니처가 없고 바이너리 바이트가 없으면 text/plain
+//  3. 그 외에는 application/octet-stream
+//
+// 최대 512 바이트만 검사함
+func SniffContentType(data []byte) string {
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+
+	for i := range sniffSignatures {
+		if sniffSignatures[i].match(data) {
+			return sniffSignatures[i].mimeType
+		}
+	}
+
+	for _, b := range data {
+		if isBinaryDataByte(b) {
+			return MIMEOctetStream
+		}
+	}
+
+	return MIMETextPlain
+}
+
+// mediaTypeEssence는 Content-Type 값에서 파라미터를 제거하고 소문자로 정규화함
+//
+// 예시: "Text/HTML; charset=UTF-8" → "text/html"
+func mediaTypeEssence(contentType string) string {
+	if idx := strings.Index(contentType, ";"); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// needsSniffing은 Content-Type이 스니핑이 필요한 값인지 확인함
+//
+// 헤더가 없거나 의미 없는 값(application/octet-stream, unknown/unknown 등)일 때 true
+func needsSniffing(essence string) bool {
+	switch essence {
+	case "", MIMEOctetStream, "unknown/unknown", "application/unknown", "*/*":
+		return true
+	}
+	return false
+}
+
+// DetermineContentType은 Content-Type 헤더 값과 본문으로 실제 MIME 타입을 결정함
+//
+// 헤더가 유효하면 그대로(essence만) 사용하고, 없거나 application/octet-stream이면
+// 본문을 스니핑함
+func DetermineContentType(headerValue string, body []byte) string {
+	essence := mediaTypeEssence(headerValue)
+	if !needsSniffing(essence) {
+		return essence
+	}
+	return SniffContentType(body)
+}
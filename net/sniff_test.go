@@ -0,0 +1,124 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ============================================
+// MIME 스니핑 테스트
+// ============================================
+
+// TestSniffContentType 기본 시그니처 표 테스트
+func TestSniffContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"doctype", "<!DOCTYPE html><html></html>", "text/html"},
+		{"leading whitespace", "\r\n\t <html>", "text/html"},
+		{"lowercase tag", "<body>hi</body>", "text/html"},
+		{"comment", "<!-- x -->", "text/html"},
+		{"tag prefix only", "<abbr>x</abbr>", "text/plain"},
+		{"xml", "<?xml version=\"1.0\"?>", "text/xml"},
+		{"pdf", "%PDF-1.4", "application/pdf"},
+		{"png", "\x89PNG\r\n\x1a\n\x00\x00", "image/png"},
+		{"gif", "GIF89a\x01\x00", "image/gif"},
+		{"jpeg", "\xFF\xD8\xFF\xE0", "image/jpeg"},
+		{"webp", "RIFF\x10\x00\x00\x00WEBPVP8 ", "image/webp"},
+		{"gzip", "\x1F\x8B\x08\x00", "application/x-gzip"},
+		{"zip", "PK\x03\x04", "application/zip"},
+		{"utf8 bom", "\xEF\xBB\xBFhello", "text/plain"},
+		{"plain text", "Hello, World!", "text/plain"},
+		{"empty", "", "text/plain"},
+		{"binary", "\x00\x01\x02\x03", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := net.SniffContentType([]byte(tt.data))
+			if got != tt.want {
+				t.Errorf("SniffContentType(%q) = %q; want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDetermineContentType Content-Type 헤더 우선, 없거나 octet-stream이면 스니핑
+func TestDetermineContentType(t *testing.T) {
+	tests := []struct {
+		header string
+		body   string
+		want   string
+	}{
+		{"text/html; charset=utf-8", "plain", "text/html"},
+		{"Text/Plain", "<html>", "text/plain"},
+		{"", "<html>", "text/html"},
+		{"application/octet-stream", "<html>", "text/html"},
+		{"application/octet-stream", "\x89PNG\r\n\x1a\n", "image/png"},
+	}
+
+	for _, tt := range tests {
+		got := net.DetermineContentType(tt.header, []byte(tt.body))
+		if got != tt.want {
+			t.Errorf("DetermineContentType(%q, %q) = %q; want %q", tt.header, tt.body, got, tt.want)
+		}
+	}
+}
+
+// TestHTTPFetcher_SniffWithoutContentType Content-Type 없는 HTML 응답
+func TestHTTPFetcher_SniffWithoutContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// nil로 설정하면 net/http가 Content-Type을 자동으로 추가하지 않음
+		w.Header()["Content-Type"] = nil
+		w.Write([]byte("  <HTML><body>no header</body></HTML>"))
+	}))
+	defer server.Close()
+
+	u, err := url.NewURL(server.URL + "/sniff")
+	if err != nil {
+		t.Fatalf("url.NewURL(%q) failed: %v", server.URL, err)
+	}
+
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+
+	if _, ok := resp.Headers["content-type"]; ok {
+		t.Fatalf("server should not send Content-Type, got %q", resp.Headers["content-type"])
+	}
+	if resp.ContentType != "text/html" {
+		t.Errorf("ContentType = %q; want %q", resp.ContentType, "text/html")
+	}
+}
+
+// TestDataFetcher_ContentType data URL의 미디어 타입 사용 (생략 시 text/plain)
+func TestDataFetcher_ContentType(t *testing.T) {
+	tests := []struct {
+		urlStr string
+		want   string
+	}{
+		{"data:text/html,<h1>x</h1>", "text/html"},
+		{"data:,<h1>x</h1>", "text/plain"},
+		{"data:image/png;base64,iVBORw0KGgo=", "image/png"},
+	}
+
+	for _, tt := range tests {
+		u, err := url.NewURL(tt.urlStr)
+		if err != nil {
+			t.Fatalf("url.NewURL(%q) failed: %v", tt.urlStr, err)
+		}
+		resp, err := net.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch(%q) failed: %v", tt.urlStr, err)
+		}
+		if resp.ContentType != tt.want {
+			t.Errorf("Fetch(%q).ContentType = %q; want %q", tt.urlStr, resp.ContentType, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the spool that tees fetched documents to disk.
+package net
+
+import (
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// spoolNameMax는 파일 이름에서 URL 부분의 최대 길이 (너무 긴 이름은 파일 시스템이 거부함)
+const spoolNameMax = 120
+
+// spoolTimeFormat은 파일 이름 앞에 붙는 시각 형식 (이름순 정렬이 시간순이 되도록)
+const spoolTimeFormat = "20060102T150405.000000"
+
+// Spool은 가져온 문서의 원본 바이트를 디렉토리에 한 파일씩 저장함
+//
+// 렌더링은 그대로 하면서 실제로 돌아다닌 페이지를 파서/레이아웃 테스트 코퍼스로 모을 때 씀
+// 본문은 문자 인코딩 변환 전의 바이트 그대로 저장함
+type Spool struct {
+	Dir string
+	Now func() time.Time // 파일 이름에 쓸 시각 (nil이면 time.Now, 테스트용)
+}
+
+// GlobalSpool은 HTTPFetcher가 네트워크에서 가져온 본문을 저장하는 곳 (nil이면 저장 안 함, opt-in)
+var GlobalSpool *Spool
+
+// NewSpool은 dir을 (없으면 만들어서) 저장 위치로 쓰는 Spool을 만듦
+func NewSpool(dir string) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("스풀 디렉토리를 만들 수 없습니다 (%s): %w", dir, err)
+	}
+	return &Spool{Dir: dir}, nil
+}
+
+// Save는 u에서 가져온 본문을 "시각-URL" 이름의 파일로 저장하고 경로를 반환함
+func (s *Spool) Save(u *url.URL, body []byte) (string, error) {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	base := now().UTC().Format(spoolTimeFormat) + "-" + spoolName(u)
+
+	// 같은 시각에 같은 URL을 다시 저장하면 덮어쓰지 않고 번호를 붙임 (O_EXCL이라 동시에 저장해도 안전함)
+	name := base
+	for i := 2; ; i++ {
+		path := filepath.Join(s.Dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			name = fmt.Sprintf("%s~%d", base, i)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(body); err != nil {
+			f.Close()
+			return "", err
+		}
+		return path, f.Close()
+	}
+}
+
+// spool은 GlobalSpool이 켜져 있으면 본문을 저장함 (실패해도 페이지는 그대로 보여주고 로그만 남김)
+func spool(u *url.URL, body string) {
+	if GlobalSpool == nil {
+		return
+	}
+	path, err := GlobalSpool.Save(u, []byte(body))
+	if err != nil {
+		logger.Logger.Printf("스풀 저장 실패 (%s): %v", u.Redacted(), err)
+		return
+	}
+	logger.Logger.Printf("스풀 저장: %s", path)
+}
+
+// spoolName은 URL을 파일 이름에 쓸 수 있는 문자열로 바꿈
+//
+// 예시: "https://example.com/a/b?q=1" → "example.com_a_b_q=1"
+func spoolName(u *url.URL) string {
+	s := u.Host + u.Path
+	if u.Port != 0 && u.Port != url.DefaultHTTPPort && u.Port != url.DefaultHTTPSPort {
+		s = fmt.Sprintf("%s_%d%s", u.Host, u.Port, u.Path)
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '=':
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(s, "/"))
+	if len(name) > spoolNameMax {
+		name = name[:spoolNameMax]
+	}
+	if name == "" {
+		name = "document"
+	}
+	return name
+}
@@ -0,0 +1,67 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSpool_Save 파일 이름은 "시각-URL", 같은 이름이 있으면 번호를 붙임
+func TestSpool_Save(t *testing.T) {
+	s, err := net.NewSpool(filepath.Join(t.TempDir(), "spool"))
+	if err != nil {
+		t.Fatalf("NewSpool() failed: %v", err)
+	}
+	s.Now = func() time.Time { return time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC) }
+
+	u, _ := url.NewURL("https://example.com/a/b?q=1")
+	want := []string{
+		"20240501T123000.000000-example.com_a_b_q=1",
+		"20240501T123000.000000-example.com_a_b_q=1~2",
+	}
+	for _, name := range want {
+		path, err := s.Save(u, []byte("<p>hi"))
+		if err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+		if got := filepath.Base(path); got != name {
+			t.Errorf("Save() = %q; want %q", got, name)
+		}
+	}
+}
+
+// TestHTTPFetcher_Spool 렌더링과 별개로 인코딩 변환 전 본문을 그대로 저장함
+func TestHTTPFetcher_Spool(t *testing.T) {
+	body := "<p>\xbe\xc8\xb3\xe7</p>" // EUC-KR "안녕"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=euc-kr")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	net.GlobalSpool = &net.Spool{Dir: dir}
+	defer func() { net.GlobalSpool = nil }()
+
+	u, _ := url.NewURL(server.URL + "/spool")
+	resp, err := (&net.HTTPFetcher{}).Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "<p>안녕</p>" {
+		t.Errorf("Body = %q; want %q", resp.Body, "<p>안녕</p>")
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 {
+		t.Fatalf("스풀 파일 %d개; want 1", len(files))
+	}
+	if data, _ := os.ReadFile(files[0]); string(data) != body {
+		t.Errorf("스풀 내용 = %q; want %q", data, body)
+	}
+}
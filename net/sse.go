@@ -0,0 +1,288 @@
+// Package net implements HTTP networking for the browser.
+// This file contains Server-Sent Events (text/event-stream) parsing and streaming.
+package net
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-web-browser/logger"
+	"go-web-browser/url"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MIMEEventStream은 Server-Sent Events 스트림의 MIME 타입
+const MIMEEventStream = "text/event-stream"
+
+// DefaultSSERetry는 서버가 retry 필드를 보내지 않았을 때 다시 연결하기 전에 기다리는 시간
+const DefaultSSERetry = 3 * time.Second
+
+// Event는 Server-Sent Events의 이벤트 하나
+type Event struct {
+	ID   string // 마지막으로 받은 id (이 이벤트에 id가 없으면 이전 값)
+	Type string // event 필드 (없으면 "message")
+	Data string // data 필드들을 줄바꿈으로 이은 값
+}
+
+// EventReader는 text/event-stream 본문을 도착하는 대로 이벤트 단위로 읽음
+//
+// 빈 줄을 만날 때마다 이벤트 하나를 돌려주므로 끝나지 않는 스트림도 읽을 수 있음
+type EventReader struct {
+	r        *bufio.Reader
+	started  bool // 첫 줄을 읽었는지 (맨 앞 BOM 제거용)
+	lastID   string
+	retry    time.Duration
+	hasRetry bool
+}
+
+// NewEventReader는 r에서 이벤트를 읽는 EventReader를 만듦
+func NewEventReader(r io.Reader) *EventReader {
+	return &EventReader{r: bufio.NewReader(r)}
+}
+
+// LastEventID는 지금까지 받은 마지막 id (다시 연결할 때 Last-Event-ID 헤더로 보냄)
+func (er *EventReader) LastEventID() string {
+	return er.lastID
+}
+
+// Retry는 서버가 retry 필드로 알려준 재연결 대기 시간
+func (er *EventReader) Retry() (time.Duration, bool) {
+	return er.retry, er.hasRetry
+}
+
+// Next는 다음 이벤트를 반환함 (스트림이 끝나면 io.EOF, 끝나기 전의 완성되지 않은 이벤트는 버림)
+//
+// 필드 해석은 HTML 표준의 event stream 해석 규칙을 따름:
+//   - ":"로 시작하는 줄은 주석 (연결 유지용)
+//   - "필드: 값" (콜론 뒤 공백 하나는 무시), 콜론이 없으면 값이 빈 필드
+//   - data가 하나도 없는 이벤트는 보내지 않음
+func (er *EventReader) Next() (Event, error) {
+	var data strings.Builder
+	var hasData bool
+	eventType := ""
+	for {
+		line, err := er.readLine()
+		if err != nil {
+			return Event{}, err
+		}
+
+		if line == "" {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = "message"
+			}
+			return Event{ID: er.lastID, Type: eventType, Data: strings.TrimSuffix(data.String(), "\n")}, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data.WriteString(value + "\n")
+			hasData = true
+		case "event":
+			eventType = value
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				er.lastID = value
+			}
+		case "retry":
+			// 숫자만으로 된 값만 받음 (밀리초)
+			if ms, err := strconv.Atoi(value); err == nil && strings.Trim(value, "0123456789") == "" {
+				er.retry, er.hasRetry = time.Duration(ms)*time.Millisecond, true
+			}
+		}
+	}
+}
+
+// readLine은 CRLF, LF, CR 중 하나로 끝나는 줄을 읽음 (줄 끝 문자 제외)
+func (er *EventReader) readLine() (string, error) {
+	var line strings.Builder
+	for {
+		c, err := er.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if c == '\n' {
+			break
+		}
+		if c == '\r' {
+			if next, err := er.r.Peek(1); err == nil && next[0] == '\n' {
+				er.r.ReadByte()
+			}
+			break
+		}
+		line.WriteByte(c)
+	}
+
+	s := line.String()
+	if !er.started {
+		er.started = true
+		s = strings.TrimPrefix(s, "\ufeff")
+	}
+	return s, nil
+}
+
+// ErrStopEvents는 EventSource.Run의 콜백이 스트림을 그만 받겠다고 알릴 때 반환하는 에러
+var ErrStopEvents = errors.New("이벤트 스트림 중단")
+
+// EventSource는 끊기면 다시 연결하면서 이벤트 스트림을 계속 받음 (브라우저의 EventSource와 같은 동작)
+//
+// 다시 연결할 때 마지막으로 받은 id를 Last-Event-ID 헤더로 보내서 서버가 이어서 보낼 수 있게 함
+// 서버가 204 No Content를 보내면 더 연결하지 않고, 200이 아니거나 text/event-stream이 아니면 실패함
+type EventSource struct {
+	URL           *url.URL
+	LastEventID   string        // 다음 연결에 보낼 Last-Event-ID (받은 id로 계속 갱신됨)
+	Retry         time.Duration // 다시 연결하기 전 대기 시간 (0이면 DefaultSSERetry, 서버의 retry 필드가 우선)
+	MaxReconnects int           // 연속으로 다시 연결하는 최대 횟수 (0이면 제한 없음)
+	Fetcher       *HTTPFetcher  // 연결에 쓸 Dialer를 가진 HTTPFetcher (nil이면 DefaultDialer)
+}
+
+// streamError는 다시 연결해도 소용없는 실패 (상태 코드, Content-Type 등)
+type streamError struct{ err error }
+
+func (e *streamError) Error() string { return e.err.Error() }
+func (e *streamError) Unwrap() error { return e.err }
+
+// errStreamClosed는 서버가 204로 스트림을 끝냈다는 뜻 (Run은 nil을 반환함)
+var errStreamClosed = errors.New("서버가 이벤트 스트림을 끝냄 (204)")
+
+// Run은 이벤트가 올 때마다 onEvent를 부름
+//
+// onEvent가 에러를 반환하면 멈추고 그 에러를 반환함 (ErrStopEvents면 nil)
+// 연결이 끊기면 Retry만큼 기다렸다가 다시 연결하고, 이벤트를 하나라도 받으면 재연결 횟수를 초기화함
+func (s *EventSource) Run(onEvent func(Event) error) error {
+	var callbackErr error
+	received := false
+	handle := func(ev Event) error {
+		received = true
+		if err := onEvent(ev); err != nil {
+			callbackErr = err
+			return err
+		}
+		return nil
+	}
+
+	for reconnects := 0; ; reconnects++ {
+		err := s.stream(handle)
+		switch {
+		case callbackErr != nil:
+			if errors.Is(callbackErr, ErrStopEvents) {
+				return nil
+			}
+			return callbackErr
+		case errors.Is(err, errStreamClosed):
+			return nil
+		}
+		var fatal *streamError
+		if errors.As(err, &fatal) {
+			return err
+		}
+
+		if received {
+			reconnects, received = 0, false
+		}
+		if s.MaxReconnects > 0 && reconnects >= s.MaxReconnects {
+			return fmt.Errorf("이벤트 스트림 재연결 %d번 실패: %w", reconnects, err)
+		}
+		delay := s.Retry
+		if delay == 0 {
+			delay = DefaultSSERetry
+		}
+		logger.Logger.Printf("이벤트 스트림 끊김, %s 후 다시 연결 (Last-Event-ID: %q): %v", delay, s.LastEventID, err)
+		time.Sleep(delay)
+	}
+}
+
+// stream은 한 번 연결해서 연결이 끊길 때까지 이벤트를 받음
+func (s *EventSource) stream(onEvent func(Event) error) error {
+	u := s.URL
+	_, address, err := dialTarget(u)
+	if err != nil {
+		return &streamError{err}
+	}
+	conn, err := s.Fetcher.dial(u, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	userAgent, _ := GlobalUserAgents.For(u.Host)
+	var request strings.Builder
+	fmt.Fprintf(&request, "GET %s %s\r\n", u.Path, HTTPVersion)
+	fmt.Fprintf(&request, "%s: %s\r\n", HeaderHost, hostHeader(u))
+	fmt.Fprintf(&request, "%s: %s\r\n", HeaderUserAgent, userAgent)
+	writePrivacyHeaders(&request)
+	fmt.Fprintf(&request, "Accept: %s\r\nCache-Control: no-cache\r\n", MIMEEventStream)
+	if s.LastEventID != "" {
+		fmt.Fprintf(&request, "Last-Event-ID: %s\r\n", s.LastEventID)
+	}
+	request.WriteString("\r\n")
+	if _, err := io.WriteString(conn, request.String()); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	var statusCode int
+	var headers Header
+	for {
+		if statusCode, err = readStatusLine(reader, GlobalParseOptions); err != nil {
+			return err
+		}
+		if headers, err = readHeaders(reader, GlobalParseOptions); err != nil {
+			return err
+		}
+		if !isInterimStatus(statusCode) {
+			break
+		}
+	}
+
+	switch {
+	case statusCode == StatusNoContent:
+		return errStreamClosed
+	case statusCode != 200:
+		return &streamError{fmt.Errorf("이벤트 스트림 요청 실패 (status %d)", statusCode)}
+	case mediaTypeEssence(headers.Get("Content-Type")) != MIMEEventStream:
+		return &streamError{fmt.Errorf("이벤트 스트림이 아닙니다 (Content-Type: %q)", headers.Get("Content-Type"))}
+	}
+
+	var body io.Reader = reader
+	if headers.Get("Transfer-Encoding") == "chunked" {
+		body = &chunkedReader{r: reader}
+	} else if n, err := strconv.ParseInt(headers.Get("Content-Length"), 10, 64); err == nil {
+		body = io.LimitReader(reader, n)
+	}
+
+	events := NewEventReader(body)
+	events.lastID = s.LastEventID // 이번 연결에서 id를 받기 전까지는 이전 값을 유지함
+	for {
+		ev, err := events.Next()
+		s.LastEventID = events.LastEventID()
+		if retry, ok := events.Retry(); ok {
+			s.Retry = retry
+		}
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("서버가 연결을 닫음")
+			}
+			return err
+		}
+		if err := onEvent(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// isEventStream은 응답이 끝나지 않는 이벤트 스트림이라 본문을 끝까지 읽으면 안 되는지 확인함
+func isEventStream(headers Header) bool {
+	return mediaTypeEssence(headers.Get("Content-Type")) == MIMEEventStream
+}
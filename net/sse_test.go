@@ -0,0 +1,101 @@
+package net_test
+
+import (
+	"fmt"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestEventReader 필드, 여러 줄 data, 주석, 줄 끝(CRLF, CR), id 유지, retry
+func TestEventReader(t *testing.T) {
+	stream := "\ufeff: comment\n" +
+		"data: first\n\n" +
+		"event: update\r\nid: 7\r\ndata:a\r\ndata: b\r\n\r\n" +
+		"retry: 1500\rdata\r\r" +
+		"event: ignored\n\n" +
+		"data: incomplete"
+
+	r := net.NewEventReader(strings.NewReader(stream))
+	var got []net.Event
+	for {
+		ev, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() failed: %v", err)
+		}
+		got = append(got, ev)
+	}
+
+	want := []net.Event{
+		{Type: "message", Data: "first"},
+		{ID: "7", Type: "update", Data: "a\nb"},
+		{ID: "7", Type: "message", Data: ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v; want %+v", got, want)
+	}
+	if retry, ok := r.Retry(); !ok || retry != 1500*time.Millisecond {
+		t.Errorf("Retry() = %v, %v; want 1.5s, true", retry, ok)
+	}
+}
+
+// TestEventSource 도착하는 대로 이벤트를 받고, 끊기면 Last-Event-ID로 다시 연결함
+func TestEventSource(t *testing.T) {
+	var mu sync.Mutex
+	var lastIDs []string // 연결마다 받은 Last-Event-ID
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		n := len(lastIDs)
+		mu.Unlock()
+		if n > 2 {
+			w.WriteHeader(http.StatusNoContent) // 더 보낼 것이 없음
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "retry: 10\nid: %d\ndata: event %d\n\n", n, n)
+		w.(http.Flusher).Flush() // chunked로 보내고 연결을 끊음
+	}))
+	defer server.Close()
+
+	// 일반 요청은 본문을 기다리지 않고 이벤트 스트림임을 알려줌
+	u, _ := url.NewURL(server.URL + "/events")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.ContentType != net.MIMEEventStream {
+		t.Errorf("ContentType = %q; want %q", resp.ContentType, net.MIMEEventStream)
+	}
+	mu.Lock()
+	lastIDs = nil
+	mu.Unlock()
+
+	var data []string
+	source := &net.EventSource{URL: u, MaxReconnects: 5}
+	if err := source.Run(func(ev net.Event) error {
+		data = append(data, ev.Data)
+		return nil
+	}); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if want := []string{"event 1", "event 2"}; !reflect.DeepEqual(data, want) {
+		t.Errorf("data = %q; want %q", data, want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"", "1", "2"}; !reflect.DeepEqual(lastIDs, want) {
+		t.Errorf("Last-Event-ID = %q; want %q", lastIDs, want)
+	}
+}
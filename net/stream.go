@@ -0,0 +1,183 @@
+// Package net implements HTTP networking for the browser.
+// This file contains streamed responses whose body is drained or closed before connection reuse.
+package net
+
+import (
+	"bufio"
+	"fmt"
+	"go-web-browser/url"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxDrainBytes는 Body를 다 읽지 않고 닫았을 때 연결을 재사용하려고 대신 읽어서 버리는 최대 크기
+//
+// 남은 본문이 이보다 크면 다 읽는 것보다 새로 연결하는 편이 빠르므로 연결을 닫음
+const MaxDrainBytes = 256 << 10
+
+// DrainTimeout은 남은 본문을 읽어서 버릴 때 기다리는 최대 시간
+const DrainTimeout = time.Second
+
+// StreamResponse는 본문을 다 읽지 않은 채로 돌려받은 응답
+//
+// 헤더만 필요한 경우(HEAD처럼)나 본문을 읽다가 그만둘 수 있는 경우에 씀
+// Body는 반드시 닫아야 하고, 닫을 때 연결을 풀에 돌려줄지 닫을지 정함
+type StreamResponse struct {
+	URL        *url.URL
+	StatusCode int
+	Headers    Header
+	Body       io.ReadCloser
+}
+
+// Stream은 u에 요청을 보내고 헤더까지만 읽은 응답을 반환함
+//
+// Fetch와 달리 캐시, 리다이렉트, 재시도 없이 요청 한 번만 보냄
+func (h *HTTPFetcher) Stream(u *url.URL) (*StreamResponse, error) {
+	_, address, err := dialTarget(u)
+	if err != nil {
+		return nil, err
+	}
+	conn, found := h.pool().Get(address)
+	if !found {
+		if conn, err = h.dial(u, address); err != nil {
+			return nil, err
+		}
+	}
+
+	userAgent, _ := GlobalUserAgents.For(u.Host)
+	var request strings.Builder
+	fmt.Fprintf(&request, "GET %s %s\r\n", u.Path, HTTPVersion)
+	fmt.Fprintf(&request, "%s: %s\r\n", HeaderHost, hostHeader(u))
+	fmt.Fprintf(&request, "%s: %s\r\n", HeaderUserAgent, userAgent)
+	writePrivacyHeaders(&request)
+	request.WriteString("\r\n")
+
+	var rw io.ReadWriter = conn
+	deadlines := withDeadlines(conn, GlobalTimeouts)
+	if deadlines != nil {
+		rw = deadlines
+	}
+	if _, err := io.WriteString(rw, request.String()); err != nil {
+		h.pool().Discard(address, conn)
+		return nil, err
+	}
+
+	reader := bufio.NewReader(rw)
+	var statusCode int
+	var headers Header
+	for {
+		if statusCode, err = readStatusLine(reader, GlobalParseOptions); err == nil {
+			headers, err = readHeaders(reader, GlobalParseOptions)
+		}
+		if err != nil {
+			h.pool().Discard(address, conn)
+			return nil, err
+		}
+		if !isInterimStatus(statusCode) {
+			break
+		}
+	}
+
+	body := &streamBody{
+		fetcher:   h,
+		reader:    reader,
+		conn:      conn,
+		deadlines: deadlines,
+		address:   address,
+		reusable:  reusable(statusCode, headers) && !isEventStream(headers),
+	}
+	switch {
+	case !hasBody(statusCode):
+		body.r = strings.NewReader("")
+	case headers.Get("Transfer-Encoding") == "chunked":
+		body.r = &chunkedReader{r: reader}
+	default:
+		body.r = reader
+		if n, err := strconv.ParseInt(headers.Get("Content-Length"), 10, 64); err == nil {
+			body.r = &lengthReader{r: reader, remaining: n}
+		}
+	}
+	return &StreamResponse{URL: u, StatusCode: statusCode, Headers: headers, Body: body}, nil
+}
+
+// lengthReader는 Content-Length만큼만 읽고, 그 전에 연결이 끝나면 ErrTruncatedBody를 반환함
+type lengthReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read는 남은 길이만큼만 읽음
+func (l *lengthReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if err == io.EOF && l.remaining > 0 {
+		err = fmt.Errorf("failed to read body: %w", truncated(err))
+	}
+	return n, err
+}
+
+// streamBody는 StreamResponse.Body (닫을 때 남은 본문을 버리고 연결을 돌려주거나 닫음)
+type streamBody struct {
+	fetcher   *HTTPFetcher
+	r         io.Reader
+	reader    *bufio.Reader
+	conn      net.Conn
+	deadlines *deadlineConn
+	address   string
+	reusable  bool // 응답이 끝나면 연결을 재사용할 수 있는지 (길이가 정해진 본문, Connection: close 아님)
+	eof       bool // 본문을 끝까지 읽었는지
+	closed    bool
+}
+
+// Read는 본문을 읽음
+func (b *streamBody) Read(p []byte) (int, error) {
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+// Close는 남은 본문을 MaxDrainBytes, DrainTimeout 안에서 읽어서 버리고
+// 응답이 정확히 끝났으면 연결을 풀에 돌려주고, 아니면 닫음
+func (b *streamBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	if b.reusable && !b.eof {
+		if b.deadlines != nil {
+			b.deadlines.limit(DrainTimeout)
+		} else {
+			b.conn.SetReadDeadline(time.Now().Add(DrainTimeout))
+		}
+		n, err := io.Copy(io.Discard, io.LimitReader(b.r, MaxDrainBytes+1))
+		b.eof = err == nil && n <= MaxDrainBytes
+		b.fetcher.logger().Printf("읽지 않은 본문 %d바이트를 버림 (%s)", n, b.address)
+	}
+	if !b.reusable || !b.eof || b.reader.Buffered() > 0 {
+		b.fetcher.logger().Printf("본문을 끝까지 읽지 못함, 연결을 닫음: %s", b.address)
+		b.fetcher.pool().Discard(b.address, b.conn)
+		return nil
+	}
+	if b.deadlines != nil {
+		b.deadlines.clear()
+	} else {
+		b.conn.SetDeadline(time.Time{})
+	}
+	b.fetcher.pool().Put(b.address, b.conn)
+	return nil
+}
@@ -0,0 +1,94 @@
+package net_test
+
+import (
+	"errors"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// streamServer: /small은 작은 본문, /chunked는 청크 본문, /big은 MaxDrainBytes보다 큰 본문, /short는 잘린 본문
+func streamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			io.WriteString(w, strings.Repeat("a", 1000))
+		case "/chunked":
+			io.WriteString(w, "hello ")
+			w.(http.Flusher).Flush()
+			io.WriteString(w, "world")
+		case "/big":
+			w.Header().Set("Content-Length", "1048576")
+			io.WriteString(w, strings.Repeat("b", 1<<20))
+		case "/short":
+			conn, buf, _ := w.(http.Hijacker).Hijack()
+			buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 10\r\n\r\nHello")
+			buf.Flush()
+			conn.Close()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestHTTPFetcher_Stream 본문을 읽지 않고 닫으면 작은 본문은 버리고 연결을 재사용하고, 큰 본문이면 연결을 닫음
+func TestHTTPFetcher_Stream(t *testing.T) {
+	t.Parallel()
+	srv := streamServer(t)
+	address := srv.Listener.Addr().String()
+	fetcher := isolatedFetcher(t)
+
+	tests := []struct {
+		path     string
+		read     int // 닫기 전에 읽을 바이트 수
+		wantIdle int // 닫은 뒤 풀에 남은 연결 수
+	}{
+		{"/small", 0, 1},
+		{"/small", 10, 1},
+		{"/chunked", 3, 1},
+		{"/chunked", 100, 1},
+		{"/big", 0, 0},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.NewURL(srv.URL + tt.path)
+		resp, err := fetcher.Stream(u)
+		if err != nil {
+			t.Fatalf("Stream(%s) failed: %v", tt.path, err)
+		}
+		if resp.StatusCode != 200 {
+			t.Errorf("Stream(%s).StatusCode = %d; want 200", tt.path, resp.StatusCode)
+		}
+		io.ReadFull(resp.Body, make([]byte, tt.read))
+		if err := resp.Body.Close(); err != nil {
+			t.Errorf("Stream(%s).Body.Close() = %v", tt.path, err)
+		}
+		if got := fetcher.Pool.Stats()[address].Idle; got != tt.wantIdle {
+			t.Errorf("after Stream(%s) reading %d bytes: idle connections = %d; want %d", tt.path, tt.read, got, tt.wantIdle)
+		}
+	}
+}
+
+// TestHTTPFetcher_StreamTruncated 선언보다 짧은 본문은 ErrTruncatedBody
+func TestHTTPFetcher_StreamTruncated(t *testing.T) {
+	srv := streamServer(t)
+	u, _ := url.NewURL(srv.URL + "/short")
+
+	resp, err := (&net.HTTPFetcher{}).Stream(u)
+	if err != nil {
+		t.Fatalf("Stream() failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if !errors.Is(err, net.ErrTruncatedBody) {
+		t.Errorf("ReadAll(Body) error = %v; want ErrTruncatedBody", err)
+	}
+	if string(body) != "Hello" {
+		t.Errorf("ReadAll(Body) = %q; want %q", body, "Hello")
+	}
+}
@@ -0,0 +1,117 @@
+// Package net implements HTTP networking for the browser.
+// This file contains parse options and the strict status-line/header syntax checks.
+package net
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseOptions는 응답 파싱 방식을 정함
+type ParseOptions struct {
+	Limits HeaderLimits // 헤더 크기/개수 제한
+	// Strict가 true면 RFC 9112 문법에 맞지 않는 상태 줄과 헤더를 거부함
+	// (기본값 false: 실제 서버들과의 호환을 위해 관대하게 파싱)
+	//
+	// 엄격 모드에서 거부하는 것:
+	//   - CRLF가 아닌 LF만으로 끝나는 줄
+	//   - obs-fold (공백으로 시작하는 이어지는 줄)
+	//   - 헤더 이름과 ':' 사이의 공백
+	//   - 헤더 이름의 token이 아닌 문자, 값의 제어 문자
+	//   - "HTTP/x.y 3자리코드 사유" 형식이 아닌 상태 줄
+	Strict bool
+}
+
+// GlobalParseOptions는 HTTPFetcher와 ParseResponse가 사용하는 파싱 옵션
+var GlobalParseOptions = ParseOptions{Limits: DefaultHeaderLimits}
+
+// SyntaxError는 엄격 모드에서 문법에 맞지 않는 줄을 만났을 때 반환되는 에러
+type SyntaxError struct {
+	Line   string // 문제가 된 줄 (줄바꿈 포함)
+	Reason string // 거부한 이유
+}
+
+// Error: error 인터페이스 구현
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("HTTP 문법 오류 (%s): %q", e.Reason, e.Line)
+}
+
+// checkLineEnding은 줄이 CRLF로 끝나는지 확인함
+func checkLineEnding(line string) error {
+	if !strings.HasSuffix(line, "\r\n") {
+		return &SyntaxError{Line: line, Reason: "CRLF로 끝나지 않는 줄"}
+	}
+	return nil
+}
+
+// checkStatusLine은 상태 줄이 "HTTP/d.d SP 3DIGIT SP [사유]" 형식인지 확인함
+func checkStatusLine(line string) error {
+	if err := checkLineEnding(line); err != nil {
+		return err
+	}
+	s := strings.TrimSuffix(line, "\r\n")
+
+	if len(s) < 13 || !strings.HasPrefix(s, "HTTP/") ||
+		!isDigit(s[5]) || s[6] != '.' || !isDigit(s[7]) || s[8] != ' ' ||
+		!isDigit(s[9]) || !isDigit(s[10]) || !isDigit(s[11]) || s[12] != ' ' {
+		return &SyntaxError{Line: line, Reason: "상태 줄 형식이 아님"}
+	}
+	for i := 13; i < len(s); i++ {
+		if isCTL(s[i]) && s[i] != '\t' {
+			return &SyntaxError{Line: line, Reason: "사유 문구에 제어 문자"}
+		}
+	}
+	return nil
+}
+
+// checkHeaderLine은 헤더 줄이 "token ':' OWS 값 OWS CRLF" 형식인지 확인함
+func checkHeaderLine(line string) error {
+	if err := checkLineEnding(line); err != nil {
+		return err
+	}
+	if line[0] == ' ' || line[0] == '\t' {
+		return &SyntaxError{Line: line, Reason: "obs-fold 이어지는 줄"}
+	}
+
+	s := strings.TrimSuffix(line, "\r\n")
+	colon := strings.IndexByte(s, ':')
+	if colon == -1 {
+		return &SyntaxError{Line: line, Reason: "':'가 없는 헤더"}
+	}
+	name, value := s[:colon], s[colon+1:]
+	if name == "" {
+		return &SyntaxError{Line: line, Reason: "빈 헤더 이름"}
+	}
+	if last := name[len(name)-1]; last == ' ' || last == '\t' {
+		return &SyntaxError{Line: line, Reason: "헤더 이름과 ':' 사이의 공백"}
+	}
+	for i := 0; i < len(name); i++ {
+		if !isTokenChar(name[i]) {
+			return &SyntaxError{Line: line, Reason: "헤더 이름에 허용되지 않는 문자"}
+		}
+	}
+	for i := 0; i < len(value); i++ {
+		if isCTL(value[i]) && value[i] != '\t' {
+			return &SyntaxError{Line: line, Reason: "헤더 값에 제어 문자"}
+		}
+	}
+	return nil
+}
+
+// isTokenChar는 RFC 9110 tchar인지 확인함
+func isTokenChar(c byte) bool {
+	if isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~", c) != -1
+}
+
+// isCTL은 제어 문자(0x00-0x1F, 0x7F)인지 확인함
+func isCTL(c byte) bool {
+	return c < 0x20 || c == 0x7F
+}
+
+// isDigit은 ASCII 숫자인지 확인함
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,59 @@
+package net_test
+
+import (
+	"errors"
+	"go-web-browser/net"
+	"strings"
+	"testing"
+)
+
+// ============================================
+// 엄격 파싱 모드 테스트
+// ============================================
+
+var strictOptions = net.ParseOptions{Limits: net.DefaultHeaderLimits, Strict: true}
+
+// TestParseResponse_Strict 엄격 모드는 문법 오류를 거부하고, 기본 모드는 그대로 받아들임
+func TestParseResponse_Strict(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		lenientOK bool // 기본(관대한) 모드에서는 성공해야 하는지
+	}{
+		{"LF 줄바꿈", "HTTP/1.1 200 OK\nContent-Length: 0\n\n", true},
+		{"obs-fold", "HTTP/1.1 200 OK\r\nX-A: b\r\n  c\r\nContent-Length: 0\r\n\r\n", true},
+		{"콜론 앞 공백", "HTTP/1.1 200 OK\r\nContent-Length : 0\r\n\r\n", true},
+		{"이름에 잘못된 문자", "HTTP/1.1 200 OK\r\nX(A): b\r\nContent-Length: 0\r\n\r\n", true},
+		{"값에 제어 문자", "HTTP/1.1 200 OK\r\nX-A: b\x00c\r\nContent-Length: 0\r\n\r\n", true},
+		{"헤더 끝 없음", "HTTP/1.1 200 OK\r\nX-A: b\r\n", true},
+		{"상태 코드 형식", "HTTP/1.1 20 OK\r\nContent-Length: 0\r\n\r\n", true},
+		{"버전 형식", "HTTP/11 200 OK\r\nContent-Length: 0\r\n\r\n", true},
+		{"상태 코드 없음", "HTTP/1.1\r\n\r\n", false},
+	}
+
+	for _, tt := range tests {
+		_, _, _, err := net.ParseResponseWith(strings.NewReader(tt.raw), strictOptions)
+		var syntaxErr *net.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%s: strict ParseResponse() error = %v; want *SyntaxError", tt.name, err)
+		}
+
+		_, _, _, err = net.ParseResponse(strings.NewReader(tt.raw))
+		if (err == nil) != tt.lenientOK {
+			t.Errorf("%s: lenient ParseResponse() error = %v; want success = %v", tt.name, err, tt.lenientOK)
+		}
+	}
+}
+
+// TestParseResponse_StrictValid 올바른 응답은 엄격 모드에서도 통과
+func TestParseResponse_StrictValid(t *testing.T) {
+	raw := "HTTP/1.1 404 \r\nContent-Type: text/plain\r\nX-Empty:\r\nContent-Length: 2\r\n\r\nno"
+
+	statusCode, body, headers, err := net.ParseResponseWith(strings.NewReader(raw), strictOptions)
+	if err != nil {
+		t.Fatalf("ParseResponseWith() failed: %v", err)
+	}
+	if statusCode != 404 || body != "no" || headers["content-type"] != "text/plain" {
+		t.Errorf("ParseResponseWith() = %d %q %v; want 404 %q", statusCode, body, headers, "no")
+	}
+}
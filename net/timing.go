@@ -0,0 +1,188 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the navigation timer that sums up phase durations from the trace hooks.
+package net
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timing은 Trace 훅으로 페이지를 불러오는 단계마다 걸린 시간과 리소스 수를 모음 (--perf)
+//
+// 한 단계는 다음 단계가 시작되거나 Stop을 부를 때 끝남
+// 하위 리소스를 백그라운드로 가져오는 동안의 단계도 지금 단계로 셈 (개발자 도구의 워터폴이 아니라 합계)
+type Timing struct {
+	mu        sync.Mutex
+	start     time.Time // Reset한 시각
+	phase     Phase     // 지금 단계
+	since     time.Time // 지금 단계가 시작된 시각 (zero면 진행 중인 단계 없음)
+	summary   TimingSummary
+	lastBytes int64 // 지금 요청에서 지난번 Received로 받은 바이트 수
+}
+
+// TimingSummary는 Timing이 모은 결과
+type TimingSummary struct {
+	Phases       map[Phase]time.Duration // 단계별로 걸린 시간 (한 번도 없었던 단계는 없음)
+	Total        time.Duration           // Reset부터 Stop까지 걸린 시간
+	Requests     int                     // 네트워크로 보낸 요청 수 (캐시에서 가져온 것은 빠짐)
+	Subresources int                     // 가져오기 시작한 하위 리소스 수
+	Bytes        int64                   // 받은 바이트 수 (헤더 포함)
+	ServerTiming []ServerTimingMetric    // 응답 헤더와 트레일러의 Server-Timing 항목 (받은 순서대로)
+}
+
+// NewTiming은 지금부터 시간을 재는 Timing을 만듦
+func NewTiming() *Timing {
+	t := &Timing{}
+	t.Reset()
+	return t
+}
+
+// Trace는 t에 시간을 모으는 Trace
+func (t *Timing) Trace() *Trace {
+	return &Trace{
+		Phase: func(phase Phase, target string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			now := time.Now()
+			t.finish(now)
+			t.phase, t.since = phase, now
+			if phase == PhaseWaiting {
+				t.summary.Requests++
+				t.lastBytes = 0
+			}
+		},
+		Received: func(n int64) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if n > t.lastBytes {
+				t.summary.Bytes += n - t.lastBytes
+			}
+			t.lastBytes = n
+		},
+		Subresource: func(done bool) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !done {
+				t.summary.Subresources++
+			}
+		},
+		Response: t.addServerTiming,
+		Trailers: t.addServerTiming,
+	}
+}
+
+// addServerTiming은 headers의 Server-Timing 항목을 요약에 더함
+//
+// 서버가 처리한 시간이라서 TTFB에서 빼면 네트워크에서 보낸 시간을 가늠할 수 있음
+func (t *Timing) addServerTiming(target string, headers Header) {
+	metrics := ParseServerTiming(headers.Get(HeaderServerTiming))
+	if len(metrics) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.summary.ServerTiming = append(t.summary.ServerTiming, metrics...)
+}
+
+// Reset은 모은 결과를 지우고 지금부터 다시 잼
+func (t *Timing) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.start, t.since = time.Now(), time.Time{}
+	t.summary = TimingSummary{Phases: make(map[Phase]time.Duration)}
+	t.lastBytes = 0
+}
+
+// Stop은 지금 단계를 끝내고 전체 시간을 기록함
+func (t *Timing) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.finish(now)
+	t.summary.Total = now.Sub(t.start)
+}
+
+// Summary는 지금까지 모은 결과 (Stop 전에는 Total이 0)
+func (t *Timing) Summary() TimingSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.summary
+	s.Phases = make(map[Phase]time.Duration, len(t.summary.Phases))
+	for phase, d := range t.summary.Phases {
+		s.Phases[phase] = d
+	}
+	s.ServerTiming = slices.Clone(t.summary.ServerTiming)
+	return s
+}
+
+// finish는 진행 중인 단계를 now에 끝냄 (t.mu를 잠근 채로 부름)
+func (t *Timing) finish(now time.Time) {
+	if !t.since.IsZero() {
+		t.summary.Phases[t.phase] += now.Sub(t.since)
+		t.since = time.Time{}
+	}
+}
+
+// timingRows는 요약에 표시하는 순서와 이름 (개발자 도구의 Timing 탭처럼)
+var timingRows = []struct {
+	phase Phase
+	name  string
+}{
+	{PhaseResolving, "DNS"},
+	{PhaseConnecting, "연결"},
+	{PhaseTLS, "TLS"},
+	{PhaseWaiting, "TTFB"},
+	{PhaseReceiving, "다운로드"},
+	{PhaseParsing, "파싱"},
+	{PhaseStyle, "스타일"},
+	{PhaseLayout, "레이아웃"},
+	{PhasePaint, "그리기"},
+}
+
+// String은 단계별 시간과 리소스 수를 한 줄에 하나씩 보여주는 요약 (없었던 단계는 "-")
+//
+// 예:
+//
+//	DNS        -
+//	연결       1.2ms
+//	TTFB       35.0ms
+//	...
+//	전체       48.3ms (요청 1개, 하위 리소스 0개, 12 KB)
+//	서버       db 53.0ms
+//	서버       cache 23.2ms (Cache Read)
+func (s TimingSummary) String() string {
+	var b strings.Builder
+	for _, row := range timingRows {
+		value := "-"
+		if d, ok := s.Phases[row.phase]; ok {
+			value = formatMillis(d)
+		}
+		fmt.Fprintf(&b, "%s %s\n", padLabel(row.name), value)
+	}
+	fmt.Fprintf(&b, "%s %s (요청 %d개, 하위 리소스 %d개, %s)\n",
+		padLabel("전체"), formatMillis(s.Total), s.Requests, s.Subresources, formatKB(s.Bytes))
+	for _, metric := range s.ServerTiming {
+		fmt.Fprintf(&b, "%s %s\n", padLabel("서버"), metric)
+	}
+	return b.String()
+}
+
+// padLabel은 요약의 이름 칸을 맞춤 (한글은 두 칸으로 셈)
+func padLabel(name string) string {
+	width := 0
+	for _, r := range name {
+		width++
+		if r >= 0x1100 {
+			width++
+		}
+	}
+	return name + strings.Repeat(" ", max(10-width, 1))
+}
+
+// formatMillis는 시간을 밀리초 소수점 한 자리로 표시함 (예: "12.3ms")
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,98 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTiming 가져온 뒤 단계별 시간, 요청 수, 받은 바이트 수를 모으고 요약에 표시함
+func TestTiming(t *testing.T) {
+	t.Parallel()
+	body := strings.Repeat("x", 2048)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	timing := net.NewTiming()
+	fetcher := isolatedFetcher(t)
+	fetcher.Trace = timing.Trace()
+	u, _ := url.NewURL(srv.URL + "/")
+	for range 2 { // 두 번째는 연결을 재사용함
+		if _, err := fetcher.Fetch(u); err != nil {
+			t.Fatalf("Fetch() failed: %v", err)
+		}
+		fetcher.Cache = net.NewCache()
+	}
+	fetcher.Trace.Report(net.PhaseParsing, "")
+	timing.Stop()
+
+	s := timing.Summary()
+	if s.Requests != 2 || s.Bytes <= int64(2*len(body)) {
+		t.Errorf("Summary() = %d requests, %d bytes; want 2 requests, more than %d bytes", s.Requests, s.Bytes, 2*len(body))
+	}
+	for _, phase := range []net.Phase{net.PhaseConnecting, net.PhaseWaiting, net.PhaseReceiving, net.PhaseParsing} {
+		if _, ok := s.Phases[phase]; !ok {
+			t.Errorf("Summary().Phases has no %v", phase)
+		}
+	}
+	if _, ok := s.Phases[net.PhaseTLS]; ok {
+		t.Errorf("Summary().Phases has %v for http", net.PhaseTLS)
+	}
+	if s.Total <= 0 {
+		t.Errorf("Summary().Total = %v; want > 0", s.Total)
+	}
+
+	text := s.String()
+	for _, want := range []string{"TTFB", "TLS        -", "요청 2개"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Summary().String() = %q; want to contain %q", text, want)
+		}
+	}
+}
+
+// TestTiming_Trailers chunked 본문 뒤의 트레일러를 Response.Trailers로 돌려주고, 헤더와 트레일러의 Server-Timing은 요약에 넣음
+func TestTiming_Trailers(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server-Timing", `app;dur=12.5;desc="App Server"`)
+		w.Header().Set("Trailer", "Server-Timing, X-Checksum")
+		w.Write([]byte("hello"))
+		w.(http.Flusher).Flush() // 길이를 모르게 해서 chunked로 보냄
+		w.Write([]byte(" world"))
+		w.Header().Set("Server-Timing", "edge;dur=4")
+		w.Header().Set("X-Checksum", "abc")
+	}))
+	defer srv.Close()
+
+	timing := net.NewTiming()
+	fetcher := isolatedFetcher(t)
+	fetcher.Trace = timing.Trace()
+	u, _ := url.NewURL(srv.URL + "/")
+	resp, err := fetcher.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	timing.Stop()
+
+	if resp.Body != "hello world" || resp.Trailers.Get("X-Checksum") != "abc" || resp.Headers.Has("X-Checksum") {
+		t.Errorf("Fetch() = %q, trailers %v, headers %v; want %q with the X-Checksum trailer only", resp.Body, resp.Trailers, resp.Headers, "hello world")
+	}
+	s := timing.Summary()
+	want := []net.ServerTimingMetric{
+		{Name: "app", Duration: 12500 * time.Microsecond, Description: "App Server"},
+		{Name: "edge", Duration: 4 * time.Millisecond},
+	}
+	if !slices.Equal(s.ServerTiming, want) {
+		t.Errorf("Summary().ServerTiming = %v; want %v", s.ServerTiming, want)
+	}
+	if text := s.String(); !strings.Contains(text, "app 12.5ms (App Server)") {
+		t.Errorf("Summary().String() = %q; want to contain %q", text, "app 12.5ms (App Server)")
+	}
+}
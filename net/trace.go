@@ -0,0 +1,292 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the trace hooks that report page load progress to status lines.
+package net
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptrace"
+	"slices"
+	"sync"
+)
+
+// Phase는 페이지를 불러오는 단계
+type Phase int
+
+// 페이지 로딩 단계 (PhaseParsing부터는 net 바깥의 파서, 스타일, 레이아웃 쪽이 Report로 알림)
+const (
+	PhaseResolving  Phase = iota // DNS로 호스트 주소를 찾는 중
+	PhaseConnecting              // TCP(또는 Unix 소켓) 연결 중
+	PhaseTLS                     // TLS 핸드셰이크 중
+	PhaseWaiting                 // 요청을 보내고 응답을 기다리는 중
+	PhaseReceiving               // 응답을 받는 중
+	PhaseParsing                 // HTML 파싱 중
+	PhaseStyle                   // CSS 스타일 계산 중
+	PhaseLayout                  // 레이아웃 중
+	PhasePaint                   // 화면(터미널)에 그리는 중
+)
+
+// phaseNames는 상태 줄에 표시할 단계 이름
+var phaseNames = map[Phase]string{
+	PhaseResolving:  "주소 찾는 중",
+	PhaseConnecting: "연결 중",
+	PhaseTLS:        "TLS 핸드셰이크 중",
+	PhaseWaiting:    "응답 기다리는 중",
+	PhaseReceiving:  "받는 중",
+	PhaseParsing:    "파싱 중",
+	PhaseStyle:      "스타일 계산 중",
+	PhaseLayout:     "레이아웃 중",
+	PhasePaint:      "그리는 중",
+}
+
+// String은 단계 이름 (예: "연결 중")
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// Trace는 페이지 로딩 진행 상황을 알려받는 훅 (필요한 것만 채우고, nil인 훅은 부르지 않음)
+//
+// 하위 리소스를 백그라운드로 가져올 때는 여러 고루틴에서 동시에 불릴 수 있음
+type Trace struct {
+	// Phase는 새 단계에 들어갈 때 불림 (target은 네트워크 단계면 "호스트:포트", 아니면 "")
+	Phase func(phase Phase, target string)
+
+	// Received는 응답 바이트를 받을 때마다 이 요청에서 지금까지 받은 바이트 수로 불림 (헤더 포함)
+	Received func(n int64)
+
+	// Subresource는 하위 리소스(Early Hints preload, FetchBatch)를 가져오기 시작할 때 false로,
+	// 끝났을 때 true로 불림
+	Subresource func(done bool)
+
+	// Response는 리다이렉트를 포함해 응답을 하나 다 받을 때마다 불림 (target은 "호스트:포트")
+	Response func(target string, headers Header)
+
+	// Trailers는 chunked 본문 뒤에 트레일러가 온 응답마다 불림 (target은 "호스트:포트")
+	// CDN이 본문을 다 보낸 뒤에 붙이는 Server-Timing 같은 값을 모을 때 씀
+	Trailers func(target string, trailers Header)
+}
+
+// GlobalTrace는 HTTPFetcher.Trace가 nil일 때 쓰는 Trace
+//
+// nil이면 연결과 요청 단계를 HTTPFetcher의 로거에 남김 (LogTrace)
+var GlobalTrace *Trace
+
+// MultiTrace는 훅마다 traces의 훅을 차례로 부르는 Trace (nil인 Trace는 건너뜀)
+func MultiTrace(traces ...*Trace) *Trace {
+	traces = slices.DeleteFunc(slices.Clone(traces), func(t *Trace) bool { return t == nil })
+	return &Trace{
+		Phase: func(phase Phase, target string) {
+			for _, t := range traces {
+				t.Report(phase, target)
+			}
+		},
+		Received: func(n int64) {
+			for _, t := range traces {
+				if t.Received != nil {
+					t.Received(n)
+				}
+			}
+		},
+		Subresource: func(done bool) {
+			for _, t := range traces {
+				t.subresource(done)
+			}
+		},
+		Response: func(target string, headers Header) {
+			for _, t := range traces {
+				t.response(target, headers)
+			}
+		},
+		Trailers: func(target string, trailers Header) {
+			for _, t := range traces {
+				t.trailers(target, trailers)
+			}
+		},
+	}
+}
+
+// LogTrace는 단계가 바뀔 때마다 l에 한 줄씩 남기는 Trace (받은 바이트 수는 남기지 않음)
+func LogTrace(l *log.Logger) *Trace {
+	return &Trace{Phase: func(phase Phase, target string) {
+		l.Printf("%s: %s", phase, target)
+	}}
+}
+
+// Report는 t.Phase 훅을 부름 (t가 nil이거나 훅이 없으면 아무것도 하지 않음)
+//
+// 파서, 레이아웃처럼 net 바깥에서 단계를 알릴 때 씀
+func (t *Trace) Report(phase Phase, target string) {
+	if t != nil && t.Phase != nil {
+		t.Phase(phase, target)
+	}
+}
+
+// subresource는 t.Subresource 훅을 부름
+func (t *Trace) subresource(done bool) {
+	if t != nil && t.Subresource != nil {
+		t.Subresource(done)
+	}
+}
+
+// response는 t.Response 훅을 부름
+func (t *Trace) response(target string, headers Header) {
+	if t != nil && t.Response != nil {
+		t.Response(target, headers)
+	}
+}
+
+// trailers는 t.Trailers 훅을 부름
+func (t *Trace) trailers(target string, trailers Header) {
+	if t != nil && t.Trailers != nil {
+		t.Trailers(target, trailers)
+	}
+}
+
+// dialContext는 DNS 조회, 연결 단계를 t에 알리는 context (NetDialer처럼 net.Dialer를 쓰는 Dialer만 알림)
+//
+// secure가 true면 TCP 연결이 끝난 뒤를 TLS 핸드셰이크 단계로 알림
+func (t *Trace) dialContext(address string, secure bool) context.Context {
+	ctx := context.Background()
+	if t == nil || t.Phase == nil {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:     func(httptrace.DNSStartInfo) { t.Phase(PhaseResolving, address) },
+		ConnectStart: func(string, string) { t.Phase(PhaseConnecting, address) },
+		ConnectDone: func(_, _ string, err error) {
+			if secure && err == nil {
+				t.Phase(PhaseTLS, address)
+			}
+		},
+	})
+}
+
+// reader는 r에서 읽은 바이트를 Received 훅으로 알리는 Reader (처음 받을 때 PhaseReceiving을 알림)
+func (t *Trace) reader(r io.Reader, address string) io.Reader {
+	if t == nil || (t.Phase == nil && t.Received == nil) {
+		return r
+	}
+	return &traceReader{r: r, trace: t, address: address}
+}
+
+// traceReader는 Trace.reader가 돌려주는 Reader
+type traceReader struct {
+	r       io.Reader
+	trace   *Trace
+	address string
+	n       int64
+}
+
+// Read는 r에서 읽고 받은 바이트 수를 알림
+func (tr *traceReader) Read(p []byte) (int, error) {
+	n, err := tr.r.Read(p)
+	if n > 0 {
+		if tr.n == 0 {
+			tr.trace.Report(PhaseReceiving, tr.address)
+		}
+		tr.n += int64(n)
+		if tr.trace.Received != nil {
+			tr.trace.Received(tr.n)
+		}
+	}
+	return n, err
+}
+
+// spinnerFrames는 Progress가 바뀔 때마다 돌리는 스피너 모양
+var spinnerFrames = []string{"|", "/", "-", `\`}
+
+// Progress는 Trace 훅으로 모은 페이지 로딩 상태를 상태 줄 글로 만듦
+//
+// 훅이 불릴 때마다 OnChange에 새 상태 줄 글을 넘김 (여러 고루틴에서 불릴 수 있으므로 OnChange도 안전해야 함)
+type Progress struct {
+	OnChange func(status string)
+
+	mu       sync.Mutex
+	phase    Phase
+	target   string
+	received int64
+	started  int // 시작한 하위 리소스 수
+	finished int // 끝난 하위 리소스 수
+	frame    int // 스피너 위치
+}
+
+// Trace는 p에 상태를 모으는 Trace
+func (p *Progress) Trace() *Trace {
+	return &Trace{
+		Phase: func(phase Phase, target string) {
+			p.update(func() {
+				p.phase, p.target = phase, target
+				if phase < PhaseReceiving {
+					p.received = 0
+				}
+			})
+		},
+		Received: func(n int64) {
+			p.update(func() { p.received = n })
+		},
+		Subresource: func(done bool) {
+			p.update(func() {
+				if done {
+					p.finished++
+				} else {
+					p.started++
+				}
+			})
+		},
+	}
+}
+
+// Reset은 새 페이지를 불러오기 전에 단계와 하위 리소스 수를 지움
+func (p *Progress) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.phase, p.target, p.received = PhaseResolving, "", 0
+	p.started, p.finished = 0, 0
+}
+
+// String은 지금 상태 줄 글 (예: `/ 받는 중 example.com:80 12 KB, 하위 리소스 1/3`)
+func (p *Progress) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.text()
+}
+
+// update는 잠근 채로 f로 상태를 바꾸고 스피너를 돌린 뒤 OnChange를 부름
+func (p *Progress) update(f func()) {
+	p.mu.Lock()
+	f()
+	p.frame = (p.frame + 1) % len(spinnerFrames)
+	text := p.text()
+	p.mu.Unlock()
+	if p.OnChange != nil {
+		p.OnChange(text)
+	}
+}
+
+// text는 상태 줄 글 (p.mu를 잠근 채로 부름)
+func (p *Progress) text() string {
+	text := spinnerFrames[p.frame] + " " + p.phase.String()
+	if p.target != "" {
+		text += " " + p.target
+	}
+	if p.phase == PhaseReceiving {
+		text += " " + formatKB(p.received)
+	}
+	if p.started > 0 {
+		text += fmt.Sprintf(", 하위 리소스 %d/%d", p.finished, p.started)
+	}
+	return text
+}
+
+// formatKB는 바이트 수를 KB 단위로 표시함 (1 KB 미만이면 바이트)
+func formatKB(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%d KB", n/1024)
+}
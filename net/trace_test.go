@@ -0,0 +1,85 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestHTTPFetcher_Trace 새 연결로 가져오면 연결, 응답 대기, 받는 중 순서로 알리고 받은 바이트 수가 늘어남
+func TestHTTPFetcher_Trace(t *testing.T) {
+	t.Parallel()
+	body := strings.Repeat("x", 4096)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	var phases []net.Phase
+	var received int64
+	fetcher := isolatedFetcher(t)
+	fetcher.Trace = &net.Trace{
+		Phase: func(phase net.Phase, target string) {
+			if len(phases) == 0 || phases[len(phases)-1] != phase {
+				phases = append(phases, phase)
+			}
+		},
+		Received: func(n int64) { received = n },
+	}
+
+	u, _ := url.NewURL(srv.URL + "/")
+	if _, err := fetcher.Fetch(u); err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	want := []net.Phase{net.PhaseConnecting, net.PhaseWaiting, net.PhaseReceiving}
+	if !slices.Equal(phases, want) {
+		t.Errorf("phases = %v; want %v", phases, want)
+	}
+	if received <= int64(len(body)) {
+		t.Errorf("received = %d; want more than the body (%d)", received, len(body))
+	}
+}
+
+// TestProgress 단계, 받은 크기, 하위 리소스 수를 상태 줄 글로 만들고, Reset하면 지움
+func TestProgress(t *testing.T) {
+	var last string
+	p := &net.Progress{OnChange: func(text string) { last = text }}
+	trace := p.Trace()
+
+	trace.Report(net.PhaseReceiving, "example.com:80")
+	trace.Received(3 << 10)
+	trace.Subresource(false)
+	trace.Subresource(false)
+	trace.Subresource(true)
+	want := "받는 중 example.com:80 3 KB, 하위 리소스 1/2"
+	if !strings.HasSuffix(last, want) || last != p.String() {
+		t.Errorf("OnChange text = %q, String() = %q; want suffix %q", last, p.String(), want)
+	}
+
+	p.Reset()
+	if got := p.String(); strings.Contains(got, "하위 리소스") || !strings.Contains(got, net.PhaseResolving.String()) {
+		t.Errorf("String() after Reset = %q; want %q without subresources", got, net.PhaseResolving)
+	}
+}
+
+// TestMultiTrace 훅마다 모든 Trace를 부르고 nil인 Trace와 훅은 건너뜀
+func TestMultiTrace(t *testing.T) {
+	var phases []net.Phase
+	var received int64
+	trace := net.MultiTrace(
+		&net.Trace{Phase: func(phase net.Phase, target string) { phases = append(phases, phase) }},
+		nil,
+		&net.Trace{Received: func(n int64) { received = n }},
+	)
+	trace.Report(net.PhaseLayout, "")
+	trace.Received(10)
+	trace.Subresource(true)
+
+	if len(phases) != 1 || phases[0] != net.PhaseLayout || received != 10 {
+		t.Errorf("phases = %v, received = %d; want [%v], 10", phases, received, net.PhaseLayout)
+	}
+}
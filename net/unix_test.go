@@ -0,0 +1,75 @@
+//go:build unix
+
+package net_test
+
+import (
+	"go-web-browser/net"
+	"go-web-browser/url"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	stdurl "net/url"
+	"path/filepath"
+	"testing"
+)
+
+// ============================================
+// http+unix (Unix 도메인 소켓) 테스트
+// ============================================
+
+// newUnixServer: Unix 소켓에서 응답하는 테스트 서버와 http+unix 기본 URL을 반환
+func newUnixServer(t *testing.T, handler http.Handler) (*httptest.Server, string) {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "app.sock")
+	listener, err := stdnet.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix 소켓을 열 수 없음: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server, "http+unix://" + stdurl.PathEscape(socket)
+}
+
+// TestHTTPFetcher_UnixSocket Unix 소켓으로 요청하고 Host는 localhost
+func TestHTTPFetcher_UnixSocket(t *testing.T) {
+	_, base := newUnixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + " " + r.URL.Path))
+	}))
+
+	u, err := url.NewURL(base + "/status")
+	if err != nil {
+		t.Fatalf("url.NewURL(%q) failed: %v", base, err)
+	}
+
+	body, err := net.Request(u)
+	if err != nil {
+		t.Fatalf("Request() failed: %v", err)
+	}
+	if body != "localhost /status" {
+		t.Errorf("body = %q; want %q", body, "localhost /status")
+	}
+}
+
+// TestHTTPFetcher_UnixSocketRedirect 상대 경로 리다이렉트는 같은 소켓으로 감
+func TestHTTPFetcher_UnixSocketRedirect(t *testing.T) {
+	_, base := newUnixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		w.Write([]byte("moved"))
+	}))
+
+	u, _ := url.NewURL(base + "/old")
+	resp, err := net.Fetch(u)
+	if err != nil {
+		t.Fatalf("Fetch() failed: %v", err)
+	}
+	if resp.Body != "moved" || resp.URL.String() != base+"/new" {
+		t.Errorf("Fetch() = %q at %s; want %q at %s", resp.Body, resp.URL, "moved", base+"/new")
+	}
+}
@@ -0,0 +1,72 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the per-host User-Agent overrides.
+package net
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 다른 브라우저인 척할 때 쓰는 User-Agent
+const (
+	UserAgentFirefox = "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"
+	UserAgentChrome  = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36"
+)
+
+// UserAgentPresets는 설정 파일에서 User-Agent 대신 쓸 수 있는 이름
+var UserAgentPresets = map[string]string{
+	"default": UserAgent,
+	"firefox": UserAgentFirefox,
+	"chrome":  UserAgentChrome,
+}
+
+// UserAgentAnyHost는 UserAgentRules에서 모든 호스트에 맞는 패턴
+const UserAgentAnyHost = "*"
+
+// UserAgentRules는 호스트 → 그 호스트에 보낼 User-Agent
+//
+// "example.com"은 example.com과 그 하위 도메인(www.example.com 등)에 맞고,
+// 더 구체적인 호스트가 우선함 ("*"는 다른 규칙이 없는 모든 호스트)
+type UserAgentRules map[string]string
+
+// GlobalUserAgents는 HTTPFetcher, Stream, EventSource가 쓰는 호스트별 User-Agent (nil이면 모두 UserAgent)
+var GlobalUserAgents UserAgentRules
+
+// ParseUserAgentRules는 설정 파일의 호스트 → User-Agent(또는 "firefox" 같은 UserAgentPresets 이름)를 UserAgentRules로 만듦
+func ParseUserAgentRules(config map[string]string) (UserAgentRules, error) {
+	rules := make(UserAgentRules, len(config))
+	for host, value := range config {
+		host = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), ".")
+		value = strings.TrimSpace(value)
+		if preset, ok := UserAgentPresets[strings.ToLower(value)]; ok {
+			value = preset
+		}
+		if host == "" || value == "" {
+			return nil, fmt.Errorf("User-Agent 규칙이 비어 있습니다 (%q: %q)", host, value)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("User-Agent에 줄바꿈을 넣을 수 없습니다 (%s)", host)
+		}
+		rules[host] = value
+	}
+	return rules, nil
+}
+
+// For는 host에 보낼 User-Agent를 찾음 (맞는 규칙이 없으면 UserAgent, false)
+func (r UserAgentRules) For(host string) (string, bool) {
+	host = strings.ToLower(host)
+	for domain := host; domain != ""; {
+		if ua, ok := r[domain]; ok {
+			return ua, true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+	if ua, ok := r[UserAgentAnyHost]; ok {
+		return ua, true
+	}
+	return UserAgent, false
+}
@@ -0,0 +1,44 @@
+package net_test
+
+import (
+	"go-web-browser/net"
+	"testing"
+)
+
+// TestUserAgentRules 더 구체적인 호스트 규칙이 우선하고, 프리셋 이름은 그 브라우저의 User-Agent로 바뀜
+func TestUserAgentRules(t *testing.T) {
+	rules, err := net.ParseUserAgentRules(map[string]string{
+		"Example.com":     "Firefox",
+		"api.example.com": "custom/1.0",
+		"*":               "chrome",
+		".other.test":     "default",
+	})
+	if err != nil {
+		t.Fatalf("ParseUserAgentRules() failed: %v", err)
+	}
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"example.com", net.UserAgentFirefox},
+		{"www.EXAMPLE.com", net.UserAgentFirefox},
+		{"v1.api.example.com", "custom/1.0"},
+		{"sub.other.test", net.UserAgent},
+		{"unrelated.org", net.UserAgentChrome},
+	}
+	for _, tt := range tests {
+		if got, ok := rules.For(tt.host); got != tt.want || !ok {
+			t.Errorf("For(%q) = %q, %v; want %q, true", tt.host, got, ok, tt.want)
+		}
+	}
+
+	var none net.UserAgentRules
+	if got, ok := none.For("example.com"); got != net.UserAgent || ok {
+		t.Errorf("nil For() = %q, %v; want %q, false", got, ok, net.UserAgent)
+	}
+	for _, bad := range []map[string]string{{"example.com": ""}, {"example.com": "a\r\nX-Evil: 1"}} {
+		if _, err := net.ParseUserAgentRules(bad); err == nil {
+			t.Errorf("ParseUserAgentRules(%q) returned no error; want error", bad)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package net implements HTTP networking for the browser.
+// This file contains the response preamble shown by view-source (status line, headers, final URL).
+package net
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// sourcePreamble은 view-source 본문 앞에 붙이는 응답 정보를 본문 형식에 맞는 주석으로 만듦
+//
+// 예시 (HTML):
+//
+//	<!--
+//	HTTP/1.1 200 OK
+//	요청한 URL: http://example.com/
+//	최종 URL: https://example.com/ (리다이렉트됨)
+//	content-type: text/html
+//	-->
+func sourcePreamble(requested string, resp *Response) string {
+	var lines []string
+	status := fmt.Sprintf("%s %d %s", HTTPVersion, resp.StatusCode, http.StatusText(resp.StatusCode))
+	if resp.FromCache {
+		status += " (캐시)"
+	}
+	lines = append(lines, strings.TrimSpace(status))
+	if final := resp.URL.Redacted(); final != requested {
+		lines = append(lines, "요청한 URL: "+requested, "최종 URL: "+final+" (리다이렉트됨)")
+	}
+
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		lines = append(lines, name+": "+resp.Headers[name])
+	}
+
+	// 주석이 본문보다 먼저 끝나지 않도록 닫는 기호를 깨뜨림
+	open, close, prefix := "<!--", "-->", ""
+	switch {
+	case resp.ContentType == "text/css" || strings.Contains(resp.ContentType, "javascript"):
+		open, close = "/*", "*/"
+	case !isMarkupType(resp.ContentType):
+		open, close, prefix = "", "", "# "
+	}
+	var b strings.Builder
+	if open != "" {
+		b.WriteString(open + "\n")
+	}
+	for _, line := range lines {
+		if close != "" {
+			line = strings.ReplaceAll(line, close, close[:1]+" "+close[1:])
+		}
+		b.WriteString(prefix + line + "\n")
+	}
+	if close != "" {
+		b.WriteString(close + "\n")
+	}
+	return b.String()
+}
+
+// isMarkupType은 <!-- --> 주석을 쓰는 HTML, XML 계열 MIME 타입인지 확인함
+func isMarkupType(mimeType string) bool {
+	return mimeType == MIMETextHTML || strings.HasSuffix(mimeType, "xml") || strings.HasSuffix(mimeType, "+xml")
+}
@@ -0,0 +1,115 @@
+// Package permissions decides per-origin permissions for capabilities that need the user's consent.
+// This file contains the permission manager that stores decisions in site settings and asks through a Prompter.
+package permissions
+
+import (
+	"fmt"
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+	"sync"
+)
+
+// Permission은 사용자의 허락이 필요한 기능 (사이트별 설정 파일에 이 이름으로 저장됨)
+type Permission string
+
+// 권한 종류 (새 기능은 여기에 이름과 Labels 항목을 더하면 Manager, about:site-settings를 그대로 씀)
+const (
+	InsecureCertificate Permission = "insecure-certificate" // 인증서 검증에 실패한 https 사이트에 검증 없이 연결
+	JavaScript          Permission = "javascript"           // 페이지의 스크립트 실행 (스크립트 계층이 생기면 씀)
+	LookalikeDomain     Permission = "lookalike-domain"     // 잘 알려진 사이트와 비슷해 보이는 주소를 경고 없이 열기
+)
+
+// Labels는 권한을 물을 때와 about:site-settings에 보여줄 이름
+var Labels = map[Permission]string{
+	InsecureCertificate: "인증서 오류 무시",
+	JavaScript:          "JavaScript 실행",
+	LookalikeDomain:     "비슷한 주소 경고 무시",
+}
+
+// All은 about:site-settings에 보여줄 권한 순서
+var All = []Permission{InsecureCertificate, LookalikeDomain, JavaScript}
+
+// String은 보여줄 이름 (Labels에 없으면 권한 이름 그대로)
+func (p Permission) String() string {
+	if label, ok := Labels[p]; ok {
+		return label
+	}
+	return string(p)
+}
+
+// Decision은 사이트별 설정에 저장한 결정
+type Decision string
+
+// 저장된 결정 (Ask면 쓸 때마다 Prompter로 물음)
+const (
+	Ask   Decision = ""
+	Allow Decision = "allow"
+	Deny  Decision = "deny"
+)
+
+// Answer는 Prompter가 받은 사용자의 대답
+type Answer int
+
+// 대답 종류 (Always가 붙은 대답만 사이트별 설정에 저장됨)
+const (
+	DenyOnce Answer = iota
+	AllowOnce
+	AllowAlways
+	DenyAlways
+)
+
+// Prompter는 사용자에게 origin이 p 권한을 써도 되는지 묻는 방법 (터미널 질문, 대화형 모드의 대화 상자 등)
+type Prompter interface {
+	Prompt(origin string, p Permission) (Answer, error)
+}
+
+// Manager는 사이트별 설정에 저장한 결정을 확인하고, 결정이 없으면 Prompter로 물음
+//
+// 하위 리소스를 가져오는 여러 goroutine에서 동시에 불러도 한 번에 하나씩만 물음
+type Manager struct {
+	Sites    *sitesettings.Store
+	Prompter Prompter // nil이면 묻지 않고 거부함 (about:site-settings에서 미리 허용해야 함)
+
+	mu sync.Mutex // 묻는 동안 다른 확인을 기다리게 함
+}
+
+// Decision은 u의 origin에 저장된 p 권한의 결정
+func (m *Manager) Decision(u *url.URL, p Permission) Decision {
+	return Decision(m.Sites.For(u).Permissions[string(p)])
+}
+
+// Set은 u의 origin에 p 권한의 결정을 저장함 (Ask면 지움)
+func (m *Manager) Set(u *url.URL, p Permission, d Decision) error {
+	origin := sitesettings.Origin(u)
+	return m.Sites.Set(origin, m.Sites.Get(origin).WithPermission(string(p), string(d)))
+}
+
+// Check는 u의 origin이 p 권한을 써도 되는지 확인함
+//
+// 저장된 결정이 있으면 그대로 따르고, 없으면 Prompter로 물어서 "항상" 대답은 저장함
+// 묻지 못하거나(Prompter가 nil, 입력 에러) 저장에 실패하면 거부함
+func (m *Manager) Check(u *url.URL, p Permission) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch m.Decision(u, p) {
+	case Allow:
+		return true, nil
+	case Deny:
+		return false, nil
+	}
+	if m.Prompter == nil {
+		return false, nil
+	}
+
+	answer, err := m.Prompter.Prompt(sitesettings.Origin(u), p)
+	if err != nil {
+		return false, fmt.Errorf("권한 묻기 실패 (%s): %w", p, err)
+	}
+	switch answer {
+	case AllowAlways:
+		return true, m.Set(u, p, Allow)
+	case DenyAlways:
+		return false, m.Set(u, p, Deny)
+	}
+	return answer == AllowOnce, nil
+}
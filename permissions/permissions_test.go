@@ -0,0 +1,100 @@
+package permissions_test
+
+import (
+	"errors"
+	"go-web-browser/permissions"
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+	"strings"
+	"testing"
+)
+
+// fakePrompter는 정해진 대답을 하고 물은 횟수를 세는 Prompter
+type fakePrompter struct {
+	answer permissions.Answer
+	err    error
+	asked  int
+}
+
+func (f *fakePrompter) Prompt(origin string, p permissions.Permission) (permissions.Answer, error) {
+	f.asked++
+	return f.answer, f.err
+}
+
+// TestManager_Check 결정이 없으면 묻고, "항상" 대답만 사이트별 설정에 저장해서 다음에는 묻지 않음
+func TestManager_Check(t *testing.T) {
+	tests := []struct {
+		answer    permissions.Answer
+		want      bool
+		wantSaved permissions.Decision
+	}{
+		{permissions.AllowOnce, true, permissions.Ask},
+		{permissions.DenyOnce, false, permissions.Ask},
+		{permissions.AllowAlways, true, permissions.Allow},
+		{permissions.DenyAlways, false, permissions.Deny},
+	}
+	u, _ := url.NewURL("https://self-signed.test/page")
+	for _, tt := range tests {
+		sites, _ := sitesettings.Open("")
+		prompter := &fakePrompter{answer: tt.answer}
+		m := &permissions.Manager{Sites: sites, Prompter: prompter}
+		if got, err := m.Check(u, permissions.InsecureCertificate); got != tt.want || err != nil {
+			t.Errorf("Check() with answer %d = %v, %v; want %v, nil", tt.answer, got, err, tt.want)
+		}
+		if got := m.Decision(u, permissions.InsecureCertificate); got != tt.wantSaved {
+			t.Errorf("Decision() after answer %d = %q; want %q", tt.answer, got, tt.wantSaved)
+		}
+		m.Check(u, permissions.InsecureCertificate)
+		wantAsked := 2
+		if tt.wantSaved != permissions.Ask {
+			wantAsked = 1
+		}
+		if prompter.asked != wantAsked {
+			t.Errorf("asked %d times with answer %d; want %d", prompter.asked, tt.answer, wantAsked)
+		}
+	}
+}
+
+// TestManager_NoPrompter 물을 방법이 없거나 묻다 실패하면 거부하고, 저장된 허용은 그대로 따름
+func TestManager_NoPrompter(t *testing.T) {
+	sites, _ := sitesettings.Open("")
+	u, _ := url.NewURL("https://self-signed.test/")
+	m := &permissions.Manager{Sites: sites}
+	if got, err := m.Check(u, permissions.InsecureCertificate); got || err != nil {
+		t.Errorf("Check() without Prompter = %v, %v; want false, nil", got, err)
+	}
+
+	m.Prompter = &fakePrompter{answer: permissions.AllowOnce, err: errors.New("EOF")}
+	if got, err := m.Check(u, permissions.InsecureCertificate); got || err == nil {
+		t.Errorf("Check() with a failing Prompter = %v, %v; want false, error", got, err)
+	}
+
+	if err := m.Set(u, permissions.InsecureCertificate, permissions.Allow); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if got, _ := m.Check(u, permissions.InsecureCertificate); !got {
+		t.Error("Check() after Set(Allow) = false; want true")
+	}
+	other, _ := url.NewURL("https://self-signed.test:8443/")
+	if got, _ := m.Check(other, permissions.InsecureCertificate); got {
+		t.Error("Check() on another origin = true; want false")
+	}
+}
+
+// TestTerminalPrompter 한 줄씩 대답을 읽고, 모르는 대답은 이번만 거부함
+func TestTerminalPrompter(t *testing.T) {
+	var out strings.Builder
+	p := &permissions.TerminalPrompter{In: strings.NewReader("y\na\nN\nmaybe\n"), Out: &out}
+	want := []permissions.Answer{permissions.AllowOnce, permissions.AllowAlways, permissions.DenyAlways, permissions.DenyOnce}
+	for i, w := range want {
+		if got, err := p.Prompt("https://self-signed.test", permissions.InsecureCertificate); got != w || err != nil {
+			t.Errorf("Prompt() #%d = %d, %v; want %d, nil", i, got, err, w)
+		}
+	}
+	if _, err := p.Prompt("https://self-signed.test", permissions.InsecureCertificate); err == nil {
+		t.Error("Prompt() at EOF returned no error; want error")
+	}
+	if !strings.Contains(out.String(), "https://self-signed.test: 인증서 오류 무시") {
+		t.Errorf("prompt = %q; want the origin and the permission label", out.String())
+	}
+}
@@ -0,0 +1,41 @@
+// Package permissions decides per-origin permissions for capabilities that need the user's consent.
+// This file contains the line-based terminal prompter.
+package permissions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TerminalPrompter는 Out에 질문을 쓰고 In에서 한 줄 대답을 읽는 Prompter (대화형 모드가 아닐 때 씀)
+//
+//	https://self-signed.test: 인증서 오류 무시를 허용할까요? [y] 이번만 / [a] 항상 / [n] 거부 / [N] 항상 거부:
+type TerminalPrompter struct {
+	In  io.Reader
+	Out io.Writer
+
+	reader *bufio.Reader // In을 감싼 Reader (질문마다 새로 만들면 미리 읽은 입력을 잃음)
+}
+
+// Prompt: TerminalPrompter의 Prompter 구현 (알 수 없는 대답, 빈 줄은 이번만 거부)
+func (t *TerminalPrompter) Prompt(origin string, p Permission) (Answer, error) {
+	if t.reader == nil {
+		t.reader = bufio.NewReader(t.In)
+	}
+	fmt.Fprintf(t.Out, "%s: %s을(를) 허용할까요? [y] 이번만 / [a] 항상 / [n] 거부 / [N] 항상 거부: ", origin, p)
+	line, err := t.reader.ReadString('\n')
+	if err != nil && line == "" {
+		return DenyOnce, err
+	}
+	switch strings.TrimSpace(line) {
+	case "y", "Y":
+		return AllowOnce, nil
+	case "a", "A":
+		return AllowAlways, nil
+	case "N":
+		return DenyAlways, nil
+	}
+	return DenyOnce, nil
+}
@@ -0,0 +1,98 @@
+// Package browser provides an embeddable API for the go-web-browser engine.
+//
+// 다른 Go 프로그램에서 브라우저 엔진을 라이브러리로 사용할 수 있도록
+// URL 파싱, 네트워크 요청, HTML 파싱을 하나의 API로 묶음
+//
+//	b := browser.New(browser.Options{})
+//	page, err := b.Navigate("https://example.com/")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(page.Title())
+//	for _, link := range page.Links() {
+//		fmt.Println(link.URL)
+//	}
+package browser
+
+import (
+	"fmt"
+	"go-web-browser/html"
+	"go-web-browser/net"
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+)
+
+// FetchFunc는 URL에서 응답을 가져오는 함수 (기본값은 net.Fetch)
+type FetchFunc func(u *url.URL) (*net.Response, error)
+
+// Options는 Browser 설정
+type Options struct {
+	// Fetch는 네트워크 요청에 사용할 함수 (nil이면 net.Fetch)
+	// 테스트에서 고정 응답을 주거나 요청을 가로챌 때 사용함
+	// 브라우저마다 캐시와 연결 풀을 따로 쓰려면
+	// (&net.HTTPFetcher{Cache: net.NewCache(), Pool: net.NewConnectionPool()}).Fetch를 넘김
+	Fetch FetchFunc
+
+	// Sites는 탐색할 때 참고하는 사이트별 설정 (nil이면 모든 사이트가 기본 동작)
+	Sites *sitesettings.Store
+
+	// Check는 가져오기 전에 부르는 탐색 검사 (에러를 반환하면 가져오지 않음, nil이면 검사하지 않음)
+	// 비슷한 주소 경고(net.LookalikeCheck)처럼 열기 전에 멈춰야 하는 주소를 걸러냄
+	Check func(u *url.URL) error
+
+	// Limits는 한 문서의 최대 크기와 DOM 노드 수 (0인 값은 html.DefaultLimits의 값)
+	// 넘으면 앞부분만 파싱하고 잘렸다는 안내를 붙임 (Page.Truncated)
+	Limits html.Limits
+}
+
+// Browser는 페이지 탐색을 담당하는 브라우저 인스턴스
+type Browser struct {
+	fetch  FetchFunc
+	check  func(u *url.URL) error
+	sites  *sitesettings.Store
+	limits html.Limits
+}
+
+// New는 옵션으로 Browser를 만듦
+func New(opts Options) *Browser {
+	fetch := opts.Fetch
+	if fetch == nil {
+		fetch = net.Fetch
+	}
+	limits := opts.Limits
+	if limits.MaxBytes == 0 {
+		limits.MaxBytes = html.DefaultLimits.MaxBytes
+	}
+	if limits.MaxNodes == 0 {
+		limits.MaxNodes = html.DefaultLimits.MaxNodes
+	}
+	return &Browser{fetch: fetch, check: opts.Check, sites: opts.Sites, limits: limits}
+}
+
+// Navigate는 URL을 가져와서 파싱된 Page를 반환함
+//
+// 스킴이 없는 주소("example.com")는 http://로 간주함 (url.FromUserInput)
+// 최종 URL의 사이트 설정에서 이미지를 껐으면 DOM에서 이미지를 뺌
+func (b *Browser) Navigate(rawURL string) (*Page, error) {
+	u, err := url.FromUserInput(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("URL 분석 에러 (%s): %w", rawURL, err)
+	}
+	if b.check != nil {
+		if err := b.check(u); err != nil {
+			return nil, fmt.Errorf("요청 실패 (%s): %w", u.Redacted(), err)
+		}
+	}
+
+	resp, err := b.fetch(u)
+	if err != nil {
+		return nil, fmt.Errorf("요청 실패 (%s): %w", u.Redacted(), err)
+	}
+
+	net.GlobalTrace.Report(net.PhaseParsing, "")
+	page := newPage(resp, b.limits)
+	if b.sites != nil && b.sites.For(page.URL()).DisableImages {
+		page.removeImages()
+	}
+	return page, nil
+}
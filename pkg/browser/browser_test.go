@@ -0,0 +1,326 @@
+package browser_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go-web-browser/html"
+	"go-web-browser/net"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/url"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testPage = `<!DOCTYPE html>
+<html lang="ko">
+<head><title>  테스트   페이지 </title></head>
+<body>
+<h1>환영합니다</h1>
+<p>첫 번째 <a href="/about">소개</a> 링크와 <a href="docs/guide.html">가이드</a>.</p>
+<a name="anchor">href 없음</a>
+</body>
+</html>`
+
+// TestNavigate_HTTP 실제 HTTP 서버에서 페이지 가져오기
+func TestNavigate_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testPage))
+	}))
+	defer server.Close()
+
+	b := browser.New(browser.Options{})
+	page, err := b.Navigate(server.URL + "/index.html")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+
+	if page.Response.StatusCode != 200 {
+		t.Errorf("StatusCode = %d; want 200", page.Response.StatusCode)
+	}
+	if page.Title() != "테스트 페이지" {
+		t.Errorf("Title() = %q; want %q", page.Title(), "테스트 페이지")
+	}
+	if page.Lang() != "ko" {
+		t.Errorf("Lang() = %q; want %q", page.Lang(), "ko")
+	}
+	if page.DOM == nil {
+		t.Fatal("DOM = nil; want parsed document")
+	}
+
+	wantText := "환영합니다\n\n첫 번째 소개 링크와 가이드.\n\nhref 없음"
+	if page.Text() != wantText {
+		t.Errorf("Text() = %q; want %q", page.Text(), wantText)
+	}
+
+	links := page.Links()
+	if len(links) != 2 {
+		t.Fatalf("len(Links()) = %d; want 2", len(links))
+	}
+	if links[0].Text != "소개" || links[0].URL.String() != server.URL+"/about" {
+		t.Errorf("Links()[0] = %q %v; want 소개 %s/about", links[0].Text, links[0].URL, server.URL)
+	}
+	if links[1].URL.String() != server.URL+"/docs/guide.html" {
+		t.Errorf("Links()[1].URL = %v; want %s/docs/guide.html", links[1].URL, server.URL)
+	}
+}
+
+// TestNavigate_CustomFetch Fetch 주입으로 네트워크 없이 테스트
+func TestNavigate_CustomFetch(t *testing.T) {
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			return &net.Response{URL: u, StatusCode: 200, Body: "plain", ContentType: "text/plain"}, nil
+		},
+	})
+
+	page, err := b.Navigate("http://example.com/")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+	if page.DOM != nil {
+		t.Error("DOM should be nil for text/plain")
+	}
+	if page.Text() != "plain" {
+		t.Errorf("Text() = %q; want %q", page.Text(), "plain")
+	}
+}
+
+// TestNavigate_Error 요청 실패는 에러로 전달
+func TestNavigate_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) { return nil, wantErr },
+	})
+
+	if _, err := b.Navigate("http://example.com/"); !errors.Is(err, wantErr) {
+		t.Errorf("Navigate() error = %v; want %v", err, wantErr)
+	}
+	if _, err := b.Navigate("not a url"); err == nil {
+		t.Error("Navigate(\"not a url\") should return error")
+	}
+}
+
+// TestNavigate_Check 탐색 검사가 에러를 반환하면 가져오지 않고 그 에러를 전달
+func TestNavigate_Check(t *testing.T) {
+	wantErr := &net.LookalikeError{Host: "g00gle.com", Reasons: []string{"google.com처럼 보임"}}
+	fetched := false
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			fetched = true
+			return &net.Response{URL: u, StatusCode: 200, Body: "plain", ContentType: "text/plain"}, nil
+		},
+		Check: func(u *url.URL) error {
+			if u.Host == "g00gle.com" {
+				return wantErr
+			}
+			return nil
+		},
+	})
+
+	if _, err := b.Navigate("http://g00gle.com/"); !errors.Is(err, wantErr) || fetched {
+		t.Errorf("Navigate() error = %v, fetched = %v; want %v without fetching", err, fetched, wantErr)
+	}
+	if _, err := b.Navigate("http://example.com/"); err != nil || !fetched {
+		t.Errorf("Navigate() error = %v, fetched = %v; want nil, true", err, fetched)
+	}
+}
+
+// TestNavigate_FirstPartyIsolation 한 HTTPFetcher를 함께 쓰는 Browser들이 동시에 탐색해도 각자 연 사이트 몫의 쿠키만 주고받음
+func TestNavigate_FirstPartyIsolation(t *testing.T) {
+	net.FirstPartyIsolation = true
+	defer func() { net.FirstPartyIsolation = false }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		http.SetCookie(w, &http.Cookie{Name: "id", Value: r.URL.Query().Get("id"), Path: "/"})
+		w.Write([]byte(r.Header.Get("Cookie")))
+	}))
+	defer server.Close()
+
+	jar := net.NewCookieJar()
+	fetcher := &net.HTTPFetcher{Cookies: jar}
+	// localhost와 127.0.0.1은 같은 서버지만 다른 사이트
+	local := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	var wg sync.WaitGroup
+	for _, rawURL := range []string{local + "/?id=local", server.URL + "/?id=ip"} {
+		wg.Go(func() {
+			b := browser.New(browser.Options{Fetch: fetcher.Fetch})
+			for i := range 10 {
+				page, err := b.Navigate(rawURL)
+				if err != nil {
+					t.Errorf("Navigate(%s) failed: %v", rawURL, err)
+					return
+				}
+				id := rawURL[strings.Index(rawURL, "id=")+3:]
+				if want := "id=" + id; i > 0 && page.Text() != want {
+					t.Errorf("Cookie sent to %s = %q; want %q", rawURL, page.Text(), want)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if all := jar.All(); len(all) != 2 || all[0].Partition != "127.0.0.1" || all[1].Partition != "localhost" {
+		t.Errorf("All() = %+v; want one cookie in each partition", all)
+	}
+}
+
+// TestPage_QuerySelector CSS 선택자와 XPath로 요소 찾기
+func TestPage_QuerySelector(t *testing.T) {
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			return &net.Response{URL: u, StatusCode: 200, Body: testPage, ContentType: net.MIMETextHTML}, nil
+		},
+	})
+	page, err := b.Navigate("http://example.com/")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+
+	h1, err := page.QuerySelector("body > h1")
+	if err != nil || h1 == nil || h1.TextContent() != "환영합니다" {
+		t.Errorf("QuerySelector(\"body > h1\") = %v, %v; want 환영합니다", h1, err)
+	}
+	links, err := page.QuerySelectorAll("p a[href]")
+	if err != nil || len(links) != 2 {
+		t.Errorf("len(QuerySelectorAll(\"p a[href]\")) = %d, %v; want 2", len(links), err)
+	}
+	if _, err := page.QuerySelector("p >"); err == nil {
+		t.Error("QuerySelector(\"p >\") should return error")
+	}
+
+	nodes, err := page.XPath("//p/a[@href='/about']")
+	if err != nil || len(nodes) != 1 || nodes[0].TextContent() != "소개" {
+		t.Errorf("XPath(\"//p/a[@href='/about']\") = %v, %v; want 소개", nodes, err)
+	}
+}
+
+// TestPage_Media <audio>, <video>의 주소를 페이지 URL 기준으로 변환함 (주소가 없는 요소는 제외)
+func TestPage_Media(t *testing.T) {
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			body := `<video><source src="intro.webm" type="video/webm"></video><audio></audio><audio src="//cdn.example.com/a.mp3"></audio>`
+			return &net.Response{URL: u, StatusCode: 200, Body: body, ContentType: net.MIMETextHTML}, nil
+		},
+	})
+	page, err := b.Navigate("https://example.com/clips/")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+
+	media := page.Media()
+	if len(media) != 2 {
+		t.Fatalf("len(Media()) = %d; want 2", len(media))
+	}
+	want := []struct{ tag, url, mimeType string }{
+		{"video", "https://example.com/clips/intro.webm", "video/webm"},
+		{"audio", "https://cdn.example.com/a.mp3", ""},
+	}
+	for i, w := range want {
+		m := media[i]
+		if m.Tag != w.tag || m.URL == nil || m.URL.String() != w.url || m.Type != w.mimeType {
+			t.Errorf("Media()[%d] = %s %v %q; want %s %s %q", i, m.Tag, m.URL, m.Type, w.tag, w.url, w.mimeType)
+		}
+	}
+}
+
+// TestPage_WriteDump json, text, html, dom 형식으로 출력
+func TestPage_WriteDump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<title>T</title><p>Hi <a href="/a">A</a>`))
+	}))
+	defer server.Close()
+
+	page, err := browser.New(browser.Options{}).Navigate(server.URL + "/dump")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+
+	var got browser.PageDump
+	var b strings.Builder
+	if err := page.WriteDump(&b, browser.DumpJSON); err != nil {
+		t.Fatalf("WriteDump(json) failed: %v", err)
+	}
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("WriteDump(json) 결과가 JSON이 아닙니다: %v\n%s", err, b.String())
+	}
+	wantLinks := []browser.LinkDump{{Text: "A", URL: server.URL + "/a"}}
+	if got.URL != server.URL+"/dump" || got.Status != 200 || got.Title != "T" || got.Text != "Hi A" ||
+		got.Headers["content-type"] != "text/html; charset=utf-8" || !reflect.DeepEqual(got.Links, wantLinks) {
+		t.Errorf("WriteDump(json) = %+v", got)
+	}
+
+	tests := map[browser.DumpFormat]string{
+		browser.DumpText: "Hi A",
+		browser.DumpHTML: `<html><head><title>T</title></head><body><p>Hi <a href="/a">A</a></p></body></html>`,
+	}
+	for format, want := range tests {
+		b.Reset()
+		if err := page.WriteDump(&b, format); err != nil {
+			t.Fatalf("WriteDump(%s) failed: %v", format, err)
+		}
+		if b.String() != want {
+			t.Errorf("WriteDump(%s) = %q; want %q", format, b.String(), want)
+		}
+	}
+
+	if _, err := browser.ParseDumpFormat("yaml"); err == nil {
+		t.Error("ParseDumpFormat(\"yaml\") returned no error; want error")
+	}
+}
+
+// TestPage_WriteDump_ControlSequences 페이지 텍스트의 ESC 시퀀스(화면 지우기)를 터미널로 보내지 않고 기호로 바꿔 씀
+func TestPage_WriteDump_ControlSequences(t *testing.T) {
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			return &net.Response{URL: u, StatusCode: 200, Body: "<p>before\x1b[2Jafter</p>", ContentType: net.MIMETextHTML}, nil
+		},
+	})
+	page, err := b.Navigate("http://example.com/")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+
+	for _, format := range []browser.DumpFormat{browser.DumpText, browser.DumpHTML, browser.DumpDOM, browser.DumpJSON} {
+		var out strings.Builder
+		if err := page.WriteDump(&out, format); err != nil {
+			t.Fatalf("WriteDump(%s) failed: %v", format, err)
+		}
+		if strings.Contains(out.String(), "\x1b") {
+			t.Errorf("WriteDump(%s) = %q; want no raw ESC", format, out.String())
+		}
+		if format == browser.DumpText && out.String() != "before\u241b[2Jafter" {
+			t.Errorf("WriteDump(%s) = %q; want %q", format, out.String(), "before\u241b[2Jafter")
+		}
+	}
+}
+
+// TestNavigate_Limits 문서가 Options.Limits를 넘으면 앞부분만 파싱하고 Truncated로 알림
+func TestNavigate_Limits(t *testing.T) {
+	body := strings.Repeat("<p>문단</p>", 100)
+	b := browser.New(browser.Options{
+		Fetch: func(u *url.URL) (*net.Response, error) {
+			return &net.Response{URL: u, StatusCode: 200, Body: body, ContentType: net.MIMETextHTML}, nil
+		},
+		Limits: html.Limits{MaxNodes: 20},
+	})
+	page, err := b.Navigate("http://example.com/")
+	if err != nil {
+		t.Fatalf("Navigate() failed: %v", err)
+	}
+	if !page.Truncated {
+		t.Error("Page.Truncated = false; want true")
+	}
+	if n := len(page.DOM.FindAll("p")); n >= 100 {
+		t.Errorf("len(FindAll(p)) = %d; want fewer than 100", n)
+	}
+	if notice, err := page.QuerySelector("." + html.TruncatedNoticeClass); err != nil || notice == nil {
+		t.Errorf("QuerySelector(notice) = %v, %v; want the truncated notice", notice, err)
+	}
+}
@@ -0,0 +1,105 @@
+package browser
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-web-browser/html"
+	"go-web-browser/term"
+	"io"
+	"strings"
+)
+
+// DumpFormat은 다른 도구가 읽을 수 있게 페이지를 출력하는 형식 (--dump)
+type DumpFormat string
+
+// 출력 형식
+const (
+	DumpText DumpFormat = "text" // 화면에 보이는 텍스트 (Page.Text)
+	DumpJSON DumpFormat = "json" // URL, 상태, 헤더, 제목, 링크, 텍스트를 담은 JSON (PageDump)
+	DumpHTML DumpFormat = "html" // 파서가 정규화한 HTML (html.Render)
+	DumpDOM  DumpFormat = "dom"  // 들여쓴 DOM 트리 (html.DumpTree)
+)
+
+// ParseDumpFormat은 --dump 옵션 값을 DumpFormat으로 바꿈
+func ParseDumpFormat(s string) (DumpFormat, error) {
+	switch f := DumpFormat(s); f {
+	case DumpText, DumpJSON, DumpHTML, DumpDOM:
+		return f, nil
+	}
+	return "", fmt.Errorf("알 수 없는 출력 형식: %q (json, text, html, dom 중 하나)", s)
+}
+
+// PageDump는 DumpJSON 형식으로 출력하는 페이지 정보
+type PageDump struct {
+	URL         string            `json:"url"`
+	Status      int               `json:"status"`
+	ContentType string            `json:"content_type"`
+	Headers     map[string]string `json:"headers"`
+	Title       string            `json:"title"`
+	Links       []LinkDump        `json:"links"`
+	Text        string            `json:"text"`
+}
+
+// LinkDump는 PageDump의 링크 하나 (URL은 절대 주소, 변환에 실패하면 원본 href)
+type LinkDump struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// Dump는 페이지를 PageDump로 정리함
+func (p *Page) Dump() PageDump {
+	d := PageDump{
+		URL:         p.URL().Redacted(),
+		Status:      p.Response.StatusCode,
+		ContentType: p.Response.ContentType,
+		Headers:     p.Response.Headers,
+		Title:       p.Title(),
+		Links:       []LinkDump{},
+		Text:        p.Text(),
+	}
+	if d.Headers == nil {
+		d.Headers = map[string]string{}
+	}
+	for _, l := range p.Links() {
+		link := LinkDump{Text: l.Text, URL: l.Href}
+		if l.URL != nil {
+			link.URL = l.URL.Redacted()
+		}
+		d.Links = append(d.Links, link)
+	}
+	return d
+}
+
+// WriteDump는 페이지를 format 형식으로 w에 씀
+//
+// HTML이 아닌 페이지는 html 형식이면 본문을 그대로 쓰고, dom 형식이면 에러
+// 페이지가 터미널에 ESC 시퀀스를 보내지 못하게 json 외의 형식은 제어 문자를 기호로 바꿈 (term.Sanitize)
+func (p *Page) WriteDump(w io.Writer, format DumpFormat) error {
+	var b strings.Builder
+	switch format {
+	case DumpJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(p.Dump())
+	case DumpText:
+		b.WriteString(p.Text())
+	case DumpHTML:
+		if p.DOM == nil {
+			b.WriteString(p.Response.Body)
+		} else if err := html.Render(&b, p.DOM); err != nil {
+			return err
+		}
+	case DumpDOM:
+		if p.DOM == nil {
+			return fmt.Errorf("HTML 문서가 아니라서 DOM이 없습니다 (%s)", p.Response.ContentType)
+		}
+		if err := html.DumpTree(&b, p.DOM); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("알 수 없는 출력 형식: %q", format)
+	}
+	_, err := io.WriteString(w, term.Sanitize(b.String()))
+	return err
+}
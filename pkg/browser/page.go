@@ -0,0 +1,199 @@
+package browser
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"go-web-browser/net"
+	"go-web-browser/url"
+	"go-web-browser/xpath"
+	"strings"
+)
+
+// Page는 탐색 결과로 얻은 문서
+//
+// HTML이면 DOM이 채워지고, 그 외 텍스트 콘텐츠는 DOM 없이 본문만 가짐
+type Page struct {
+	Response *net.Response // 최종 URL, 상태 코드, 헤더, MIME 타입 등 응답 메타데이터
+	DOM      *html.Node    // 파싱된 Document 노드 (HTML이 아니면 nil)
+
+	// Truncated는 문서가 Options.Limits를 넘어서 앞부분만 파싱했는지 여부
+	// (DOM의 body 끝에 html.TruncatedNoticeClass 안내 문단이 붙어 있음)
+	Truncated bool
+}
+
+// Link는 페이지 안의 <a href> 링크 하나
+type Link struct {
+	Text string     // 링크 텍스트 (공백 정리됨)
+	Href string     // 원본 href 속성 값
+	URL  *url.URL   // 페이지 URL 기준으로 변환한 절대 URL (변환 실패 시 nil)
+	Node *html.Node // <a> 요소 (화면에서 링크 위치를 찾을 때 사용)
+}
+
+// Media는 페이지 안의 <audio>, <video> 요소 하나
+type Media struct {
+	Tag  string     // "audio" 또는 "video"
+	Src  string     // 원본 src 속성 값 (없으면 첫 번째 <source src>)
+	Type string     // type 속성의 MIME 타입 (없으면 빈 문자열)
+	URL  *url.URL   // 페이지 URL 기준으로 변환한 절대 URL (변환 실패 시 nil)
+	Node *html.Node // 미디어 요소
+}
+
+// newPage는 응답으로 Page를 만들고 HTML이면 DOM을 limits 안에서 파싱함
+func newPage(resp *net.Response, limits html.Limits) *Page {
+	page := &Page{Response: resp}
+	if resp.ContentType == net.MIMETextHTML {
+		page.DOM, page.Truncated = html.ParseWithLimits(resp.Body, limits)
+	}
+	return page
+}
+
+// imageTags는 이미지를 끈 사이트에서 DOM에서 빼는 요소 (대체 텍스트도 보이지 않게)
+var imageTags = []string{"img", "picture"}
+
+// removeImages는 DOM에서 이미지 요소를 모두 뺌
+func (p *Page) removeImages() {
+	if p.DOM == nil {
+		return
+	}
+	for _, tag := range imageTags {
+		for _, n := range p.DOM.FindAll(tag) {
+			if n.Parent != nil {
+				n.Parent.RemoveChild(n)
+			}
+		}
+	}
+}
+
+// URL은 페이지의 최종 URL (리다이렉트 이후)
+func (p *Page) URL() *url.URL {
+	return p.Response.URL
+}
+
+// Title은 <title> 요소의 텍스트를 반환함 (없으면 빈 문자열)
+func (p *Page) Title() string {
+	if p.DOM == nil {
+		return ""
+	}
+	title := p.DOM.Find("title")
+	if title == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(title.TextContent()), " ")
+}
+
+// Lang은 <html lang="..."> 속성의 문서 언어를 반환함
+//
+// 속성이 없으면 Content-Language 헤더의 첫 번째 언어, 둘 다 없으면 빈 문자열
+func (p *Page) Lang() string {
+	if p.DOM != nil {
+		if htmlNode := p.DOM.Find("html"); htmlNode != nil {
+			if lang, ok := htmlNode.Attr("lang"); ok {
+				return lang
+			}
+		}
+	}
+	if p.Response == nil {
+		return ""
+	}
+	first, _, _ := strings.Cut(p.Response.Headers.Get("Content-Language"), ",")
+	return strings.TrimSpace(first)
+}
+
+// Text는 화면에 보이는 텍스트를 반환함
+//
+// HTML은 블록 구조에 맞춰 줄바꿈된 텍스트, 그 외 text/* 는 본문 그대로,
+// 바이너리 콘텐츠는 빈 문자열
+func (p *Page) Text() string {
+	if p.DOM != nil {
+		return p.DOM.InnerText()
+	}
+	if strings.HasPrefix(p.Response.ContentType, "text/") {
+		return p.Response.Body
+	}
+	return ""
+}
+
+// QuerySelector는 CSS 선택자와 일치하는 첫 번째 요소를 반환함
+//
+// HTML이 아니거나 일치하는 요소가 없으면 nil, 선택자 문법이 틀리면 에러
+func (p *Page) QuerySelector(selector string) (*html.Node, error) {
+	sel, err := css.Parse(selector)
+	if err != nil || p.DOM == nil {
+		return nil, err
+	}
+	return sel.First(p.DOM), nil
+}
+
+// QuerySelectorAll은 CSS 선택자와 일치하는 모든 요소를 문서 순서로 반환함
+func (p *Page) QuerySelectorAll(selector string) ([]*html.Node, error) {
+	sel, err := css.Parse(selector)
+	if err != nil || p.DOM == nil {
+		return nil, err
+	}
+	return sel.All(p.DOM), nil
+}
+
+// XPath는 XPath 부분집합 식(xpath 패키지 참고)과 일치하는 노드들을 문서 순서로 반환함
+//
+// 속성 값이나 텍스트가 필요하면 xpath.QueryStrings(p.DOM, expr)를 사용함
+func (p *Page) XPath(expr string) ([]*html.Node, error) {
+	e, err := xpath.Compile(expr)
+	if err != nil || p.DOM == nil {
+		return nil, err
+	}
+	return e.Select(p.DOM), nil
+}
+
+// Links는 문서의 모든 <a href> 링크를 문서 순서로 반환함
+func (p *Page) Links() []Link {
+	if p.DOM == nil {
+		return nil
+	}
+
+	var links []Link
+	for _, a := range p.DOM.FindAll("a") {
+		href, ok := a.Attr("href")
+		if !ok {
+			continue
+		}
+		link := Link{
+			Text: strings.Join(strings.Fields(a.TextContent()), " "),
+			Href: href,
+			Node: a,
+		}
+		if p.Response.URL != nil {
+			if resolved, err := p.Response.URL.Resolve(href); err == nil {
+				link.URL = resolved
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// Media는 문서의 주소가 있는 <audio>, <video> 요소를 문서 순서로 반환함
+func (p *Page) Media() []Media {
+	if p.DOM == nil {
+		return nil
+	}
+
+	var media []Media
+	p.DOM.Walk(func(n *html.Node) bool {
+		if n.Type != html.ElementNode || !html.IsMedia(n.Tag) {
+			return true
+		}
+		src, mimeType := n.MediaSource()
+		if src == "" {
+			return false
+		}
+		m := Media{Tag: n.Tag, Src: src, Type: mimeType, Node: n}
+		if p.Response.URL != nil {
+			if resolved, err := p.Response.URL.Resolve(src); err == nil {
+				m.URL = resolved
+			}
+		}
+		media = append(media, m)
+		return false
+	})
+	return media
+}
@@ -0,0 +1,271 @@
+// Package renderer implements output backends that draw fetched content.
+// This file contains the accessibility renderer that announces document structure.
+package renderer
+
+import (
+	"fmt"
+	"go-web-browser/html"
+	"go-web-browser/term"
+	"io"
+	"strings"
+)
+
+// A11yRenderer: 스크린 리더나 점자 디스플레이로 보내기 좋은 텍스트 렌더러
+//
+// 태그를 지운 텍스트 대신 DOM 구조를 말로 알려줌
+// ("Heading level 1: …", "Link: …", "List with 5 items")
+// 줄바꿈은 하지 않고 블록 하나를 한 줄로 출력함 (줄 나눔은 읽는 쪽이 결정)
+type A11yRenderer struct{}
+
+// landmarks: 영역의 시작과 끝을 알려주는 요소와 그 이름
+var landmarks = map[string]string{
+	"nav":    "Navigation",
+	"main":   "Main",
+	"header": "Banner",
+	"footer": "Content info",
+	"aside":  "Complementary",
+}
+
+// Render: HTML을 파싱해서 구조를 알려주는 텍스트로 출력
+func (a *A11yRenderer) Render(w io.Writer, content string) error {
+	doc := html.Parse(content)
+
+	aw := &a11yWriter{}
+	if title := doc.Find("title"); title != nil {
+		if text := strings.Join(strings.Fields(title.TextContent()), " "); text != "" {
+			aw.line("Page title: " + text)
+		}
+	}
+	aw.walk(doc)
+	aw.flush()
+
+	if len(aw.lines) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w, term.Sanitize(strings.Join(aw.lines, "\n"))+"\n")
+	return err
+}
+
+// a11yWriter: 구조 안내 줄을 모으는 버퍼
+//
+// 인라인 텍스트는 cur에 모았다가 블록 경계에서 한 줄로 내보냄
+type a11yWriter struct {
+	lines []string
+	cur   strings.Builder
+}
+
+// inline: 인라인 텍스트를 이어 붙임 (연속된 공백은 하나로)
+func (w *a11yWriter) inline(s string) {
+	if strings.HasPrefix(s, " ") && (w.cur.Len() == 0 || strings.HasSuffix(w.cur.String(), " ")) {
+		s = s[1:]
+	}
+	w.cur.WriteString(s)
+}
+
+// flush: 모아둔 인라인 텍스트를 한 줄로 내보냄
+func (w *a11yWriter) flush() {
+	text := strings.TrimSpace(w.cur.String())
+	w.cur.Reset()
+	if text != "" {
+		w.lines = append(w.lines, text)
+	}
+}
+
+// line: 지금까지의 인라인 텍스트를 내보내고 s를 한 줄로 추가
+func (w *a11yWriter) line(s string) {
+	w.flush()
+	w.lines = append(w.lines, s)
+}
+
+// sub: n의 자식들을 따로 렌더링한 줄들
+func sub(n *html.Node) []string {
+	w := &a11yWriter{}
+	w.walkChildren(n)
+	w.flush()
+	return w.lines
+}
+
+// inlineText: n의 내용을 한 줄로 (제목, 링크, 버튼 안쪽)
+func inlineText(n *html.Node) string {
+	return strings.Join(sub(n), " ")
+}
+
+// labelText: 링크/버튼의 이름 (내용이 없으면 aria-label 또는 title)
+func labelText(n *html.Node) string {
+	if !n.HasVisibleText() {
+		return n.AccessibleLabel()
+	}
+	return inlineText(n)
+}
+
+// walkChildren: 자식 노드를 차례로 방문
+func (w *a11yWriter) walkChildren(n *html.Node) {
+	for _, c := range n.Children {
+		w.walk(c)
+	}
+}
+
+// walk: 노드 종류에 따라 구조를 알리는 텍스트를 씀
+func (w *a11yWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.inline(collapseWhitespace(n.Data))
+		return
+	case html.DocumentNode:
+		w.walkChildren(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if html.IsHidden(n.Tag) || n.AriaHidden() {
+		return
+	}
+
+	switch tag := n.Tag; tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.line(fmt.Sprintf("Heading level %c: %s", tag[1], inlineText(n)))
+	case "a":
+		if _, ok := n.Attr("href"); ok {
+			w.inline("[Link: " + labelText(n) + "]")
+		} else {
+			w.walkChildren(n)
+		}
+	case "img":
+		if alt := n.AltText(); alt != "" {
+			w.inline("[Image: " + alt + "]")
+		} else {
+			w.inline("[Image]")
+		}
+	case "audio", "video":
+		w.inline(n.MediaPlaceholder())
+	case "button":
+		w.inline("[Button: " + labelText(n) + "]")
+	case "input":
+		w.input(n)
+	case "br":
+		w.flush()
+	case "hr":
+		w.line("Separator")
+	case "ul", "ol":
+		w.list(n)
+	case "table":
+		w.table(n)
+	case "pre":
+		w.line("Code block:")
+		for _, l := range strings.Split(strings.Trim(n.TextContent(), "\n"), "\n") {
+			w.lines = append(w.lines, l)
+		}
+		w.lines = append(w.lines, "End of code block")
+	case "blockquote":
+		w.line("Quote:")
+		w.walkChildren(n)
+		w.line("End of quote")
+	default:
+		if name, ok := landmarks[tag]; ok {
+			w.line(name + " region")
+			w.walkChildren(n)
+			w.line("End of " + strings.ToLower(name) + " region")
+			return
+		}
+		if html.IsBlock(tag) {
+			w.flush()
+			w.walkChildren(n)
+			w.flush()
+			return
+		}
+		w.walkChildren(n)
+	}
+}
+
+// input: 입력 요소를 종류에 따라 알림
+func (w *a11yWriter) input(n *html.Node) {
+	typ, _ := n.Attr("type")
+	label, _ := n.Attr("placeholder")
+	if label == "" {
+		label, _ = n.Attr("name")
+	}
+
+	switch strings.ToLower(typ) {
+	case "hidden":
+	case "submit", "button", "reset":
+		value, _ := n.Attr("value")
+		w.inline("[Button: " + value + "]")
+	case "checkbox", "radio":
+		state := "not checked"
+		if _, ok := n.Attr("checked"); ok {
+			state = "checked"
+		}
+		w.inline(fmt.Sprintf("[%s%s: %s]", strings.ToUpper(typ[:1]), strings.ToLower(typ[1:]), state))
+	default:
+		w.inline("[Text field: " + label + "]")
+	}
+}
+
+// list: "List with N items" 뒤에 항목을 한 줄씩 (중첩된 내용은 들여씀)
+func (w *a11yWriter) list(n *html.Node) {
+	var items []*html.Node
+	for _, c := range n.Children {
+		if c.Type == html.ElementNode && c.Tag == "li" {
+			items = append(items, c)
+		}
+	}
+
+	w.line("List with " + plural(len(items), "item"))
+	for i, item := range items {
+		marker := "-"
+		if n.Tag == "ol" {
+			marker = fmt.Sprintf("%d.", i+1)
+		}
+		for j, l := range sub(item) {
+			if j == 0 {
+				w.lines = append(w.lines, marker+" "+l)
+			} else {
+				w.lines = append(w.lines, "  "+l)
+			}
+		}
+	}
+	w.lines = append(w.lines, "End of list")
+}
+
+// table: "Table with R rows and C columns" 뒤에 행마다 셀을 " | "로 구분
+func (w *a11yWriter) table(n *html.Node) {
+	rows := n.FindAll("tr")
+	columns := 0
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		for _, c := range row.Children {
+			if c.Type == html.ElementNode && (c.Tag == "td" || c.Tag == "th") {
+				cells[i] = append(cells[i], inlineText(c))
+			}
+		}
+		columns = max(columns, len(cells[i]))
+	}
+
+	w.line(fmt.Sprintf("Table with %s and %s", plural(len(rows), "row"), plural(columns, "column")))
+	for i, row := range cells {
+		w.lines = append(w.lines, fmt.Sprintf("Row %d: %s", i+1, strings.Join(row, " | ")))
+	}
+	w.lines = append(w.lines, "End of table")
+}
+
+// collapseWhitespace: 연속된 공백 문자를 공백 하나로 줄임 (앞뒤 공백도 하나로 남김)
+func collapseWhitespace(s string) string {
+	text := strings.Join(strings.Fields(s), " ")
+	if s != "" && strings.TrimLeft(s, " \t\n\r\f") != s {
+		text = " " + text
+	}
+	if text != " " && strings.TrimRight(s, " \t\n\r\f") != s {
+		text += " "
+	}
+	return text
+}
+
+// plural: "1 item", "5 items"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,36 @@
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// largeHTML: 제목, 문단, 목록, 표가 섞인 약 1MiB짜리 문서
+func largeHTML() string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html><html lang=\"en\"><head><title>Bench</title></head><body>")
+	for i := 0; sb.Len() < 1<<20; i++ {
+		fmt.Fprintf(&sb, "<h2>Section %d</h2>", i)
+		fmt.Fprintf(&sb, "<p>Lorem ipsum dolor sit amet, <a href=\"/p/%d\">consectetur</a> adipiscing elit, "+
+			"sed do <em>eiusmod</em> tempor incididunt ut labore et dolore magna aliqua.</p>", i)
+		sb.WriteString("<ul><li>one</li><li>two &amp; three</li></ul>")
+		sb.WriteString("<table><tr><td>cell</td><td>cell</td></tr></table>")
+	}
+	sb.WriteString("</body></html>")
+	return sb.String()
+}
+
+// BenchmarkHTMLRenderer_Large 큰 HTML 문서를 파싱하고 줄바꿈해서 렌더링
+func BenchmarkHTMLRenderer_Large(b *testing.B) {
+	doc := largeHTML()
+	r := &HTMLRenderer{Width: 80}
+	b.SetBytes(int64(len(doc)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := r.Render(io.Discard, doc); err != nil {
+			b.Fatalf("Render() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package renderer implements output backends that draw fetched content.
+// This file contains the HTML text renderer.
+package renderer
+
+import (
+	"go-web-browser/html"
+	"go-web-browser/layout"
+	"go-web-browser/net"
+	"go-web-browser/term"
+	"io"
+	"strings"
+)
+
+// HTMLRenderer: HTML을 DOM으로 파싱해서 보이는 텍스트를 터미널 폭에 맞게 렌더링
+type HTMLRenderer struct {
+	Width int // 줄 폭 (칸 수, 0 이하면 layout.DefaultWidth)
+}
+
+// Render: HTML을 파싱하고 줄바꿈된 텍스트를 출력 (단계는 net.GlobalTrace로 알림)
+func (h *HTMLRenderer) Render(w io.Writer, content string) error {
+	net.GlobalTrace.Report(net.PhaseParsing, "")
+	doc := html.Parse(content)
+	net.GlobalTrace.Report(net.PhaseLayout, "")
+	text := h.renderText(doc)
+	net.GlobalTrace.Report(net.PhasePaint, "")
+	_, err := io.WriteString(w, text+"\n")
+	return err
+}
+
+// renderText: DOM의 보이는 텍스트를 줄바꿈해서 반환
+// 문서 언어에 맞는 하이픈 규칙이 있으면 긴 단어를 음절 단위로 나눔
+// 제어 문자는 줄을 나누기 전에 기호로 바꿔서 바뀐 폭대로 줄바꿈함
+func (h *HTMLRenderer) renderText(doc *html.Node) string {
+	opts := layout.Options{
+		Width:      Options{Width: h.Width}.width(),
+		Hyphenator: layout.HyphenatorFor(documentLang(doc)),
+	}
+	return strings.Join(layout.WrapWith(term.Sanitize(doc.InnerText()), opts), "\n")
+}
+
+// documentLang: <html lang="..."> 속성에서 문서 언어를 찾음 (없으면 빈 문자열)
+func documentLang(doc *html.Node) string {
+	htmlNode := doc.Find("html")
+	if htmlNode == nil {
+		return ""
+	}
+	lang, _ := htmlNode.Attr("lang")
+	return lang
+}
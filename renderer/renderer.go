@@ -0,0 +1,91 @@
+// Package renderer implements output backends that draw fetched content.
+// This file contains the Renderer interface and renderer lookup by scheme and MIME type.
+package renderer
+
+import (
+	"fmt"
+	"go-web-browser/layout"
+	"go-web-browser/net"
+	"go-web-browser/term"
+	"go-web-browser/url"
+	"io"
+	"strings"
+)
+
+// Renderer 인터페이스: 콘텐츠 렌더링을 추상화
+type Renderer interface {
+	Render(w io.Writer, content string) error
+}
+
+// Options: 렌더러 공통 설정
+type Options struct {
+	Width int  // 텍스트 레이아웃 폭 (칸 수, 0 이하면 layout.DefaultWidth)
+	A11y  bool // HTML을 구조 안내 텍스트로 출력 (A11yRenderer)
+}
+
+// SourceRenderer: 원본 소스를 그대로 렌더링
+type SourceRenderer struct{}
+
+// Render: 콘텐츠를 가공 없이 출력 (제어 문자만 눈에 보이는 기호로 바꿈, term.Sanitize)
+func (s *SourceRenderer) Render(w io.Writer, content string) error {
+	_, err := io.WriteString(w, term.Sanitize(content))
+	return err
+}
+
+// BinaryRenderer: 텍스트로 표시할 수 없는 콘텐츠는 본문 대신 안내만 출력
+type BinaryRenderer struct {
+	ContentType string
+}
+
+// Render: MIME 타입과 크기만 안내
+func (b *BinaryRenderer) Render(w io.Writer, content string) error {
+	_, err := fmt.Fprintf(w, "표시할 수 없는 콘텐츠입니다 (%s, %d 바이트)\n", term.Sanitize(b.ContentType), len(content))
+	return err
+}
+
+// rendererFactory: 옵션을 받아 Renderer를 만드는 함수
+type rendererFactory func(opts Options) Renderer
+
+func newSourceRenderer(Options) Renderer { return &SourceRenderer{} }
+
+func newHTMLRenderer(opts Options) Renderer {
+	if opts.A11y {
+		return &A11yRenderer{}
+	}
+	return &HTMLRenderer{Width: opts.Width}
+}
+
+// schemeRegistry: scheme에 따른 Renderer 레지스트리 (MIME 타입보다 우선)
+var schemeRegistry = map[url.Scheme]rendererFactory{
+	url.SchemeViewSource: newSourceRenderer,
+}
+
+// contentTypeRegistry: MIME 타입에 따른 Renderer 레지스트리
+var contentTypeRegistry = map[string]rendererFactory{
+	net.MIMETextHTML: newHTMLRenderer,
+	"text/xml":       newSourceRenderer,
+}
+
+// For: scheme과 MIME 타입에 맞는 Renderer 반환
+//
+// 등록되지 않은 text/* 는 원본 그대로, 그 외(바이너리)는 터미널에 쏟아내지 않고 안내만 출력
+func For(scheme url.Scheme, contentType string, opts Options) Renderer {
+	if factory, ok := schemeRegistry[scheme]; ok {
+		return factory(opts)
+	}
+	if factory, ok := contentTypeRegistry[contentType]; ok {
+		return factory(opts)
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		return &SourceRenderer{}
+	}
+	return &BinaryRenderer{ContentType: contentType}
+}
+
+// width: 옵션의 폭 또는 기본 폭
+func (o Options) width() int {
+	if o.Width <= 0 {
+		return layout.DefaultWidth
+	}
+	return o.Width
+}
@@ -0,0 +1,204 @@
+package renderer
+
+import (
+	"go-web-browser/html"
+	"go-web-browser/url"
+	"strings"
+	"testing"
+)
+
+// render: 테스트용으로 렌더러 출력을 문자열로 반환
+func render(t *testing.T, r Renderer, content string) string {
+	t.Helper()
+	var b strings.Builder
+	if err := r.Render(&b, content); err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	return b.String()
+}
+
+// TestHTMLRenderer_BasicTag 기본 태그 제거 테스트
+func TestHTMLRenderer_BasicTag(t *testing.T) {
+	input := "<h1>Hello</h1>"
+	expected := "Hello\n"
+
+	result := render(t, &HTMLRenderer{}, input)
+
+	if result != expected {
+		t.Errorf("Render(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+// TestHTMLRenderer_HTMLEntities HTML 엔티티 변환 테스트
+func TestHTMLRenderer_HTMLEntities(t *testing.T) {
+	input := "<p>&lt;code&gt;&amp;&lt;/code&gt;</p>"
+	expected := "<code>&</code>\n"
+
+	result := render(t, &HTMLRenderer{}, input)
+
+	if result != expected {
+		t.Errorf("Render(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+// TestHTMLRenderer_MultipleTags 여러 블록 태그는 줄로 구분
+func TestHTMLRenderer_MultipleTags(t *testing.T) {
+	input := "<h1>Title</h1><p>Paragraph</p>"
+	expected := "Title\n\nParagraph\n"
+
+	result := render(t, &HTMLRenderer{}, input)
+
+	if result != expected {
+		t.Errorf("Render(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+// TestRenderer_ControlChars 페이지 글의 ESC 같은 제어 문자는 터미널로 보내지 않고 기호로 보여줌
+func TestRenderer_ControlChars(t *testing.T) {
+	tests := []struct {
+		r        Renderer
+		input    string
+		expected string
+	}{
+		{&HTMLRenderer{}, "<p>a\x1b[2Jb\x07</p>", "a␛[2Jb␇\n"},
+		{&SourceRenderer{}, "plain\x1b]0;title\x07", "plain␛]0;title␇"},
+		{&A11yRenderer{}, "<p>a\x1b[31mb</p>", "a␛[31mb\n"},
+	}
+	for _, tt := range tests {
+		if result := render(t, tt.r, tt.input); result != tt.expected {
+			t.Errorf("%T.Render(%q) = %q; want %q", tt.r, tt.input, result, tt.expected)
+		}
+	}
+}
+
+// TestHTMLRenderer_Width 폭에 맞춰 줄바꿈
+func TestHTMLRenderer_Width(t *testing.T) {
+	input := "<p>가나다라마바사</p>"
+	expected := "가나다\n라마바\n사\n"
+
+	result := render(t, &HTMLRenderer{Width: 6}, input)
+
+	if result != expected {
+		t.Errorf("Render(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+// TestDocumentLang <html lang> 속성 추출 테스트
+func TestDocumentLang(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<!DOCTYPE html><html lang="ko"><body></body></html>`, "ko"},
+		{`<HTML LANG='en-US'>`, "en-US"},
+		{`<html><body lang="fr"></body></html>`, ""},
+		{`no html tag`, ""},
+	}
+
+	for _, tt := range tests {
+		result := documentLang(html.Parse(tt.input))
+		if result != tt.expected {
+			t.Errorf("documentLang(%q) = %q; want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+// TestFor 스킴과 MIME 타입에 따른 렌더러 선택
+func TestFor(t *testing.T) {
+	tests := []struct {
+		scheme      url.Scheme
+		contentType string
+		want        string
+	}{
+		{url.SchemeViewSource, "text/html", "*renderer.SourceRenderer"},
+		{url.SchemeHTTP, "text/html", "*renderer.HTMLRenderer"},
+		{url.SchemeHTTP, "text/plain", "*renderer.SourceRenderer"},
+		{url.SchemeHTTP, "image/png", "*renderer.BinaryRenderer"},
+	}
+
+	for _, tt := range tests {
+		got := typeName(For(tt.scheme, tt.contentType, Options{}))
+		if got != tt.want {
+			t.Errorf("For(%q, %q) = %s; want %s", tt.scheme, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+// typeName: 렌더러의 타입 이름
+func typeName(r Renderer) string {
+	switch r.(type) {
+	case *SourceRenderer:
+		return "*renderer.SourceRenderer"
+	case *HTMLRenderer:
+		return "*renderer.HTMLRenderer"
+	case *BinaryRenderer:
+		return "*renderer.BinaryRenderer"
+	}
+	return "unknown"
+}
+
+// ============================================================================
+// A11yRenderer
+// ============================================================================
+
+// TestA11yRenderer 문서 구조를 말로 알려줌
+func TestA11yRenderer(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"heading and link",
+			`<title>Home</title><h1>Welcome</h1><p>Go to <a href="/a">the <b>first</b> page</a>.</p>`,
+			"Page title: Home\nHeading level 1: Welcome\nGo to [Link: the first page].\n",
+		},
+		{
+			"lists",
+			"<ul><li>one</li><li>two<ol><li>nested</li></ol></li></ul>",
+			"List with 2 items\n- one\n- two\n  List with 1 item\n  1. nested\n  End of list\nEnd of list\n",
+		},
+		{
+			"table",
+			"<table><tr><th>A</th><th>B</th></tr><tr><td>1</td></tr></table>",
+			"Table with 2 rows and 2 columns\nRow 1: A | B\nRow 2: 1\nEnd of table\n",
+		},
+		{
+			"landmark and quote",
+			"<nav><a href=/>Home</a></nav><blockquote>Hi</blockquote>",
+			"Navigation region\n[Link: Home]\nEnd of navigation region\nQuote:\nHi\nEnd of quote\n",
+		},
+		{
+			"images and form controls",
+			`<p><img src=a.png alt="Logo"><img src=b.png> <input name=q><input type=checkbox checked><input type=submit value=Go></p>`,
+			"[Image: Logo][Image] [Text field: q][Checkbox: checked][Button: Go]\n",
+		},
+		{
+			"aria labels",
+			`<p><a href="/s" aria-label="Search"><i aria-hidden="true">🔍</i></a> <button title="Close"></button><span aria-hidden="true">decor</span></p>`,
+			"[Link: Search] [Button: Close]\n",
+		},
+		{
+			"code block",
+			"<pre>a  b\nc</pre>",
+			"Code block:\na  b\nc\nEnd of code block\n",
+		},
+	}
+
+	for _, tt := range tests {
+		result := render(t, &A11yRenderer{}, tt.input)
+		if result != tt.want {
+			t.Errorf("%s: Render(%q) = %q; want %q", tt.name, tt.input, result, tt.want)
+		}
+	}
+}
+
+// TestFor_A11y A11y 옵션이면 HTML에 A11yRenderer 사용
+func TestFor_A11y(t *testing.T) {
+	if _, ok := For(url.SchemeHTTP, "text/html", Options{A11y: true}).(*A11yRenderer); !ok {
+		t.Error("For(http, text/html, A11y) should return *A11yRenderer")
+	}
+	if _, ok := For(url.SchemeViewSource, "text/html", Options{A11y: true}).(*SourceRenderer); !ok {
+		t.Error("For(view-source, text/html, A11y) should return *SourceRenderer")
+	}
+}
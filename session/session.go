@@ -0,0 +1,98 @@
+// Package session saves and restores the set of open tabs.
+// This file contains the session file format and crash-safe writing.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName은 설정 디렉토리 안의 세션 파일 이름
+const FileName = "session.json"
+
+// sessionVersion은 세션 파일 형식의 버전 (형식이 바뀌면 올림)
+const sessionVersion = 1
+
+// ErrNoSession은 저장된 세션이 없을 때 반환됨
+var ErrNoSession = errors.New("저장된 세션이 없습니다")
+
+// Tab은 탭 하나의 상태
+type Tab struct {
+	URL string `json:"url"`
+	Top int    `json:"top"` // 화면 맨 위에 보이던 문서 줄 번호
+}
+
+// Session은 열려 있던 탭 목록
+type Session struct {
+	Version int       `json:"version"`
+	Saved   time.Time `json:"saved"`
+	Tabs    []Tab     `json:"tabs"`
+	Current int       `json:"current"` // 선택되어 있던 탭 인덱스
+}
+
+// Load는 path의 세션 파일을 읽음 (파일이 없으면 ErrNoSession)
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, fmt.Errorf("세션 파일 읽기 실패: %w", err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("세션 파일 형식 오류 (%s): %w", path, err)
+	}
+	if s.Version != sessionVersion {
+		return nil, fmt.Errorf("지원하지 않는 세션 파일 버전: %d", s.Version)
+	}
+	if s.Current < 0 || s.Current >= len(s.Tabs) {
+		s.Current = 0
+	}
+	return &s, nil
+}
+
+// Save는 세션을 path에 씀
+//
+// 같은 디렉토리의 임시 파일에 쓰고 디스크에 반영(fsync)한 뒤 이름을 바꾸므로,
+// 쓰는 도중 프로그램이 죽어도 이전 세션 파일은 그대로 남음
+func Save(path string, s Session) error {
+	s.Version = sessionVersion
+	s.Saved = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, FileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // 이름을 바꾼 뒤에는 아무 일도 하지 않음
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("세션 저장 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package session_test
+
+import (
+	"errors"
+	"go-web-browser/session"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestSaveLoad 저장한 탭 목록을 그대로 다시 읽음
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile", session.FileName)
+	want := session.Session{
+		Tabs:    []session.Tab{{URL: "http://example.com/", Top: 3}, {URL: "about:blank"}},
+		Current: 1,
+	}
+
+	if err := session.Save(path, want); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	got, err := session.Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Tabs, want.Tabs) || got.Current != want.Current {
+		t.Errorf("Load() = %+v; want %+v", got, want)
+	}
+
+	// 임시 파일이 남지 않아야 함
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("디렉토리 항목 %d개; want 1 (세션 파일만)", len(entries))
+	}
+}
+
+// TestLoad_Errors 없는 파일, 잘못된 형식, 범위를 벗어난 Current
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := session.Load(filepath.Join(dir, "none.json")); !errors.Is(err, session.ErrNoSession) {
+		t.Errorf("Load(없는 파일) error = %v; want ErrNoSession", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"invalid json", `{"tabs": [`, true},
+		{"unknown version", `{"version": 99, "tabs": []}`, true},
+		{"current out of range", `{"version": 1, "tabs": [{"url": "about:blank"}], "current": 5}`, false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name+".json")
+		os.WriteFile(path, []byte(tt.content), 0o644)
+		s, err := session.Load(path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Load() error = %v; wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && s.Current != 0 {
+			t.Errorf("%s: Current = %d; want 0", tt.name, s.Current)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package sitesettings stores per-site overrides keyed by origin.
+// This file contains the JSON-backed site settings store.
+package sitesettings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-web-browser/url"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileName은 프로필 디렉토리 안의 사이트별 설정 파일 이름
+const FileName = "site-settings.json"
+
+// Settings는 한 사이트(origin)에 대한 설정 (값이 0이면 기본 동작)
+type Settings struct {
+	AllowInsecure bool    `json:"allow_insecure,omitempty"` // HTTPS-first 모드에서도 https로 올리지 않고 http 그대로 씀
+	DisableImages bool    `json:"disable_images,omitempty"` // 이미지(대체 텍스트 포함)를 표시하지 않음
+	Zoom          float64 `json:"zoom,omitempty"`           // 확대 배율 (0이면 기본값 1)
+
+	// Permissions는 권한 이름 → 사용자가 내린 결정 ("allow", "deny", permissions 패키지가 씀)
+	Permissions map[string]string `json:"permissions,omitempty"`
+}
+
+// IsZero는 기본값에서 바꾼 설정이 없는지 확인함
+func (s Settings) IsZero() bool {
+	return !s.AllowInsecure && !s.DisableImages && s.Zoom == 0 && len(s.Permissions) == 0
+}
+
+// WithPermission은 name 권한의 결정을 decision으로 바꾼 복사본 (""이면 결정을 지움)
+//
+// Get으로 받은 Settings의 Permissions는 Store와 같은 map이므로 직접 바꾸지 않고 이것을 씀
+func (s Settings) WithPermission(name, decision string) Settings {
+	s.Permissions = maps.Clone(s.Permissions)
+	if decision == "" {
+		delete(s.Permissions, name)
+	} else {
+		if s.Permissions == nil {
+			s.Permissions = make(map[string]string)
+		}
+		s.Permissions[name] = decision
+	}
+	if len(s.Permissions) == 0 {
+		s.Permissions = nil
+	}
+	return s
+}
+
+// Site는 origin과 그 설정
+type Site struct {
+	Origin   string
+	Settings Settings
+}
+
+// Store는 origin별 설정과 저장 위치
+//
+// 탐색할 때마다 여러 곳(HTTPS-first, 페이지 표시)에서 읽으므로 동시에 써도 안전함
+// path가 비어 있으면 파일에 저장하지 않음 (테스트, 임시 사용)
+type Store struct {
+	mu    sync.Mutex
+	path  string
+	sites map[string]Settings
+}
+
+// Open은 path의 사이트별 설정 파일을 읽어 Store를 만듦 (파일이 없으면 빈 설정)
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, sites: make(map[string]Settings)}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("사이트별 설정 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.sites); err != nil {
+		return nil, fmt.Errorf("사이트별 설정 파일 형식 오류 (%s): %w", path, err)
+	}
+	return s, nil
+}
+
+// Origin은 u의 origin ("https://example.com", 기본 포트가 아니면 ":8080"까지)
+//
+// 호스트가 없는 스킴(file, data 등)은 "file:"처럼 스킴만 씀
+func Origin(u *url.URL) string {
+	n := u.Normalize()
+	if n.Host == "" {
+		return string(n.Scheme) + ":"
+	}
+	origin := string(n.Scheme) + "://" + n.HostLiteral()
+	switch {
+	case n.Port == 0:
+	case n.Scheme == url.SchemeHTTP && n.Port == url.DefaultHTTPPort:
+	case n.Scheme == url.SchemeHTTPS && n.Port == url.DefaultHTTPSPort:
+	default:
+		origin += fmt.Sprintf(":%d", n.Port)
+	}
+	return origin
+}
+
+// Get은 origin의 설정 (없으면 기본값)
+func (s *Store) Get(origin string) Settings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sites[origin]
+}
+
+// For는 u가 속한 사이트의 설정
+func (s *Store) For(u *url.URL) Settings {
+	return s.Get(Origin(u))
+}
+
+// Set은 origin의 설정을 바꾸고 파일에 저장함 (기본값이면 지움)
+func (s *Store) Set(origin string, settings Settings) error {
+	s.mu.Lock()
+	if settings.IsZero() {
+		delete(s.sites, origin)
+	} else {
+		s.sites[origin] = settings
+	}
+	s.mu.Unlock()
+	return s.Save()
+}
+
+// All은 설정이 있는 사이트를 origin 순서로 반환함
+func (s *Store) All() []Site {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sites := make([]Site, 0, len(s.sites))
+	for origin, settings := range s.sites {
+		sites = append(sites, Site{Origin: origin, Settings: settings})
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].Origin < sites[j].Origin })
+	return sites
+}
+
+// Save는 설정을 파일에 씀
+//
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰는 도중 종료되어도 기존 파일이 깨지지 않음
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.sites, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("사이트별 설정 저장 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("사이트별 설정 저장 실패: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("사이트별 설정 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("사이트별 설정 저장 실패: %w", err)
+	}
+	return nil
+}
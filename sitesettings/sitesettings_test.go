@@ -0,0 +1,71 @@
+package sitesettings_test
+
+import (
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestOrigin 기본 포트는 생략하고 호스트가 없는 스킴은 스킴만 씀
+func TestOrigin(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"http://Example.com/a/b?q=1", "http://example.com"},
+		{"https://example.com:443/", "https://example.com"},
+		{"http://example.com:8080/x", "http://example.com:8080"},
+		{"https://example.com:80/", "https://example.com:80"},
+		{"file:///tmp/index.html", "file:"},
+	}
+	for _, tt := range tests {
+		u, err := url.NewURL(tt.input)
+		if err != nil {
+			t.Fatalf("NewURL(%q) failed: %v", tt.input, err)
+		}
+		if got := sitesettings.Origin(u); got != tt.want {
+			t.Errorf("Origin(%q) = %q; want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestStore_SetAndReopen 바꾼 설정이 파일에 저장되고, 기본값으로 되돌리면 지워짐
+func TestStore_SetAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", sitesettings.FileName)
+	s, err := sitesettings.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	if err := s.Set("https://b.example", sitesettings.Settings{DisableImages: true, Zoom: 1.5}); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if err := s.Set("http://a.example", sitesettings.Settings{AllowInsecure: true}); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	reopened, err := sitesettings.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	want := []sitesettings.Site{
+		{Origin: "http://a.example", Settings: sitesettings.Settings{AllowInsecure: true}},
+		{Origin: "https://b.example", Settings: sitesettings.Settings{DisableImages: true, Zoom: 1.5}},
+	}
+	if got := reopened.All(); !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %+v; want %+v", got, want)
+	}
+
+	u, _ := url.NewURL("https://b.example/page")
+	if got := reopened.For(u); !got.DisableImages {
+		t.Errorf("For(%q) = %+v; want DisableImages", u, got)
+	}
+
+	if err := reopened.Set("http://a.example", sitesettings.Settings{}); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if got := len(reopened.All()); got != 1 {
+		t.Errorf("기본값으로 되돌린 뒤 len(All()) = %d; want 1", got)
+	}
+}
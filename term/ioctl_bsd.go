@@ -0,0 +1,13 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the termios ioctl request numbers for BSD-derived systems.
+package term
+
+import "syscall"
+
+// termios 조회/설정 ioctl 요청 번호
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
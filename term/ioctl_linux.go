@@ -0,0 +1,11 @@
+// Package term provides terminal capability detection for the browser.
+// This file contains the termios ioctl request numbers for Linux.
+package term
+
+import "syscall"
+
+// termios 조회/설정 ioctl 요청 번호
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
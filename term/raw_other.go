@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the raw mode fallback for platforms without termios.
+package term
+
+import "errors"
+
+// makeRaw는 termios가 없는 플랫폼에서는 지원하지 않음
+func makeRaw(fd uintptr) (restore func() error, err error) {
+	return nil, errors.New("이 플랫폼에서는 raw 모드를 지원하지 않습니다")
+}
@@ -0,0 +1,46 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+// Package term provides terminal capability detection for the browser.
+// This file contains termios-based raw mode for Unix systems.
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw는 터미널을 raw 모드로 바꾸고 원래 설정을 되돌리는 함수를 반환함
+//
+// 줄 단위 입력(ICANON), 에코(ECHO), Ctrl+C 시그널(ISIG) 등을 끄고 한 바이트씩 읽음
+// 출력 후처리(OPOST)는 그대로 둬서 "\n"이 줄 처음으로 이동하도록 함
+func makeRaw(fd uintptr) (restore func() error, err error) {
+	var old syscall.Termios
+	if err := termiosIoctl(fd, ioctlGetTermios, &old); err != nil {
+		return nil, ErrNotTerminal
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := termiosIoctl(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return termiosIoctl(fd, ioctlSetTermios, &old)
+	}, nil
+}
+
+// termiosIoctl은 termios 구조체를 읽거나 쓰는 ioctl을 호출함
+func termiosIoctl(fd, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
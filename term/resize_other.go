@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the resize notification fallback for platforms without SIGWINCH.
+package term
+
+// WatchResize는 SIGWINCH가 없는 플랫폼에서는 아무것도 하지 않음
+//
+// Windows 콘솔은 크기 변경 시그널이 없으므로 다음 렌더링 때 Size로 다시 조회해야 함
+func WatchResize(fd uintptr, onResize func(width, height int)) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,39 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+// Package term provides terminal capability detection for the browser.
+// This file contains SIGWINCH-based resize notification for Unix systems.
+package term
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WatchResize는 터미널 크기가 바뀔 때마다(SIGWINCH) onResize를 새 크기로 호출함
+//
+// 대화형 모드에서 창 크기가 바뀌면 다시 레이아웃하기 위해 사용함
+// 반환된 stop 함수를 호출하면 감시를 중단함
+func WatchResize(fd uintptr, onResize func(width, height int)) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigCh, syscall.SIGWINCH)
+
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				if width, height, err := Size(fd); err == nil {
+					onResize(width, height)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
@@ -0,0 +1,77 @@
+// Package term provides terminal capability detection for the browser.
+// This file contains the sanitizing of page text so pages cannot send control sequences to the terminal.
+package term
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// controlPictures는 C0 제어 문자를 대신 보여주는 유니코드 제어 그림 문자의 시작 (U+2400 ␀, ESC는 U+241B ␛)
+const controlPictures = 0x2400
+
+// Sanitize는 페이지 글에 들어 있는 제어 문자를 눈에 보이는 기호로 바꿈
+//
+// 페이지가 ESC 시퀀스로 화면을 지우거나 색, 창 제목, 클립보드를 바꾸지 못하게 함
+//
+//   - C0 제어 문자(ESC, CR, BEL 등): 제어 그림 문자 (ESC → ␛, CR → ␍)
+//   - DEL: ␡
+//   - C1 제어 문자(U+0080~U+009F, 8비트 CSI 포함)와 잘못된 UTF-8 바이트: U+FFFD
+//
+// 줄바꿈(\n)과 탭(\t)은 그대로 둠
+func Sanitize(s string) string {
+	return mapControls(s, true)
+}
+
+// StripControls는 Sanitize처럼 제어 문자를 막되 기호 대신 지움
+//
+// 레이아웃이 폭 0으로 센 제어 문자를 지우므로 이미 줄을 나눈 글(대화형 모드의 화면)에 씀
+func StripControls(s string) string {
+	return mapControls(s, false)
+}
+
+// mapControls는 제어 문자를 visualize면 기호로 바꾸고 아니면 지움
+func mapControls(s string, visualize bool) string {
+	if safeText(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r < 0x20:
+			if visualize {
+				b.WriteRune(controlPictures + r)
+			}
+		case r == 0x7F:
+			if visualize {
+				b.WriteRune('␡')
+			}
+		case r >= 0x80 && r < 0xA0:
+			if visualize {
+				b.WriteRune(utf8.RuneError)
+			}
+		default:
+			// 잘못된 UTF-8 바이트는 range가 utf8.RuneError로 바꿔 줌
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// safeText는 s에 바꿀 글자가 없는지 확인함 (대부분의 글은 복사 없이 그대로 씀)
+func safeText(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 0x20 && c != '\n' && c != '\t') || c == 0x7F {
+			return false
+		}
+		// C1 제어 문자는 UTF-8로 0xC2 0x80~0x9F
+		if c == 0xC2 && i+1 < len(s) && s[i+1] < 0xA0 {
+			return false
+		}
+	}
+	return utf8.ValidString(s)
+}
@@ -0,0 +1,27 @@
+package term
+
+import "testing"
+
+// TestSanitize 제어 문자는 기호로 바꾸거나 지우고, 줄바꿈, 탭과 보통 글은 그대로 둠
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		input     string
+		sanitized string
+		stripped  string
+	}{
+		{"안녕 hello\n\tworld", "안녕 hello\n\tworld", "안녕 hello\n\tworld"},
+		{"a\x1b[2Jb", "a␛[2Jb", "a[2Jb"},
+		{"\x1b]0;title\x07x", "␛]0;title␇x", "]0;titlex"},
+		{"over\rwrite\x7f", "over␍write␡", "overwrite"},
+		{"c1 \u009b31m", "c1 �31m", "c1 31m"},
+		{"bad \xff byte", "bad � byte", "bad � byte"},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.input); got != tt.sanitized {
+			t.Errorf("Sanitize(%q) = %q; want %q", tt.input, got, tt.sanitized)
+		}
+		if got := StripControls(tt.input); got != tt.stripped {
+			t.Errorf("StripControls(%q) = %q; want %q", tt.input, got, tt.stripped)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly || windows)
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the fallback for platforms without terminal size support.
+package term
+
+// getSize는 지원하지 않는 플랫폼에서 항상 ErrNotTerminal을 반환함
+func getSize(fd uintptr) (width, height int, err error) {
+	return 0, 0, ErrNotTerminal
+}
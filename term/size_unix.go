@@ -0,0 +1,28 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the ioctl-based terminal size query for Unix systems.
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize는 TIOCGWINSZ ioctl이 채워주는 커널 구조체 (struct winsize)
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+// getSize는 TIOCGWINSZ ioctl로 터미널 크기를 조회함
+func getSize(fd uintptr) (width, height int, err error) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, 0, ErrNotTerminal
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+// Package term provides terminal capability detection for the browser.
+// This file contains the console size query for Windows.
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+)
+
+// coord, smallRect, consoleScreenBufferInfo는 Win32 CONSOLE_SCREEN_BUFFER_INFO 구조체
+type coord struct {
+	X, Y int16
+}
+
+type smallRect struct {
+	Left, Top, Right, Bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	Size              coord
+	CursorPosition    coord
+	Attributes        uint16
+	Window            smallRect
+	MaximumWindowSize coord
+}
+
+// getSize는 GetConsoleScreenBufferInfo로 현재 콘솔 창 크기를 조회함
+func getSize(fd uintptr) (width, height int, err error) {
+	var info consoleScreenBufferInfo
+	ret, _, _ := procGetConsoleScreenBufferInfo.Call(fd, uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return 0, 0, ErrNotTerminal
+	}
+	width = int(info.Window.Right-info.Window.Left) + 1
+	height = int(info.Window.Bottom-info.Window.Top) + 1
+	return width, height, nil
+}
@@ -0,0 +1,54 @@
+// Package term provides terminal capability detection for the browser.
+// This file contains the one-line status display that is overwritten in place.
+package term
+
+import (
+	"io"
+	"sync"
+)
+
+// StatusLine은 터미널의 한 줄을 제자리에서 계속 덮어쓰는 상태 표시 (페이지 로딩 진행 상황 등)
+//
+// 본문은 StatusLine에 Write로 써야 함 (쓰기 전에 상태 줄을 지워서 본문과 섞이지 않게 함)
+// 여러 고루틴에서 동시에 써도 됨
+type StatusLine struct {
+	w     io.Writer
+	mu    sync.Mutex
+	shown bool // 지금 상태 줄이 보이는지
+}
+
+// NewStatusLine은 w(터미널)에 그리는 StatusLine을 만듦
+func NewStatusLine(w io.Writer) *StatusLine {
+	return &StatusLine{w: w}
+}
+
+// Set은 상태 줄을 text로 바꿈 (text는 터미널 폭보다 짧아야 함, 줄이 넘어가면 덮어쓸 수 없음)
+func (s *StatusLine) Set(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	io.WriteString(s.w, "\r"+text+"\x1b[K")
+	s.shown = true
+}
+
+// Clear는 상태 줄을 지우고 커서를 줄 처음으로 옮김
+func (s *StatusLine) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear()
+}
+
+// Write는 상태 줄을 지우고 p를 씀
+func (s *StatusLine) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear()
+	return s.w.Write(p)
+}
+
+// clear는 상태 줄이 보이면 지움 (s.mu를 잠근 채로 부름)
+func (s *StatusLine) clear() {
+	if s.shown {
+		io.WriteString(s.w, "\r\x1b[K")
+		s.shown = false
+	}
+}
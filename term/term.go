@@ -0,0 +1,54 @@
+// Package term provides terminal capability detection for the browser.
+// This file contains the platform-independent terminal size API.
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrNotTerminal은 파일 디스크립터가 터미널이 아닐 때 반환됨 (파이프, 파일 리다이렉트 등)
+var ErrNotTerminal = errors.New("터미널이 아닙니다")
+
+// Size는 fd가 가리키는 터미널의 열(width)과 행(height) 수를 반환함
+//
+// 터미널이 아니거나 크기를 알 수 없으면 ErrNotTerminal 반환
+func Size(fd uintptr) (width, height int, err error) {
+	width, height, err = getSize(fd)
+	if err != nil {
+		return 0, 0, err
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, ErrNotTerminal
+	}
+	return width, height, nil
+}
+
+// Width는 stdout 터미널의 폭을 반환하고, 알 수 없으면 fallback을 반환함
+func Width(fallback int) int {
+	width, _, err := Size(os.Stdout.Fd())
+	if err != nil {
+		return fallback
+	}
+	return width
+}
+
+// IsTerminal은 f가 터미널(문자 장치)에 연결되어 있는지 확인함
+//
+// 파이프나 파일로 리다이렉트된 경우 false를 반환하므로
+// grep 같은 도구와 함께 쓸 때 상태 메시지를 끄는 데 사용함
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// MakeRaw는 fd 터미널을 raw 모드(키 입력을 한 바이트씩, 에코 없이)로 바꿈
+//
+// 대화형 모드에서 키를 바로 처리하기 위해 사용함
+// 반환된 restore를 반드시 호출해서 원래 터미널 설정으로 되돌려야 함
+func MakeRaw(fd uintptr) (restore func() error, err error) {
+	return makeRaw(fd)
+}
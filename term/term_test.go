@@ -0,0 +1,68 @@
+package term
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestSize_NotTerminal 파이프는 터미널이 아니므로 ErrNotTerminal
+func TestSize_NotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() failed: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	_, _, err = Size(w.Fd())
+	if !errors.Is(err, ErrNotTerminal) {
+		t.Errorf("Size(pipe) error = %v; want ErrNotTerminal", err)
+	}
+}
+
+// TestWatchResize_Stop 감시 중단이 패닉 없이 동작
+func TestWatchResize_Stop(t *testing.T) {
+	stop := WatchResize(os.Stdout.Fd(), func(width, height int) {})
+	stop()
+}
+
+// TestIsTerminal_Pipe 파이프와 일반 파일은 터미널이 아님
+func TestIsTerminal_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() failed: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if IsTerminal(w) {
+		t.Error("IsTerminal(pipe) = true; want false")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("os.CreateTemp() failed: %v", err)
+	}
+	defer f.Close()
+
+	if IsTerminal(f) {
+		t.Error("IsTerminal(file) = true; want false")
+	}
+}
+
+// TestStatusLine 상태 줄은 제자리에서 덮어쓰고, 본문을 쓰기 전에 지움
+func TestStatusLine(t *testing.T) {
+	var b strings.Builder
+	s := NewStatusLine(&b)
+	s.Set("연결 중")
+	s.Set("받는 중")
+	s.Write([]byte("본문\n"))
+	s.Clear() // 이미 지웠으므로 아무것도 쓰지 않음
+
+	want := "\r연결 중\x1b[K\r받는 중\x1b[K\r\x1b[K본문\n"
+	if got := b.String(); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Package theme defines color themes for the interactive terminal UI.
+// This file contains conversion of color names to RGB for image output.
+package theme
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ansiRGB는 ANSI 16색의 RGB 값 (xterm 기본 팔레트)
+var ansiRGB = [16]color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0xcd, 0x00, 0x00, 0xff}, {0x00, 0xcd, 0x00, 0xff}, {0xcd, 0xcd, 0x00, 0xff},
+	{0x00, 0x00, 0xee, 0xff}, {0xcd, 0x00, 0xcd, 0xff}, {0x00, 0xcd, 0xcd, 0xff}, {0xe5, 0xe5, 0xe5, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff}, {0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0xff}, {0xff, 0xff, 0x00, 0xff},
+	{0x5c, 0x5c, 0xff, 0xff}, {0xff, 0x00, 0xff, 0xff}, {0x00, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// RGB는 색 이름을 RGB 값으로 바꿈 ("default"이거나 잘못된 이름이면 ok = false)
+func RGB(name string) (c color.RGBA, ok bool) {
+	switch {
+	case strings.HasPrefix(name, "#") && len(name) == 7:
+		v, err := strconv.ParseUint(name[1:], 16, 32)
+		if err != nil {
+			return c, false
+		}
+		return colorRGBA(uint32(v)), true
+	case strings.HasPrefix(name, "bright-"):
+		if n, ok := ansiColors[strings.TrimPrefix(name, "bright-")]; ok {
+			return ansiRGB[8+n], true
+		}
+	default:
+		if n, ok := ansiColors[name]; ok {
+			return ansiRGB[n], true
+		}
+		if n, err := strconv.Atoi(name); err == nil && n >= 0 && n <= 255 {
+			return xterm256(n), true
+		}
+	}
+	return c, false
+}
+
+// colorRGBA는 0xrrggbb 값을 불투명한 RGBA로 바꿈
+func colorRGBA(rgb uint32) color.RGBA {
+	return color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 0xff}
+}
+
+// xterm256은 256색 번호의 RGB 값 (0~15는 ANSI 16색, 16~231은 6x6x6 색 큐브, 232~255는 회색조)
+func xterm256(n int) color.RGBA {
+	switch {
+	case n < 16:
+		return ansiRGB[n]
+	case n < 232:
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + 40*v)
+		}
+		n -= 16
+		return color.RGBA{level(n / 36), level(n / 6 % 6), level(n % 6), 0xff}
+	default:
+		g := uint8(8 + 10*(n-232))
+		return color.RGBA{g, g, g, 0xff}
+	}
+}
+
+// Colors는 role의 글자색과 배경색 (Reverse면 서로 바꿈)
+//
+// "default"는 테마의 기본 색 (어두운 테마는 검은 배경에 밝은 회색 글자)
+func (t *Theme) Colors(role Role) (fg, bg color.RGBA) {
+	fg, bg = color.RGBA{0xd0, 0xd0, 0xd0, 0xff}, color.RGBA{0x00, 0x00, 0x00, 0xff}
+	if t.light {
+		fg, bg = color.RGBA{0x00, 0x00, 0x00, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}
+	}
+	for _, s := range []Style{t.styles[Text], t.styles[role]} {
+		if c, ok := RGB(s.Fg); ok {
+			fg = c
+		}
+		if c, ok := RGB(s.Bg); ok {
+			bg = c
+		}
+	}
+	if t.styles[role].Reverse {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
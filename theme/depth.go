@@ -0,0 +1,89 @@
+// Package theme defines color themes for the interactive terminal UI.
+// This file contains terminal color depth detection and 256-color approximation.
+package theme
+
+import (
+	"image/color"
+	"math"
+	"os"
+	"strings"
+)
+
+// Depth는 터미널이 표시할 수 있는 색의 수
+type Depth int
+
+// 색 깊이
+const (
+	TrueColor Depth = iota // 24비트 색 (#rrggbb를 그대로 보냄)
+	Colors256              // xterm 256색 (#rrggbb는 가장 가까운 번호로 바꿈)
+	NoColor                // 색 없음 (굵게, 밑줄 같은 글자 모양만)
+)
+
+// DetectDepth는 환경 변수로 터미널의 색 깊이를 추정함
+//
+//   - NO_COLOR가 있으면 NoColor (https://no-color.org)
+//   - COLORTERM이 truecolor 또는 24bit이면 TrueColor
+//   - 그 외에는 Colors256 (대부분의 터미널이 지원함)
+func DetectDepth() Depth {
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	return Colors256
+}
+
+// cubeLevels는 256색 6x6x6 색 큐브의 채널 값
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// Nearest256은 c와 가장 가까운 xterm 256색 번호 (16~255 중, 기본 16색은 터미널마다 달라서 쓰지 않음)
+func Nearest256(c color.RGBA) int {
+	level := func(v uint8) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if math.Abs(float64(int(v)-l)) < math.Abs(float64(int(v)-cubeLevels[best])) {
+				best = i
+			}
+		}
+		return best
+	}
+	r, g, b := level(c.R), level(c.G), level(c.B)
+	cube := 16 + 36*r + 6*g + b
+
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	gray := 232 + min(max((avg-3)/10, 0), 23)
+
+	if distance(c, xterm256(gray)) < distance(c, xterm256(cube)) {
+		return gray
+	}
+	return cube
+}
+
+// distance는 두 색 사이의 거리의 제곱 (RGB 공간)
+func distance(a, b color.RGBA) int {
+	dr, dg, db := int(a.R)-int(b.R), int(a.G)-int(b.G), int(a.B)-int(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+// Contrast는 두 색의 명암비 (WCAG 2 정의, 1~21)
+func Contrast(a, b color.RGBA) float64 {
+	la, lb := luminance(a), luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// luminance는 색의 상대 휘도 (0~1)
+func luminance(c color.RGBA) float64 {
+	linear := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
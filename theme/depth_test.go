@@ -0,0 +1,78 @@
+package theme_test
+
+import (
+	"go-web-browser/theme"
+	"image/color"
+	"testing"
+)
+
+// TestStyle_SGRDepth 색 깊이에 따라 #rrggbb를 그대로, 256색 번호로, 또는 빼고 보냄
+func TestStyle_SGRDepth(t *testing.T) {
+	style := theme.Style{Fg: "#ff8000", Bg: "blue", Bold: true}
+	tests := []struct {
+		depth theme.Depth
+		want  string
+	}{
+		{theme.TrueColor, "\x1b[1;38;2;255;128;0;44m"},
+		{theme.Colors256, "\x1b[1;38;5;208;44m"},
+		{theme.NoColor, "\x1b[1m"},
+	}
+
+	for _, tt := range tests {
+		if got := style.SGRDepth(tt.depth); got != tt.want {
+			t.Errorf("%+v.SGRDepth(%d) = %q; want %q", style, tt.depth, got, tt.want)
+		}
+	}
+}
+
+// TestNearest256 색 큐브와 회색조 중 가까운 번호
+func TestNearest256(t *testing.T) {
+	tests := []struct {
+		c    color.RGBA
+		want int
+	}{
+		{color.RGBA{0, 0, 0, 0xff}, 16},
+		{color.RGBA{0xff, 0xff, 0xff, 0xff}, 231},
+		{color.RGBA{0xff, 0x00, 0x00, 0xff}, 196},
+		{color.RGBA{0x33, 0x33, 0x33, 0xff}, 236},
+		{color.RGBA{0x87, 0xaf, 0xd7, 0xff}, 110},
+	}
+
+	for _, tt := range tests {
+		if got := theme.Nearest256(tt.c); got != tt.want {
+			t.Errorf("Nearest256(%v) = %d; want %d", tt.c, got, tt.want)
+		}
+	}
+}
+
+// TestContrast 검은색과 흰색은 21, 같은 색은 1
+func TestContrast(t *testing.T) {
+	black, white := color.RGBA{0, 0, 0, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}
+	if got := theme.Contrast(black, white); got < 20.9 || got > 21.1 {
+		t.Errorf("Contrast(black, white) = %v; want 21", got)
+	}
+	if got := theme.Contrast(white, white); got != 1 {
+		t.Errorf("Contrast(white, white) = %v; want 1", got)
+	}
+}
+
+// TestDetectDepth NO_COLOR, COLORTERM으로 색 깊이 선택
+func TestDetectDepth(t *testing.T) {
+	tests := []struct {
+		noColor, colorterm string
+		want               theme.Depth
+	}{
+		{"", "", theme.Colors256},
+		{"", "truecolor", theme.TrueColor},
+		{"", "24bit", theme.TrueColor},
+		{"1", "truecolor", theme.NoColor},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("NO_COLOR", tt.noColor)
+		t.Setenv("COLORTERM", tt.colorterm)
+		if got := theme.DetectDepth(); got != tt.want {
+			t.Errorf("DetectDepth() with NO_COLOR=%q COLORTERM=%q = %d; want %d", tt.noColor, tt.colorterm, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package theme defines color themes for the interactive terminal UI.
+// This file contains styles, roles and the built-in presets.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Role은 화면 요소의 종류 (종류마다 Style이 하나씩 있음)
+type Role int
+
+// 화면 요소 종류
+const (
+	Text        Role = iota // 본문
+	Link                    // 링크
+	VisitedLink             // 방문한 링크
+	Heading                 // 제목 (h1~h6)
+	Code                    // 코드 블록, 인라인 코드
+	Selection               // 선택 표시 (링크 힌트 라벨)
+	Status                  // 상태 줄
+	roleCount
+)
+
+// roleNames는 설정 파일에서 쓰는 Role 이름
+var roleNames = map[string]Role{
+	"text":         Text,
+	"link":         Link,
+	"visited-link": VisitedLink,
+	"heading":      Heading,
+	"code":         Code,
+	"selection":    Selection,
+	"status":       Status,
+}
+
+// Style은 글자색, 배경색, 글자 모양
+//
+// 색은 "default", ANSI 색 이름("red", "bright-blue"), 256색 번호("208"), "#rrggbb" 중 하나
+type Style struct {
+	Fg        string `json:"fg,omitempty"`
+	Bg        string `json:"bg,omitempty"`
+	Bold      bool   `json:"bold,omitempty"`
+	Italic    bool   `json:"italic,omitempty"`
+	Underline bool   `json:"underline,omitempty"`
+	Reverse   bool   `json:"reverse,omitempty"`
+}
+
+// Theme은 이름과 Role별 Style
+type Theme struct {
+	Name   string
+	Depth  Depth // 이스케이프 시퀀스로 보낼 색 깊이 (기본값 TrueColor)
+	styles [roleCount]Style
+	light  bool // 밝은 배경용 테마 ("default" 색을 RGB로 바꿀 때 사용)
+}
+
+// Style은 role의 Style을 반환함
+func (t *Theme) Style(role Role) Style {
+	return t.styles[role]
+}
+
+// SGR은 role을 그리기 시작할 때 쓰는 이스케이프 시퀀스 (꾸밈이 없으면 "")
+func (t *Theme) SGR(role Role) string {
+	return t.styles[role].SGRDepth(t.Depth)
+}
+
+// StyleSGR은 s를 테마의 색 깊이로 그리는 이스케이프 시퀀스 (페이지 CSS 색을 덮어쓴 Style 등)
+func (t *Theme) StyleSGR(s Style) string {
+	return s.SGRDepth(t.Depth)
+}
+
+// ansiColors는 ANSI 기본 8색의 번호
+var ansiColors = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3,
+	"blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+}
+
+// colorCodes는 색 하나를 SGR 파라미터로 바꿈 (base는 글자색 30, 배경색 40)
+//
+// depth가 Colors256이면 #rrggbb는 가장 가까운 256색 번호로, NoColor면 색을 빼고 바꿈
+func colorCodes(color string, base int, depth Depth) ([]string, error) {
+	switch {
+	case color == "" || color == "default":
+		return nil, nil
+	case strings.HasPrefix(color, "#") && len(color) == 7:
+		rgb, err := strconv.ParseUint(color[1:], 16, 32)
+		if err != nil {
+			break
+		}
+		switch depth {
+		case NoColor:
+			return nil, nil
+		case Colors256:
+			n := Nearest256(colorRGBA(uint32(rgb)))
+			return []string{strconv.Itoa(base + 8), "5", strconv.Itoa(n)}, nil
+		}
+		return []string{strconv.Itoa(base + 8), "2",
+			strconv.Itoa(int(rgb >> 16)), strconv.Itoa(int(rgb >> 8 & 0xff)), strconv.Itoa(int(rgb & 0xff))}, nil
+	case strings.HasPrefix(color, "bright-"):
+		if n, ok := ansiColors[strings.TrimPrefix(color, "bright-")]; ok {
+			if depth == NoColor {
+				return nil, nil
+			}
+			return []string{strconv.Itoa(base + 60 + n)}, nil
+		}
+	default:
+		n, ok := ansiColors[color]
+		if !ok {
+			var err error
+			if n, err = strconv.Atoi(color); err != nil || n < 0 || n > 255 {
+				break
+			}
+		}
+		switch {
+		case depth == NoColor:
+			return nil, nil
+		case ok:
+			return []string{strconv.Itoa(base + n)}, nil
+		}
+		return []string{strconv.Itoa(base + 8), "5", color}, nil
+	}
+	return nil, fmt.Errorf("알 수 없는 색: %q", color)
+}
+
+// validate는 Style의 색 이름이 올바른지 확인함
+func (s Style) validate() error {
+	if _, err := colorCodes(s.Fg, 30, TrueColor); err != nil {
+		return err
+	}
+	_, err := colorCodes(s.Bg, 40, TrueColor)
+	return err
+}
+
+// SGR은 Style을 SGR 이스케이프 시퀀스로 바꿈 (꾸밈이 없으면 "")
+//
+// 잘못된 색은 무시함 (Resolve에서 미리 검사함)
+func (s Style) SGR() string {
+	return s.SGRDepth(TrueColor)
+}
+
+// SGRDepth는 SGR과 같지만 색을 depth에 맞게 바꿈
+func (s Style) SGRDepth(depth Depth) string {
+	var codes []string
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+	if s.Italic {
+		codes = append(codes, "3")
+	}
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+	if s.Reverse {
+		codes = append(codes, "7")
+	}
+	fg, _ := colorCodes(s.Fg, 30, depth)
+	bg, _ := colorCodes(s.Bg, 40, depth)
+	codes = append(append(codes, fg...), bg...)
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// Dark은 어두운 배경용 기본 테마
+var Dark = Theme{Name: "dark", styles: [roleCount]Style{
+	Link:        {Fg: "bright-cyan", Underline: true},
+	VisitedLink: {Fg: "bright-magenta", Underline: true},
+	Heading:     {Fg: "bright-yellow", Bold: true},
+	Code:        {Fg: "bright-green"},
+	Selection:   {Fg: "black", Bg: "yellow", Bold: true},
+	Status:      {Reverse: true},
+}}
+
+// Light는 밝은 배경용 기본 테마
+var Light = Theme{Name: "light", light: true, styles: [roleCount]Style{
+	Link:        {Fg: "blue", Underline: true},
+	VisitedLink: {Fg: "magenta", Underline: true},
+	Heading:     {Fg: "red", Bold: true},
+	Code:        {Fg: "green"},
+	Selection:   {Fg: "black", Bg: "bright-yellow", Bold: true},
+	Status:      {Reverse: true},
+}}
+
+// Presets는 이름별 기본 테마
+var Presets = map[string]Theme{
+	"dark":  Dark,
+	"light": Light,
+}
+
+// Auto는 테마 이름 대신 쓰는 값 (터미널 배경색에 따라 dark/light 선택)
+const Auto = "auto"
+
+// Resolve는 테마 이름("" 또는 "auto"면 자동 감지)에 Role별 덮어쓰기를 적용한 테마를 만듦
+//
+// overrides의 키는 Role 이름 ("link", "heading" 등), 값은 그 Role의 Style 전체
+func Resolve(name string, overrides map[string]Style) (Theme, error) {
+	if name == "" || name == Auto {
+		name = Detect()
+	}
+	t, ok := Presets[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("알 수 없는 테마: %q (%s 중 하나)", name, strings.Join(presetNames(), ", "))
+	}
+
+	for roleName, style := range overrides {
+		role, ok := roleNames[roleName]
+		if !ok {
+			return Theme{}, fmt.Errorf("알 수 없는 테마 항목: %q", roleName)
+		}
+		if err := style.validate(); err != nil {
+			return Theme{}, fmt.Errorf("테마 항목 %q: %w", roleName, err)
+		}
+		t.styles[role] = style
+	}
+	return t, nil
+}
+
+// presetNames는 기본 테마 이름들과 auto
+func presetNames() []string {
+	names := []string{Auto}
+	for name := range Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Detect는 터미널 배경색을 추정해서 "dark" 또는 "light"를 반환함
+//
+// 많은 터미널(rxvt, Konsole, iTerm2 등)이 설정하는 COLORFGBG("글자색;배경색")를 보고,
+// 알 수 없으면 "dark"
+func Detect() string {
+	if dark, ok := darkBackground(os.Getenv("COLORFGBG")); ok && !dark {
+		return "light"
+	}
+	return "dark"
+}
+
+// darkBackground는 COLORFGBG 값의 마지막 필드(배경색 번호)로 배경이 어두운지 판단함
+func darkBackground(colorfgbg string) (dark, ok bool) {
+	fields := strings.Split(colorfgbg, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	// 7(흰색)과 9~15(밝은 색, 8은 회색)는 밝은 배경
+	return !(bg == 7 || bg >= 9), true
+}
@@ -0,0 +1,130 @@
+package theme_test
+
+import (
+	"go-web-browser/theme"
+	"image/color"
+	"testing"
+)
+
+// TestStyle_SGR 글자 모양과 색 이름을 SGR 시퀀스로
+func TestStyle_SGR(t *testing.T) {
+	tests := []struct {
+		style theme.Style
+		want  string
+	}{
+		{theme.Style{}, ""},
+		{theme.Style{Fg: "default"}, ""},
+		{theme.Style{Underline: true}, "\x1b[4m"},
+		{theme.Style{Fg: "red"}, "\x1b[31m"},
+		{theme.Style{Fg: "bright-blue", Bg: "black"}, "\x1b[94;40m"},
+		{theme.Style{Fg: "208", Bold: true}, "\x1b[1;38;5;208m"},
+		{theme.Style{Bg: "#ff8000", Reverse: true}, "\x1b[7;48;2;255;128;0m"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.SGR(); got != tt.want {
+			t.Errorf("%+v.SGR() = %q; want %q", tt.style, got, tt.want)
+		}
+	}
+}
+
+// TestResolve 기본 테마에 항목별 덮어쓰기 적용
+func TestResolve(t *testing.T) {
+	th, err := theme.Resolve("dark", map[string]theme.Style{"heading": {Fg: "magenta"}})
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if got := th.Style(theme.Heading); got != (theme.Style{Fg: "magenta"}) {
+		t.Errorf("Style(Heading) = %+v; want {Fg:magenta}", got)
+	}
+	if got, want := th.Style(theme.Link), theme.Dark.Style(theme.Link); got != want {
+		t.Errorf("Style(Link) = %+v; want %+v (preset unchanged)", got, want)
+	}
+}
+
+// TestResolve_Invalid 알 수 없는 테마, 항목, 색은 에러
+func TestResolve_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]theme.Style
+	}{
+		{"solarized", nil},
+		{"dark", map[string]theme.Style{"sidebar": {Fg: "red"}}},
+		{"dark", map[string]theme.Style{"link": {Fg: "reddish"}}},
+		{"dark", map[string]theme.Style{"link": {Bg: "256"}}},
+		{"dark", map[string]theme.Style{"link": {Fg: "#12345g"}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := theme.Resolve(tt.name, tt.overrides); err == nil {
+			t.Errorf("Resolve(%q, %v) should fail", tt.name, tt.overrides)
+		}
+	}
+}
+
+// TestDetect COLORFGBG의 배경색 번호로 dark/light 선택
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		colorfgbg string
+		want      string
+	}{
+		{"", "dark"},
+		{"15;0", "dark"},
+		{"0;15", "light"},
+		{"0;default;7", "light"},
+		{"7;8", "dark"},
+		{"garbage", "dark"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("COLORFGBG", tt.colorfgbg)
+		if got := theme.Detect(); got != tt.want {
+			t.Errorf("Detect() with COLORFGBG=%q = %q; want %q", tt.colorfgbg, got, tt.want)
+		}
+		th, err := theme.Resolve(theme.Auto, nil)
+		if err != nil {
+			t.Fatalf("Resolve(auto) failed: %v", err)
+		}
+		if th.Name != tt.want {
+			t.Errorf("Resolve(auto) with COLORFGBG=%q = %q; want %q", tt.colorfgbg, th.Name, tt.want)
+		}
+	}
+}
+
+// TestRGB 색 이름을 RGB 값으로
+func TestRGB(t *testing.T) {
+	tests := []struct {
+		name string
+		want color.RGBA
+		ok   bool
+	}{
+		{"red", color.RGBA{0xcd, 0, 0, 0xff}, true},
+		{"bright-white", color.RGBA{0xff, 0xff, 0xff, 0xff}, true},
+		{"#0066cc", color.RGBA{0, 0x66, 0xcc, 0xff}, true},
+		{"196", color.RGBA{0xff, 0, 0, 0xff}, true},
+		{"244", color.RGBA{0x80, 0x80, 0x80, 0xff}, true},
+		{"default", color.RGBA{}, false},
+		{"reddish", color.RGBA{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := theme.RGB(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RGB(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestTheme_Colors 기본 색은 테마의 배경에 따르고, Reverse는 글자색과 배경색을 바꿈
+func TestTheme_Colors(t *testing.T) {
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	if _, bg := theme.Light.Colors(theme.Text); bg != white {
+		t.Errorf("Light.Colors(Text) bg = %v; want white", bg)
+	}
+	if fg, _ := theme.Light.Colors(theme.Status); fg != white {
+		t.Errorf("Light.Colors(Status) fg = %v; want white (reversed)", fg)
+	}
+	if fg, _ := theme.Dark.Colors(theme.Link); fg != (color.RGBA{0, 0xff, 0xff, 0xff}) {
+		t.Errorf("Dark.Colors(Link) fg = %v; want bright-cyan", fg)
+	}
+}
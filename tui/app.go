@@ -0,0 +1,627 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the application state, key handling and main loop.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-web-browser/bookmarks"
+	"go-web-browser/history"
+	"go-web-browser/html"
+	"go-web-browser/layout"
+	"go-web-browser/net"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/sitesettings"
+	"go-web-browser/term"
+	"go-web-browser/theme"
+	"go-web-browser/url"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// mode는 키 입력을 해석하는 방식
+type mode int
+
+const (
+	modeNormal mode = iota // 스크롤, 탐색
+	modeHint               // 링크 힌트 라벨 입력 중
+	modePrompt             // 명령 팔레트(:) 입력 중
+)
+
+// historyEntry는 뒤로 가기 스택의 항목 (페이지와 스크롤 위치)
+type historyEntry struct {
+	page *browser.Page
+	top  int
+}
+
+// tab은 탭 하나의 문서, 스크롤 위치, 뒤로 가기 스택
+type tab struct {
+	doc     *Document
+	top     int     // 화면 맨 위에 보이는 문서 줄 번호
+	zoom    float64 // 확대 배율 (0이면 1, 다른 사이트로 이동하면 그 사이트의 설정 값)
+	history []historyEntry
+}
+
+// App은 전체 화면 대화형 브라우저
+//
+// 키 처리(HandleKey)와 그리기(Draw)를 분리해서 터미널 없이도 테스트할 수 있음
+type App struct {
+	Bindings  Bindings            // 일반 모드의 키 → 명령 (NewApp은 DefaultBindings 사용)
+	Bookmarks *bookmarks.Store    // :bookmark 명령이 쓰는 북마크 저장소
+	History   *history.Store      // 방문 기록 (about:history, 방문한 링크 표시)
+	Sites     *sitesettings.Store // 사이트별 설정 (about:site-settings에서 바꿈, Browser와 같은 저장소를 씀)
+	Cookies   *net.CookieJar      // 쿠키 저장소 (about:cookies에서 보고 지움, nil이면 빈 목록)
+	Theme     theme.Theme         // 화면 색 (NewApp은 theme.Dark 사용)
+
+	// Player는 :play 명령으로 <audio>, <video>를 넘길 외부 플레이어 명령 (예: "mpv", ""이면 :play 불가)
+	Player string
+
+	// Handlers는 스킴 → 그 스킴의 링크를 넘길 외부 프로그램 명령 (mailto:, tel: 등, 없으면 페이지로 보여줌)
+	Handlers map[url.Scheme]string
+
+	// CopyText, OpenExternal은 클립보드 복사와 기본 브라우저로 열기 (NewApp은 운영체제의 명령을 씀)
+	CopyText     func(text string) error
+	OpenExternal func(rawURL string) error
+
+	// SessionFile이 있으면 Run이 주기적으로(SnapshotInterval)와 종료할 때 탭 목록을 저장함
+	SessionFile string
+
+	browser *browser.Browser
+	width   int
+	height  int
+
+	*tab // 지금 보고 있는 탭
+	tabs []*tab
+
+	mode   mode
+	hints  []Hint
+	onHint func(browser.Link) // 힌트로 링크를 골랐을 때 할 일 (따라가기, 주소 복사 등)
+	typed  string             // 힌트 모드에서 지금까지 입력한 글자
+	input  string             // 명령 팔레트에 입력 중인 명령
+	status string             // 상태 줄에 잠깐 표시할 메시지
+	quit   bool
+
+	screen []string // 지난번 Draw가 그린 줄들 (바뀐 줄만 다시 그리기 위해)
+
+	// 페이지를 불러오는 동안(load부터 다음 Draw까지) progress가 바뀌면 out의 상태 줄에 바로 그림
+	// 하위 리소스는 다른 고루틴에서 알려오므로 out에 쓸 때는 drawMu를 잠금
+	progress *net.Progress
+	trace    *net.Trace
+	loading  atomic.Bool
+	out      io.Writer // Run 중인 화면 (Run 밖에서는 nil)
+	drawMu   sync.Mutex
+}
+
+// NewApp은 width x height 크기 화면의 App을 만듦
+//
+// 북마크, 방문 기록, 사이트별 설정은 메모리에만 저장됨 (파일에 저장하려면 Bookmarks, History, Sites를 바꿈)
+func NewApp(b *browser.Browser, width, height int) *App {
+	store, _ := bookmarks.Open("")
+	visits, _ := history.Open("")
+	sites, _ := sitesettings.Open("")
+	first := &tab{}
+	a := &App{
+		Bindings:     DefaultBindings(),
+		Bookmarks:    store,
+		History:      visits,
+		Sites:        sites,
+		Theme:        theme.Dark,
+		CopyText:     systemCopy,
+		OpenExternal: systemOpen,
+		browser:      b,
+		width:        width,
+		height:       height,
+		tab:          first,
+		tabs:         []*tab{first},
+	}
+	a.progress = &net.Progress{OnChange: a.drawProgress}
+	a.trace = a.progress.Trace()
+	return a
+}
+
+// Trace는 페이지를 불러오는 진행 상황을 상태 줄에 보여주는 Trace (net.GlobalTrace나 HTTPFetcher.Trace에 넣음)
+func (a *App) Trace() *net.Trace {
+	return a.trace
+}
+
+// drawProgress는 페이지를 불러오는 중이면 상태 줄을 text로 바로 그림 (다음 Draw가 원래 상태 줄로 되돌림)
+func (a *App) drawProgress(text string) {
+	if !a.loading.Load() {
+		return
+	}
+	a.drawMu.Lock()
+	defer a.drawMu.Unlock()
+	if a.out == nil || len(a.screen) == 0 {
+		return
+	}
+	var b strings.Builder
+	last := len(a.screen) - 1
+	drawStatus(&b, &a.Theme, last, a.width, text)
+	a.screen[last] = "" // 다음 Draw에서 다시 그림
+	io.WriteString(a.out, b.String())
+}
+
+// Document는 지금 보고 있는 문서 (아직 없으면 nil)
+func (a *App) Document() *Document {
+	return a.doc
+}
+
+// Top은 화면 맨 위에 보이는 문서 줄 번호
+func (a *App) Top() int {
+	return a.top
+}
+
+// Status는 상태 줄 메시지
+func (a *App) Status() string {
+	return a.status
+}
+
+// Quit은 종료 키가 눌렸는지 확인함
+func (a *App) Quit() bool {
+	return a.quit
+}
+
+// Hints는 힌트 모드에서 지금 입력과 일치하는 힌트들 (힌트 모드가 아니면 nil)
+func (a *App) Hints() []Hint {
+	if a.mode != modeHint {
+		return nil
+	}
+	return matchHints(a.hints, a.typed)
+}
+
+// viewHeight는 문서를 그릴 수 있는 줄 수 (마지막 줄은 상태 줄)
+func (a *App) viewHeight() int {
+	if a.height <= 1 {
+		return 1
+	}
+	return a.height - 1
+}
+
+// Open은 URL로 이동함 (지금 문서는 뒤로 가기 스택에 쌓임)
+//
+// 외부 프로그램을 정한 스킴(Handlers)이면 이동하지 않고 그 프로그램으로 넘김
+// 가져오지 못하면 다시 시도 링크가 있는 에러 페이지로 이동하고 에러를 반환함 (상태 줄에 표시)
+func (a *App) Open(rawURL string) error {
+	if handled, err := a.handOff(rawURL); handled {
+		return err
+	}
+	page, err := a.load(rawURL)
+	if err != nil {
+		if page = errorPage(rawURL, err); page == nil {
+			return err
+		}
+	}
+	if a.doc != nil {
+		a.history = append(a.history, historyEntry{page: a.doc.Page, top: a.top})
+	}
+	a.show(page, 0)
+	if err != nil {
+		return err
+	}
+	if page.Response.Upgrade == net.UpgradeFallback {
+		a.status = "HTTPS 연결에 실패해서 HTTP로 열었습니다"
+	}
+	a.recordVisit(rawURL)
+	return nil
+}
+
+// errorPage는 rawURL을 가져오다 err로 실패했을 때 보여줄 에러 페이지 (내부 페이지이거나 주소 자체가 잘못됐으면 nil)
+func errorPage(rawURL string, err error) *browser.Page {
+	if _, _, internal := internalAddress(rawURL); internal {
+		return nil
+	}
+	u, parseErr := url.FromUserInput(rawURL)
+	if parseErr != nil {
+		return nil
+	}
+	if cause := errors.Unwrap(err); cause != nil {
+		err = cause
+	}
+	resp := net.ErrorPage(u, err)
+	return &browser.Page{Response: resp, DOM: html.Parse(resp.Body)}
+}
+
+// recordVisit은 방금 연 페이지를 방문 기록에 추가함 (내부 페이지는 제외)
+//
+// 기본 포트, 대소문자, 프래그먼트만 다른 방문이 같은 기록이 되도록 정규화하고 비밀번호는 가려서 저장함
+func (a *App) recordVisit(rawURL string) {
+	if _, _, internal := internalAddress(rawURL); internal {
+		return
+	}
+	entry := history.Entry{Title: a.doc.Title(), URL: a.doc.Page.URL().Normalize().Redacted(), Lang: a.doc.Page.Lang()}
+	if err := a.History.AddEntry(entry); err != nil {
+		a.status = err.Error()
+	}
+}
+
+// load는 내부 페이지는 직접 만들고, 그 외에는 Browser로 가져옴
+func (a *App) load(rawURL string) (*browser.Page, error) {
+	if page, ok, err := a.internalPage(rawURL); ok {
+		return page, err
+	}
+	a.progress.Reset()
+	a.loading.Store(true)
+	return a.browser.Navigate(rawURL)
+}
+
+// reload는 지금 페이지를 다시 가져옴 (스크롤 위치 유지)
+func (a *App) reload() error {
+	if a.doc == nil {
+		return nil
+	}
+	page, err := a.load(a.doc.Page.URL().String())
+	if err != nil {
+		return err
+	}
+	a.show(page, a.top)
+	return nil
+}
+
+// show는 page를 현재 화면 크기와 탭의 확대 배율로 레이아웃해서 top 줄부터 보여줌
+func (a *App) show(page *browser.Page, top int) {
+	a.zoom = a.siteZoom(page)
+	a.doc = newDocument(page, a.width, a.viewHeight(), DrawBorders, a.zoom)
+	a.top = 0
+	a.scroll(top)
+	a.status = ""
+}
+
+// Resize는 화면 크기가 바뀌었을 때 문서를 다시 레이아웃함 (CSS @media 조건도 새 크기로 다시 평가함)
+func (a *App) Resize(width, height int) {
+	a.width, a.height = width, height
+	a.screen = nil // 다음 Draw에서 전체를 다시 그림
+	a.cancelHints()
+	if a.doc != nil {
+		a.show(a.doc.Page, a.top)
+	}
+}
+
+// back은 뒤로 가기 스택의 이전 페이지로 돌아감
+func (a *App) back() error {
+	if len(a.history) == 0 {
+		a.status = "이전 페이지가 없습니다"
+		return nil
+	}
+	prev := a.history[len(a.history)-1]
+	a.history = a.history[:len(a.history)-1]
+	a.show(prev.page, prev.top)
+	return nil
+}
+
+// scroll은 delta 줄만큼 스크롤함 (문서 범위를 벗어나지 않음)
+func (a *App) scroll(delta int) {
+	if a.doc == nil {
+		return
+	}
+	a.top += delta
+	if maxTop := len(a.doc.Lines) - a.viewHeight(); a.top > maxTop {
+		a.top = maxTop
+	}
+	if a.top < 0 {
+		a.top = 0
+	}
+}
+
+// HandleKey는 키 하나를 처리함
+//
+// 일반 모드에서는 Bindings에 연결된 명령을 실행함
+func (a *App) HandleKey(k Key) {
+	switch a.mode {
+	case modeHint:
+		a.handleHintKey(k)
+		return
+	case modePrompt:
+		a.handlePromptKey(k)
+		return
+	}
+
+	a.status = ""
+	if name, ok := a.Bindings[k]; ok {
+		a.Execute(name)
+	}
+}
+
+// Execute는 명령 한 줄(명령 이름과 인자)을 실행함
+//
+// 실패하면 상태 줄에 에러를 표시함
+func (a *App) Execute(line string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	cmd, ok := lookupCommand(fields[0])
+	if !ok {
+		a.status = "알 수 없는 명령: " + fields[0]
+		return
+	}
+	if err := cmd.Run(a, fields[1:]); err != nil {
+		a.status = err.Error()
+	}
+}
+
+// startPrompt는 명령 팔레트를 열고 initial을 미리 입력해 둠
+func (a *App) startPrompt(initial string) {
+	a.mode = modePrompt
+	a.input = initial
+}
+
+// handlePromptKey는 명령 팔레트의 키를 처리함
+//
+// Enter는 실행, Esc는 취소, Tab은 명령 이름 자동 완성
+func (a *App) handlePromptKey(k Key) {
+	switch k {
+	case KeyEsc:
+		a.mode = modeNormal
+	case KeyEnter:
+		a.mode = modeNormal
+		a.Execute(a.input)
+	case KeyBackspace:
+		if a.input == "" {
+			a.mode = modeNormal
+			return
+		}
+		runes := []rune(a.input)
+		a.input = string(runes[:len(runes)-1])
+	case '\t':
+		if strings.Contains(a.input, " ") {
+			return
+		}
+		names := commandNames(a.input)
+		switch len(names) {
+		case 0:
+		case 1:
+			a.input = names[0] + " "
+		default:
+			a.input = commonPrefix(names)
+		}
+	default:
+		if k >= ' ' && k <= unicode.MaxRune {
+			a.input += string(rune(k))
+		}
+	}
+}
+
+// commonPrefix는 names의 공통 접두사 (names는 비어 있지 않음)
+func commonPrefix(names []string) string {
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// startHints는 화면에 보이는 링크마다 라벨을 붙이고 힌트 모드로 들어감 (링크를 고르면 onHint를 부름)
+func (a *App) startHints(onHint func(browser.Link)) {
+	if a.doc == nil {
+		return
+	}
+	a.hints = hintsFor(a.doc.Boxes, a.top, a.viewHeight(), DefaultHintAlphabet)
+	if len(a.hints) == 0 {
+		a.status = "화면에 링크가 없습니다"
+		return
+	}
+	a.mode = modeHint
+	a.onHint = onHint
+	a.typed = ""
+}
+
+// cancelHints는 힌트 모드를 끝냄
+func (a *App) cancelHints() {
+	a.mode = modeNormal
+	a.hints = nil
+	a.onHint = nil
+	a.typed = ""
+}
+
+// handleHintKey는 힌트 모드의 키를 처리함
+//
+// 입력한 글자로 시작하는 라벨만 남기고, 하나로 좁혀지면 그 링크로 onHint를 부름
+func (a *App) handleHintKey(k Key) {
+	switch k {
+	case KeyEsc:
+		a.cancelHints()
+		return
+	case KeyBackspace:
+		if a.typed != "" {
+			a.typed = a.typed[:len(a.typed)-1]
+		}
+		return
+	}
+
+	if k < 0 || k > unicode.MaxRune {
+		return
+	}
+	typed := a.typed + string(unicode.ToLower(rune(k)))
+	matched := matchHints(a.hints, typed)
+	switch {
+	case len(matched) == 0:
+		// 잘못 누른 글자는 무시 (라벨을 다시 입력할 수 있도록)
+		return
+	case len(matched) == 1 && matched[0].Label == typed:
+		onHint := a.onHint
+		a.cancelHints()
+		onHint(a.doc.Links[matched[0].Box.Link])
+	default:
+		a.typed = typed
+	}
+}
+
+// follow는 링크를 따라감 (실패하면 상태 줄에 에러를 표시하고 그대로 머무름)
+func (a *App) follow(link browser.Link) {
+	if link.URL == nil {
+		a.status = fmt.Sprintf("링크 주소를 해석할 수 없습니다: %s", link.Href)
+		return
+	}
+	if err := a.Open(link.URL.String()); err != nil {
+		a.status = err.Error()
+	}
+}
+
+// statusText는 상태 줄 내용 (메시지가 있으면 메시지, 없으면 제목과 위치)
+func (a *App) statusText() string {
+	switch {
+	case a.mode == modeHint:
+		return "링크 선택: " + strings.ToUpper(a.typed) + "  (Esc: 취소)"
+	case a.mode == modePrompt:
+		return ":" + a.input
+	case a.status != "":
+		return a.status
+	case a.doc == nil:
+		return ""
+	}
+	text := fmt.Sprintf("%s  [%d/%d]", a.doc.Title(), a.top+1, len(a.doc.Lines))
+	if sec := a.doc.Page.Response.Security; sec != nil {
+		text = "[" + sec.Indicator() + "] " + text
+	}
+	if zoom := a.Zoom(); zoom != 1 {
+		text = fmt.Sprintf("%s  %d%%", text, int(math.Round(zoom*100)))
+	}
+	if len(a.tabs) > 1 {
+		text = fmt.Sprintf("탭 %d/%d  %s", a.CurrentTab()+1, len(a.tabs), text)
+	}
+	return text
+}
+
+// Draw는 화면을 그림 (깜빡임을 줄이기 위해 한 번에 씀)
+//
+// 지난번에 그린 화면과 비교해서 바뀐 줄만 다시 그림
+// DOM이 바뀌었으면(스크립트, 폼 입력 등) 바뀐 문단만 다시 레이아웃함
+func (a *App) Draw(w io.Writer) error {
+	a.loading.Store(false)
+	a.drawMu.Lock()
+	defer a.drawMu.Unlock()
+	if a.doc != nil && a.doc.Refresh() {
+		a.cancelHints() // 링크 위치가 바뀌었을 수 있음
+		a.scroll(0)     // 문서가 짧아졌으면 스크롤 위치 조정
+	}
+	rows := a.frame()
+
+	var b strings.Builder
+	full := len(a.screen) != len(rows)
+	if full {
+		b.WriteString(clearScreen)
+	}
+	for row, content := range rows {
+		if !full && a.screen[row] == content {
+			continue
+		}
+		b.WriteString(moveTo(row, 0) + clearLine + content)
+	}
+	a.screen = rows
+
+	if a.mode == modePrompt {
+		// 입력 위치에 커서 표시
+		b.WriteString(moveTo(a.height-1, min(layout.StringWidth(a.statusText()), a.width-1)) + showCursor)
+	} else {
+		b.WriteString(hideCursor)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// frame은 화면의 줄마다 그릴 내용 (마지막 줄은 상태 줄)
+func (a *App) frame() []string {
+	rows := make([]strings.Builder, max(a.height, 1))
+	if a.doc != nil {
+		a.doc.MarkVisited(a.History)
+		for row := 0; row < a.viewHeight() && a.top+row < len(a.doc.Lines); row++ {
+			drawLine(&rows[row], &a.Theme, a.doc.Styles, a.doc.Lines[a.top+row])
+		}
+		for _, h := range a.Hints() {
+			row := h.Box.Line - a.top
+			drawHint(&rows[row], &a.Theme, row, h, a.typed)
+		}
+	}
+	last := len(rows) - 1
+	drawStatus(&rows[last], &a.Theme, last, a.width, a.statusText())
+
+	frame := make([]string, len(rows))
+	for i := range rows {
+		frame[i] = rows[i].String()
+	}
+	return frame
+}
+
+// Run은 터미널을 raw 모드로 바꾸고 q를 누를 때까지 키 입력을 처리함
+//
+// 대체 화면을 사용하므로 종료하면 원래 터미널 내용이 그대로 돌아옴
+func (a *App) Run(in, out *os.File) error {
+	restore, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return fmt.Errorf("터미널을 raw 모드로 바꿀 수 없습니다: %w", err)
+	}
+	defer restore()
+
+	io.WriteString(out, enterAltScreen+hideCursor)
+	defer io.WriteString(out, showCursor+exitAltScreen)
+
+	a.drawMu.Lock()
+	a.out = out
+	a.drawMu.Unlock()
+	defer func() {
+		a.drawMu.Lock()
+		a.out = nil
+		a.drawMu.Unlock()
+	}()
+
+	type size struct{ width, height int }
+	resizes := make(chan size, 1)
+	stop := term.WatchResize(out.Fd(), func(width, height int) {
+		// 아직 처리하지 않은 크기 변경이 있으면 버리고 새 크기로 바꿈 (보내는 쪽은 하나뿐이라 비운 칸에 바로 들어감)
+		select {
+		case <-resizes:
+		default:
+		}
+		resizes <- size{width, height}
+	})
+	defer stop()
+
+	keys := make(chan Key)
+	errs := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(in)
+		for {
+			k, err := ReadKey(r)
+			if err != nil {
+				errs <- err
+				return
+			}
+			keys <- k
+		}
+	}()
+
+	snapshots := time.NewTicker(SnapshotInterval)
+	defer snapshots.Stop()
+
+	for !a.quit {
+		if err := a.Draw(out); err != nil {
+			return err
+		}
+		select {
+		case k := <-keys:
+			a.HandleKey(k)
+		case s := <-resizes:
+			a.Resize(s.width, s.height)
+		case <-snapshots.C:
+			if err := a.saveSession(); err != nil {
+				a.status = err.Error()
+			}
+		case err := <-errs:
+			if err != io.EOF {
+				return err
+			}
+			a.quit = true
+		}
+	}
+	return a.saveSession()
+}
@@ -0,0 +1,97 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains key names and the key → command binding map.
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bindings는 키 → 명령 이름 (Commands의 키)
+type Bindings map[Key]string
+
+// keyNames는 설정 파일에서 쓰는 특수 키 이름
+var keyNames = map[string]Key{
+	"Space":     ' ',
+	"Tab":       '\t',
+	"Enter":     KeyEnter,
+	"Esc":       KeyEsc,
+	"Backspace": KeyBackspace,
+	"Up":        KeyUp,
+	"Down":      KeyDown,
+	"Left":      KeyLeft,
+	"Right":     KeyRight,
+	"Home":      KeyHome,
+	"End":       KeyEnd,
+	"PageUp":    KeyPageUp,
+	"PageDown":  KeyPageDown,
+}
+
+// DefaultBindings는 기본 키 바인딩
+func DefaultBindings() Bindings {
+	return Bindings{
+		'j': "scroll-down", KeyDown: "scroll-down", KeyEnter: "scroll-down",
+		'k': "scroll-up", KeyUp: "scroll-up",
+		' ': "page-down", KeyPageDown: "page-down",
+		'u': "page-up", KeyPageUp: "page-up",
+		'<': "top", KeyHome: "top",
+		'>': "bottom", 'G': "bottom", KeyEnd: "bottom",
+		'f': "hints",
+		'g': "prompt-open",
+		'r': "reload",
+		't': "prompt-tabopen",
+		'J': "tabnext", 'K': "tabprev",
+		'd': "tabclose",
+		'b': "back", 'h': "back", KeyLeft: "back", KeyBackspace: "back",
+		'B': "bookmark",
+		'H': "history",
+		'y': "copy-url", 'Y': "copy-link", 'O': "open-external",
+		'+': "zoom-in", '-': "zoom-out", '0': "zoom-reset",
+		':': "palette",
+		'q': "quit",
+	}
+}
+
+// ParseKey는 설정 파일의 키 이름을 Key로 바꿈
+//
+//   - 글자 하나: 그 글자 ("j", "G", ":")
+//   - 특수 키 이름: "Space", "Enter", "Esc", "Up", "PageDown" 등
+//   - "C-x": Ctrl+x
+func ParseKey(name string) (Key, error) {
+	if k, ok := keyNames[name]; ok {
+		return k, nil
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		return Key(r), nil
+	}
+	if rest, ok := strings.CutPrefix(name, "C-"); ok && len(rest) == 1 {
+		c := rest[0] | 0x20 // 소문자로
+		if c >= 'a' && c <= 'z' {
+			return Key(c & 0x1f), nil
+		}
+	}
+	return 0, fmt.Errorf("알 수 없는 키 이름: %q", name)
+}
+
+// Apply는 설정 파일의 키 바인딩(키 이름 → 명령 이름)을 덮어씀
+//
+// 명령 이름이 ""이면 그 키의 바인딩을 해제함
+func (b Bindings) Apply(keys map[string]string) error {
+	for name, command := range keys {
+		k, err := ParseKey(name)
+		if err != nil {
+			return err
+		}
+		if command == "" {
+			delete(b, k)
+			continue
+		}
+		if _, ok := Commands[command]; !ok {
+			return fmt.Errorf("알 수 없는 명령: %q (키 %q)", command, name)
+		}
+		b[k] = command
+	}
+	return nil
+}
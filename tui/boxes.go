@@ -0,0 +1,193 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the block boxes built from computed CSS styles.
+package tui
+
+import (
+	"go-web-browser/css"
+	"go-web-browser/html"
+	"go-web-browser/layout"
+	"strings"
+)
+
+// DrawBorders는 CSS 테두리를 상자 그리기 문자(┌─┐│└┘)로 그릴지 여부
+//
+// false면 테두리는 없는 것으로 보고 margin, padding, 배경만 적용함
+var DrawBorders = false
+
+// UserStyles는 모든 페이지에 사용자 출처로 적용할 스타일시트 (프로필의 user.css, nil이면 없음)
+var UserStyles *css.Stylesheet
+
+// boxEdges는 상자 모델에서 폭을 가지는 속성들 (이 중 하나라도 0이 아니면 상자로 레이아웃함)
+var boxEdges = []string{
+	"margin-top", "margin-right", "margin-bottom", "margin-left",
+	"padding-top", "padding-right", "padding-bottom", "padding-left",
+	"border-top-width", "border-right-width", "border-bottom-width", "border-left-width",
+	"width",
+}
+
+// background는 계산된 스타일의 배경색 ("#rrggbb", 없거나 투명하면 "")
+func background(s css.Style) string {
+	return cssColor(s.Get("background-color"))
+}
+
+// isBox는 n을 따로 레이아웃할 블록 상자로 볼지 확인함
+//
+// 블록 요소(또는 display: block)이면서 margin, padding, 테두리, 폭, 배경 중 하나가 있는 요소
+func (s *styler) isBox(n *html.Node) bool {
+	style := s.computed[n]
+	if style == nil {
+		return false
+	}
+	switch display := style.Get("display"); {
+	case display == "none", display == "inline", display == "" && !html.IsBlock(n.Tag):
+		return false
+	}
+	if background(style) != "" {
+		return true
+	}
+	for _, property := range boxEdges {
+		if s.cells(style, property) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isHidden은 n이 display: none인지 확인함 (좁은 화면용 @media 규칙으로 메뉴를 숨기는 경우 등)
+func (s *styler) isHidden(n *html.Node) bool {
+	return s.computed[n].Get("display") == "none"
+}
+
+// runOptions는 DOM을 텍스트 구간으로 나눌 때의 옵션 (boxes면 상자 요소를 따로 남김)
+func (s *styler) runOptions(boxes bool) html.RunOptions {
+	opts := html.RunOptions{Hidden: s.isHidden}
+	if boxes {
+		opts.Box = s.isBox
+	}
+	return opts
+}
+
+// cells는 속성 값을 칸 수(세로 속성이면 줄 수)로 바꿈
+//
+// 테두리는 폭이 0보다 크면 항상 한 칸 (1px 테두리도 보이도록)
+func (s *styler) cells(style css.Style, property string) int {
+	if strings.HasPrefix(property, "border-") {
+		if px, ok := css.Pixels(style.Get(property), 0); ok && px > 0 {
+			return 1
+		}
+		return 0
+	}
+	vertical := strings.HasSuffix(property, "-top") || strings.HasSuffix(property, "-bottom")
+	return css.Cells(style.Get(property), vertical, s.d.width)
+}
+
+// hasBoxes는 DOM에 상자로 레이아웃할 요소가 있는지 확인함
+func (s *styler) hasBoxes() bool {
+	for n := range s.computed {
+		if s.isBox(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// newBox는 요소 n의 상자 (outer는 바깥 상자의 배경 속성, 테두리 글자에 사용)
+//
+// 문단 요소(p, h1 등)의 세로 margin을 CSS가 정하지 않았으면 한 줄로 둠
+// (상자가 없을 때 문단 앞뒤에 빈 줄이 하나 들어가는 것과 같게)
+func (s *styler) newBox(n *html.Node, outer int) *layout.Box {
+	style := s.computed[n]
+	edges := func(prefix, suffix string) layout.Edges {
+		return layout.Edges{
+			Top:    s.cells(style, prefix+"-top"+suffix),
+			Right:  s.cells(style, prefix+"-right"+suffix),
+			Bottom: s.cells(style, prefix+"-bottom"+suffix),
+			Left:   s.cells(style, prefix+"-left"+suffix),
+		}
+	}
+	b := &layout.Box{
+		Margin:     edges("margin", ""),
+		Border:     edges("border", "-width"),
+		Padding:    edges("padding", ""),
+		Width:      s.cells(style, "width"),
+		BorderAttr: outer,
+		Fill:       outer,
+	}
+	if html.IsParagraph(n.Tag) {
+		if style.Get("margin-top") == "" {
+			b.Margin.Top = 1
+		}
+		if style.Get("margin-bottom") == "" {
+			b.Margin.Bottom = 1
+		}
+	}
+	if bg := background(style); bg != "" {
+		b.Fill = s.attr(SpanStyle{Link: -1, Role: plainStyle.Role, Background: bg})
+	}
+	return b
+}
+
+// fillBox는 b의 내용을 n의 자손으로 채움
+//
+// 상자 자손은 자식 상자가 되고, 그 사이의 텍스트는 이름 없는 상자로 묶임
+func (s *styler) fillBox(b *layout.Box, n *html.Node) *layout.Box {
+	var pending []html.TextRun
+	flush := func() {
+		if anon := s.anonymousBox(pending); anon != nil {
+			b.Children = append(b.Children, anon)
+		}
+		pending = nil
+	}
+	for _, run := range n.TextRunsWith(s.runOptions(true)) {
+		if !run.Box {
+			pending = append(pending, run)
+			continue
+		}
+		flush()
+		b.Children = append(b.Children, s.fillBox(s.newBox(run.Node, b.Fill), run.Node))
+	}
+	flush()
+	return b
+}
+
+// anonymousBox는 상자 사이의 텍스트를 담는 이름 없는 상자 (보이는 텍스트가 없으면 nil)
+//
+// 앞뒤의 줄바꿈은 블록 경계 하나를 빼고 margin-top, margin-bottom이 됨 (이웃 상자의 margin과 겹침)
+func (s *styler) anonymousBox(runs []html.TextRun) *layout.Box {
+	spans := s.spans(runs)
+	breaks := 0
+	for len(spans) > 0 {
+		text := strings.TrimLeft(spans[0].Text, "\n")
+		breaks += len(spans[0].Text) - len(text)
+		if text != "" {
+			spans[0].Text = text
+			break
+		}
+		spans = spans[1:]
+	}
+	trailing := 0
+	for len(spans) > 0 {
+		last := &spans[len(spans)-1]
+		text := strings.TrimRight(last.Text, "\n")
+		trailing += len(last.Text) - len(text)
+		if last.Text = text; text != "" {
+			break
+		}
+		spans = spans[:len(spans)-1]
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	return &layout.Box{Margin: layout.Edges{Top: max(breaks-1, 0), Bottom: max(trailing-1, 0)}, Spans: spans}
+}
+
+// trimEmptyLines는 문서 앞뒤의 빈 줄(margin)을 지움
+func trimEmptyLines(lines []layout.Line) []layout.Line {
+	for len(lines) > 0 && len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
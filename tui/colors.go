@@ -0,0 +1,66 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains page CSS colors drawn on top of the theme.
+package tui
+
+import (
+	"fmt"
+	"go-web-browser/css"
+	"go-web-browser/theme"
+	"image/color"
+)
+
+// MinContrast는 페이지 CSS 글자색을 쓰는 최소 명암비
+//
+// 흰 배경을 가정한 어두운 글자색(#333 등)이 어두운 터미널에서 안 보이지 않도록,
+// 배경과의 명암비가 이보다 낮으면 테마의 글자색을 씀
+const MinContrast = 3.0
+
+// cssColor는 CSS 색 값을 테마 색 이름("#rrggbb")으로 바꿈 (알 수 없거나 투명하면 "")
+func cssColor(value string) string {
+	c, ok := css.ParseColor(value)
+	if !ok || c.A == 0 {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// background는 조각의 배경색 (페이지 CSS 배경색이 없으면 테마의 배경색)
+func (s SpanStyle) background(t *theme.Theme) color.RGBA {
+	if c, ok := theme.RGB(s.Background); ok {
+		return c
+	}
+	_, bg := t.Colors(s.Role)
+	return bg
+}
+
+// readableColor는 페이지 CSS 글자색을 쓸 수 있는지 확인함 (배경과의 명암비가 MinContrast 이상)
+func (s SpanStyle) readableColor(t *theme.Theme) (color.RGBA, bool) {
+	c, ok := theme.RGB(s.Color)
+	return c, ok && theme.Contrast(c, s.background(t)) >= MinContrast
+}
+
+// colors는 조각의 글자색과 배경색 (테마의 색에 페이지 CSS 색을 덮어씀)
+func (s SpanStyle) colors(t *theme.Theme) (fg, bg color.RGBA) {
+	fg, _ = t.Colors(s.Role)
+	if c, ok := s.readableColor(t); ok {
+		fg = c
+	}
+	return fg, s.background(t)
+}
+
+// sgr은 조각을 그리기 시작할 때 쓰는 이스케이프 시퀀스 (테마의 Style에 페이지 CSS 색을 덮어씀)
+//
+// 색은 테마의 색 깊이(theme.Depth)에 맞게 바꾸고, NoColor면 글자 모양만 남음
+func (s SpanStyle) sgr(t *theme.Theme) string {
+	if s.Color == "" && s.Background == "" {
+		return t.SGR(s.Role)
+	}
+	style := t.Style(s.Role)
+	if s.Background != "" {
+		style.Bg = s.Background
+	}
+	if _, ok := s.readableColor(t); ok {
+		style.Fg = s.Color
+	}
+	return t.StyleSGR(style)
+}
@@ -0,0 +1,169 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the command registry used by key bindings and the palette.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"go-web-browser/export"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Command는 키 바인딩이나 명령 팔레트(:)에서 실행하는 동작
+type Command struct {
+	Help string                            // 명령 설명
+	Run  func(a *App, args []string) error // args는 명령 이름 뒤의 단어들
+}
+
+// Commands는 이름별 명령 레지스트리
+var Commands = map[string]Command{
+	"scroll-down":    {"한 줄 아래로", func(a *App, _ []string) error { a.scroll(1); return nil }},
+	"scroll-up":      {"한 줄 위로", func(a *App, _ []string) error { a.scroll(-1); return nil }},
+	"page-down":      {"한 화면 아래로", func(a *App, _ []string) error { a.scroll(a.viewHeight()); return nil }},
+	"page-up":        {"한 화면 위로", func(a *App, _ []string) error { a.scroll(-a.viewHeight()); return nil }},
+	"top":            {"문서 처음으로", func(a *App, _ []string) error { a.scroll(-a.top); return nil }},
+	"bottom":         {"문서 끝으로", cmdBottom},
+	"hints":          {"링크 힌트 표시", func(a *App, _ []string) error { a.startHints(a.follow); return nil }},
+	"open":           {"URL 열기 (:open <url>, :open g <검색어>)", cmdOpen},
+	"prompt-open":    {"주소 입력 (:open)", func(a *App, _ []string) error { a.startPrompt("open "); return nil }},
+	"palette":        {"명령 팔레트", func(a *App, _ []string) error { a.startPrompt(""); return nil }},
+	"reload":         {"새로고침", func(a *App, _ []string) error { return a.reload() }},
+	"back":           {"뒤로 가기", func(a *App, _ []string) error { return a.back() }},
+	"tabopen":        {"새 탭에서 URL 열기 (:tabopen <url>)", cmdTabOpen},
+	"prompt-tabopen": {"새 탭 주소 입력 (:tabopen)", func(a *App, _ []string) error { a.startPrompt("tabopen "); return nil }},
+	"tabnext":        {"다음 탭", func(a *App, _ []string) error { a.switchTab(1); return nil }},
+	"tabprev":        {"이전 탭", func(a *App, _ []string) error { a.switchTab(-1); return nil }},
+	"tabclose":       {"지금 탭 닫기", func(a *App, _ []string) error { a.closeTab(); return nil }},
+	"history":        {"방문 기록 보기 (:history [lang:언어] [검색어])", cmdHistory},
+	"bookmark":       {"지금 페이지를 북마크에 추가", cmdBookmark},
+	"bookmarks":      {"북마크 목록 보기", func(a *App, _ []string) error { return a.Open(aboutBookmarks) }},
+	"zoom-in":        {"확대 (사이트별 설정에 저장)", func(a *App, _ []string) error { return a.zoomBy(1) }},
+	"zoom-out":       {"축소 (사이트별 설정에 저장)", func(a *App, _ []string) error { return a.zoomBy(-1) }},
+	"zoom-reset":     {"기본 크기로", func(a *App, _ []string) error { return a.zoomBy(0) }},
+	"copy-url":       {"지금 페이지 주소를 클립보드에 복사", cmdCopyURL},
+	"copy-link":      {"링크 힌트로 고른 링크 주소를 클립보드에 복사", cmdCopyLink},
+	"open-external":  {"지금 페이지를 기본 브라우저로 열기", cmdOpenExternal},
+	"play":           {"<audio>, <video>를 외부 플레이어로 열기 (:play [번호])", cmdPlay},
+	"site-settings":  {"사이트별 설정 보기", func(a *App, _ []string) error { return a.Open(aboutSiteSettings) }},
+	"cookies":        {"저장된 쿠키 보기", func(a *App, _ []string) error { return a.Open(aboutCookies) }},
+	"save-as-pdf":    {"지금 페이지를 PDF로 저장 (:save-as-pdf <파일>)", cmdSaveAs("save-as-pdf", export.WritePDF)},
+	"save-as-text":   {"지금 페이지를 쪽 나눔 텍스트로 저장 (:save-as-text <파일>)", cmdSaveAs("save-as-text", export.WriteText)},
+	"quit":           {"종료", func(a *App, _ []string) error { a.quit = true; return nil }},
+}
+
+// cmdBottom은 문서 끝으로 스크롤함
+func cmdBottom(a *App, _ []string) error {
+	if a.doc != nil {
+		a.scroll(len(a.doc.Lines))
+	}
+	return nil
+}
+
+// cmdOpen은 인자로 받은 URL을 엶
+func cmdOpen(a *App, args []string) error {
+	if len(args) == 0 {
+		return errors.New("열 주소를 입력하세요 (:open <url>)")
+	}
+	return a.Open(strings.Join(args, " "))
+}
+
+// cmdTabOpen은 인자로 받은 URL을 새 탭에서 엶
+func cmdTabOpen(a *App, args []string) error {
+	if len(args) == 0 {
+		return errors.New("열 주소를 입력하세요 (:tabopen <url>)")
+	}
+	return a.OpenTab(strings.Join(args, " "))
+}
+
+// cmdHistory는 방문 기록을 엶 (lang:ko 같은 단어는 언어 필터, 나머지는 퍼지 검색어)
+func cmdHistory(a *App, args []string) error {
+	var words []string
+	lang := ""
+	for _, arg := range args {
+		if l, ok := strings.CutPrefix(arg, "lang:"); ok {
+			lang = l
+			continue
+		}
+		words = append(words, arg)
+	}
+	return a.Open(historyAddress(strings.Join(words, " "), lang))
+}
+
+// cmdBookmark은 지금 페이지를 북마크에 추가함
+func cmdBookmark(a *App, _ []string) error {
+	if a.doc == nil {
+		return nil
+	}
+	added, err := a.Bookmarks.Add(a.doc.Title(), a.doc.Page.URL().Redacted())
+	if err != nil {
+		return err
+	}
+	if added {
+		a.status = "북마크에 추가했습니다: " + a.doc.Title()
+	} else {
+		a.status = "이미 북마크에 있습니다"
+	}
+	return nil
+}
+
+// cmdSaveAs는 지금 페이지를 인쇄용 폭으로 다시 레이아웃해서 write 형식으로 저장하는 명령을 만듦
+func cmdSaveAs(name string, write func(io.Writer, []export.Page) error) func(a *App, args []string) error {
+	return func(a *App, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("저장할 파일 이름을 입력하세요 (:%s <파일>)", name)
+		}
+		if a.doc == nil {
+			return errors.New("저장할 페이지가 없습니다")
+		}
+		path := strings.Join(args, " ")
+
+		doc := NewDocument(a.doc.Page, export.DefaultWidth)
+		lines := make([]string, len(doc.Lines))
+		for i, line := range doc.Lines {
+			lines[i] = line.Text()
+		}
+		pages := export.Paginate(lines, export.Options{Title: doc.Title(), URL: doc.Page.URL().Redacted()})
+
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("저장 실패: %w", err)
+		}
+		if err := write(f, pages); err != nil {
+			f.Close()
+			return fmt.Errorf("저장 실패: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("저장 실패: %w", err)
+		}
+		a.status = fmt.Sprintf("저장했습니다: %s (%d쪽)", path, len(pages))
+		return nil
+	}
+}
+
+// commandNames는 prefix로 시작하는 명령 이름들을 정렬해서 반환함
+func commandNames(prefix string) []string {
+	var names []string
+	for name := range Commands {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupCommand는 이름이 정확히 같은 명령, 없으면 유일하게 이름이 name으로 시작하는 명령을 찾음
+//
+// 팔레트에서 ":hist"처럼 줄여 쓸 수 있게 함
+func lookupCommand(name string) (Command, bool) {
+	if c, ok := Commands[name]; ok {
+		return c, true
+	}
+	if names := commandNames(name); len(names) == 1 {
+		return Commands[names[0]], true
+	}
+	return Command{}, false
+}
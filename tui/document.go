@@ -0,0 +1,289 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the laid-out document and link positions.
+package tui
+
+import (
+	"bytes"
+	"go-web-browser/css"
+	"go-web-browser/history"
+	"go-web-browser/html"
+	"go-web-browser/layout"
+	"go-web-browser/net"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/renderer"
+	"go-web-browser/theme"
+	"strconv"
+	"strings"
+)
+
+// LinkBox는 화면의 한 줄 안에서 링크 텍스트가 차지하는 구간
+//
+// 링크가 여러 줄에 걸치면 줄마다 LinkBox가 하나씩 생김
+type LinkBox struct {
+	Line  int // 문서 줄 번호 (0부터)
+	Col   int // 시작 열 (칸 수, 0부터)
+	Width int // 칸 수
+	Link  int // Document.Links 인덱스
+}
+
+// SpanStyle은 줄 조각 하나의 링크와 화면 요소 종류
+type SpanStyle struct {
+	Link       int        // Document.Links 인덱스 (링크가 아니면 -1)
+	Role       theme.Role // 테마에서 찾을 Style의 종류
+	Color      string     // 페이지 CSS color ("#rrggbb", ""이면 테마의 글자색)
+	Background string     // 페이지 CSS background-color ("#rrggbb", ""이면 테마의 배경색)
+}
+
+// plainStyle은 일반 본문 조각의 SpanStyle (Document.Styles[0])
+var plainStyle = SpanStyle{Link: -1, Role: theme.Text}
+
+// Document는 화면 폭에 맞게 줄바꿈된 페이지
+//
+// Lines의 Span.Attr은 Styles 인덱스 (0은 항상 일반 본문)
+type Document struct {
+	Page   *browser.Page
+	Lines  []layout.Line
+	Styles []SpanStyle
+	Links  []browser.Link
+	Boxes  []LinkBox // 문서 순서 (줄, 열 순)
+
+	width  int     // 레이아웃한 폭
+	height int     // CSS 미디어 쿼리에 쓰는 화면 줄 수 (0이면 모름)
+	zoom   float64 // 확대 배율 (0이면 1, css.Zoom)
+	opts   layout.Options
+
+	// paragraphs는 문단(줄바꿈 문자 사이의 조각들) → 줄바꿈 결과
+	// DOM이 바뀌어 다시 레이아웃할 때 바뀌지 않은 문단은 그대로 재사용함 (HTML이 아니면 nil)
+	paragraphs map[string][]layout.Line
+}
+
+// NewDocument는 page를 width 칸에 맞게 레이아웃함
+//
+// HTML은 링크 위치를 기억하면서 줄바꿈하고, 그 외 콘텐츠는
+// renderer 패키지가 고른 렌더러의 출력을 그대로 줄로 나눔
+func NewDocument(page *browser.Page, width int) *Document {
+	return newDocument(page, width, 0, DrawBorders, 0)
+}
+
+// newDocument는 NewDocument와 같지만 화면 줄 수(CSS @media의 height), CSS 테두리를 그릴지, 확대 배율을 직접 정함
+func newDocument(page *browser.Page, width, height int, borders bool, zoom float64) *Document {
+	doc := &Document{Page: page, Styles: []SpanStyle{plainStyle}, width: width, height: height, zoom: zoom}
+	resp := page.Response
+
+	r := renderer.For(resp.URL.Scheme, resp.ContentType, renderer.Options{Width: width})
+	if _, ok := r.(*renderer.HTMLRenderer); ok && page.DOM != nil {
+		doc.opts = layout.Options{Width: width, Hyphenator: layout.HyphenatorFor(page.Lang()), Borders: borders}
+		doc.layoutDOM()
+		return doc
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, resp.Body); err != nil {
+		buf.WriteString(err.Error())
+	}
+	for _, text := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		doc.Lines = append(doc.Lines, layout.Line{{Text: text}})
+	}
+	return doc
+}
+
+// Refresh는 NewDocument나 지난 Refresh 이후 DOM이 바뀌었으면 다시 레이아웃하고 true를 반환함
+//
+// 보이는 텍스트와 스타일은 다시 계산하지만, 줄바꿈은 내용이 바뀐 문단만 다시 함
+// 화면에 그리는 쪽(App.Draw)에서 부르고, DOM의 바뀐 표시를 지움
+func (d *Document) Refresh() bool {
+	dom := d.Page.DOM
+	if d.paragraphs == nil || !dom.Dirty() {
+		return false
+	}
+	d.layoutDOM()
+	dom.ClearDirty()
+	return true
+}
+
+// layoutDOM은 DOM을 문단별로 줄바꿈함 (지난 레이아웃에 같은 문단이 있으면 재사용)
+//
+// CSS로 margin, padding, 테두리, 배경을 준 블록 요소가 있으면 상자 모델로 레이아웃함
+// (이때는 문단 캐시를 쓰지 않음). display: none인 요소는 건너뛰고,
+// @media 조건은 터미널 크기(css.TerminalViewport)를 확대 배율만큼 줄인 화면으로 평가함
+func (d *Document) layoutDOM() {
+	d.Links = d.Page.Links()
+	d.Styles = []SpanStyle{plainStyle}
+	d.Lines = nil
+
+	dom := d.Page.DOM
+	net.GlobalTrace.Report(net.PhaseStyle, "")
+	computed := css.ComputeDocument(dom, css.TerminalViewport(d.width, d.height).Zoomed(d.zoom), userSheets()...)
+	css.Zoom(computed, d.zoom)
+	s := d.newStyler(computed)
+	net.GlobalTrace.Report(net.PhaseLayout, "")
+	if s.hasBoxes() {
+		d.Lines = trimEmptyLines(layout.LayoutBox(s.fillBox(&layout.Box{}, dom), d.opts))
+		d.paragraphs = map[string][]layout.Line{}
+		d.Boxes = d.linkBoxes()
+		return
+	}
+
+	cache := make(map[string][]layout.Line)
+	for _, para := range splitParagraphs(s.spans(dom.TextRunsWith(s.runOptions(false)))) {
+		key := paragraphKey(para)
+		lines, ok := d.paragraphs[key]
+		if !ok {
+			lines = layout.WrapSpans(para, d.opts)
+		}
+		cache[key] = lines
+		d.Lines = append(d.Lines, lines...)
+	}
+	d.paragraphs = cache
+	d.Boxes = d.linkBoxes()
+}
+
+// userSheets는 캐스케이드에 더할 사용자 스타일시트 (UserStyles가 없으면 비어 있음)
+func userSheets() []*css.Stylesheet {
+	if UserStyles == nil {
+		return nil
+	}
+	return []*css.Stylesheet{UserStyles}
+}
+
+// splitParagraphs는 조각들을 줄바꿈 문자에서 나눔
+//
+// WrapSpans는 문단마다 따로 줄바꿈하므로 문단별 결과를 이어 붙여도 결과가 같음
+func splitParagraphs(spans []layout.Span) [][]layout.Span {
+	paras := [][]layout.Span{nil}
+	for _, s := range spans {
+		for i, part := range strings.Split(s.Text, "\n") {
+			if i > 0 {
+				paras = append(paras, nil)
+			}
+			if part != "" {
+				last := len(paras) - 1
+				paras[last] = append(paras[last], layout.Span{Text: part, Attr: s.Attr})
+			}
+		}
+	}
+	return paras
+}
+
+// paragraphKey는 문단의 캐시 키 (텍스트와 스타일 번호)
+func paragraphKey(para []layout.Span) string {
+	var b strings.Builder
+	for _, s := range para {
+		b.WriteString(strconv.Itoa(s.Attr))
+		b.WriteByte(0)
+		b.WriteString(s.Text)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// styler는 텍스트 구간에 SpanStyle을 붙이는 도우미 (Document.Styles에 새 스타일을 추가함)
+type styler struct {
+	d        *Document
+	computed map[*html.Node]css.Style
+	linkOf   map[*html.Node]int
+	attrOf   map[SpanStyle]int
+}
+
+// newStyler는 d의 링크와 계산된 CSS 스타일로 styler를 만듦
+func (d *Document) newStyler(computed map[*html.Node]css.Style) *styler {
+	s := &styler{d: d, computed: computed, linkOf: make(map[*html.Node]int, len(d.Links)), attrOf: map[SpanStyle]int{plainStyle: 0}}
+	for i, link := range d.Links {
+		s.linkOf[link.Node] = i
+	}
+	return s
+}
+
+// attr은 style의 Styles 인덱스 (처음 보는 스타일이면 추가함)
+func (s *styler) attr(style SpanStyle) int {
+	attr, ok := s.attrOf[style]
+	if !ok {
+		attr = len(s.d.Styles)
+		s.attrOf[style] = attr
+		s.d.Styles = append(s.d.Styles, style)
+	}
+	return attr
+}
+
+// spans는 보이는 텍스트 구간들을 링크, 제목, 코드 같은 종류별 조각으로 바꿈
+//
+// 요소의 CSS 글자색과, 조상 요소 중 가장 가까운 CSS 배경색을 조각의 색으로 씀
+func (s *styler) spans(runs []html.TextRun) []layout.Span {
+	spans := make([]layout.Span, len(runs))
+	for i, run := range runs {
+		style := plainStyle
+		if run.Node != nil {
+			style.Color = cssColor(s.computed[run.Node].Get("color"))
+		}
+		for n := run.Node; n != nil; n = n.Parent {
+			if link, ok := s.linkOf[n]; ok && style.Link < 0 {
+				style.Link = link
+				style.Role = theme.Link
+			}
+			if role, ok := elementRoles[n.Tag]; ok && style.Role == theme.Text {
+				style.Role = role
+			}
+			if style.Background == "" {
+				style.Background = background(s.computed[n])
+			}
+		}
+		spans[i] = layout.Span{Text: run.Text, Attr: s.attr(style)}
+	}
+
+	// 같은 링크(또는 제목) 안의 단어 사이 공백도 같은 스타일로 (밑줄이 끊기지 않도록)
+	for i := 1; i+1 < len(spans); i++ {
+		if runs[i].Node == nil && spans[i-1].Attr != 0 && spans[i-1].Attr == spans[i+1].Attr &&
+			!strings.Contains(spans[i].Text, "\n") {
+			spans[i].Attr = spans[i-1].Attr
+		}
+	}
+	return spans
+}
+
+// elementRoles는 조상 요소에 따라 정해지는 화면 요소 종류 (링크가 아닐 때)
+var elementRoles = map[string]theme.Role{
+	"h1": theme.Heading, "h2": theme.Heading, "h3": theme.Heading,
+	"h4": theme.Heading, "h5": theme.Heading, "h6": theme.Heading,
+	"pre": theme.Code, "code": theme.Code, "kbd": theme.Code, "samp": theme.Code,
+}
+
+// linkBoxes는 줄마다 링크 조각의 위치를 계산함
+func (d *Document) linkBoxes() []LinkBox {
+	var boxes []LinkBox
+	for i, line := range d.Lines {
+		col := 0
+		for _, span := range line {
+			width := layout.StringWidth(span.Text)
+			if link := d.Styles[span.Attr].Link; link >= 0 {
+				boxes = append(boxes, LinkBox{Line: i, Col: col, Width: width, Link: link})
+			}
+			col += width
+		}
+	}
+	return boxes
+}
+
+// MarkVisited는 방문 기록에 있는 링크를 방문한 링크(theme.VisitedLink)로 표시함
+//
+// 그리기 전에 매번 부르면 다른 탭에서 방문한 링크도 바로 반영됨
+func (d *Document) MarkVisited(store *history.Store) {
+	for i, style := range d.Styles {
+		if style.Link < 0 {
+			continue
+		}
+		u := d.Links[style.Link].URL
+		if u != nil && store.Visited(u.Normalize().Redacted()) {
+			d.Styles[i].Role = theme.VisitedLink
+		} else {
+			d.Styles[i].Role = theme.Link
+		}
+	}
+}
+
+// Title은 상태 줄에 표시할 제목 (<title>이 없으면 사람이 읽는 모양의 URL)
+func (d *Document) Title() string {
+	if title := d.Page.Title(); title != "" {
+		return title
+	}
+	return d.Page.URL().Display()
+}
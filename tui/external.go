@@ -0,0 +1,182 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the hand-off to external programs (media player, default browser, clipboard).
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// launch는 command(공백으로 구분한 프로그램과 인자) 뒤에 args를 붙여 실행하고 끝나기를 기다리지 않음
+//
+// 외부 프로그램의 출력이 화면을 덮어쓰지 않도록 표준 입출력은 연결하지 않음
+func launch(command string, args ...string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return errors.New("실행할 명령이 없습니다")
+	}
+	cmd := exec.Command(fields[0], append(fields[1:], args...)...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s 실행 실패: %w", fields[0], err)
+	}
+	go cmd.Wait() // 끝난 프로세스를 거둠
+	return nil
+}
+
+// cmdPlay는 지금 페이지의 n번째(기본값 1) <audio>, <video>를 외부 플레이어로 엶
+func cmdPlay(a *App, args []string) error {
+	if a.doc == nil {
+		return nil
+	}
+	media := a.doc.Page.Media()
+	if len(media) == 0 {
+		return errors.New("재생할 미디어가 없습니다")
+	}
+	i := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(media) {
+			return fmt.Errorf("미디어 번호는 1부터 %d까지입니다", len(media))
+		}
+		i = n
+	}
+	m := media[i-1]
+	if m.URL == nil {
+		return fmt.Errorf("미디어 주소를 해석할 수 없습니다: %q", m.Src)
+	}
+	if a.Player == "" {
+		return errors.New("미디어 플레이어가 설정되지 않았습니다 (설정 파일의 player)")
+	}
+
+	if err := launch(a.Player, m.URL.String()); err != nil {
+		return err
+	}
+	a.status = fmt.Sprintf("외부 플레이어로 엶 (%d/%d): %s", i, len(media), m.URL.Redacted())
+	return nil
+}
+
+// handOff는 rawURL의 스킴에 외부 프로그램(Handlers)이 정해져 있으면 그 프로그램으로 넘김
+//
+// 넘겼으면(또는 넘기다 실패했으면) handled = true
+func (a *App) handOff(rawURL string) (handled bool, err error) {
+	if len(a.Handlers) == 0 {
+		return false, nil
+	}
+	u, err := url.FromUserInput(rawURL)
+	if err != nil {
+		return false, nil
+	}
+	command, ok := a.Handlers[u.Scheme]
+	if !ok {
+		return false, nil
+	}
+	if err := launch(command, u.String()); err != nil {
+		return true, err
+	}
+	a.status = fmt.Sprintf("%s 링크를 외부 프로그램으로 넘겼습니다: %s", u.Scheme, u.Redacted())
+	return true, nil
+}
+
+// systemOpenCommand는 운영체제의 기본 프로그램(브라우저)으로 URL을 여는 명령
+func systemOpenCommand() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "open"
+	case "windows":
+		return "rundll32 url.dll,FileProtocolHandler"
+	}
+	return "xdg-open"
+}
+
+// systemOpen은 rawURL을 운영체제의 기본 브라우저로 엶
+func systemOpen(rawURL string) error {
+	return launch(systemOpenCommand(), rawURL)
+}
+
+// clipboardCommands는 표준 입력을 클립보드에 넣는 명령들 (설치된 첫 번째 명령을 씀)
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	commands := [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append([][]string{{"wl-copy"}}, commands...)
+	}
+	return commands
+}
+
+// systemCopy는 text를 시스템 클립보드에 넣음
+func systemCopy(text string) error {
+	var names []string
+	for _, c := range clipboardCommands() {
+		names = append(names, c[0])
+		path, err := exec.LookPath(c[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("클립보드 복사 실패 (%s): %w", c[0], err)
+		}
+		return nil
+	}
+	return fmt.Errorf("클립보드 프로그램을 찾을 수 없습니다 (%s)", strings.Join(names, ", "))
+}
+
+// copyURL은 u를 클립보드에 넣고 상태 줄에 알림
+func (a *App) copyURL(u *url.URL) error {
+	if err := a.CopyText(u.String()); err != nil {
+		return err
+	}
+	a.status = "클립보드에 복사했습니다: " + u.Redacted()
+	return nil
+}
+
+// cmdCopyURL은 지금 페이지의 주소를 클립보드에 넣음
+func cmdCopyURL(a *App, _ []string) error {
+	if a.doc == nil {
+		return nil
+	}
+	return a.copyURL(a.doc.Page.URL())
+}
+
+// cmdCopyLink는 링크 힌트로 고른 링크의 주소를 클립보드에 넣음
+func cmdCopyLink(a *App, _ []string) error {
+	a.startHints(func(link browser.Link) {
+		if link.URL == nil {
+			a.status = fmt.Sprintf("링크 주소를 해석할 수 없습니다: %s", link.Href)
+			return
+		}
+		if err := a.copyURL(link.URL); err != nil {
+			a.status = err.Error()
+		}
+	})
+	return nil
+}
+
+// cmdOpenExternal은 지금 페이지를 운영체제의 기본 브라우저로 엶 (텍스트로 보기에 부족할 때)
+func cmdOpenExternal(a *App, _ []string) error {
+	if a.doc == nil {
+		return nil
+	}
+	u := a.doc.Page.URL()
+	if u.Scheme == url.SchemeAbout {
+		return fmt.Errorf("내부 페이지는 외부 브라우저로 열 수 없습니다: %s", u)
+	}
+	if err := a.OpenExternal(u.String()); err != nil {
+		return err
+	}
+	a.status = "기본 브라우저로 열었습니다: " + u.Redacted()
+	return nil
+}
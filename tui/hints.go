@@ -0,0 +1,77 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains keyboard link hints (label generation and matching).
+package tui
+
+import "strings"
+
+// DefaultHintAlphabet은 힌트 라벨에 쓰는 글자들
+//
+// 손을 홈 row에 둔 채로 누르기 쉬운 글자를 앞에 둠 (Vimium과 같은 순서)
+const DefaultHintAlphabet = "sadfjklewcmpgh"
+
+// Hint는 화면에 표시된 링크 하나와 그 라벨
+type Hint struct {
+	Label string
+	Box   LinkBox // 라벨을 그릴 위치 (링크의 첫 번째 보이는 조각)
+}
+
+// HintLabels는 n개의 링크에 붙일 라벨을 만듦
+//
+// 모든 라벨의 길이가 같으므로 어떤 라벨도 다른 라벨의 접두사가 아님
+// (라벨 길이만큼 입력하면 바로 링크가 결정됨)
+// 앞 글자가 먼저 바뀌도록 만들어서 링크가 적을 때는 첫 글자만 보고도 구분됨
+func HintLabels(n int, alphabet string) []string {
+	letters := []rune(alphabet)
+	if n <= 0 || len(letters) == 0 {
+		return nil
+	}
+
+	length := 1
+	for total := len(letters); total < n && len(letters) > 1; total *= len(letters) {
+		length++
+	}
+
+	labels := make([]string, n)
+	for i := range labels {
+		label := make([]rune, length)
+		for j, rest := 0, i; j < length; j++ {
+			label[j] = letters[rest%len(letters)]
+			rest /= len(letters)
+		}
+		labels[i] = string(label)
+	}
+	return labels
+}
+
+// hintsFor는 top부터 height 줄 안에 보이는 링크마다 힌트를 만듦
+//
+// 한 링크가 여러 줄에 걸쳐 있으면 첫 번째로 보이는 조각에만 라벨을 붙임
+func hintsFor(boxes []LinkBox, top, height int, alphabet string) []Hint {
+	var visible []LinkBox
+	seen := make(map[int]bool)
+	for _, box := range boxes {
+		if box.Line < top || box.Line >= top+height || seen[box.Link] {
+			continue
+		}
+		seen[box.Link] = true
+		visible = append(visible, box)
+	}
+
+	labels := HintLabels(len(visible), alphabet)
+	hints := make([]Hint, len(visible))
+	for i, box := range visible {
+		hints[i] = Hint{Label: labels[i], Box: box}
+	}
+	return hints
+}
+
+// matchHints는 입력한 글자로 시작하는 힌트만 골라냄
+func matchHints(hints []Hint, typed string) []Hint {
+	var matched []Hint
+	for _, h := range hints {
+		if strings.HasPrefix(h.Label, typed) {
+			matched = append(matched, h)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,101 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains decoding of raw terminal input into keys.
+package tui
+
+import (
+	"bufio"
+	"unicode"
+)
+
+// Key는 눌린 키 하나 (일반 글자는 그 룬, 특수 키는 아래 상수)
+type Key rune
+
+// 제어 문자로 들어오는 키
+const (
+	KeyEnter     Key = '\r'
+	KeyEsc       Key = 0x1b
+	KeyBackspace Key = 0x7f
+)
+
+// 이스케이프 시퀀스로 들어오는 특수 키 (유니코드 범위 밖의 값을 사용)
+const (
+	KeyUp Key = unicode.MaxRune + 1 + iota
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyUnknown // 해석할 수 없는 이스케이프 시퀀스
+)
+
+// csiFinal은 "ESC [ X" 형태 시퀀스의 마지막 글자별 키
+var csiFinal = map[byte]Key{
+	'A': KeyUp, 'B': KeyDown, 'C': KeyRight, 'D': KeyLeft,
+	'H': KeyHome, 'F': KeyEnd,
+}
+
+// csiTilde는 "ESC [ n ~" 형태 시퀀스의 숫자별 키
+var csiTilde = map[string]Key{
+	"1": KeyHome, "7": KeyHome, "4": KeyEnd, "8": KeyEnd,
+	"5": KeyPageUp, "6": KeyPageDown,
+}
+
+// ReadKey는 raw 모드 터미널 입력에서 키 하나를 읽음
+//
+// ESC 뒤에 바로 이어지는 바이트가 없으면 Esc 키로 취급함
+// (터미널은 이스케이프 시퀀스를 한 번에 보내므로 버퍼에 함께 들어옴)
+func ReadKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+
+	switch c {
+	case '\n':
+		return KeyEnter, nil
+	case '\b':
+		return KeyBackspace, nil
+	case 0x1b:
+		if r.Buffered() == 0 {
+			return KeyEsc, nil
+		}
+		return readEscape(r)
+	}
+	return Key(c), nil
+}
+
+// readEscape는 ESC 다음의 CSI("ESC [") 또는 SS3("ESC O") 시퀀스를 해석함
+func readEscape(r *bufio.Reader) (Key, error) {
+	intro, err := r.ReadByte()
+	if err != nil {
+		return KeyEsc, nil
+	}
+	if intro != '[' && intro != 'O' {
+		// Alt+글자 등은 지원하지 않음
+		return KeyUnknown, nil
+	}
+
+	var params []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return KeyUnknown, nil
+		}
+		if b >= '0' && b <= '9' || b == ';' {
+			params = append(params, b)
+			continue
+		}
+		if b == '~' {
+			if key, ok := csiTilde[string(params)]; ok {
+				return key, nil
+			}
+			return KeyUnknown, nil
+		}
+		if key, ok := csiFinal[b]; ok {
+			return key, nil
+		}
+		return KeyUnknown, nil
+	}
+}
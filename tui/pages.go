@@ -0,0 +1,368 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains the internal pages generated by the TUI (history, bookmarks, site settings, cookies).
+package tui
+
+import (
+	"fmt"
+	"go-web-browser/history"
+	"go-web-browser/html"
+	"go-web-browser/net"
+	"go-web-browser/permissions"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+	stdhtml "html"
+	stdurl "net/url"
+	"strings"
+)
+
+// TUI가 직접 만드는 내부 페이지 주소
+const (
+	aboutHistory      = "about:history"
+	aboutBookmarks    = "about:bookmarks"
+	aboutSiteSettings = "about:site-settings"
+	aboutCookies      = "about:cookies"
+)
+
+// pageLink는 내부 페이지 목록의 항목 하나
+type pageLink struct {
+	Title string
+	URL   string
+}
+
+// internalPages는 주소별 내부 페이지 (제목, 목록)
+//
+// 네트워크 대신 App 상태로 만들기 때문에 Browser.Navigate를 거치지 않음
+// 쿼리(about:history?q=...)는 query로 받음
+var internalPages = map[string]func(a *App, query stdurl.Values) (string, []pageLink){
+	aboutHistory:      historyPage,
+	aboutBookmarks:    bookmarksPage,
+	aboutSiteSettings: siteSettingsPage,
+	aboutCookies:      cookiesPage,
+}
+
+// internalAddress는 rawURL이 내부 페이지면 쿼리를 뗀 주소와 쿼리를 반환함
+//
+// 예시: "about:site-settings?origin=...&toggle=images" → "about:site-settings", "origin=...&toggle=images"
+func internalAddress(rawURL string) (string, string, bool) {
+	address, query, _ := strings.Cut(rawURL, "?")
+	_, ok := internalPages[address]
+	return address, query, ok
+}
+
+// historyPage는 방문 기록 (최근 방문이 위)
+//
+// q가 있으면 제목과 주소를 퍼지 검색해서 잘 맞는 순서로, lang이 있으면 그 언어의 문서만 보여줌
+// 기록에 언어 정보가 있으면 목록 뒤에 언어별로 거르는 링크를 붙임
+func historyPage(a *App, query stdurl.Values) (string, []pageLink) {
+	q, lang := query.Get("q"), query.Get("lang")
+	var links []pageLink
+	if q == "" && lang == "" {
+		visits := a.History.All()
+		for i := len(visits) - 1; i >= 0; i-- {
+			links = append(links, historyLink(visits[i]))
+		}
+	} else {
+		for _, e := range a.History.Search(q, lang) {
+			links = append(links, historyLink(e))
+		}
+	}
+	for _, l := range a.History.Langs() {
+		if l != strings.ToLower(lang) {
+			links = append(links, pageLink{Title: "언어: " + l, URL: historyAddress(q, l)})
+		}
+	}
+
+	title := "방문 기록"
+	switch {
+	case q != "" && lang != "":
+		title = fmt.Sprintf("방문 기록 검색: %s [%s]", q, lang)
+	case q != "":
+		title = "방문 기록 검색: " + q
+	case lang != "":
+		title = fmt.Sprintf("방문 기록 [%s]", lang)
+	}
+	return title, links
+}
+
+// historyLink는 방문 기록 항목의 링크 (언어를 알면 제목 뒤에 붙임)
+func historyLink(e history.Entry) pageLink {
+	title := e.Title
+	if e.Lang != "" {
+		title = fmt.Sprintf("%s [%s]", title, e.Lang)
+	}
+	return pageLink{Title: title, URL: e.URL}
+}
+
+// historyAddress는 검색어 q와 언어 lang으로 거른 about:history 주소 (둘 다 없으면 전체 기록)
+func historyAddress(q, lang string) string {
+	values := stdurl.Values{}
+	if q != "" {
+		values.Set("q", q)
+	}
+	if lang != "" {
+		values.Set("lang", lang)
+	}
+	if len(values) == 0 {
+		return aboutHistory
+	}
+	return aboutHistory + "?" + values.Encode()
+}
+
+// bookmarksPage는 북마크 목록 (추가한 순서)
+func bookmarksPage(a *App, _ stdurl.Values) (string, []pageLink) {
+	var links []pageLink
+	for _, b := range a.Bookmarks.All() {
+		links = append(links, pageLink{Title: b.Title, URL: b.URL})
+	}
+	return "북마크", links
+}
+
+// siteSettingToggles는 about:site-settings에서 켜고 끌 수 있는 설정 (toggle 값 → 이름, 값 위치)
+var siteSettingToggles = []struct {
+	key   string
+	label string
+	field func(s *sitesettings.Settings) *bool
+}{
+	{"insecure", "http 허용 (HTTPS-first 예외)", func(s *sitesettings.Settings) *bool { return &s.AllowInsecure }},
+	{"images", "이미지 끄기", func(s *sitesettings.Settings) *bool { return &s.DisableImages }},
+}
+
+// siteSettingsPage는 지금 사이트와 설정을 바꾼 사이트들의 설정 (링크를 따라가면 켜고 끔)
+func siteSettingsPage(a *App, _ stdurl.Values) (string, []pageLink) {
+	var links []pageLink
+	if u := a.currentSite(); u != nil {
+		links = append(links, siteSettingLinks(sitesettings.Origin(u), a.Sites.For(u))...)
+	}
+	current := ""
+	if len(links) > 0 {
+		current = links[0].URL
+	}
+	for _, site := range a.Sites.All() {
+		site := siteSettingLinks(site.Origin, site.Settings)
+		if site[0].URL == current {
+			continue
+		}
+		links = append(links, site...)
+	}
+	return "사이트별 설정", links
+}
+
+// siteSettingLinks는 사이트 하나의 설정 항목들 (켜고 끄는 링크와 초기화 링크)
+func siteSettingLinks(origin string, settings sitesettings.Settings) []pageLink {
+	var links []pageLink
+	for _, t := range siteSettingToggles {
+		state := "꺼짐"
+		if *t.field(&settings) {
+			state = "켜짐"
+		}
+		links = append(links, pageLink{
+			Title: fmt.Sprintf("%s: %s [%s]", origin, t.label, state),
+			URL:   siteSettingAction(origin, "toggle", t.key),
+		})
+	}
+	for _, p := range permissions.All {
+		decision := "묻기"
+		switch permissions.Decision(settings.Permissions[string(p)]) {
+		case permissions.Allow:
+			decision = "허용"
+		case permissions.Deny:
+			decision = "차단"
+		}
+		links = append(links, pageLink{
+			Title: fmt.Sprintf("%s: %s [%s]", origin, p, decision),
+			URL:   siteSettingAction(origin, "permission", string(p)),
+		})
+	}
+	if settings.Zoom != 0 {
+		links = append(links, pageLink{
+			Title: fmt.Sprintf("%s: 확대 %g배", origin, settings.Zoom),
+			URL:   siteSettingAction(origin, "reset", "zoom"),
+		})
+	}
+	if !settings.IsZero() {
+		links = append(links, pageLink{Title: origin + ": 기본값으로 되돌리기", URL: siteSettingAction(origin, "reset", "all")})
+	}
+	return links
+}
+
+// siteSettingAction은 about:site-settings의 설정 변경 링크 주소
+func siteSettingAction(origin, action, value string) string {
+	return aboutSiteSettings + "?" + stdurl.Values{"origin": {origin}, action: {value}}.Encode()
+}
+
+// applySiteSetting은 about:site-settings 링크의 쿼리대로 설정을 바꿈
+//
+// toggle=insecure|images는 켜고 끄고, permission=<권한>은 묻기 → 허용 → 차단 순서로 바꾸고,
+// reset=zoom|all은 기본값으로 되돌림
+func (a *App) applySiteSetting(query string) error {
+	values, err := stdurl.ParseQuery(query)
+	if err != nil {
+		return fmt.Errorf("잘못된 사이트 설정 주소: %w", err)
+	}
+	origin := values.Get("origin")
+	if origin == "" {
+		return nil
+	}
+
+	settings := a.Sites.Get(origin)
+	switch values.Get("reset") {
+	case "zoom":
+		settings.Zoom = 0
+	case "all":
+		settings = sitesettings.Settings{}
+	}
+	if name := values.Get("permission"); name != "" {
+		if _, ok := permissions.Labels[permissions.Permission(name)]; !ok {
+			return fmt.Errorf("알 수 없는 권한: %q", name)
+		}
+		next := permissions.Ask
+		switch permissions.Decision(settings.Permissions[name]) {
+		case permissions.Ask:
+			next = permissions.Allow
+		case permissions.Allow:
+			next = permissions.Deny
+		}
+		settings = settings.WithPermission(name, string(next))
+	}
+	if key := values.Get("toggle"); key != "" {
+		found := false
+		for _, t := range siteSettingToggles {
+			if t.key == key {
+				field := t.field(&settings)
+				*field = !*field
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("알 수 없는 사이트 설정: %q", key)
+		}
+	}
+	return a.Sites.Set(origin, settings)
+}
+
+// cookiesPage는 저장된 쿠키를 도메인별로 (링크를 따라가면 도메인 전체 또는 쿠키 하나를 지움)
+func cookiesPage(a *App, _ stdurl.Values) (string, []pageLink) {
+	var links []pageLink
+	cookies := a.Cookies.All()
+	for i, c := range cookies {
+		if i == 0 || c.Domain != cookies[i-1].Domain {
+			n := 0
+			for _, o := range cookies[i:] {
+				if o.Domain == c.Domain {
+					n++
+				}
+			}
+			links = append(links, pageLink{
+				Title: fmt.Sprintf("%s: 쿠키 %d개 모두 지우기", c.Domain, n),
+				URL:   aboutCookies + "?" + stdurl.Values{"delete-domain": {c.Domain}}.Encode(),
+			})
+		}
+		expires := "세션"
+		if !c.Session() {
+			expires = c.Expires.Local().Format("2006-01-02 15:04") + " 만료"
+		}
+		if c.Partition != "" {
+			expires += ", " + c.Partition + "에서 받음"
+		}
+		links = append(links, pageLink{
+			Title: fmt.Sprintf("%s%s %s=%s (%s) 지우기", c.Domain, c.Path, c.Name, truncateValue(c.Value, 20), expires),
+			URL:   aboutCookies + "?" + stdurl.Values{"domain": {c.Domain}, "path": {c.Path}, "delete": {c.Name}}.Encode(),
+		})
+	}
+	return "쿠키", links
+}
+
+// truncateValue는 긴 쿠키 값을 max 글자까지만 보여줌
+func truncateValue(value string, max int) string {
+	if r := []rune(value); len(r) > max {
+		return string(r[:max]) + "…"
+	}
+	return value
+}
+
+// applyCookieAction은 about:cookies 링크의 쿼리대로 쿠키를 지움
+//
+// delete-domain=도메인은 그 도메인(과 하위 도메인)의 쿠키를, domain, path, delete=이름은 쿠키 하나를 지움
+func (a *App) applyCookieAction(query string) error {
+	values, err := stdurl.ParseQuery(query)
+	if err != nil {
+		return fmt.Errorf("잘못된 쿠키 주소: %w", err)
+	}
+	if domain := values.Get("delete-domain"); domain != "" {
+		_, err := a.Cookies.DeleteDomain(domain)
+		return err
+	}
+	if name := values.Get("delete"); name != "" {
+		_, err := a.Cookies.Delete(values.Get("domain"), values.Get("path"), name)
+		return err
+	}
+	return nil
+}
+
+// currentSite는 about:site-settings를 열기 전에 보던 웹 페이지의 URL (없으면 nil)
+func (a *App) currentSite() *url.URL {
+	pages := make([]*browser.Page, 0, len(a.history)+1)
+	if a.doc != nil {
+		pages = append(pages, a.doc.Page)
+	}
+	for i := len(a.history) - 1; i >= 0; i-- {
+		pages = append(pages, a.history[i].page)
+	}
+	for _, page := range pages {
+		if u := page.URL(); u.Scheme == url.SchemeHTTP || u.Scheme == url.SchemeHTTPS {
+			return u
+		}
+	}
+	return nil
+}
+
+// internalPage는 rawURL이 내부 페이지면 그 Page를 만듦
+//
+// about:site-settings, about:cookies 링크에 쿼리가 있으면 설정을 바꾸거나 쿠키를 지우고, 쿼리 없는 주소의 페이지를 만듦
+// (새로고침해도 같은 변경이 다시 적용되지 않도록)
+func (a *App) internalPage(rawURL string) (*browser.Page, bool, error) {
+	address, query, ok := internalAddress(rawURL)
+	if !ok {
+		return nil, false, nil
+	}
+	values, err := stdurl.ParseQuery(query)
+	if err != nil {
+		return nil, true, fmt.Errorf("잘못된 내부 페이지 주소: %w", err)
+	}
+	// 검색 결과는 새로고침해도 같은 결과가 나오도록 쿼리를 주소에 남김
+	pageAddress := address
+	if address == aboutHistory && query != "" {
+		pageAddress = rawURL
+	}
+	u, err := url.NewURL(pageAddress)
+	if err != nil {
+		return nil, false, nil
+	}
+	if address == aboutSiteSettings && query != "" {
+		if err := a.applySiteSetting(query); err != nil {
+			return nil, true, err
+		}
+	}
+	if address == aboutCookies && query != "" {
+		if err := a.applyCookieAction(query); err != nil {
+			return nil, true, err
+		}
+	}
+	build := internalPages[address]
+
+	title, links := build(a, values)
+	var b strings.Builder
+	fmt.Fprintf(&b, "<title>%s</title><h1>%s</h1>", stdhtml.EscapeString(title), stdhtml.EscapeString(title))
+	if len(links) == 0 {
+		b.WriteString("<p>(비어 있음)</p>")
+	}
+	b.WriteString("<ul>")
+	for _, l := range links {
+		fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a></li>", stdhtml.EscapeString(l.URL), stdhtml.EscapeString(l.Title))
+	}
+	b.WriteString("</ul>")
+
+	resp := &net.Response{URL: u, StatusCode: 200, Body: b.String(), ContentType: net.MIMETextHTML}
+	return &browser.Page{Response: resp, DOM: html.Parse(resp.Body)}, true, nil
+}
@@ -0,0 +1,56 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains ANSI escape sequences and frame drawing.
+package tui
+
+import (
+	"fmt"
+	"go-web-browser/layout"
+	"go-web-browser/term"
+	"go-web-browser/theme"
+	"strings"
+)
+
+// ANSI 이스케이프 시퀀스
+const (
+	enterAltScreen = "\x1b[?1049h" // 대체 화면 (종료하면 원래 화면이 돌아옴)
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	clearScreen    = "\x1b[2J"
+	clearLine      = "\x1b[K"
+
+	sgrReset = "\x1b[0m"
+)
+
+// moveTo는 커서를 row행 col열로 옮기는 시퀀스 (0부터 셈)
+func moveTo(row, col int) string {
+	return fmt.Sprintf("\x1b[%d;%dH", row+1, col+1)
+}
+
+// styled는 text를 sgr로 꾸며서 씀 (꾸밈이 없으면 그대로)
+func styled(b *strings.Builder, sgr, text string) {
+	if sgr == "" {
+		b.WriteString(text)
+		return
+	}
+	b.WriteString(sgr + text + sgrReset)
+}
+
+// drawLine은 줄 하나를 조각마다 테마의 색으로 그림 (페이지 글의 제어 문자는 지움)
+func drawLine(b *strings.Builder, t *theme.Theme, styles []SpanStyle, line layout.Line) {
+	for _, span := range line {
+		styled(b, styles[span.Attr].sgr(t), term.StripControls(span.Text))
+	}
+}
+
+// drawHint는 링크 시작 위치에 라벨을 덮어 그림 (이미 입력한 글자는 빼고 표시)
+func drawHint(b *strings.Builder, t *theme.Theme, row int, h Hint, typed string) {
+	b.WriteString(moveTo(row, h.Box.Col))
+	styled(b, t.SGR(theme.Selection), strings.ToUpper(h.Label[len(typed):]))
+}
+
+// drawStatus는 마지막 줄에 상태 줄을 그림 (페이지 제목 등에 든 제어 문자는 지움)
+func drawStatus(b *strings.Builder, t *theme.Theme, row, width int, text string) {
+	b.WriteString(moveTo(row, 0))
+	styled(b, t.SGR(theme.Status), layout.PadRight(layout.Truncate(term.StripControls(text), width), width))
+}
@@ -0,0 +1,35 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains headless screenshots of the laid-out page.
+package tui
+
+import (
+	"go-web-browser/export"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/theme"
+	"image"
+)
+
+// Screenshot은 page를 width x height 픽셀 화면에 레이아웃한 모습을 이미지로 그림
+//
+// 터미널 없이 동작하며, 열 수는 width / export.CellWidth
+// 화면에 보이는 첫 부분(스크롤하지 않은 상태)만 그림
+// CSS 테두리는 DrawBorders와 상관없이 항상 선으로 그림
+func Screenshot(page *browser.Page, t *theme.Theme, width, height int) *image.RGBA {
+	doc := newDocument(page, max(width/export.CellWidth, 1), height/export.CellHeight, true, 0)
+	rows := make([][]export.Run, 0, min(len(doc.Lines), height/export.CellHeight+1))
+	for i, line := range doc.Lines {
+		if i*export.CellHeight >= height {
+			break
+		}
+		runs := make([]export.Run, len(line))
+		for j, span := range line {
+			style := doc.Styles[span.Attr]
+			fg, bg := style.colors(t)
+			runs[j] = export.Run{Text: span.Text, Fg: fg, Bg: bg, Underline: t.Style(style.Role).Underline}
+		}
+		rows = append(rows, runs)
+	}
+
+	_, background := t.Colors(theme.Text)
+	return export.Rasterize(rows, width, height, background)
+}
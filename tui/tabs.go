@@ -0,0 +1,169 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains tab management and session save/restore.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"go-web-browser/session"
+	"time"
+)
+
+// SnapshotInterval은 Run이 세션 파일을 저장하는 주기
+//
+// 비정상 종료되더라도 이 시간 안의 변경만 잃음
+const SnapshotInterval = 30 * time.Second
+
+// TabCount는 열린 탭 수
+func (a *App) TabCount() int {
+	return len(a.tabs)
+}
+
+// CurrentTab은 지금 보고 있는 탭 인덱스 (0부터)
+func (a *App) CurrentTab() int {
+	for i, t := range a.tabs {
+		if t == a.tab {
+			return i
+		}
+	}
+	return 0
+}
+
+// OpenTab은 새 탭을 지금 탭 오른쪽에 열고 그 탭으로 전환함
+//
+// 지금 탭이 아직 아무 페이지도 열지 않았으면 그 탭을 그대로 사용함
+func (a *App) OpenTab(rawURL string) error {
+	if a.doc == nil {
+		return a.Open(rawURL)
+	}
+	if handled, err := a.handOff(rawURL); handled {
+		return err
+	}
+	page, err := a.load(rawURL)
+	if err != nil {
+		return err
+	}
+
+	t := &tab{}
+	i := a.CurrentTab() + 1
+	a.tabs = append(a.tabs[:i], append([]*tab{t}, a.tabs[i:]...)...)
+	a.cancelHints()
+	a.tab = t
+	a.show(page, 0)
+	a.recordVisit(rawURL)
+	return nil
+}
+
+// OpenTabs는 urls를 각각 새 탭으로 열고 첫 번째 탭을 보여줌
+//
+// 일부를 열지 못하면 나머지만 열고 상태 줄에 알림
+// 하나도 열지 못하면 마지막 에러를 반환함
+func (a *App) OpenTabs(urls []string) error {
+	var first *tab
+	var lastErr error
+	failed := 0
+	for _, rawURL := range urls {
+		if err := a.OpenTab(rawURL); err != nil {
+			failed++
+			lastErr = err
+			continue
+		}
+		if first == nil {
+			first = a.tab
+		}
+	}
+	if first == nil {
+		return lastErr
+	}
+
+	a.tab = first
+	if failed > 0 {
+		a.status = fmt.Sprintf("탭 %d개를 열지 못했습니다: %v", failed, lastErr)
+	}
+	return nil
+}
+
+// switchTab은 delta만큼 옆 탭으로 전환함 (끝에서는 반대쪽으로 돌아감)
+func (a *App) switchTab(delta int) {
+	n := len(a.tabs)
+	i := ((a.CurrentTab()+delta)%n + n) % n
+	a.cancelHints()
+	a.tab = a.tabs[i]
+	if a.doc != nil && (a.doc.width != a.width || a.doc.height != a.viewHeight()) {
+		// 다른 탭을 보는 동안 화면 크기가 바뀌었으면 다시 레이아웃
+		a.show(a.doc.Page, a.top)
+	}
+}
+
+// closeTab은 지금 탭을 닫음 (마지막 탭을 닫으면 종료)
+func (a *App) closeTab() {
+	if len(a.tabs) == 1 {
+		a.quit = true
+		return
+	}
+	i := a.CurrentTab()
+	a.tabs = append(a.tabs[:i], a.tabs[i+1:]...)
+	if i == len(a.tabs) {
+		i--
+	}
+	a.cancelHints()
+	a.tab = a.tabs[i]
+}
+
+// Session은 열린 탭들의 URL과 스크롤 위치를 반환함 (페이지를 열지 않은 탭은 제외)
+func (a *App) Session() session.Session {
+	var s session.Session
+	for _, t := range a.tabs {
+		if t.doc == nil {
+			continue
+		}
+		if t == a.tab {
+			s.Current = len(s.Tabs)
+		}
+		s.Tabs = append(s.Tabs, session.Tab{URL: t.doc.Page.URL().Redacted(), Top: t.top})
+	}
+	return s
+}
+
+// Restore는 저장된 세션의 탭들을 다시 엶
+//
+// 일부 탭을 열지 못하면 나머지만 복원하고 상태 줄에 알림
+// 하나도 열지 못하면 에러를 반환함
+func (a *App) Restore(s *session.Session) error {
+	var restored []*tab
+	current := a.tab
+	failed := 0
+	for i, saved := range s.Tabs {
+		page, err := a.load(saved.URL)
+		if err != nil {
+			failed++
+			continue
+		}
+		a.tab = &tab{}
+		a.show(page, saved.Top)
+		a.recordVisit(saved.URL)
+		restored = append(restored, a.tab)
+		if i == s.Current || len(restored) == 1 {
+			current = a.tab
+		}
+	}
+	if len(restored) == 0 {
+		a.tab = current
+		return errors.New("세션의 탭을 하나도 열지 못했습니다")
+	}
+
+	a.tabs = restored
+	a.tab = current
+	if failed > 0 {
+		a.status = fmt.Sprintf("탭 %d개를 복원하지 못했습니다", failed)
+	}
+	return nil
+}
+
+// saveSession은 SessionFile이 있으면 지금 세션을 저장함
+func (a *App) saveSession() error {
+	if a.SessionFile == "" {
+		return nil
+	}
+	return session.Save(a.SessionFile, a.Session())
+}
@@ -0,0 +1,74 @@
+// Package tui implements the interactive full-screen terminal browser.
+// This file contains per-tab zoom and its per-site persistence.
+package tui
+
+import (
+	"fmt"
+	"go-web-browser/pkg/browser"
+	"go-web-browser/sitesettings"
+	"go-web-browser/url"
+	"math"
+)
+
+// zoomLevels는 확대(+)와 축소(-)가 차례로 거치는 배율
+var zoomLevels = []float64{0.5, 0.67, 0.75, 0.8, 0.9, 1, 1.1, 1.25, 1.5, 1.75, 2, 2.5, 3}
+
+// Zoom은 지금 탭의 확대 배율 (1이 기본 크기)
+func (a *App) Zoom() float64 {
+	if a.zoom == 0 {
+		return 1
+	}
+	return a.zoom
+}
+
+// zoomBy는 지금 탭의 배율을 zoomLevels에서 steps칸 옮김 (양수면 확대, 음수면 축소, 0이면 기본 크기)
+func (a *App) zoomBy(steps int) error {
+	if a.doc == nil {
+		return nil
+	}
+	zoom := 1.0
+	if steps != 0 {
+		i := nearestZoomLevel(a.Zoom()) + steps
+		zoom = zoomLevels[max(0, min(i, len(zoomLevels)-1))]
+	}
+	a.zoom = zoom
+	a.show(a.doc.Page, a.top)
+	a.status = fmt.Sprintf("확대 %d%%", int(math.Round(zoom*100)))
+	return a.saveZoom()
+}
+
+// nearestZoomLevel은 zoom에 가장 가까운 zoomLevels 인덱스 (사이트 설정 파일에 임의 배율이 있어도 단계를 이어감)
+func nearestZoomLevel(zoom float64) int {
+	best := 0
+	for i, level := range zoomLevels {
+		if math.Abs(level-zoom) < math.Abs(zoomLevels[best]-zoom) {
+			best = i
+		}
+	}
+	return best
+}
+
+// saveZoom은 지금 배율을 지금 사이트의 설정에 저장함 (내부 페이지는 저장하지 않음)
+func (a *App) saveZoom() error {
+	u := a.doc.Page.URL()
+	if u.Scheme == url.SchemeAbout {
+		return nil
+	}
+	origin := sitesettings.Origin(u)
+	settings := a.Sites.Get(origin)
+	settings.Zoom = a.zoom
+	if settings.Zoom == 1 {
+		settings.Zoom = 0
+	}
+	return a.Sites.Set(origin, settings)
+}
+
+// siteZoom은 page를 보여줄 때의 배율
+//
+// 같은 사이트 안에서 이동하면 탭의 배율을 유지하고, 다른 사이트로 가면 그 사이트에 저장된 배율을 씀
+func (a *App) siteZoom(page *browser.Page) float64 {
+	if a.doc != nil && sitesettings.Origin(a.doc.Page.URL()) == sitesettings.Origin(page.URL()) {
+		return a.zoom
+	}
+	return a.Sites.For(page.URL()).Zoom
+}
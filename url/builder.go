@@ -0,0 +1,142 @@
+package url
+
+import (
+	"fmt"
+	"net/netip"
+	stdurl "net/url"
+	"strings"
+)
+
+// Builder: 문자열을 이어 붙이지 않고 http/https URL을 만드는 빌더입니다.
+//
+// Set 메서드들은 값을 기록만 하고 자기 자신을 반환하므로 이어서 부를 수 있습니다.
+// 검증과 이스케이프는 Build에서 한꺼번에 합니다.
+//
+// 예시:
+//
+//	u, err := url.NewBuilder().SetScheme(url.SchemeHTTPS).SetHost("example.com").
+//		SetPath("/search").AddQuery("q", "a&b c").Build()
+//	// → "https://example.com/search?q=a%26b+c"
+type Builder struct {
+	scheme      Scheme
+	host        string
+	port        int
+	path        string
+	query       []string // 쿼리 문자열로 이스케이프한 "이름=값" (넣은 순서대로)
+	fragment    string
+	hasFragment bool
+}
+
+// NewBuilder: 빈 Builder를 만듭니다 (스킴을 정하지 않으면 http).
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// SetScheme: 스킴을 정합니다 (http 또는 https).
+func (b *Builder) SetScheme(scheme Scheme) *Builder {
+	b.scheme = scheme
+	return b
+}
+
+// SetHost: 호스트를 정합니다 (국제화 도메인은 Build에서 Punycode로, IPv6 주소는 대괄호가 있어도 없어도 됩니다).
+func (b *Builder) SetHost(host string) *Builder {
+	b.host = host
+	return b
+}
+
+// SetPort: 포트를 정합니다 (0이면 스킴의 기본 포트).
+func (b *Builder) SetPort(port int) *Builder {
+	b.port = port
+	return b
+}
+
+// SetPath: 이스케이프하지 않은 경로를 정합니다 ("/a b" → "/a%20b", 비어 있으면 "/").
+func (b *Builder) SetPath(path string) *Builder {
+	b.path = path
+	return b
+}
+
+// AddQuery: 쿼리 파라미터를 하나 더합니다 (같은 이름도 넣은 순서대로 모두 남깁니다).
+func (b *Builder) AddQuery(name, value string) *Builder {
+	b.query = append(b.query, stdurl.QueryEscape(name)+"="+stdurl.QueryEscape(value))
+	return b
+}
+
+// SetFragment: 이스케이프하지 않은 프래그먼트를 정합니다 ("#" 없이).
+func (b *Builder) SetFragment(fragment string) *Builder {
+	b.fragment, b.hasFragment = fragment, true
+	return b
+}
+
+// Build: 기록한 값을 검증하고 이스케이프해서 URL을 만듭니다.
+//
+// 다음과 같으면 에러를 반환합니다.
+//   - 스킴이 http/https가 아님
+//   - 호스트가 비어 있거나 "/", "?", "#", "@", 공백이 들어 있음
+//   - 포트가 1-65535 밖임
+//   - 경로가 "/"로 시작하지 않음
+func (b *Builder) Build() (*URL, error) {
+	scheme := Scheme(strings.ToLower(string(b.scheme)))
+	switch scheme {
+	case "":
+		scheme = SchemeHTTP
+	case SchemeHTTP, SchemeHTTPS:
+	default:
+		return nil, fmt.Errorf("지원하지 않는 프로토콜입니다: %s", b.scheme)
+	}
+
+	host, err := buildHost(b.host)
+	if err != nil {
+		return nil, err
+	}
+
+	port := b.port
+	switch {
+	case port == 0 && scheme == SchemeHTTPS:
+		port = DefaultHTTPSPort
+	case port == 0:
+		port = DefaultHTTPPort
+	case port < 0 || port > 65535:
+		return nil, fmt.Errorf("포트 번호가 올바르지 않습니다 (%d)", port)
+	}
+
+	if b.path != "" && !strings.HasPrefix(b.path, PathDelimiter) {
+		return nil, fmt.Errorf("경로는 %s로 시작해야 합니다 (%s)", PathDelimiter, b.path)
+	}
+	escaped := &stdurl.URL{Path: b.path, Fragment: b.fragment}
+	path := escaped.EscapedPath()
+	if path == "" {
+		path = PathDelimiter
+	}
+	if len(b.query) > 0 {
+		path += "?" + strings.Join(b.query, "&")
+	}
+	if b.hasFragment {
+		path += "#" + escaped.EscapedFragment()
+	}
+
+	return &URL{Scheme: scheme, Host: host, Port: port, Path: path}, nil
+}
+
+// buildHost: Builder의 호스트를 검증해서 URL.Host에 넣는 모양으로 바꿉니다.
+func buildHost(host string) (string, error) {
+	if host == "" {
+		return "", fmt.Errorf("호스트가 비어 있습니다")
+	}
+	if literal, ok := strings.CutPrefix(host, "["); ok {
+		host, ok = strings.CutSuffix(literal, "]")
+		if !ok {
+			return "", fmt.Errorf("IPv6 주소의 닫는 대괄호가 없습니다 (%s)", literal)
+		}
+	}
+	if strings.Contains(host, PortDelimiter) {
+		if addr, err := netip.ParseAddr(host); err != nil || !addr.Is6() {
+			return "", fmt.Errorf("IPv6 주소가 올바르지 않습니다 (%s)", host)
+		}
+		return host, nil
+	}
+	if strings.ContainsAny(host, "/?#@ \t\r\n") {
+		return "", fmt.Errorf("호스트에 쓸 수 없는 글자가 있습니다 (%q)", host)
+	}
+	return HostToASCII(host)
+}
@@ -0,0 +1,56 @@
+package url
+
+import "testing"
+
+// TestBuilder 경로, 쿼리, 프래그먼트를 이스케이프하고 기본 포트, IPv6, 국제화 도메인을 NewURL과 같은 모양으로 만듭니다.
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		builder *Builder
+		want    string
+	}{
+		{NewBuilder().SetHost("example.com"), "http://example.com/"},
+		{NewBuilder().SetScheme(SchemeHTTPS).SetHost("example.com").SetPath("/search").
+			AddQuery("q", "a&b c").AddQuery("q", "두번째"), "https://example.com/search?q=a%26b+c&q=%EB%91%90%EB%B2%88%EC%A7%B8"},
+		{NewBuilder().SetHost("example.com").SetPort(8080).SetPath("/a b/c?d").SetFragment("top 1"),
+			"http://example.com:8080/a%20b/c%3Fd#top%201"},
+		{NewBuilder().SetScheme(SchemeHTTPS).SetHost("example.com").SetPort(443), "https://example.com/"},
+		{NewBuilder().SetHost("[::1]").SetPort(8080), "http://[::1]:8080/"},
+		{NewBuilder().SetHost("::1"), "http://[::1]/"},
+		{NewBuilder().SetHost("한글도메인.kr"), "http://xn--bj0bj3i97fq8o5lq.kr/"},
+	}
+	for _, tt := range tests {
+		u, err := tt.builder.Build()
+		if err != nil {
+			t.Fatalf("Build() for %q returned error: %v", tt.want, err)
+		}
+		if got := u.String(); got != tt.want {
+			t.Errorf("Build().String() = %q; want %q", got, tt.want)
+		}
+		parsed, err := NewURL(u.String())
+		if err != nil || *parsed != *u {
+			t.Errorf("NewURL(%q) = %+v, %v; want %+v", u.String(), parsed, err, u)
+		}
+	}
+}
+
+// TestBuilder_Invalid 잘못된 스킴, 호스트, 포트, 경로는 Build에서 에러를 반환합니다.
+func TestBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+	}{
+		{"scheme", NewBuilder().SetScheme(SchemeFile).SetHost("example.com")},
+		{"empty host", NewBuilder()},
+		{"host with path", NewBuilder().SetHost("example.com/a")},
+		{"host with userinfo", NewBuilder().SetHost("user@example.com")},
+		{"bad IPv6", NewBuilder().SetHost("[::1")},
+		{"host with port", NewBuilder().SetHost("example.com:80")},
+		{"port", NewBuilder().SetHost("example.com").SetPort(70000)},
+		{"relative path", NewBuilder().SetHost("example.com").SetPath("a/b")},
+	}
+	for _, tt := range tests {
+		if u, err := tt.builder.Build(); err == nil {
+			t.Errorf("Build() with %s = %q; want error", tt.name, u)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package url
+
+import (
+	"fmt"
+	stdurl "net/url"
+	"strings"
+)
+
+// Mailto: mailto: URL의 받는 사람, 제목, 본문 (RFC 6068)
+type Mailto struct {
+	To      []string // 받는 사람 (경로의 주소와 to 필드)
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Body    string
+}
+
+// Mailto: mailto: URL을 분석합니다.
+//
+// 예시: "mailto:a@example.com,b@example.com?subject=Hi%20there&cc=c@example.com" →
+// To: [a@example.com b@example.com], Cc: [c@example.com], Subject: "Hi there"
+func (u *URL) Mailto() (*Mailto, error) {
+	if u.Scheme != SchemeMailto {
+		return nil, fmt.Errorf("mailto URL이 아닙니다: %s", u.Scheme)
+	}
+	to, query, _ := strings.Cut(u.Path, "?")
+	addresses, err := stdurl.PathUnescape(to)
+	if err != nil {
+		return nil, fmt.Errorf("mailto 주소 형식이 잘못되었습니다: %w", err)
+	}
+	fields, err := stdurl.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("mailto 필드 형식이 잘못되었습니다: %w", err)
+	}
+
+	m := &Mailto{To: splitAddresses(addresses)}
+	// 필드 이름은 대소문자를 구분하지 않음
+	for name, values := range fields {
+		for _, v := range values {
+			switch strings.ToLower(name) {
+			case "to":
+				m.To = append(m.To, splitAddresses(v)...)
+			case "cc":
+				m.Cc = append(m.Cc, splitAddresses(v)...)
+			case "bcc":
+				m.Bcc = append(m.Bcc, splitAddresses(v)...)
+			case "subject":
+				m.Subject = v
+			case "body":
+				m.Body = v
+			}
+		}
+	}
+	return m, nil
+}
+
+// splitAddresses: 쉼표로 구분한 메일 주소들을 나눕니다 (빈 항목 제외).
+func splitAddresses(s string) []string {
+	var addresses []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
+
+// Telephone: tel: URL의 전화번호를 반환합니다 (";ext=" 같은 매개변수는 제외, RFC 3966).
+//
+// 예시: "tel:+1-201-555-0123;ext=42" → "+1-201-555-0123"
+func (u *URL) Telephone() (string, error) {
+	if u.Scheme != SchemeTel {
+		return "", fmt.Errorf("tel URL이 아닙니다: %s", u.Scheme)
+	}
+	number, _, _ := strings.Cut(u.Path, ";")
+	number, err := stdurl.PathUnescape(number)
+	if err != nil || strings.TrimSpace(number) == "" {
+		return "", fmt.Errorf("전화번호 형식이 잘못되었습니다: %q", u.Path)
+	}
+	return strings.TrimSpace(number), nil
+}
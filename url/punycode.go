@@ -0,0 +1,227 @@
+package url
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// ACEPrefix: 국제화 도메인 이름(IDN)의 라벨을 ASCII로 바꿨다는 표시입니다 (RFC 3490).
+const ACEPrefix = "xn--"
+
+// Punycode 매개변수 (RFC 3492 5절)
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// errPunycode: 잘못된 Punycode 라벨입니다.
+var errPunycode = errors.New("잘못된 Punycode")
+
+// HostToUnicode: host의 "xn--" 라벨을 유니코드로 바꿔서 사람이 읽는 모양을 반환합니다.
+//
+// ("xn--mnchen-3ya.de" → "münchen.de")
+// 디코딩할 수 없는 라벨은 그대로 둡니다.
+func HostToUnicode(host string) string {
+	if !strings.Contains(strings.ToLower(host), ACEPrefix) {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if len(label) > len(ACEPrefix) && strings.EqualFold(label[:len(ACEPrefix)], ACEPrefix) {
+			if decoded, err := decodePunycode(strings.ToLower(label[len(ACEPrefix):])); err == nil {
+				labels[i] = decoded
+			}
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// maxLabelLength: DNS 라벨 하나의 최대 길이입니다 (RFC 1035).
+const maxLabelLength = 63
+
+// labelSeparators: IDNA에서 "."로 보는 전각, 반각 마침표입니다 (RFC 3490 3.1절).
+var labelSeparators = strings.NewReplacer("\u3002", ".", "\uff0e", ".", "\uff61", ".")
+
+// HostToASCII: host의 유니코드 라벨을 "xn--" Punycode 라벨로 바꿔서 DNS에 물을 수 있는 모양을 반환합니다.
+//
+// ("münchen.de" → "xn--mnchen-3ya.de", "한글도메인.kr" → "xn--bj0bj3i97fq8o5lq.kr")
+// 유니코드 라벨은 소문자로 바꾼 뒤 인코딩하고, ASCII 라벨은 그대로 둡니다.
+// NFC 정규화 같은 IDNA 매핑의 나머지는 하지 않습니다.
+func HostToASCII(host string) (string, error) {
+	if isASCII(host) {
+		return host, nil
+	}
+	if !utf8.ValidString(host) {
+		return "", fmt.Errorf("호스트가 올바른 UTF-8이 아닙니다 (%q)", host)
+	}
+	labels := strings.Split(labelSeparators.Replace(host), ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := encodePunycode(strings.ToLower(label))
+		if err != nil {
+			return "", fmt.Errorf("호스트를 Punycode로 바꿀 수 없습니다 (%s): %w", label, err)
+		}
+		labels[i] = ACEPrefix + encoded
+		if len(labels[i]) > maxLabelLength {
+			return "", fmt.Errorf("호스트의 라벨이 너무 깁니다 (%s)", label)
+		}
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// isASCII: s가 ASCII 글자로만 되어 있는지 확인합니다.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// punyAdapt: 다음 글자의 가변 길이 정수를 읽기 위한 bias를 구합니다 (RFC 3492 6.1절).
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+// punyDigit: Punycode 글자 하나의 값(0-35)을 반환합니다.
+func punyDigit(c byte) (int, bool) {
+	switch {
+	case 'a' <= c && c <= 'z':
+		return int(c - 'a'), true
+	case 'A' <= c && c <= 'Z':
+		return int(c - 'A'), true
+	case '0' <= c && c <= '9':
+		return int(c-'0') + 26, true
+	}
+	return 0, false
+}
+
+// punyEncodeDigit: 값(0-35)을 Punycode 글자 하나로 바꿉니다 (소문자).
+func punyEncodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// encodePunycode: 유니코드 라벨을 "xn--"를 붙이기 전의 Punycode 문자열로 바꿉니다 (RFC 3492 6.3절).
+func encodePunycode(label string) (string, error) {
+	runes := []rune(label)
+	var out strings.Builder
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		}
+	}
+	basic := out.Len()
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+
+	n, delta, bias := punyInitialN, 0, punyInitialBias
+	for handled := basic; handled < len(runes); {
+		// 아직 넣지 않은 글자 중 가장 작은 코드 포인트
+		m := math.MaxInt32
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		if m-n > (math.MaxInt32-delta)/(handled+1) {
+			return "", errPunycode
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := min(max(k-bias, punyTMin), punyTMax)
+				if q < t {
+					break
+				}
+				out.WriteByte(punyEncodeDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyEncodeDigit(q))
+			bias = punyAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return out.String(), nil
+}
+
+// decodePunycode: "xn--"를 뗀 Punycode 라벨을 유니코드 문자열로 바꿉니다 (RFC 3492 6.2절).
+func decodePunycode(encoded string) (string, error) {
+	var output []rune
+	rest := encoded
+	if i := strings.LastIndexByte(encoded, '-'); i >= 0 {
+		for _, r := range encoded[:i] {
+			if r >= utf8.RuneSelf {
+				return "", errPunycode
+			}
+			output = append(output, r)
+		}
+		rest = encoded[i+1:]
+	}
+
+	n, bias, i := punyInitialN, punyInitialBias, 0
+	for pos := 0; pos < len(rest); {
+		oldI, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos >= len(rest) {
+				return "", errPunycode
+			}
+			digit, ok := punyDigit(rest[pos])
+			pos++
+			if !ok || digit > (math.MaxInt32-i)/w {
+				return "", errPunycode
+			}
+			i += digit * w
+			t := min(max(k-bias, punyTMin), punyTMax)
+			if digit < t {
+				break
+			}
+			w *= punyBase - t
+		}
+		bias = punyAdapt(i-oldI, len(output)+1, oldI == 0)
+		n += i / (len(output) + 1)
+		i %= len(output) + 1
+		if n > utf8.MaxRune {
+			return "", errPunycode
+		}
+		output = append(output[:i], append([]rune{rune(n)}, output[i:]...)...)
+		i++
+	}
+	return string(output), nil
+}
@@ -0,0 +1,63 @@
+package url
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestHostToUnicode "xn--" 라벨만 유니코드로 바꾸고 잘못된 라벨은 그대로 둡니다.
+func TestHostToUnicode(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"xn--mnchen-3ya.de", "münchen.de"},
+		{"www.XN--bcher-kva.example", "www.bücher.example"},
+		{"xn--80ak6aa92e.com", "аррӏе.com"},
+		{"example.com", "example.com"},
+		{"xn--.com", "xn--.com"},
+		{"xn--a-é.com", "xn--a-é.com"},
+	}
+	for _, tt := range tests {
+		if got := HostToUnicode(tt.host); got != tt.want {
+			t.Errorf("HostToUnicode(%q) = %q; want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+// TestHostToASCII 유니코드 라벨만 소문자로 바꿔 "xn--" 라벨로 만들고, HostToUnicode로 되돌릴 수 있습니다.
+func TestHostToASCII(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"münchen.de", "xn--mnchen-3ya.de"},
+		{"www.Bücher.example", "www.xn--bcher-kva.example"},
+		{"한글도메인.kr", "xn--bj0bj3i97fq8o5lq.kr"},
+		{"例え。テスト", "xn--r8jz45g.xn--zckzah"},
+		{"example.com", "example.com"},
+	}
+	for _, tt := range tests {
+		got, err := HostToASCII(tt.host)
+		if err != nil {
+			t.Fatalf("HostToASCII(%q) returned error: %v", tt.host, err)
+		}
+		if got != tt.want {
+			t.Errorf("HostToASCII(%q) = %q; want %q", tt.host, got, tt.want)
+		}
+		if back := HostToUnicode(got); back != HostToUnicode(tt.want) {
+			t.Errorf("HostToUnicode(%q) = %q; want %q", got, back, HostToUnicode(tt.want))
+		}
+	}
+
+	// 서로 다른 글자 30개는 Punycode로 63바이트를 넘음
+	var longLabel strings.Builder
+	for i := range 30 {
+		longLabel.WriteRune(rune(0xAC00 + i*37))
+	}
+	for _, host := range []string{"bad\xff.com", longLabel.String() + ".kr"} {
+		if _, err := HostToASCII(host); err == nil {
+			t.Errorf("HostToASCII(%q) should return error", host)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package url
+
+import (
+	stdurl "net/url"
+	"strings"
+)
+
+// SearchKeywords: 주소창의 "키워드 검색어" 입력을 검색 주소로 바꿀 때 쓰는 키워드 → URL 템플릿입니다.
+//
+// 템플릿의 %s 자리에 쿼리 문자열로 이스케이프한 검색어가 들어갑니다.
+// 설정 파일의 search_keywords로 항목을 더하거나 덮어쓸 수 있습니다.
+var SearchKeywords = map[string]string{
+	"g":   "https://www.google.com/search?q=%s",
+	"w":   "https://en.wikipedia.org/w/index.php?search=%s",
+	"ddg": "https://duckduckgo.com/html/?q=%s",
+}
+
+// SearchPlaceholder: 검색 URL 템플릿에서 검색어가 들어갈 자리입니다.
+const SearchPlaceholder = "%s"
+
+// ExpandKeyword: input이 "키워드 검색어" 꼴이고 키워드가 keywords에 있으면 검색 주소를 반환합니다.
+//
+// 키워드는 대소문자를 가리지 않고, 검색어 사이의 공백은 하나로 합칩니다.
+// ("g golang chunked encoding" → "https://www.google.com/search?q=golang+chunked+encoding")
+// 검색어가 없거나 키워드가 없으면 ok가 false입니다.
+func ExpandKeyword(input string, keywords map[string]string) (expanded string, ok bool) {
+	fields := strings.Fields(input)
+	if len(fields) < 2 {
+		return "", false
+	}
+	template, ok := keywords[strings.ToLower(fields[0])]
+	if !ok || !strings.Contains(template, SearchPlaceholder) {
+		return "", false
+	}
+	query := stdurl.QueryEscape(strings.Join(fields[1:], " "))
+	return strings.ReplaceAll(template, SearchPlaceholder, query), true
+}
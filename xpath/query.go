@@ -0,0 +1,23 @@
+// Package xpath implements a small XPath subset for querying the DOM.
+// This file contains the one-shot query helpers.
+package xpath
+
+import "go-web-browser/html"
+
+// Query는 expr을 컴파일해서 context에서 평가한 노드들을 반환함
+func Query(context *html.Node, expr string) ([]*html.Node, error) {
+	e, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return e.Select(context), nil
+}
+
+// QueryStrings는 expr을 평가한 문자열 값들을 반환함 ("//a/@href" → 링크 주소들)
+func QueryStrings(context *html.Node, expr string) ([]string, error) {
+	e, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return e.Strings(context), nil
+}
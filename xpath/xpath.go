@@ -0,0 +1,473 @@
+// Package xpath implements a small XPath subset for querying the DOM.
+// This file contains the expression parser and evaluator.
+package xpath
+
+import (
+	"fmt"
+	"go-web-browser/html"
+	"strconv"
+	"strings"
+)
+
+// Expr은 컴파일된 XPath 식
+//
+// 지원하는 부분집합:
+//
+//	/html/body/p        절대 경로 (자식 축)
+//	//a                 자손 축 (descendant-or-self)
+//	div/p, ./p, ..      상대 경로, 현재 노드, 부모
+//	*, text(), node()   모든 요소, 텍스트 노드, 모든 노드
+//	@href               속성 (마지막 단계에서만, Strings로 값을 얻음)
+//	a[@href]            속성이 있는 요소
+//	a[@rel='next']      속성 값 비교 (=, !=)
+//	li[2]               위치 (1부터)
+//	p[text()='Hi']      텍스트 노드 비교
+//	li[a], li[a='B']    자식 요소가 있는지, 자식 요소의 텍스트 비교
+//	a[contains(@href, 'x')], a[starts-with(., 'x')]
+type Expr struct {
+	src      string
+	absolute bool
+	steps    []step
+	attr     string // 마지막 단계가 @attr이면 속성 이름
+}
+
+// axis는 단계가 후보를 고르는 방향
+type axis int
+
+const (
+	childAxis      axis = iota // a/b
+	descendantAxis             // a//b (자손 중 b, 위치는 부모 기준)
+	selfAxis                   // .
+	parentAxis                 // ..
+)
+
+// nodeTest는 후보 노드의 종류
+type nodeTest int
+
+const (
+	elementTest nodeTest = iota // 이름 또는 *
+	textTest                    // text()
+	anyTest                     // node()
+)
+
+// step은 경로의 단계 하나
+type step struct {
+	axis       axis
+	test       nodeTest
+	name       string // elementTest의 태그 이름 ("*"이면 모든 요소)
+	predicates []predicate
+}
+
+// predicate는 [..] 조건 하나
+type predicate struct {
+	position int    // [N] (0이면 위치 조건 아님)
+	fn       string // "=", "!=", "contains", "starts-with", ""(존재)
+	operand  string // "@name", "text()", ".", 자식 요소 이름
+	literal  string
+}
+
+// Compile은 XPath 식을 파싱함
+func Compile(expr string) (*Expr, error) {
+	p := &exprParser{src: expr}
+	e := &Expr{src: expr}
+	ax := childAxis
+	switch {
+	case strings.HasPrefix(expr, "//"):
+		e.absolute = true
+		ax = descendantAxis
+		p.pos = 2
+	case strings.HasPrefix(expr, "/"):
+		e.absolute = true
+		p.pos = 1
+	}
+
+	for {
+		if p.eof() {
+			return nil, p.errorf("단계가 필요함")
+		}
+		if p.peek() == '@' {
+			p.pos++
+			if e.attr = p.name(); e.attr == "" {
+				return nil, p.errorf("'@' 뒤에 속성 이름이 필요함")
+			}
+			if !p.eof() {
+				return nil, p.errorf("속성 단계는 마지막에만 올 수 있음")
+			}
+			if ax == descendantAxis {
+				// //@href: 모든 자손 요소의 속성
+				e.steps = append(e.steps, step{axis: descendantAxis, test: elementTest, name: "*"})
+			}
+			return e, nil
+		}
+
+		s, err := p.step(ax)
+		if err != nil {
+			return nil, err
+		}
+		e.steps = append(e.steps, s)
+
+		switch {
+		case p.eof():
+			return e, nil
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			ax = descendantAxis
+			p.pos += 2
+		case p.peek() == '/':
+			ax = childAxis
+			p.pos++
+		default:
+			return nil, p.errorf("예상하지 못한 문자 %q", p.peek())
+		}
+	}
+}
+
+// exprParser는 식 문자열과 읽는 위치
+type exprParser struct {
+	src string
+	pos int
+}
+
+func (p *exprParser) eof() bool  { return p.pos >= len(p.src) }
+func (p *exprParser) peek() byte { return p.src[p.pos] }
+
+// errorf는 위치가 들어간 파싱 에러를 만듦
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("잘못된 XPath %q (위치 %d): %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+// consume은 s로 시작하면 건너뛰고 true를 반환함
+func (p *exprParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// skipSpace는 공백을 건너뜀
+func (p *exprParser) skipSpace() {
+	for !p.eof() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// name은 태그/속성 이름을 읽음 (소문자로)
+func (p *exprParser) name() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == ':') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToLower(p.src[start:p.pos])
+}
+
+// step은 단계 하나와 그 조건들을 읽음
+func (p *exprParser) step(ax axis) (step, error) {
+	s := step{axis: ax, test: elementTest}
+	switch {
+	case p.consume(".."):
+		s.axis, s.test = parentAxis, anyTest
+	case p.consume("."):
+		s.axis, s.test = selfAxis, anyTest
+	case p.consume("text()"):
+		s.test = textTest
+	case p.consume("node()"):
+		s.test = anyTest
+	case p.consume("*"):
+		s.name = "*"
+	default:
+		if s.name = p.name(); s.name == "" {
+			return s, p.errorf("이름이 필요함")
+		}
+	}
+	if ax == descendantAxis && (s.axis == selfAxis || s.axis == parentAxis) {
+		return s, p.errorf("'//' 뒤에는 '.'이나 '..'을 쓸 수 없음")
+	}
+
+	for !p.eof() && p.peek() == '[' {
+		p.pos++
+		pred, err := p.predicate()
+		if err != nil {
+			return s, err
+		}
+		s.predicates = append(s.predicates, pred)
+	}
+	return s, nil
+}
+
+// predicate는 '[' 다음부터 ']'까지를 읽음
+func (p *exprParser) predicate() (predicate, error) {
+	var pred predicate
+	p.skipSpace()
+	start := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos > start {
+		pred.position, _ = strconv.Atoi(p.src[start:p.pos])
+		if pred.position == 0 {
+			return pred, p.errorf("위치는 1부터 시작함")
+		}
+	} else {
+		var err error
+		for _, fn := range []string{"contains", "starts-with"} {
+			if p.consume(fn + "(") {
+				pred.fn = fn
+				break
+			}
+		}
+		p.skipSpace()
+		if pred.operand, err = p.operand(); err != nil {
+			return pred, err
+		}
+		p.skipSpace()
+		if pred.fn != "" {
+			if !p.consume(",") {
+				return pred, p.errorf("','가 필요함")
+			}
+			p.skipSpace()
+			if pred.literal, err = p.literal(); err != nil {
+				return pred, err
+			}
+			p.skipSpace()
+			if !p.consume(")") {
+				return pred, p.errorf("')'가 필요함")
+			}
+		} else if p.consume("!=") || p.consume("=") {
+			pred.fn = "="
+			if p.src[p.pos-2] == '!' {
+				pred.fn = "!="
+			}
+			p.skipSpace()
+			if pred.literal, err = p.literal(); err != nil {
+				return pred, err
+			}
+		}
+	}
+	p.skipSpace()
+	if !p.consume("]") {
+		return pred, p.errorf("']'가 필요함")
+	}
+	return pred, nil
+}
+
+// operand는 조건에서 비교할 값 (@name, text(), ., 자식 요소 이름)
+func (p *exprParser) operand() (string, error) {
+	switch {
+	case p.consume("text()"):
+		return "text()", nil
+	case p.consume("."):
+		return ".", nil
+	case p.consume("@"):
+		if name := p.name(); name != "" {
+			return "@" + name, nil
+		}
+	default:
+		if name := p.name(); name != "" {
+			return name, nil
+		}
+	}
+	return "", p.errorf("@속성, text(), ., 자식 요소 이름 중 하나가 필요함")
+}
+
+// literal은 따옴표로 감싼 문자열을 읽음
+func (p *exprParser) literal() (string, error) {
+	if p.eof() || (p.peek() != '\'' && p.peek() != '"') {
+		return "", p.errorf("따옴표로 감싼 문자열이 필요함")
+	}
+	quote := p.peek()
+	end := strings.IndexByte(p.src[p.pos+1:], quote)
+	if end < 0 {
+		return "", p.errorf("닫는 따옴표가 없음")
+	}
+	s := p.src[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return s, nil
+}
+
+// Select는 context 노드에서 식을 평가한 노드들을 문서 순서로 반환함
+//
+// 절대 경로는 context가 속한 문서의 루트에서 시작함
+// 마지막 단계가 @attr이면 그 속성이 있는 요소들을 반환함 (값은 Strings로)
+func (e *Expr) Select(context *html.Node) []*html.Node {
+	root := context
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	nodes := []*html.Node{context}
+	if e.absolute {
+		nodes = []*html.Node{root}
+	}
+
+	for _, s := range e.steps {
+		nodes = s.apply(nodes)
+	}
+	if e.attr != "" {
+		var owners []*html.Node
+		for _, n := range nodes {
+			if _, ok := n.Attr(e.attr); ok {
+				owners = append(owners, n)
+			}
+		}
+		nodes = owners
+	}
+	return documentOrder(root, nodes)
+}
+
+// Strings는 Select한 노드들의 문자열 값 (@attr이면 속성 값, 텍스트 노드는 내용, 요소는 TextContent)
+func (e *Expr) Strings(context *html.Node) []string {
+	var values []string
+	for _, n := range e.Select(context) {
+		switch {
+		case e.attr != "":
+			v, _ := n.Attr(e.attr)
+			values = append(values, v)
+		case n.Type == html.TextNode:
+			values = append(values, n.Data)
+		default:
+			values = append(values, n.TextContent())
+		}
+	}
+	return values
+}
+
+// String은 원래 식
+func (e *Expr) String() string {
+	return e.src
+}
+
+// apply는 각 노드에서 단계의 후보를 고르고 조건을 적용함
+func (s step) apply(nodes []*html.Node) []*html.Node {
+	var result []*html.Node
+	for _, n := range nodes {
+		switch s.axis {
+		case selfAxis:
+			result = append(result, s.filter([]*html.Node{n})...)
+		case parentAxis:
+			if n.Parent != nil {
+				result = append(result, s.filter([]*html.Node{n.Parent})...)
+			}
+		case childAxis:
+			result = append(result, s.filter(n.Children)...)
+		case descendantAxis:
+			// descendant-or-self::node()/child::x (위치 조건은 부모마다 따로 셈)
+			n.Walk(func(d *html.Node) bool {
+				result = append(result, s.filter(d.Children)...)
+				return true
+			})
+		}
+	}
+	return result
+}
+
+// filter는 candidates 중 노드 종류와 조건이 맞는 노드들
+func (s step) filter(candidates []*html.Node) []*html.Node {
+	var matched []*html.Node
+	for _, c := range candidates {
+		if s.test.match(c, s.name) {
+			matched = append(matched, c)
+		}
+	}
+	for _, pred := range s.predicates {
+		var kept []*html.Node
+		for i, c := range matched {
+			if pred.match(c, i+1) {
+				kept = append(kept, c)
+			}
+		}
+		matched = kept
+	}
+	return matched
+}
+
+// match는 노드 n이 노드 종류 검사를 통과하는지 확인함
+func (t nodeTest) match(n *html.Node, name string) bool {
+	switch t {
+	case textTest:
+		return n.Type == html.TextNode
+	case anyTest:
+		return true
+	default:
+		return n.Type == html.ElementNode && (name == "*" || n.Tag == name)
+	}
+}
+
+// match는 position번째 후보 n이 조건을 만족하는지 확인함
+func (pred predicate) match(n *html.Node, position int) bool {
+	if pred.position > 0 {
+		return position == pred.position
+	}
+
+	values, ok := pred.values(n)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		var hit bool
+		switch pred.fn {
+		case "":
+			hit = true
+		case "=":
+			hit = v == pred.literal
+		case "!=":
+			hit = v != pred.literal
+		case "contains":
+			hit = strings.Contains(v, pred.literal)
+		case "starts-with":
+			hit = strings.HasPrefix(v, pred.literal)
+		}
+		if hit {
+			return true
+		}
+	}
+	return false
+}
+
+// values는 조건에서 비교할 n의 값들 (text()와 자식 요소 이름은 자식마다 하나)
+//
+// 값이 없으면(속성이 없거나 해당 자식이 없으면) ok = false
+func (pred predicate) values(n *html.Node) (values []string, ok bool) {
+	switch {
+	case pred.operand == ".":
+		return []string{n.TextContent()}, true
+	case pred.operand == "text()":
+		for _, c := range n.Children {
+			if c.Type == html.TextNode {
+				values = append(values, c.Data)
+			}
+		}
+		return values, len(values) > 0
+	case strings.HasPrefix(pred.operand, "@"):
+		v, ok := n.Attr(pred.operand[1:])
+		return []string{v}, ok
+	default:
+		for _, c := range n.Children {
+			if c.Type == html.ElementNode && c.Tag == pred.operand {
+				values = append(values, c.TextContent())
+			}
+		}
+		return values, len(values) > 0
+	}
+}
+
+// documentOrder는 nodes의 중복을 없애고 root 기준 문서 순서로 정렬함
+func documentOrder(root *html.Node, nodes []*html.Node) []*html.Node {
+	if len(nodes) < 2 {
+		return nodes
+	}
+	want := make(map[*html.Node]bool, len(nodes))
+	for _, n := range nodes {
+		want[n] = true
+	}
+	ordered := make([]*html.Node, 0, len(want))
+	root.Walk(func(n *html.Node) bool {
+		if want[n] {
+			ordered = append(ordered, n)
+		}
+		return true
+	})
+	return ordered
+}
@@ -0,0 +1,83 @@
+package xpath_test
+
+import (
+	"go-web-browser/html"
+	"go-web-browser/xpath"
+	"reflect"
+	"testing"
+)
+
+const testDoc = `<html><body><div id="main"><h1>Title</h1>` +
+	`<ul><li><a href="/a" rel="next">A</a></li><li><a href="/b">B</a></li><li>plain</li></ul>` +
+	`<div class="inner"><p>Hi</p><p>Bye <a href="https://x.com/">X</a></p></div></div>` +
+	`<p>Outside</p></body></html>`
+
+// TestQueryStrings 경로, 축, 조건, text(), @attr
+func TestQueryStrings(t *testing.T) {
+	doc := html.Parse(testDoc)
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"/html/body/div/h1", []string{"Title"}},
+		{"//a", []string{"A", "B", "X"}},
+		{"//a/@href", []string{"/a", "/b", "https://x.com/"}},
+		{"//@rel", []string{"next"}},
+		{"//li[2]/a", []string{"B"}},
+		{"//li[a]", []string{"A", "B"}},
+		{"//li[a='B']/a/@href", []string{"/b"}},
+		{"//a[@rel]", []string{"A"}},
+		{"//a[@rel='next']/@href", []string{"/a"}},
+		{"//a[@href!='/a']", []string{"B", "X"}},
+		{"//a[contains(@href, 'x.com')]", []string{"X"}},
+		{"//a[starts-with(@href, '/')]", []string{"A", "B"}},
+		{"//p[text()='Hi']", []string{"Hi"}},
+		{"//p[contains(., 'X')]", []string{"Bye X"}},
+		{"//div[@class='inner']/p/text()", []string{"Hi", "Bye "}},
+		{"//div//p", []string{"Hi", "Bye X"}},
+		{"/html/body/p", []string{"Outside"}},
+		{"//li[3]/text()", []string{"plain"}},
+		{"//a[@href='/b']/../../li[1]/a", []string{"A"}},
+		{"//h1/.", []string{"Title"}},
+		{"//*[@id='main']/h1", []string{"Title"}},
+		{"//table", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := xpath.QueryStrings(doc, tt.expr)
+		if err != nil {
+			t.Errorf("QueryStrings(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("QueryStrings(%q) = %q; want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+// TestQuery_Relative 상대 경로는 context 노드에서 시작함
+func TestQuery_Relative(t *testing.T) {
+	doc := html.Parse(testDoc)
+	inner, err := xpath.Query(doc, "//div[@class='inner']")
+	if err != nil || len(inner) != 1 {
+		t.Fatalf("Query(inner) = %v, %v; want one div", inner, err)
+	}
+
+	got, _ := xpath.QueryStrings(inner[0], "p/a")
+	if !reflect.DeepEqual(got, []string{"X"}) {
+		t.Errorf("QueryStrings(div, \"p/a\") = %q; want [X]", got)
+	}
+	got, _ = xpath.QueryStrings(inner[0], "/html/body/p")
+	if !reflect.DeepEqual(got, []string{"Outside"}) {
+		t.Errorf("절대 경로는 문서 루트에서: %q; want [Outside]", got)
+	}
+}
+
+// TestCompile_Invalid 문법 오류는 에러
+func TestCompile_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "/", "//a/", "a[", "a[0]", "a[@]", "a[@x=1]", "a[@x='1]", "@href/a", "a[contains(@x)]", "//..", "a|b"} {
+		if _, err := xpath.Compile(expr); err == nil {
+			t.Errorf("Compile(%q) should fail", expr)
+		}
+	}
+}